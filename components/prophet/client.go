@@ -16,6 +16,7 @@ package prophet
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -50,7 +51,19 @@ type Client interface {
 	GetDestroying(id uint64) (*metapb.DestroyingStatus, error)
 	PutStore(container metapb.Store) error
 	GetStore(containerID uint64) (*metapb.Store, error)
-	ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatReq) error
+	// ShardHeartbeat sends the heartbeat of the shard to prophet without waiting
+	// for the result. cb, if not nil, is invoked with the result once prophet
+	// responds, the request times out or fails to be written. cb is not invoked
+	// if an error is returned.
+	ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatReq, cb func(error)) error
+	// ShardHeartbeats sends the heartbeats of many shards to prophet in a single
+	// request and waits for the results. The returned errors are in the same
+	// order as the shards, nil if the heartbeat of the shard is handled.
+	ShardHeartbeats(metas []metapb.Shard, hbs []rpcpb.ShardHeartbeatReq) []error
+	// ShardKeepalive sends the keepalive of a shard unchanged since its last
+	// heartbeat to prophet without waiting for the result. cb is invoked the same
+	// way as in ShardHeartbeat, an error means a full heartbeat should be sent.
+	ShardKeepalive(req rpcpb.ShardKeepaliveReq, cb func(error)) error
 	StoreHeartbeat(hb rpcpb.StoreHeartbeatReq) (rpcpb.StoreHeartbeatRsp, error)
 	AskBatchSplit(res metapb.Shard, count uint32) ([]rpcpb.SplitID, error)
 	NewWatcher(flag uint32) (EventWatcher, error)
@@ -182,7 +195,7 @@ func (c *asyncClient) AllocID() (uint64, error) {
 	return resp.AllocID.ID, nil
 }
 
-func (c *asyncClient) ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatReq) error {
+func (c *asyncClient) ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatReq, cb func(error)) error {
 	if !c.running() {
 		return ErrClosed
 	}
@@ -197,8 +210,81 @@ func (c *asyncClient) ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatR
 	req.Type = rpcpb.TypeShardHeartbeatReq
 	req.ShardHeartbeat = hb
 
-	c.asyncDo(req, nil)
-	return nil
+	var respCB func(*rpcpb.ProphetResponse, error)
+	if cb != nil {
+		respCB = func(_ *rpcpb.ProphetResponse, err error) {
+			cb(err)
+		}
+	}
+	return c.asyncDo(req, respCB)
+}
+
+func (c *asyncClient) ShardKeepalive(keepalive rpcpb.ShardKeepaliveReq, cb func(error)) error {
+	if !c.running() {
+		return ErrClosed
+	}
+
+	req := &rpcpb.ProphetRequest{}
+	req.Type = rpcpb.TypeShardKeepaliveReq
+	req.ShardKeepalive = keepalive
+
+	var respCB func(*rpcpb.ProphetResponse, error)
+	if cb != nil {
+		respCB = func(_ *rpcpb.ProphetResponse, err error) {
+			cb(err)
+		}
+	}
+	return c.asyncDo(req, respCB)
+}
+
+func (c *asyncClient) ShardHeartbeats(metas []metapb.Shard, hbs []rpcpb.ShardHeartbeatReq) []error {
+	errs := make([]error, len(metas))
+	if !c.running() {
+		for i := range errs {
+			errs[i] = ErrClosed
+		}
+		return errs
+	}
+
+	req := &rpcpb.ProphetRequest{}
+	req.Type = rpcpb.TypeShardHeartbeatsReq
+	sent := make([]int, 0, len(metas))
+	for i, meta := range metas {
+		data, err := meta.Marshal()
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		hb := hbs[i]
+		hb.Shard = data
+		req.ShardHeartbeats.Heartbeats = append(req.ShardHeartbeats.Heartbeats, hb)
+		sent = append(sent, i)
+	}
+	if len(sent) == 0 {
+		return errs
+	}
+
+	resp, err := c.syncDo(req)
+	if err == nil && len(resp.ShardHeartbeats.Errors) != len(sent) {
+		err = fmt.Errorf("%d shard heartbeats sent, but %d results received",
+			len(sent), len(resp.ShardHeartbeats.Errors))
+	}
+	if err != nil {
+		c.opts.logger.Error("fail to send shard heartbeats",
+			zap.Int("count", len(sent)),
+			zap.Error(err))
+		for _, i := range sent {
+			errs[i] = err
+		}
+		return errs
+	}
+	for j, i := range sent {
+		if e := resp.ShardHeartbeats.Errors[j]; e != "" {
+			errs[i] = errors.New(e)
+		}
+	}
+	return errs
 }
 
 func (c *asyncClient) StoreHeartbeat(hb rpcpb.StoreHeartbeatReq) (rpcpb.StoreHeartbeatRsp, error) {
@@ -578,8 +664,8 @@ func (c *asyncClient) syncDo(req *rpcpb.ProphetRequest) (*rpcpb.ProphetResponse,
 	}
 }
 
-func (c *asyncClient) asyncDo(req *rpcpb.ProphetRequest, cb func(*rpcpb.ProphetResponse, error)) {
-	c.do(newAsyncCtx(req, cb))
+func (c *asyncClient) asyncDo(req *rpcpb.ProphetRequest, cb func(*rpcpb.ProphetResponse, error)) error {
+	return c.do(newAsyncCtx(req, cb))
 }
 
 func (c *asyncClient) do(ctx *ctx) error {
@@ -616,11 +702,16 @@ func (c *asyncClient) do(ctx *ctx) error {
 }
 
 func (c *asyncClient) timeout(arg interface{}) {
-	c.contextsMu.RLock()
-	defer c.contextsMu.RUnlock()
+	c.requestFailed(arg.(uint64), ErrTimeout)
+}
+
+func (c *asyncClient) requestFailed(id uint64, err error) {
+	c.contextsMu.Lock()
+	defer c.contextsMu.Unlock()
 
-	if ctx, ok := c.contextsMu.contexts[arg.(uint64)]; ok {
-		ctx.done(nil, ErrTimeout)
+	if ctx, ok := c.contextsMu.contexts[id]; ok {
+		delete(c.contextsMu.contexts, id)
+		ctx.done(nil, err)
 	}
 }
 
@@ -761,6 +852,7 @@ func (c *asyncClient) doWrite(ctx *ctx) {
 		c.opts.logger.Error("fail to send request",
 			zap.Uint64("id", ctx.req.ID),
 			zap.Error(err))
+		c.requestFailed(ctx.req.ID, err)
 	}
 }
 
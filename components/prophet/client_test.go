@@ -172,7 +172,7 @@ func TestPutPlacementRule(t *testing.T) {
 	peer := metapb.Replica{ID: 1, StoreID: 1}
 	assert.NoError(t, c.ShardHeartbeat(newTestShardMeta(2, peer), rpcpb.ShardHeartbeatReq{
 		StoreID: 1,
-		Leader:  &peer}))
+		Leader:  &peer}, nil))
 	rules, err := c.GetAppliedRules(2)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(rules))
@@ -182,12 +182,111 @@ func TestPutPlacementRule(t *testing.T) {
 	res.SetRuleGroups("group01")
 	assert.NoError(t, c.ShardHeartbeat(res, rpcpb.ShardHeartbeatReq{
 		StoreID: 1,
-		Leader:  &peer}))
+		Leader:  &peer}, nil))
 	rules, err = c.GetAppliedRules(3)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(rules))
 }
 
+func TestShardHeartbeats(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	assert.NoError(t, c.PutStore(newTestStoreMeta(1)))
+	_, err := c.StoreHeartbeat(newTestStoreHeartbeat(1, 1))
+	assert.NoError(t, err)
+
+	var metas []metapb.Shard
+	var hbs []rpcpb.ShardHeartbeatReq
+	for id := uint64(2); id < 5; id++ {
+		peer := metapb.Replica{ID: id, StoreID: 1}
+		metas = append(metas, newTestShardMeta(id, peer))
+		hbs = append(hbs, rpcpb.ShardHeartbeatReq{StoreID: 1, Leader: &peer})
+	}
+	// the leader store of the last shard is not found
+	peer := metapb.Replica{ID: 5, StoreID: 100}
+	metas = append(metas, newTestShardMeta(5, peer))
+	hbs = append(hbs, rpcpb.ShardHeartbeatReq{StoreID: 100, Leader: &peer})
+
+	errs := c.ShardHeartbeats(metas, hbs)
+	assert.Equal(t, 4, len(errs))
+	assert.Equal(t, []error{nil, nil, nil}, errs[:3])
+	assert.Error(t, errs[3])
+	for id := uint64(2); id < 5; id++ {
+		rules, err := c.GetAppliedRules(id)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(rules))
+	}
+}
+
+func TestShardHeartbeatResult(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	assert.NoError(t, c.PutStore(newTestStoreMeta(1)))
+	_, err := c.StoreHeartbeat(newTestStoreHeartbeat(1, 1))
+	assert.NoError(t, err)
+
+	sendHeartbeat := func(id, storeID uint64) error {
+		resultC := make(chan error, 1)
+		peer := metapb.Replica{ID: id, StoreID: storeID}
+		assert.NoError(t, c.ShardHeartbeat(newTestShardMeta(id, peer),
+			rpcpb.ShardHeartbeatReq{StoreID: storeID, Leader: &peer},
+			func(err error) { resultC <- err }))
+		select {
+		case err := <-resultC:
+			return err
+		case <-time.After(time.Second * 10):
+			assert.FailNow(t, "timeout waiting for the heartbeat result")
+		}
+		return nil
+	}
+	assert.NoError(t, sendHeartbeat(2, 1))
+	// the leader store is not found
+	assert.Error(t, sendHeartbeat(3, 100))
+}
+
+func TestShardKeepalive(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	assert.NoError(t, c.PutStore(newTestStoreMeta(1)))
+	_, err := c.StoreHeartbeat(newTestStoreHeartbeat(1, 1))
+	assert.NoError(t, err)
+
+	peer := metapb.Replica{ID: 2, StoreID: 1}
+	shard := newTestShardMeta(2, peer)
+	assert.NoError(t, c.ShardHeartbeats([]metapb.Shard{shard},
+		[]rpcpb.ShardHeartbeatReq{{StoreID: 1, Term: 1, Leader: &peer}})[0])
+
+	sendKeepalive := func(req rpcpb.ShardKeepaliveReq) error {
+		resultC := make(chan error, 1)
+		assert.NoError(t, c.ShardKeepalive(req, func(err error) { resultC <- err }))
+		select {
+		case err := <-resultC:
+			return err
+		case <-time.After(time.Second * 10):
+			assert.FailNow(t, "timeout waiting for the keepalive result")
+		}
+		return nil
+	}
+	keepalive := rpcpb.ShardKeepaliveReq{ShardID: 2, Epoch: shard.Epoch, Term: 1, Leader: peer}
+	assert.NoError(t, sendKeepalive(keepalive))
+
+	// the term changed since the last heartbeat
+	changed := keepalive
+	changed.Term = 2
+	assert.Error(t, sendKeepalive(changed))
+
+	// the shard is unknown
+	unknown := keepalive
+	unknown.ShardID = 3
+	assert.Error(t, sendKeepalive(unknown))
+}
+
 func TestIssue106(t *testing.T) {
 	clusterSize := 3
 	cluster := newTestClusterProphet(t, clusterSize, func(c *config.Config) {
@@ -47,6 +47,31 @@ func (c *RaftCluster) HandleShardHeartbeat(res *core.CachedShard) error {
 	return nil
 }
 
+// HandleShardKeepalive processes the keepalive sent instead of the heartbeat of
+// a shard unchanged since its last heartbeat. The pending operators of the shard
+// are dispatched as on heartbeats. An error is returned if the cached shard is
+// not the one described by the keepalive, so that a full heartbeat is sent.
+func (c *RaftCluster) HandleShardKeepalive(req rpcpb.ShardKeepaliveReq) error {
+	c.RLock()
+	co := c.coordinator
+	c.RUnlock()
+
+	res := c.GetShard(req.ShardID)
+	if res == nil {
+		return fmt.Errorf("shard %d not found", req.ShardID)
+	}
+	epoch := res.Meta.GetEpoch()
+	if res.GetTerm() != req.Term ||
+		res.GetLeader().GetID() != req.Leader.ID ||
+		epoch.ConfigVer != req.Epoch.ConfigVer ||
+		epoch.Generation != req.Epoch.Generation {
+		return fmt.Errorf("shard %d changed since the last heartbeat", req.ShardID)
+	}
+
+	co.opController.Dispatch(res, schedule.DispatchFromHeartBeat)
+	return nil
+}
+
 // HandleCreateDestroying handle create destroying
 func (c *RaftCluster) HandleCreateDestroying(req rpcpb.CreateDestroyingReq) (metapb.ShardState, error) {
 	c.Lock()
@@ -352,17 +352,45 @@ func (mr *MockClientMockRecorder) ReportDestroyed(id, replicaID interface{}) *go
 }
 
 // ShardHeartbeat mocks base method.
-func (m *MockClient) ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatReq) error {
+func (m *MockClient) ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatReq, cb func(error)) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ShardHeartbeat", meta, hb)
+	ret := m.ctrl.Call(m, "ShardHeartbeat", meta, hb, cb)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ShardHeartbeat indicates an expected call of ShardHeartbeat.
-func (mr *MockClientMockRecorder) ShardHeartbeat(meta, hb interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) ShardHeartbeat(meta, hb, cb interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShardHeartbeat", reflect.TypeOf((*MockClient)(nil).ShardHeartbeat), meta, hb)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShardHeartbeat", reflect.TypeOf((*MockClient)(nil).ShardHeartbeat), meta, hb, cb)
+}
+
+// ShardHeartbeats mocks base method.
+func (m *MockClient) ShardHeartbeats(metas []metapb.Shard, hbs []rpcpb.ShardHeartbeatReq) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShardHeartbeats", metas, hbs)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// ShardHeartbeats indicates an expected call of ShardHeartbeats.
+func (mr *MockClientMockRecorder) ShardHeartbeats(metas, hbs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShardHeartbeats", reflect.TypeOf((*MockClient)(nil).ShardHeartbeats), metas, hbs)
+}
+
+// ShardKeepalive mocks base method.
+func (m *MockClient) ShardKeepalive(req rpcpb.ShardKeepaliveReq, cb func(error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShardKeepalive", req, cb)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShardKeepalive indicates an expected call of ShardKeepalive.
+func (mr *MockClientMockRecorder) ShardKeepalive(req, cb interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShardKeepalive", reflect.TypeOf((*MockClient)(nil).ShardKeepalive), req, cb)
 }
 
 // StoreHeartbeat mocks base method.
@@ -79,6 +79,15 @@ func (p *defaultProphet) handleRPCRequest(rs goetty.IOSession, data interface{},
 		if err != nil {
 			resp.Error = err.Error()
 		}
+	case rpcpb.TypeShardHeartbeatsReq:
+		resp.Type = rpcpb.TypeShardHeartbeatsRsp
+		p.handleShardHeartbeats(rc, req, resp)
+	case rpcpb.TypeShardKeepaliveReq:
+		resp.Type = rpcpb.TypeShardKeepaliveRsp
+		err := rc.HandleShardKeepalive(req.ShardKeepalive)
+		if err != nil {
+			resp.Error = err.Error()
+		}
 	case rpcpb.TypeStoreHeartbeatReq:
 		resp.Type = rpcpb.TypeStoreHeartbeatRsp
 		err := p.handleStoreHeartbeat(rc, req, resp)
@@ -228,19 +237,34 @@ func (p *defaultProphet) handlePutStore(rc *cluster.RaftCluster, req *rpcpb.Prop
 }
 
 func (p *defaultProphet) handleShardHeartbeat(rc *cluster.RaftCluster, req *rpcpb.ProphetRequest, resp *rpcpb.ProphetResponse) error {
+	return p.doHandleShardHeartbeat(rc, req.ShardHeartbeat)
+}
+
+// handleShardHeartbeats handles each heartbeat in the request, the failure of a
+// heartbeat does not fail the others.
+func (p *defaultProphet) handleShardHeartbeats(rc *cluster.RaftCluster, req *rpcpb.ProphetRequest, resp *rpcpb.ProphetResponse) {
+	resp.ShardHeartbeats.Errors = make([]string, len(req.ShardHeartbeats.Heartbeats))
+	for i, hb := range req.ShardHeartbeats.Heartbeats {
+		if err := p.doHandleShardHeartbeat(rc, hb); err != nil {
+			resp.ShardHeartbeats.Errors[i] = err.Error()
+		}
+	}
+}
+
+func (p *defaultProphet) doHandleShardHeartbeat(rc *cluster.RaftCluster, hb rpcpb.ShardHeartbeatReq) error {
 	meta := metapb.Shard{}
-	err := meta.Unmarshal(req.ShardHeartbeat.Shard)
+	err := meta.Unmarshal(hb.Shard)
 	if err != nil {
 		return err
 	}
 
-	storeID := req.ShardHeartbeat.GetLeader().GetStoreID()
+	storeID := hb.GetLeader().GetStoreID()
 	store := rc.GetStore(storeID)
 	if store == nil {
 		return fmt.Errorf("invalid contianer ID %d, not found", storeID)
 	}
 
-	res := core.ShardFromHeartbeat(req.ShardHeartbeat, meta)
+	res := core.ShardFromHeartbeat(hb, meta)
 	if res.GetLeader() == nil {
 		err := errors.New("invalid request, the leader is nil")
 		p.logger.Error("invalid request, the leader is nil")
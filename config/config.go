@@ -26,7 +26,9 @@ import (
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/transport"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
 	"github.com/matrixorigin/matrixcube/vfs"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -34,27 +36,39 @@ var (
 	kb = 1024
 	mb = 1024 * kb
 
-	defaultSendRaftBatchSize        uint64 = 64
-	defaultMaxConcurrencySnapChunks uint64 = 8
-	defaultSnapChunkSize                   = 4 * mb
-	defaultRaftMaxWorkers           uint64 = 64
-	defaultRaftElectionTick                = 10
-	defaultRaftHeartbeatTick               = 2
-	defaultShardStateCheckDuration         = time.Second * 60
-	defaultCompactLogCheckDuration         = time.Second * 60
-	defaultMaxEntryBytes                   = 10 * mb
-	defaultMaxAllowTransferLag      uint64 = 2
-	defaultCompactThreshold         uint64 = 256
-	defaultRaftTickDuration                = time.Second
-	defaultMaxPeerDownTime                 = time.Minute * 30
-	defaultShardHeartbeatDuration          = time.Second * 2
-	defaultStoreHeartbeatDuration          = time.Second * 10
-	defaultMaxInflightMsgs                 = 8
-	defaultDataPath                        = "/tmp/matrixcube"
-	defaultSnapshotDirName                 = "snapshots"
-	defaultProphetDirName                  = "prophet"
-	defaultRaftAddr                        = "127.0.0.1:20001"
-	defaultRPCAddr                         = "127.0.0.1:20002"
+	defaultSendRaftBatchSize          uint64 = 64
+	defaultMaxConcurrencySnapChunks   uint64 = 8
+	defaultSnapChunkSize                     = 4 * mb
+	defaultRaftMaxWorkers             uint64 = 64
+	defaultRaftElectionTick                  = 10
+	defaultRaftHeartbeatTick                 = 2
+	defaultShardStateCheckDuration           = time.Second * 60
+	defaultCompactLogCheckDuration           = time.Second * 60
+	defaultMaxEntryBytes                     = 10 * mb
+	defaultMaxAllowTransferLag        uint64 = 2
+	defaultCompactThreshold           uint64 = 256
+	defaultRaftTickDuration                  = time.Second
+	defaultMaxPeerDownTime                   = time.Minute * 30
+	defaultShardHeartbeatDuration            = time.Second * 2
+	defaultStoreHeartbeatDuration            = time.Second * 10
+	defaultLeaderCountReportDuration         = time.Second * 30
+	defaultHeartbeatBatchInterval            = time.Millisecond * 100
+	defaultTombstoneGCDuration               = time.Minute
+	defaultTombstoneRetention                = time.Minute * 10
+	defaultMaxInflightMsgs                   = 8
+	defaultMaxSnapshotStatusQueueSize        = 128
+	defaultMaxConfigChangeHistory            = 16
+	defaultReadyBatchSize                    = 1024
+	defaultMaxFreezeDuration                 = time.Second * 10
+	defaultMaxInitRetryDuration              = time.Second * 10
+	defaultMetricsFlushInterval              = time.Second
+	defaultWriteThroughQueueSize             = 1024
+	defaultApplyBurstEntries                 = 64
+	defaultDataPath                          = "/tmp/matrixcube"
+	defaultSnapshotDirName                   = "snapshots"
+	defaultProphetDirName                    = "prophet"
+	defaultRaftAddr                          = "127.0.0.1:20001"
+	defaultRPCAddr                           = "127.0.0.1:20002"
 )
 
 // Config matrixcube config
@@ -132,6 +146,15 @@ func (c *Config) Adjust() {
 	(&c.Snapshot).adjust()
 	(&c.Replication).adjust()
 	(&c.Raft).adjust()
+	if c.Replication.MaxPeerDownTime.Duration < c.Raft.GetHeartbeatDuration() {
+		panic("invalid Config.Replication.MaxPeerDownTime, must not be less than the raft heartbeat duration")
+	}
+	if c.Customize.KeyCodec == nil {
+		c.Customize.KeyCodec = keysutil.DataKeyCodec{}
+	}
+	if err := storage.ValidateKeyCodec(c.Customize.KeyCodec); err != nil {
+		panic("invalid Config.Customize.KeyCodec, " + err.Error())
+	}
 	c.Prophet.DataDir = path.Join(c.DataPath, defaultProphetDirName)
 	c.Prophet.StoreHeartbeatDataProcessor = c.Customize.CustomStoreHeartbeatDataProcessor
 	if err := (&c.Prophet).Adjust(nil, false); err != nil {
@@ -170,6 +193,10 @@ func (c *Config) validate() {
 	if c.Storage.ForeachDataStorageFunc == nil {
 		panic("missing Config.Storage.ForeachDataStorageFunc")
 	}
+
+	if c.Raft.ReadyBatchSize < 0 {
+		panic("invalid Config.Raft.ReadyBatchSize, must be positive")
+	}
 }
 
 // SnapshotDir returns snapshot dir
@@ -190,6 +217,28 @@ type ReplicationConfig struct {
 	ShardStateCheckDuration typeutil.Duration `toml:"shard-state-check-duration"`
 	CompactLogCheckDuration typeutil.Duration `toml:"compact-log-check-duration"`
 	AllowRemoveLeader       bool              `toml:"allow-remove-leader"`
+	// LeaderCountReportDuration is the interval of reporting the number of shard
+	// leaders of each group on the current store.
+	LeaderCountReportDuration typeutil.Duration `toml:"leader-count-report-duration"`
+	// ShardHeartbeatBatchSize max number of shard heartbeats sent to prophet in
+	// a single batch. The heartbeats of the shard leaders on the current store
+	// are collected and sent in batches if it is greater than 0.
+	ShardHeartbeatBatchSize int `toml:"shard-heartbeat-batch-size"`
+	// ShardHeartbeatBatchInterval is the interval of flushing the collected
+	// shard heartbeats, a batch is flushed earlier once it is full.
+	ShardHeartbeatBatchInterval typeutil.Duration `toml:"shard-heartbeat-batch-interval"`
+	// MaxShardKeepalives max number of consecutive keepalives sent instead of
+	// the shard heartbeats when nothing about the shard changed since the last
+	// heartbeat, so a full heartbeat is sent at least every MaxShardKeepalives+1
+	// intervals. 0 means always sending full heartbeats.
+	MaxShardKeepalives int `toml:"max-shard-keepalives"`
+	// TombstoneGCDuration is the interval of destroying the replicas removed by
+	// config changes but left undestroyed on the current store.
+	TombstoneGCDuration typeutil.Duration `toml:"tombstone-gc-duration"`
+	// TombstoneRetention is the time the removed replicas are kept before they
+	// are destroyed by the tombstone GC, so that the in-flight messages of them
+	// are not raced.
+	TombstoneRetention typeutil.Duration `toml:"tombstone-retention"`
 }
 
 func (c *ReplicationConfig) adjust() {
@@ -212,6 +261,22 @@ func (c *ReplicationConfig) adjust() {
 	if c.CompactLogCheckDuration.Duration == 0 {
 		c.CompactLogCheckDuration.Duration = defaultCompactLogCheckDuration
 	}
+
+	if c.LeaderCountReportDuration.Duration == 0 {
+		c.LeaderCountReportDuration.Duration = defaultLeaderCountReportDuration
+	}
+
+	if c.ShardHeartbeatBatchInterval.Duration == 0 {
+		c.ShardHeartbeatBatchInterval.Duration = defaultHeartbeatBatchInterval
+	}
+
+	if c.TombstoneGCDuration.Duration == 0 {
+		c.TombstoneGCDuration.Duration = defaultTombstoneGCDuration
+	}
+
+	if c.TombstoneRetention.Duration == 0 {
+		c.TombstoneRetention.Duration = defaultTombstoneRetention
+	}
 }
 
 // SnapshotConfig snapshot config
@@ -270,6 +335,128 @@ type RaftConfig struct {
 	RaftLog RaftLogConfig `toml:"raft-log"`
 	// LimitRequestBytesPerShard request's bytes per second limit
 	LimitRequestBytesPerShard typeutil.ByteSize `toml:"send-raft-batch-size"`
+	// MaxApplyLag max gap between the committed index and the applied index of a
+	// shard leader, new write proposals are rejected with a retryable ServerIsBusy
+	// error once the gap is exceeded. 0 means no limit.
+	MaxApplyLag uint64 `toml:"max-apply-lag"`
+	// ReadyBatchSize max number of items drained from each replica queue in a
+	// single round of the replica event loop.
+	ReadyBatchSize int `toml:"ready-batch-size"`
+	// TargetBatchLatency target processing time of a single round of the replica
+	// event loop, the number of items drained from the replica queues in a round
+	// is adjusted to meet it. 0 means always use the fixed batch size.
+	TargetBatchLatency typeutil.Duration `toml:"target-batch-latency"`
+	// WriteDedupWindow how many recently applied write requests are remembered by
+	// each shard, a retried write request with the same request ID in the window
+	// is not applied again and the original response is returned. 0 means disabled.
+	WriteDedupWindow int `toml:"write-dedup-window"`
+	// MaxSnapshotStatusQueueSize max number of pending snapshot statuses of a
+	// shard. Only the latest status of each target replica is kept, once the
+	// queue is full the oldest status is dropped.
+	MaxSnapshotStatusQueueSize int `toml:"max-snapshot-status-queue-size"`
+	// JointStateTimeout how long a shard leader waits for the explicit leave
+	// joint config change after the shard entered the joint state, a leave joint
+	// config change is proposed automatically once it is exceeded. 0 means never.
+	JointStateTimeout typeutil.Duration `toml:"joint-state-timeout"`
+	// UnavailableTimeout how long a shard leader with pending proposals waits for
+	// a raft log to be committed before it considers the shard unavailable, e.g.
+	// the quorum is lost, see Store.OnShardUnavailable. 0 means never.
+	UnavailableTimeout typeutil.Duration `toml:"unavailable-timeout"`
+	// MaxRequestQueueSize max number of pending requests of a shard, new requests
+	// are rejected once it is exceeded. 0 means no limit.
+	MaxRequestQueueSize int `toml:"max-request-queue-size"`
+	// MaxActionQueueSize max number of pending actions of a shard. Once it is
+	// exceeded, new split and log compaction actions are retried with backoff
+	// and other actions are dropped. 0 means no limit.
+	MaxActionQueueSize int `toml:"max-action-queue-size"`
+	// MaxMessageQueueSize max number of pending raft messages of a shard. Once it
+	// is exceeded, new heartbeat messages are dropped, and once twice of it is
+	// exceeded, all new messages except votes and snapshots are dropped. 0 means
+	// no limit.
+	MaxMessageQueueSize int `toml:"max-message-queue-size"`
+	// ShutdownDrainTimeout how long a closing replica keeps applying the entries
+	// already committed, so that their proposals get the real responses rather
+	// than the store not match error. 0 means not waiting.
+	ShutdownDrainTimeout typeutil.Duration `toml:"shutdown-drain-timeout"`
+	// MaxFreezeDuration max duration a shard stays frozen by a backup, the shard
+	// resumes proposing and applying once it is exceeded even if the backup does
+	// not unfreeze it, to avoid stalling the cluster.
+	MaxFreezeDuration typeutil.Duration `toml:"max-freeze-duration"`
+	// MaxInitRetryDuration how long a shard replica retries the transient logdb
+	// and data storage failures while loading its initial state, before giving
+	// up the initialization.
+	MaxInitRetryDuration typeutil.Duration `toml:"max-init-retry-duration"`
+	// MetricsFlushInterval how often the raft metrics accumulated by the shard
+	// replicas on the store are flushed to the metric backend in a single batch.
+	MetricsFlushInterval typeutil.Duration `toml:"metrics-flush-interval"`
+	// ServeStaleReadsAfterRemoved allow a shard replica removed by a config change
+	// but not yet destroyed to serve the reads sent by Store.OnStaleReadWithCB
+	// from its local data, which may be stale. Writes are always refused.
+	ServeStaleReadsAfterRemoved bool `toml:"serve-stale-reads-after-removed"`
+	// RejectRateLimitedRequests reject the requests exceeding the
+	// LimitRequestBytesPerShard instead of waiting for the rate limiter.
+	RejectRateLimitedRequests bool `toml:"reject-rate-limited-requests"`
+	// MaxPromoteLearnerLag max gap between the last index of the shard leader and
+	// the match index of a learner, the learner can not be promoted to voter until
+	// it catches up. 0 means no limit.
+	MaxPromoteLearnerLag uint64 `toml:"max-promote-learner-lag"`
+	// MaxPendingReplicaLag max gap between the last index of the shard leader and
+	// the match index of a replica, the replicas lagging further behind are
+	// reported to prophet as pending replicas with the shard heartbeat, along with
+	// the replicas waiting for snapshots. 0 means only the replicas waiting for
+	// snapshots are pending.
+	MaxPendingReplicaLag uint64 `toml:"max-pending-replica-lag"`
+	// ReadOnly the replicas on the store never tick and campaign, so they never
+	// become the shard leader and only replicate the raft logs.
+	ReadOnly bool `toml:"read-only"`
+	// MaxConfigChangeHistory how many recently applied config changes are kept by
+	// each shard for auditing and debugging.
+	MaxConfigChangeHistory int `toml:"max-config-change-history"`
+	// MaxRepeatedConfigChanges how many times the same config change can be
+	// proposed by a shard leader among its recent config changes before it is
+	// reported by the log and the metric, repeated proposals usually mean the
+	// scheduler is stuck on a change that keeps failing. 0 means disabled.
+	MaxRepeatedConfigChanges int `toml:"max-repeated-config-changes"`
+	// EventQueueBudget max number of items drained from each replica queue in a
+	// single round of the replica event loop.
+	EventQueueBudget EventQueueBudgetConfig `toml:"event-queue-budget"`
+	// ReportCompactionLag attach the first index of the raft log to the raft
+	// messages, so the shard leader can report how far the compacted raft log of
+	// each follower falls behind its own, a follower with a large lag is likely
+	// to need a snapshot.
+	ReportCompactionLag bool `toml:"report-compaction-lag"`
+	// AllowPartialWrite allow the data storage to return storage.ErrPartialWrite
+	// when only a part of the write requests in the batch are applied, the
+	// requests not applied are responded with the error instead of crashing the
+	// store. The data storage must make sure the same requests are applied on
+	// all replicas.
+	AllowPartialWrite bool `toml:"allow-partial-write"`
+	// MaxApplyFailures how many times applying the same committed log can fail,
+	// counted across restarts, before the replica is quarantined. The failures
+	// before that still crash the store, so the log is applied again after the
+	// restart. The quarantined replica stops applying the committed logs and
+	// rejects all requests, so a single poison log can not crash-loop the whole
+	// store. 0 means the replica is never quarantined.
+	MaxApplyFailures int `toml:"max-apply-failures"`
+	// ApplyWriteBatch batch the write requests of the consecutive committed logs
+	// into a single write of the data storage when applying them.
+	ApplyWriteBatch ApplyWriteBatchConfig `toml:"apply-write-batch"`
+	// ApplyBurstEntries min number of committed logs applied at once to be
+	// considered as a burst of apply work, the background compactions of the data
+	// storages implementing storage.CompactionCoordinator are paused while
+	// applying them.
+	ApplyBurstEntries int `toml:"apply-burst-entries"`
+	// EnforceKeyRange reject the write requests whose keys are not in the range
+	// of the shard when applying them, such requests are sent by the clients
+	// with stale routes, especially right after the shard is split.
+	EnforceKeyRange bool `toml:"enforce-key-range"`
+	// MaxReplicasPerShard max number of replicas of a shard, the config changes
+	// adding more replicas are rejected when applying them. 0 means no limit.
+	MaxReplicasPerShard int `toml:"max-replicas-per-shard"`
+	// WriteThroughQueueSize max number of pending write through events when the
+	// Customize.CustomWriteThroughHandler is set, the events are dropped once the
+	// queue is full.
+	WriteThroughQueueSize int `toml:"write-through-queue-size"`
 }
 
 // GetElectionTimeoutDuration returns ElectionTimeoutTicks * TickInterval
@@ -299,10 +486,42 @@ func (c *RaftConfig) adjust() {
 		c.MaxInflightMsgs = defaultMaxInflightMsgs
 	}
 
+	if c.MaxSnapshotStatusQueueSize == 0 {
+		c.MaxSnapshotStatusQueueSize = defaultMaxSnapshotStatusQueueSize
+	}
+
+	if c.MaxConfigChangeHistory == 0 {
+		c.MaxConfigChangeHistory = defaultMaxConfigChangeHistory
+	}
+
+	if c.ReadyBatchSize == 0 {
+		c.ReadyBatchSize = defaultReadyBatchSize
+	}
+
+	if c.MaxFreezeDuration.Duration == 0 {
+		c.MaxFreezeDuration.Duration = defaultMaxFreezeDuration
+	}
+
+	if c.MaxInitRetryDuration.Duration == 0 {
+		c.MaxInitRetryDuration.Duration = defaultMaxInitRetryDuration
+	}
+
+	if c.MetricsFlushInterval.Duration == 0 {
+		c.MetricsFlushInterval.Duration = defaultMetricsFlushInterval
+	}
+
 	if c.SendRaftBatchSize == 0 {
 		c.SendRaftBatchSize = defaultSendRaftBatchSize
 	}
 
+	if c.WriteThroughQueueSize == 0 {
+		c.WriteThroughQueueSize = defaultWriteThroughQueueSize
+	}
+
+	if c.ApplyBurstEntries == 0 {
+		c.ApplyBurstEntries = defaultApplyBurstEntries
+	}
+
 	if c.MaxEntryBytes == 0 {
 		c.MaxEntryBytes = typeutil.ByteSize(defaultMaxEntryBytes)
 	}
@@ -319,6 +538,27 @@ type RaftLogConfig struct {
 	DisableSync         bool   `toml:"disable-sync"`
 	CompactThreshold    uint64 `toml:"compact-threshold"`
 	MaxAllowTransferLag uint64 `toml:"max-allow-transfer-lag"`
+	// MaxCompactionSnapshotRate max number of snapshots per second in the store
+	// induced by log compactions which compact past the match index of lagging
+	// replicas. Such log compactions are skipped once the rate is exceeded. 0
+	// means no limit.
+	MaxCompactionSnapshotRate float64 `toml:"max-compaction-snapshot-rate"`
+	// EnableGroupCompactionStats aggregate the compaction statistics by shard
+	// group, they are exposed by the store API and the metrics labeled by group.
+	EnableGroupCompactionStats bool `toml:"enable-group-compaction-stats"`
+	// SnapshotTransferTimeout defer the log compactions removing the entries
+	// following the snapshots being sent to the followers, until the transfers
+	// complete or exceed the timeout. 0 means never defer.
+	SnapshotTransferTimeout typeutil.Duration `toml:"snapshot-transfer-timeout"`
+	// LaggingHighWaterMark the shard leader marks a replica as lagging once the
+	// number of raft log entries it has not replicated exceeds the mark, the
+	// replica is marked until the lag drops below LaggingLowWaterMark. 0 means
+	// the lagging replicas are not tracked.
+	LaggingHighWaterMark uint64 `toml:"lagging-high-water-mark"`
+	// LaggingLowWaterMark the lag below which a lagging replica is no longer
+	// marked as lagging, defaults to LaggingHighWaterMark. It must not be greater
+	// than LaggingHighWaterMark.
+	LaggingLowWaterMark uint64 `toml:"lagging-low-water-mark"`
 }
 
 func (c *RaftLogConfig) adjust() {
@@ -329,6 +569,44 @@ func (c *RaftLogConfig) adjust() {
 	if c.CompactThreshold == 0 {
 		c.CompactThreshold = defaultCompactThreshold
 	}
+
+	if c.LaggingLowWaterMark == 0 {
+		c.LaggingLowWaterMark = c.LaggingHighWaterMark
+	}
+	if c.LaggingLowWaterMark > c.LaggingHighWaterMark {
+		panic("invalid Config.Raft.RaftLog.LaggingLowWaterMark, must not be greater than LaggingHighWaterMark")
+	}
+}
+
+// EventQueueBudgetConfig caps the number of items drained from each replica
+// queue in a single round of the replica event loop, so that a flood of one
+// kind of event can not starve the processing of the others. The queues are
+// still drained in the same order, the budget only bounds the slice of work
+// each queue gets. 0 means the queue is only limited by the batch size.
+type EventQueueBudgetConfig struct {
+	Messages       int `toml:"messages"`
+	Ticks          int `toml:"ticks"`
+	Feedbacks      int `toml:"feedbacks"`
+	SnapshotStatus int `toml:"snapshot-status"`
+	Requests       int `toml:"requests"`
+	Actions        int `toml:"actions"`
+	// ActionsDuration max time spent on handling the actions in a single round,
+	// the actions left are handled in the next round after the other events.
+	// 0 means no limit.
+	ActionsDuration typeutil.Duration `toml:"actions-duration"`
+}
+
+// ApplyWriteBatchConfig limits of batching the write requests of the
+// consecutive committed logs into a single write of the data storage, which
+// reduces the storage writes and syncs when many small logs are committed
+// together.
+type ApplyWriteBatchConfig struct {
+	// MaxEntries max number of committed logs written together, batching is
+	// disabled if it is less than 2.
+	MaxEntries int `toml:"max-entries"`
+	// MaxBytes max bytes of the committed logs written together, 0 means no
+	// limit.
+	MaxBytes typeutil.ByteSize `toml:"max-bytes"`
 }
 
 // StorageConfig storage config
@@ -360,6 +638,67 @@ type CustomizeConfig struct {
 		lease metapb.EpochLease,
 		req rpcpb.Request,
 		cb func(resp []byte, err error)) error `json:"-" toml:"-"`
+	// CustomGroupKeyFunc returns the group key of the shard used by the prophet scheduler to
+	// bucket shards, it overrides the key built from the schedule group rules. The returned
+	// key must start with the encoded shard group, an empty key means using the default one.
+	CustomGroupKeyFunc func(shard metapb.Shard) string `json:"-" toml:"-"`
+	// CustomSnapshotCreatedHandler is invoked in the event worker of the replica after a
+	// snapshot of the shard is created and registered, it must not block.
+	CustomSnapshotCreatedHandler func(event SnapshotCreatedEvent) `json:"-" toml:"-"`
+	// CustomWriteThroughHandler forwards the writes applied to the data storage to a
+	// secondary storage, e.g. mirroring them for analytics. It is invoked in a dedicated
+	// worker of the store after the primary writes are applied, best effort only, the
+	// returned errors and the events dropped are counted by the metrics and never fail
+	// the primary writes.
+	CustomWriteThroughHandler func(event WriteThroughEvent) error `json:"-" toml:"-"`
+	// KeyCodec encodes the keys of the application into the data keys stored in the
+	// data storage, the built-in keys codec is used by default. It must be passed to
+	// the kv.NewBaseStorage and kv.NewKVDataStorage by the kv.WithKeyCodec option,
+	// to the executor.NewKVExecutor by the executor.WithKeyCodec option and to the
+	// scans by the executor.WithScanKeyCodec option, so all the data keys written,
+	// read, scanned, split and included in the snapshots are encoded by the same
+	// codec. The transactional storage only supports the built-in codec.
+	KeyCodec storage.KeyCodec `json:"-" toml:"-"`
+	// PreApplyHook is invoked synchronously with the write requests of each committed
+	// log in the commit order, before they are written to the data storage, e.g. to
+	// mirror the writes to an external WAL or CDC stream. Only the applied requests
+	// are passed, the duplicated ones and those with keys not in the shard are not,
+	// and the hook is not invoked for a log without any applied request. A returned
+	// error aborts the apply of the log, the failure is handled as a failed write of
+	// the data storage, see Raft.MaxApplyFailures. The logs batched before the failed
+	// one are still applied, only the failed log is passed again after a restart.
+	PreApplyHook func(shardID uint64, requests []rpcpb.Request) error `json:"-" toml:"-"`
+	// Tracer is used to trace the requests of the shard replicas from they are
+	// received until their responses are returned, with a span for each of the
+	// proposal, the commit and the apply stages. Nil disables the tracing.
+	Tracer trace.Tracer `json:"-" toml:"-"`
+}
+
+// WriteThroughEvent describes the writes applied to the data storage by a shard
+// replica, which are forwarded to the secondary storage.
+type WriteThroughEvent struct {
+	// ShardID is the id of the shard
+	ShardID uint64
+	// Index is the raft log index of the writes
+	Index uint64
+	// Requests is the write requests successfully applied, in the applied order
+	Requests []storage.Request
+}
+
+// SnapshotCreatedEvent describes a snapshot created by a shard replica.
+type SnapshotCreatedEvent struct {
+	// ShardID is the id of the shard
+	ShardID uint64
+	// ReplicaID is the id of the replica which created the snapshot
+	ReplicaID uint64
+	// Index is the raft log index of the snapshot
+	Index uint64
+	// Term is the raft term of the snapshot
+	Term uint64
+	// Size is the bytes of the snapshot directory on disk
+	Size uint64
+	// Duration is the time taken to save and register the snapshot
+	Duration time.Duration
 }
 
 // GetLabels returns lables
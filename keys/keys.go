@@ -25,6 +25,10 @@ const (
 	appliedIndexSuffix = 0x07
 	metadataSuffix     = 0x08
 	snapshotSuffix     = 0x09
+	committedSuffix    = 0x0A
+	applyFailureSuffix = 0x0B
+	// appliedRequestSuffix is followed by the ID of the applied write request
+	appliedRequestSuffix = 0x0C
 )
 
 // data is in (z, z+1)
@@ -86,6 +90,48 @@ func GetHardStateKey(shardID uint64, replicaID uint64, key []byte) []byte {
 	return getIndexedIDKey(hardStateSuffix, shardID, replicaID, key)
 }
 
+// GetCommittedIndexesKey returns key that used to store the committed indexes
+// of the replicas known by the shard replica on the current store
+func GetCommittedIndexesKey(shardID uint64, key []byte) []byte {
+	key = getKeySlice(key, idKeyLength)
+	return getIDKey(committedSuffix, shardID, key)
+}
+
+// GetApplyFailureKey returns key that used to store the index of the committed
+// log failed to be applied and the number of failures
+func GetApplyFailureKey(shardID uint64, key []byte) []byte {
+	key = getKeySlice(key, idKeyLength)
+	return getIDKey(applyFailureSuffix, shardID, key)
+}
+
+// GetAppliedRequestKey returns key that used to store a recently applied write
+// request of the shard, the key ends with the request ID
+func GetAppliedRequestKey(shardID uint64, id []byte, key []byte) []byte {
+	key = getKeySlice(key, idKeyLength+len(id))
+	getIDKey(appliedRequestSuffix, shardID, key)
+	copy(key[idKeyLength:], id)
+	return key[:idKeyLength+len(id)]
+}
+
+// GetAppliedRequestRange returns the range of the applied request keys of the
+// shard
+func GetAppliedRequestRange(shardID uint64) ([]byte, []byte) {
+	return getIDKey(appliedRequestSuffix, shardID, make([]byte, idKeyLength)),
+		getIDKey(appliedRequestSuffix+1, shardID, make([]byte, idKeyLength))
+}
+
+// GetAppliedRequestID returns the request ID in the applied request key
+func GetAppliedRequestID(key []byte) ([]byte, error) {
+	if !IsAppliedRequestKey(key) {
+		return nil, fmt.Errorf("key<%v> is not a valid applied request key", key)
+	}
+	return key[idKeyLength:], nil
+}
+
+func IsAppliedRequestKey(key []byte) bool {
+	return isRaftSuffixKey(key, appliedRequestSuffix) && len(key) > idKeyLength
+}
+
 // GetAppliedIndexKey returns key that used to store `applied log index` for `storage.DataStorage`
 func GetAppliedIndexKey(shardID uint64, key []byte) []byte {
 	key = getKeySlice(key, idKeyLength)
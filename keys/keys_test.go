@@ -14,6 +14,7 @@
 package keys
 
 import (
+	"bytes"
 	"math"
 	"testing"
 
@@ -230,6 +231,26 @@ func TestGetAppliedIndexKey(t *testing.T) {
 	assert.True(t, IsAppliedIndexKey(key4))
 }
 
+func TestGetAppliedRequestKey(t *testing.T) {
+	id := []byte("request-1")
+	key1 := GetAppliedRequestKey(10, id, nil)
+	key2 := GetAppliedRequestKey(10, id, make([]byte, idKeyLength*4))
+	assert.Equal(t, key1, key2)
+	assert.True(t, IsAppliedRequestKey(key1))
+	assert.False(t, IsAppliedIndexKey(key1))
+	assert.False(t, IsAppliedRequestKey(GetAppliedIndexKey(10, nil)))
+	v, err := GetAppliedRequestID(key1)
+	assert.NoError(t, err)
+	assert.Equal(t, id, v)
+	_, err = GetAppliedRequestID(GetAppliedIndexKey(10, nil))
+	assert.Error(t, err)
+
+	min, max := GetAppliedRequestRange(10)
+	assert.True(t, bytes.Compare(min, key1) < 0)
+	assert.True(t, bytes.Compare(key1, max) < 0)
+	assert.True(t, bytes.Compare(max, GetAppliedRequestKey(11, nil, nil)) < 0)
+}
+
 func TestGetMaxIndexKey(t *testing.T) {
 	keyL := make([]byte, indexedIDKeyLength*2)
 	keyI := make([]byte, indexedIDKeyLength)
@@ -115,6 +115,18 @@ type LogDB interface {
 	// RemoveReplicaData removes all LogDB data that belongs to the specified
 	// replica.
 	RemoveReplicaData(shardID uint64) error
+	// SaveCommittedIndexes saves the committed indexes of the replicas known by
+	// the specified shard replica, keyed by replica id.
+	SaveCommittedIndexes(shardID uint64, indexes map[uint64]uint64) error
+	// GetCommittedIndexes returns the committed indexes saved by
+	// SaveCommittedIndexes, an empty map is returned if nothing saved.
+	GetCommittedIndexes(shardID uint64) (map[uint64]uint64, error)
+	// SaveApplyFailures saves the index of the committed log failed to be
+	// applied by the specified shard replica and the number of failures.
+	SaveApplyFailures(shardID uint64, index uint64, failures uint64) error
+	// GetApplyFailures returns the values saved by SaveApplyFailures, zeros are
+	// returned if nothing saved.
+	GetApplyFailures(shardID uint64) (uint64, uint64, error)
 }
 
 // KVLogDB is a LogDB implementation built on top of a Key-Value store.
@@ -357,10 +369,63 @@ func (l *KVLogDB) RemoveReplicaData(shardID uint64) error {
 	wc.wb.DeleteRange(fk, lk)
 	// max index
 	wc.wb.Delete(keys.GetMaxIndexKey(shardID, nil))
+	// committed indexes
+	wc.wb.Delete(keys.GetCommittedIndexesKey(shardID, nil))
+	// apply failures
+	wc.wb.Delete(keys.GetApplyFailureKey(shardID, nil))
 
 	return l.ms.Write(wc.wb, true)
 }
 
+func (l *KVLogDB) SaveCommittedIndexes(shardID uint64,
+	indexes map[uint64]uint64) error {
+	v := make([]byte, len(indexes)*16)
+	offset := 0
+	for id, index := range indexes {
+		binary.BigEndian.PutUint64(v[offset:], id)
+		binary.BigEndian.PutUint64(v[offset+8:], index)
+		offset += 16
+	}
+	return l.ms.Set(keys.GetCommittedIndexesKey(shardID, nil), v, false)
+}
+
+func (l *KVLogDB) GetCommittedIndexes(shardID uint64) (map[uint64]uint64, error) {
+	v, err := l.ms.Get(keys.GetCommittedIndexesKey(shardID, nil))
+	if err != nil {
+		return nil, err
+	}
+	if len(v)%16 != 0 {
+		panic("unexpected committed indexes value")
+	}
+	indexes := make(map[uint64]uint64, len(v)/16)
+	for ; len(v) > 0; v = v[16:] {
+		indexes[binary.BigEndian.Uint64(v)] = binary.BigEndian.Uint64(v[8:])
+	}
+	return indexes, nil
+}
+
+func (l *KVLogDB) SaveApplyFailures(shardID uint64,
+	index uint64, failures uint64) error {
+	v := make([]byte, 16)
+	binary.BigEndian.PutUint64(v, index)
+	binary.BigEndian.PutUint64(v[8:], failures)
+	return l.ms.Set(keys.GetApplyFailureKey(shardID, nil), v, true)
+}
+
+func (l *KVLogDB) GetApplyFailures(shardID uint64) (uint64, uint64, error) {
+	v, err := l.ms.Get(keys.GetApplyFailureKey(shardID, nil))
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(v) == 0 {
+		return 0, 0, nil
+	}
+	if len(v) != 16 {
+		panic("unexpected apply failures value")
+	}
+	return binary.BigEndian.Uint64(v), binary.BigEndian.Uint64(v[8:]), nil
+}
+
 func (l *KVLogDB) getRange(shardID uint64,
 	replicaID uint64, snapshotIndex uint64) (uint64, uint64, error) {
 	maxIndex, err := l.getMaxIndex(shardID, replicaID)
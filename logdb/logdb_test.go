@@ -464,6 +464,8 @@ func TestLogDBRemoveReplicaData(t *testing.T) {
 		if err := db.SaveRaftState(testShardID, testReplicaID, rd1, wc); err != nil {
 			t.Fatalf("failed to save raft state, %v", err)
 		}
+		assert.NoError(t, db.SaveCommittedIndexes(testShardID, map[uint64]uint64{1: 100}))
+		assert.NoError(t, db.SaveApplyFailures(testShardID, 10, 1))
 		assert.NoError(t, db.RemoveReplicaData(testShardID))
 		first, length, err := db.getRange(testShardID, testReplicaID, 0)
 		assert.NoError(t, err)
@@ -480,6 +482,55 @@ func TestLogDBRemoveReplicaData(t *testing.T) {
 		v, err := db.ms.Get(keys.GetHardStateKey(testShardID, testReplicaID, nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 0, len(v))
+
+		indexes, err := db.GetCommittedIndexes(testShardID)
+		assert.NoError(t, err)
+		assert.Empty(t, indexes)
+
+		index, failures, err := db.GetApplyFailures(testShardID)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), index)
+		assert.Equal(t, uint64(0), failures)
+	}
+	fs := vfs.GetTestFS()
+	runLogDBTest(t, tf, fs)
+}
+
+func TestLogDBCommittedIndexes(t *testing.T) {
+	tf := func(t *testing.T, db *KVLogDB) {
+		indexes, err := db.GetCommittedIndexes(testShardID)
+		assert.NoError(t, err)
+		assert.Empty(t, indexes)
+
+		assert.NoError(t, db.SaveCommittedIndexes(testShardID, map[uint64]uint64{1: 100, 2: 90}))
+		assert.NoError(t, db.SaveCommittedIndexes(testShardID+1, map[uint64]uint64{3: 10}))
+		indexes, err = db.GetCommittedIndexes(testShardID)
+		assert.NoError(t, err)
+		assert.Equal(t, map[uint64]uint64{1: 100, 2: 90}, indexes)
+
+		// overwritten
+		assert.NoError(t, db.SaveCommittedIndexes(testShardID, map[uint64]uint64{1: 120}))
+		indexes, err = db.GetCommittedIndexes(testShardID)
+		assert.NoError(t, err)
+		assert.Equal(t, map[uint64]uint64{1: 120}, indexes)
+	}
+	fs := vfs.GetTestFS()
+	runLogDBTest(t, tf, fs)
+}
+
+func TestLogDBApplyFailures(t *testing.T) {
+	tf := func(t *testing.T, db *KVLogDB) {
+		index, failures, err := db.GetApplyFailures(testShardID)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), index)
+		assert.Equal(t, uint64(0), failures)
+
+		assert.NoError(t, db.SaveApplyFailures(testShardID, 10, 1))
+		assert.NoError(t, db.SaveApplyFailures(testShardID, 10, 2))
+		index, failures, err = db.GetApplyFailures(testShardID)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10), index)
+		assert.Equal(t, uint64(2), failures)
 	}
 	fs := vfs.GetTestFS()
 	runLogDBTest(t, tf, fs)
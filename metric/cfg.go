@@ -23,6 +23,10 @@ type Cfg struct {
 	Interval int    `toml:"interval"`
 	Job      string `toml:"job"`
 	Instance string `toml:"instance"`
+	// MaxShardLabels enable the metrics labeled by shard ID for the shard
+	// replicas on the store, see ShardCollector. At most MaxShardLabels shards
+	// are labeled by their IDs, the others are aggregated. 0 means disabled.
+	MaxShardLabels int `toml:"max-shard-labels"`
 }
 
 func (c Cfg) instance() string {
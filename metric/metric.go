@@ -26,16 +26,48 @@ func MustRegister(cs ...prometheus.Collector) {
 	registry.MustRegister(cs...)
 }
 
+// Unregister Delegate the prometheus Unregister
+func Unregister(c prometheus.Collector) bool {
+	return registry.Unregister(c)
+}
+
+// Gatherer returns the prometheus Gatherer of the registered metrics
+func Gatherer() prometheus.Gatherer {
+	return registry
+}
+
 func init() {
 	registry.MustRegister(queueGauge)
+	registry.MustRegister(queueHighWaterGauge)
 	registry.MustRegister(batchGauge)
 	registry.MustRegister(storeStorageGauge)
 	registry.MustRegister(shardCountGauge)
+	registry.MustRegister(shardApplyRateGauge)
+	registry.MustRegister(shardCompactionLagGauge)
+	registry.MustRegister(shardLaggingGauge)
+	registry.MustRegister(shardUnavailableGauge)
+	registry.MustRegister(shardQPSGauge)
+	registry.MustRegister(raftLogSizeGauge)
+	registry.MustRegister(groupLeaderCountGauge)
+	registry.MustRegister(prophetHeartbeatFailuresGauge)
+	registry.MustRegister(snapshotApplyProgressGauge)
+	registry.MustRegister(shardSizeDiffGauge)
 
 	registry.MustRegister(raftReadyCounter)
 	registry.MustRegister(raftMsgsCounter)
 	registry.MustRegister(raftCommandCounter)
 	registry.MustRegister(raftAdminCommandCounter)
+	registry.MustRegister(snapshotReclaimedBytesCounter)
+	registry.MustRegister(invalidGroupKeyCounter)
+	registry.MustRegister(droppedActionCounter)
+	registry.MustRegister(repeatedConfigChangeCounter)
+	registry.MustRegister(writeThroughFailedCounter)
+	registry.MustRegister(droppedNotificationCounter)
+	registry.MustRegister(droppedRaftMessageCounter)
+	registry.MustRegister(raftStepErrorCounter)
+	registry.MustRegister(groupLogCompactionCounter)
+	registry.MustRegister(groupCompactionRemovedEntriesCounter)
+	registry.MustRegister(groupCompactionReclaimedBytesCounter)
 
 	registry.MustRegister(raftLogLagHistogram)
 	registry.MustRegister(raftLogAppendDurationHistogram)
@@ -44,4 +76,8 @@ func init() {
 	registry.MustRegister(snapshotSizeHistogram)
 	registry.MustRegister(snapshotBuildingDurationHistogram)
 	registry.MustRegister(snapshotSendingDurationHistogram)
+	registry.MustRegister(writeAmplificationHistogram)
+	registry.MustRegister(raftTickDriftHistogram)
+	registry.MustRegister(applyLatencyHistogram)
+	registry.MustRegister(commitToApplyLatencyHistogram)
 }
@@ -14,6 +14,8 @@
 package metric
 
 import (
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -49,6 +51,94 @@ var (
 			Name:      "command_admin_total",
 			Help:      "Total number of admin commands processed.",
 		}, []string{"type", "status"})
+
+	snapshotReclaimedBytesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "snapshot_reclaimed_bytes_total",
+			Help:      "Total bytes of snapshots removed when destroying replicas.",
+		})
+
+	invalidGroupKeyCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "invalid_group_key_total",
+			Help:      "Total number of invalid shard group keys replaced by the fallback key.",
+		})
+
+	droppedActionCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "action_dropped_total",
+			Help:      "Total number of replica actions dropped.",
+		}, []string{"type"})
+
+	droppedRaftMessageCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "raft_msg_dropped_total",
+			Help:      "Total number of received raft messages dropped because of the queue size.",
+		}, []string{"type"})
+
+	raftStepErrorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "raft_step_error_total",
+			Help:      "Total number of received raft messages failed to be stepped into raft.",
+		}, []string{"type"})
+
+	groupLogCompactionCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "group_log_compaction_total",
+			Help:      "Total number of log compactions of the shard group.",
+		}, []string{"group"})
+
+	groupCompactionRemovedEntriesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "group_compaction_removed_entries_total",
+			Help:      "Total number of raft log entries removed by the log compactions of the shard group.",
+		}, []string{"group"})
+
+	groupCompactionReclaimedBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "group_compaction_reclaimed_bytes_total",
+			Help:      "Total bytes of snapshots removed by the snapshot compactions of the shard group.",
+		}, []string{"group"})
+
+	repeatedConfigChangeCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "config_change_repeated_total",
+			Help:      "Total number of config changes proposed repeatedly beyond the threshold.",
+		})
+
+	droppedNotificationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "notification_dropped_total",
+			Help:      "Total number of store events dropped before notifying the observers because of the queue size.",
+		}, []string{"type"})
+
+	writeThroughFailedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "write_through_failed_total",
+			Help:      "Total number of write through events failed to be forwarded to the secondary storage.",
+		}, []string{"type"})
 )
 
 // IncComandCount inc the command received
@@ -175,3 +265,71 @@ func AddRaftAdminCommandCompactCount(value uint64) {
 func AddRaftAdminCommandCompactSucceedCount(value uint64) {
 	raftAdminCommandCounter.WithLabelValues("compact", "succeed").Add(float64(value))
 }
+
+// AddDecommissionReplicaRemoved admin command of removing the replica on a decommissioning store
+func AddDecommissionReplicaRemoved(value uint64) {
+	raftAdminCommandCounter.WithLabelValues("remove", "decommission").Add(float64(value))
+}
+
+// AddSnapshotReclaimedBytes add the bytes of removed snapshots
+func AddSnapshotReclaimedBytes(value uint64) {
+	snapshotReclaimedBytesCounter.Add(float64(value))
+}
+
+// IncInvalidGroupKeyCount inc the invalid shard group key
+func IncInvalidGroupKeyCount() {
+	invalidGroupKeyCounter.Inc()
+}
+
+// AddDroppedAction add the dropped replica actions of the action type
+func AddDroppedAction(action string, value uint64) {
+	droppedActionCounter.WithLabelValues(action).Add(float64(value))
+}
+
+// AddDroppedRaftMessage add the dropped raft messages of the message type
+func AddDroppedRaftMessage(msgType string, value uint64) {
+	droppedRaftMessageCounter.WithLabelValues(msgType).Add(float64(value))
+}
+
+// AddRaftStepError add a received raft message of the message type failed to be
+// stepped into raft
+func AddRaftStepError(msgType string) {
+	raftStepErrorCounter.WithLabelValues(msgType).Inc()
+}
+
+// AddGroupLogCompaction add a log compaction of the shard group and the raft
+// log entries removed by it
+func AddGroupLogCompaction(group uint64, removedEntries uint64) {
+	label := strconv.FormatUint(group, 10)
+	groupLogCompactionCounter.WithLabelValues(label).Inc()
+	groupCompactionRemovedEntriesCounter.WithLabelValues(label).Add(float64(removedEntries))
+}
+
+// AddGroupCompactionReclaimedBytes add the bytes of snapshots removed by the
+// snapshot compactions of the shard group
+func AddGroupCompactionReclaimedBytes(group uint64, value uint64) {
+	groupCompactionReclaimedBytesCounter.WithLabelValues(strconv.FormatUint(group, 10)).Add(float64(value))
+}
+
+// IncRepeatedConfigChangeCount inc the config change proposed repeatedly beyond
+// the threshold
+func IncRepeatedConfigChangeCount() {
+	repeatedConfigChangeCounter.Inc()
+}
+
+// IncDroppedNotification inc the store event of the type dropped before
+// notifying the observers
+func IncDroppedNotification(eventType string) {
+	droppedNotificationCounter.WithLabelValues(eventType).Inc()
+}
+
+// IncWriteThroughErrorCount inc the write through event failed by the handler
+func IncWriteThroughErrorCount() {
+	writeThroughFailedCounter.WithLabelValues("error").Inc()
+}
+
+// IncWriteThroughDroppedCount inc the write through event dropped because of
+// the queue size
+func IncWriteThroughDroppedCount() {
+	writeThroughFailedCounter.WithLabelValues("dropped").Inc()
+}
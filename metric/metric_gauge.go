@@ -14,9 +14,20 @@
 package metric
 
 import (
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// types of the replica event queues
+const (
+	RaftStepQueue    = "raft-step"
+	RaftTickQueue    = "raft-tick"
+	RaftReportQueue  = "raft-report"
+	RaftRequestQueue = "raft-request"
+	RaftActionQueue  = "raft-action"
+)
+
 var (
 	queueGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -26,6 +37,14 @@ var (
 			Help:      "Total size of queue size.",
 		}, []string{"type"})
 
+	queueHighWaterGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "queue_size_high_water",
+			Help:      "Max size of queue seen on the store.",
+		}, []string{"type"})
+
 	batchGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "matrixcube",
@@ -49,6 +68,86 @@ var (
 			Name:      "store_storage_bytes",
 			Help:      "Size of raftstore storage.",
 		}, []string{"type"})
+
+	shardApplyRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "shard_apply_entries_per_second",
+			Help:      "Number of raft entries applied per second of the shard.",
+		}, []string{"shard"})
+
+	shardQPSGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "shard_keys_per_second",
+			Help:      "Number of keys read and written per second of the shard during the last heartbeat interval.",
+		}, []string{"shard", "type"})
+
+	raftLogSizeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "raft_log_size_bytes",
+			Help:      "Estimated size of the raft log retained by the shard replica.",
+		}, []string{"shard"})
+
+	shardCompactionLagGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "shard_compaction_lag_entries",
+			Help:      "Number of raft log entries compacted by the shard leader but not by the follower.",
+		}, []string{"shard", "replica"})
+
+	groupLeaderCountGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "group_leader_total",
+			Help:      "Total number of shard leaders of the group on the current store.",
+		}, []string{"group"})
+
+	prophetHeartbeatFailuresGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "prophet_heartbeat_failures",
+			Help:      "Number of consecutive failed shard heartbeats sent to prophet by the store.",
+		}, []string{"store"})
+
+	shardSizeDiffGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "shard_size_diff_hint_bytes",
+			Help:      "Approximate size of the shard used to decide whether to check the shard for splitting.",
+		}, []string{"shard"})
+
+	shardLaggingGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "shard_lagging",
+			Help:      "Replicas marked as lagging behind the shard leader, 1 if lagging.",
+		}, []string{"shard", "replica"})
+
+	shardUnavailableGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "shard_unavailable",
+			Help:      "Whether the shard leader made no commit progress with pending proposals, 1 if unavailable.",
+		}, []string{"shard"})
+
+	snapshotApplyProgressGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "snapshot_apply_progress_bytes",
+			Help:      "Bytes of the snapshot applied and the total bytes of the snapshot being applied by the shard replica.",
+		}, []string{"shard", "type"})
 )
 
 // SetRaftMsgQueueMetric set send raft message queue size
@@ -58,22 +157,38 @@ func SetRaftMsgQueueMetric(size int64) {
 
 // SetRaftTickQueueMetric set raft tick queue size
 func SetRaftTickQueueMetric(size int64) {
-	queueGauge.WithLabelValues("raft-tick").Set(float64(size))
+	SetRaftQueueMetric(RaftTickQueue, size)
 }
 
 // SetRaftReportQueueMetric set raft report queue size
 func SetRaftReportQueueMetric(size int64) {
-	queueGauge.WithLabelValues("raft-tick").Set(float64(size))
+	SetRaftQueueMetric(RaftReportQueue, size)
 }
 
 // SetRaftStepQueueMetric set raft step queue size
 func SetRaftStepQueueMetric(size int64) {
-	queueGauge.WithLabelValues("raft-step").Set(float64(size))
+	SetRaftQueueMetric(RaftStepQueue, size)
 }
 
 // SetRaftRequestQueueMetric set raft request queue size
 func SetRaftRequestQueueMetric(size int64) {
-	queueGauge.WithLabelValues("raft-request").Set(float64(size))
+	SetRaftQueueMetric(RaftRequestQueue, size)
+}
+
+// SetRaftActionQueueMetric set raft action queue size
+func SetRaftActionQueueMetric(size int64) {
+	SetRaftQueueMetric(RaftActionQueue, size)
+}
+
+// SetRaftQueueMetric set the size of the specified replica event queue
+func SetRaftQueueMetric(queue string, size int64) {
+	queueGauge.WithLabelValues(queue).Set(float64(size))
+}
+
+// SetRaftQueueHighWaterMetric set the max size of the specified replica event
+// queue seen on the current store
+func SetRaftQueueHighWaterMetric(queue string, size int64) {
+	queueHighWaterGauge.WithLabelValues(queue).Set(float64(size))
 }
 
 // SetRaftApplyResultQueueMetric set raft apply result queue size
@@ -102,3 +217,112 @@ func SetStorageOnStore(total uint64, free uint64) {
 	storeStorageGauge.WithLabelValues("total").Set(float64(total))
 	storeStorageGauge.WithLabelValues("free").Set(float64(free))
 }
+
+// SetShardApplyRate set the number of entries applied per second of the shard
+func SetShardApplyRate(shardID uint64, rate float64) {
+	shardApplyRateGauge.WithLabelValues(strconv.FormatUint(shardID, 10)).Set(rate)
+}
+
+// DeleteShardApplyRate delete the apply rate of the shard no longer on the store
+func DeleteShardApplyRate(shardID uint64) {
+	shardApplyRateGauge.DeleteLabelValues(strconv.FormatUint(shardID, 10))
+}
+
+// SetShardQPS set the number of keys read and written per second of the shard
+func SetShardQPS(shardID uint64, readQPS, writeQPS float64) {
+	shard := strconv.FormatUint(shardID, 10)
+	shardQPSGauge.WithLabelValues(shard, "read").Set(readQPS)
+	shardQPSGauge.WithLabelValues(shard, "write").Set(writeQPS)
+}
+
+// DeleteShardQPS delete the keys read and written per second of the shard no
+// longer on the store
+func DeleteShardQPS(shardID uint64) {
+	shard := strconv.FormatUint(shardID, 10)
+	shardQPSGauge.DeleteLabelValues(shard, "read")
+	shardQPSGauge.DeleteLabelValues(shard, "write")
+}
+
+// SetRaftLogSize set the estimated size of the raft log retained by the shard replica
+func SetRaftLogSize(shardID uint64, size uint64) {
+	raftLogSizeGauge.WithLabelValues(strconv.FormatUint(shardID, 10)).Set(float64(size))
+}
+
+// DeleteRaftLogSize delete the raft log size of the shard no longer on the store
+func DeleteRaftLogSize(shardID uint64) {
+	raftLogSizeGauge.DeleteLabelValues(strconv.FormatUint(shardID, 10))
+}
+
+// SetGroupLeaderCount set the number of shard leaders of the group on the current store
+func SetGroupLeaderCount(group uint64, count int) {
+	groupLeaderCountGauge.WithLabelValues(strconv.FormatUint(group, 10)).Set(float64(count))
+}
+
+// SetShardCompactionLag set the number of raft log entries compacted by the shard
+// leader but not by the follower replica
+func SetShardCompactionLag(shardID, replicaID uint64, lag uint64) {
+	shardCompactionLagGauge.WithLabelValues(strconv.FormatUint(shardID, 10),
+		strconv.FormatUint(replicaID, 10)).Set(float64(lag))
+}
+
+// DeleteShardCompactionLag delete the compaction lag of the replica
+func DeleteShardCompactionLag(shardID, replicaID uint64) {
+	shardCompactionLagGauge.DeleteLabelValues(strconv.FormatUint(shardID, 10),
+		strconv.FormatUint(replicaID, 10))
+}
+
+// SetShardLagging mark the replica as lagging behind the shard leader
+func SetShardLagging(shardID, replicaID uint64) {
+	shardLaggingGauge.WithLabelValues(strconv.FormatUint(shardID, 10),
+		strconv.FormatUint(replicaID, 10)).Set(1)
+}
+
+// DeleteShardLagging delete the lagging mark of the replica
+func DeleteShardLagging(shardID, replicaID uint64) {
+	shardLaggingGauge.DeleteLabelValues(strconv.FormatUint(shardID, 10),
+		strconv.FormatUint(replicaID, 10))
+}
+
+// SetShardUnavailable set whether the shard is unavailable, the shard leader
+// made no commit progress with pending proposals
+func SetShardUnavailable(shardID uint64, unavailable bool) {
+	value := float64(0)
+	if unavailable {
+		value = 1
+	}
+	shardUnavailableGauge.WithLabelValues(strconv.FormatUint(shardID, 10)).Set(value)
+}
+
+// DeleteShardUnavailable delete the unavailable mark of the shard no longer on
+// the store
+func DeleteShardUnavailable(shardID uint64) {
+	shardUnavailableGauge.DeleteLabelValues(strconv.FormatUint(shardID, 10))
+}
+
+// SetProphetHeartbeatFailures set the number of consecutive failed shard
+// heartbeats sent to prophet by the store
+func SetProphetHeartbeatFailures(storeID uint64, failures uint64) {
+	prophetHeartbeatFailuresGauge.WithLabelValues(strconv.FormatUint(storeID, 10)).Set(float64(failures))
+}
+
+// SetSnapshotApplyProgress set the bytes of the snapshot applied and the total
+// bytes of the snapshot being applied by the shard replica
+func SetSnapshotApplyProgress(shardID uint64, applied, total uint64) {
+	shard := strconv.FormatUint(shardID, 10)
+	snapshotApplyProgressGauge.WithLabelValues(shard, "applied").Set(float64(applied))
+	snapshotApplyProgressGauge.WithLabelValues(shard, "total").Set(float64(total))
+}
+
+// DeleteSnapshotApplyProgress delete the snapshot apply progress of the shard no
+// longer on the store
+func DeleteSnapshotApplyProgress(shardID uint64) {
+	shard := strconv.FormatUint(shardID, 10)
+	snapshotApplyProgressGauge.DeleteLabelValues(shard, "applied")
+	snapshotApplyProgressGauge.DeleteLabelValues(shard, "total")
+}
+
+// SetShardSizeDiff set the approximate size of the shard used to decide whether
+// to check the shard for splitting
+func SetShardSizeDiff(shardID uint64, size uint64) {
+	shardSizeDiffGauge.WithLabelValues(strconv.FormatUint(shardID, 10)).Set(float64(size))
+}
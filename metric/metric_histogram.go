@@ -14,6 +14,7 @@
 package metric
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -81,6 +82,42 @@ var (
 			Help:      "Bucketed histogram of log lag in a shard.",
 			Buckets:   []float64{2.0, 4.0, 8.0, 16.0, 32.0, 64.0, 128.0, 256.0, 512.0, 1024.0, 5120.0, 10240.0},
 		})
+
+	raftTickDriftHistogram = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "raft_tick_drift_seconds",
+			Help:      "Bucketed histogram of how late the raft ticks are fired.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2.0, 20),
+		})
+
+	writeAmplificationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "write_amplification",
+			Help:      "Bucketed histogram of bytes written to storage divided by bytes proposed per write batch.",
+			Buckets:   []float64{0.25, 0.5, 0.75, 1.0, 1.25, 1.5, 2.0, 3.0, 4.0, 8.0, 16.0, 32.0},
+		}, []string{"group"})
+
+	applyLatencyHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "apply_latency_seconds",
+			Help:      "Bucketed histogram of the time spent in applying the committed requests to the storage.",
+			Buckets:   prometheus.ExponentialBuckets(0.00005, 2.0, 20),
+		}, []string{"group", "type"})
+
+	commitToApplyLatencyHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "commit_to_apply_latency_seconds",
+			Help:      "Bucketed histogram of the time the committed logs wait before they are applied.",
+			Buckets:   prometheus.ExponentialBuckets(0.00005, 2.0, 20),
+		}, []string{"group"})
 )
 
 // ObserveProposalBytes observe bytes per raft proposal
@@ -117,3 +154,26 @@ func ObserveRaftLogApplyDuration(start time.Time) {
 func ObserveRaftLogLag(size uint64) {
 	raftLogLagHistogram.Observe(float64(size))
 }
+
+// ObserveRaftTickDrift observe how late a raft tick is fired
+func ObserveRaftTickDrift(drift time.Duration) {
+	raftTickDriftHistogram.Observe(drift.Seconds())
+}
+
+// ObserveWriteAmplification observe write amplification of a write batch in the
+// shard group
+func ObserveWriteAmplification(group uint64, ratio float64) {
+	writeAmplificationHistogram.WithLabelValues(strconv.FormatUint(group, 10)).Observe(ratio)
+}
+
+// ObserveApplyLatency observe the time spent in applying the committed write or
+// admin requests of the shard group, the type is "write" or "admin"
+func ObserveApplyLatency(group uint64, cmdType string, latency time.Duration) {
+	applyLatencyHistogram.WithLabelValues(strconv.FormatUint(group, 10), cmdType).Observe(latency.Seconds())
+}
+
+// ObserveCommitToApplyLatency observe the time a committed log of the shard
+// group waits before it is applied
+func ObserveCommitToApplyLatency(group uint64, latency time.Duration) {
+	commitToApplyLatencyHistogram.WithLabelValues(strconv.FormatUint(group, 10)).Observe(latency.Seconds())
+}
@@ -0,0 +1,225 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// OtherShards is the shard label of the metrics aggregated from the shards
+// exceeding the cardinality cap of the ShardCollector.
+const OtherShards = "other"
+
+var shardQueues = []string{RaftStepQueue, RaftTickQueue, RaftReportQueue,
+	RaftRequestQueue, RaftActionQueue}
+
+// ShardMetrics is the metrics of a shard replica collected by a
+// ShardCollector. A nil ShardMetrics collects nothing.
+type ShardMetrics struct {
+	queueSizes map[string]*int64
+	lag        uint64
+	applied    uint64
+	// applyNanos is the total apply latency in nanoseconds
+	applyNanos uint64
+}
+
+func newShardMetrics() *ShardMetrics {
+	m := &ShardMetrics{queueSizes: make(map[string]*int64)}
+	for _, queue := range shardQueues {
+		m.queueSizes[queue] = new(int64)
+	}
+	return m
+}
+
+// SetQueueSize set the size of the specified replica event queue
+func (m *ShardMetrics) SetQueueSize(queue string, size int64) {
+	if m == nil {
+		return
+	}
+	if v, ok := m.queueSizes[queue]; ok {
+		atomic.StoreInt64(v, size)
+	}
+}
+
+// SetRaftLogLag set the number of raft log entries not yet replicated to the
+// slowest replica, only reported by the shard leader
+func (m *ShardMetrics) SetRaftLogLag(lag uint64) {
+	if m == nil {
+		return
+	}
+	atomic.StoreUint64(&m.lag, lag)
+}
+
+// ObserveApplyLatency observe the latency of applying a batch of requests
+func (m *ShardMetrics) ObserveApplyLatency(latency time.Duration) {
+	if m == nil {
+		return
+	}
+	atomic.AddUint64(&m.applied, 1)
+	atomic.AddUint64(&m.applyNanos, uint64(latency))
+}
+
+// merge aggregates the metrics of the shard into m, m must not be shared.
+func (m *ShardMetrics) merge(shard *ShardMetrics) {
+	for _, queue := range shardQueues {
+		*m.queueSizes[queue] += atomic.LoadInt64(shard.queueSizes[queue])
+	}
+	if lag := atomic.LoadUint64(&shard.lag); lag > m.lag {
+		m.lag = lag
+	}
+	m.applied += atomic.LoadUint64(&shard.applied)
+	m.applyNanos += atomic.LoadUint64(&shard.applyNanos)
+}
+
+// ShardCollector is a prometheus collector exposing the metrics of each shard
+// replica on the store labeled by shard ID. To bound the cardinality, only the
+// maxShards busiest shards, ranked by the number of applied batches, are
+// labeled by their IDs, the metrics of the other shards are aggregated under
+// the OtherShards label. The queue sizes and the counters of the aggregated
+// shards are summed and their lag is the max lag. The counters of the
+// aggregated shards may go backwards when a shard moves out of the aggregate,
+// which is handled as a counter reset by prometheus.
+type ShardCollector struct {
+	maxShards int
+
+	queueSizeDesc    *prometheus.Desc
+	lagDesc          *prometheus.Desc
+	appliedDesc      *prometheus.Desc
+	applySecondsDesc *prometheus.Desc
+
+	mu struct {
+		sync.Mutex
+		shards map[uint64]*ShardMetrics
+	}
+}
+
+// NewShardCollector returns a ShardCollector of the store labeling at most
+// maxShards shards by their IDs. The metrics are also labeled by the store ID,
+// so the collectors of the stores in the same process do not conflict.
+func NewShardCollector(storeID uint64, maxShards int) *ShardCollector {
+	constLabels := prometheus.Labels{"store": strconv.FormatUint(storeID, 10)}
+	newDesc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName("matrixcube", "raftstore", name),
+			help, append([]string{"shard"}, labels...), constLabels)
+	}
+
+	c := &ShardCollector{
+		maxShards: maxShards,
+		queueSizeDesc: newDesc("shard_queue_size",
+			"Size of the replica event queues of the shard.", "queue"),
+		lagDesc: newDesc("shard_raft_log_lag_entries",
+			"Number of raft log entries not yet replicated to the slowest replica of the shard."),
+		appliedDesc: newDesc("shard_applied_total",
+			"Total number of request batches applied by the shard replica."),
+		applySecondsDesc: newDesc("shard_apply_seconds_total",
+			"Total time spent on applying the request batches by the shard replica."),
+	}
+	c.mu.shards = make(map[uint64]*ShardMetrics)
+	return c
+}
+
+// Shard returns the metrics of the shard, they are created on the first call.
+// A nil ShardCollector returns a nil ShardMetrics.
+func (c *ShardCollector) Shard(shardID uint64) *ShardMetrics {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, ok := c.mu.shards[shardID]
+	if !ok {
+		m = newShardMetrics()
+		c.mu.shards[shardID] = m
+	}
+	return m
+}
+
+// Remove removes the metrics of the shard no longer on the store.
+func (c *ShardCollector) Remove(shardID uint64) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.mu.shards, shardID)
+}
+
+// Describe implements prometheus.Collector
+func (c *ShardCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queueSizeDesc
+	ch <- c.lagDesc
+	ch <- c.appliedDesc
+	ch <- c.applySecondsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *ShardCollector) Collect(ch chan<- prometheus.Metric) {
+	type shard struct {
+		id      uint64
+		metrics *ShardMetrics
+		applied uint64
+	}
+
+	c.mu.Lock()
+	shards := make([]shard, 0, len(c.mu.shards))
+	for id, m := range c.mu.shards {
+		shards = append(shards, shard{id: id, metrics: m,
+			applied: atomic.LoadUint64(&m.applied)})
+	}
+	c.mu.Unlock()
+
+	sort.Slice(shards, func(i, j int) bool {
+		if shards[i].applied != shards[j].applied {
+			return shards[i].applied > shards[j].applied
+		}
+		return shards[i].id < shards[j].id
+	})
+
+	var other *ShardMetrics
+	for idx, s := range shards {
+		if idx < c.maxShards {
+			c.collectShard(ch, strconv.FormatUint(s.id, 10), s.metrics)
+			continue
+		}
+		if other == nil {
+			other = newShardMetrics()
+		}
+		other.merge(s.metrics)
+	}
+	if other != nil {
+		c.collectShard(ch, OtherShards, other)
+	}
+}
+
+func (c *ShardCollector) collectShard(ch chan<- prometheus.Metric, shard string, m *ShardMetrics) {
+	for _, queue := range shardQueues {
+		ch <- prometheus.MustNewConstMetric(c.queueSizeDesc, prometheus.GaugeValue,
+			float64(atomic.LoadInt64(m.queueSizes[queue])), shard, queue)
+	}
+	ch <- prometheus.MustNewConstMetric(c.lagDesc, prometheus.GaugeValue,
+		float64(atomic.LoadUint64(&m.lag)), shard)
+	ch <- prometheus.MustNewConstMetric(c.appliedDesc, prometheus.CounterValue,
+		float64(atomic.LoadUint64(&m.applied)), shard)
+	ch <- prometheus.MustNewConstMetric(c.applySecondsDesc, prometheus.CounterValue,
+		time.Duration(atomic.LoadUint64(&m.applyNanos)).Seconds(), shard)
+}
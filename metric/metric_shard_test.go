@@ -0,0 +1,68 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardCollectorMergesExcessShards(t *testing.T) {
+	c := NewShardCollector(1, 2)
+	// shard 1 and 3 are the busiest
+	for id, applied := range map[uint64]int{1: 3, 2: 1, 3: 2, 4: 1} {
+		m := c.Shard(id)
+		for i := 0; i < applied; i++ {
+			m.ObserveApplyLatency(time.Second)
+		}
+		m.SetQueueSize(RaftRequestQueue, int64(id))
+		m.SetRaftLogLag(id * 10)
+	}
+	assert.Equal(t, c.Shard(1), c.Shard(1))
+
+	expected := `
+# HELP matrixcube_raftstore_shard_applied_total Total number of request batches applied by the shard replica.
+# TYPE matrixcube_raftstore_shard_applied_total counter
+matrixcube_raftstore_shard_applied_total{shard="1",store="1"} 3
+matrixcube_raftstore_shard_applied_total{shard="3",store="1"} 2
+matrixcube_raftstore_shard_applied_total{shard="other",store="1"} 2
+# HELP matrixcube_raftstore_shard_raft_log_lag_entries Number of raft log entries not yet replicated to the slowest replica of the shard.
+# TYPE matrixcube_raftstore_shard_raft_log_lag_entries gauge
+matrixcube_raftstore_shard_raft_log_lag_entries{shard="1",store="1"} 10
+matrixcube_raftstore_shard_raft_log_lag_entries{shard="3",store="1"} 30
+matrixcube_raftstore_shard_raft_log_lag_entries{shard="other",store="1"} 40
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"matrixcube_raftstore_shard_applied_total",
+		"matrixcube_raftstore_shard_raft_log_lag_entries"))
+	// 5 queues, the lag and 2 counters of the 2 labeled shards and the
+	// aggregate
+	assert.Equal(t, 3*8, testutil.CollectAndCount(c))
+
+	// the removed shards are no longer aggregated
+	c.Remove(2)
+	c.Remove(4)
+	expected = `
+# HELP matrixcube_raftstore_shard_applied_total Total number of request batches applied by the shard replica.
+# TYPE matrixcube_raftstore_shard_applied_total counter
+matrixcube_raftstore_shard_applied_total{shard="1",store="1"} 3
+matrixcube_raftstore_shard_applied_total{shard="3",store="1"} 2
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"matrixcube_raftstore_shard_applied_total"))
+}
@@ -1656,6 +1656,7 @@ type RaftMessage struct {
 	RuleGroups           []string       `protobuf:"bytes,11,rep,name=ruleGroups,proto3" json:"ruleGroups,omitempty"`
 	CommitIndex          uint64         `protobuf:"varint,12,opt,name=commitIndex,proto3" json:"commitIndex,omitempty"`
 	SendTime             uint64         `protobuf:"varint,13,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
+	FirstIndex           uint64         `protobuf:"varint,14,opt,name=firstIndex,proto3" json:"firstIndex,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -1785,6 +1786,13 @@ func (m *RaftMessage) GetSendTime() uint64 {
 	return 0
 }
 
+func (m *RaftMessage) GetFirstIndex() uint64 {
+	if m != nil {
+		return m.FirstIndex
+	}
+	return 0
+}
+
 type SnapshotChunk struct {
 	StoreID              uint64           `protobuf:"varint,1,opt,name=storeID,proto3" json:"storeID,omitempty"`
 	ShardID              uint64           `protobuf:"varint,2,opt,name=shardID,proto3" json:"shardID,omitempty"`
@@ -2805,8 +2813,10 @@ func (m *ShardsPoolAllocCmd) GetPurpose() []byte {
 
 // SnapshotInfo contains additional information associated with a snapshot.
 type SnapshotInfo struct {
-	Extra                uint64   `protobuf:"varint,1,opt,name=extra,proto3" json:"extra,omitempty"`
-	Dummy                bool     `protobuf:"varint,2,opt,name=dummy,proto3" json:"dummy,omitempty"`
+	Extra uint64 `protobuf:"varint,1,opt,name=extra,proto3" json:"extra,omitempty"`
+	Dummy bool   `protobuf:"varint,2,opt,name=dummy,proto3" json:"dummy,omitempty"`
+	// TotalSize is the total bytes of the snapshot files, 0 if unknown.
+	TotalSize            uint64   `protobuf:"varint,3,opt,name=totalSize,proto3" json:"totalSize,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2859,6 +2869,13 @@ func (m *SnapshotInfo) GetDummy() bool {
 	return false
 }
 
+func (m *SnapshotInfo) GetTotalSize() uint64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
 // EpochLease an Epoch-based Lease. A Shard has one and only one Replica that
 // can hold a Lease, and all read and write requests to the Shard need to be
 // initiated by the node holding the Lease. In most cases, the Replica holding
@@ -2976,159 +2993,160 @@ func init() {
 func init() { proto.RegisterFile("metapb.proto", fileDescriptor_77b4d575d5a68dda) }
 
 var fileDescriptor_77b4d575d5a68dda = []byte{
-	// 2431 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x59, 0x4f, 0x73, 0x23, 0x47,
-	0x15, 0xf7, 0x8c, 0x64, 0x5b, 0x7a, 0xb2, 0xec, 0x71, 0x67, 0x09, 0xc2, 0x84, 0x8d, 0x6b, 0x08,
-	0x89, 0x23, 0x12, 0x3b, 0xec, 0x6e, 0x52, 0x49, 0xa0, 0xa8, 0xc8, 0x92, 0x49, 0x94, 0xf5, 0x7a,
-	0x5d, 0xa3, 0x75, 0x80, 0x63, 0x4b, 0xd3, 0x92, 0xa7, 0x76, 0x66, 0x7a, 0x32, 0xd3, 0x72, 0x56,
-	0x54, 0x51, 0xc5, 0x99, 0x03, 0xdf, 0x82, 0x1b, 0x27, 0x8e, 0x9c, 0xb8, 0x50, 0xe4, 0x46, 0xce,
-	0x1c, 0x52, 0xb0, 0x5f, 0x81, 0x3b, 0x45, 0xf5, 0xeb, 0x9e, 0x99, 0x1e, 0xc9, 0xf6, 0x2e, 0x17,
-	0x6b, 0xde, 0xeb, 0xf7, 0xfa, 0xcf, 0xfb, 0xfb, 0xeb, 0x36, 0x6c, 0x45, 0x4c, 0xd0, 0x64, 0x7c,
-	0x98, 0xa4, 0x5c, 0x70, 0xb2, 0xa1, 0xa8, 0xbd, 0x77, 0x67, 0x81, 0xb8, 0x9c, 0x8f, 0x0f, 0x27,
-	0x3c, 0x3a, 0x9a, 0xf1, 0x19, 0x3f, 0xc2, 0xe1, 0xf1, 0x7c, 0x8a, 0x14, 0x12, 0xf8, 0xa5, 0xd4,
-	0xf6, 0xde, 0x9e, 0xf1, 0x43, 0x26, 0x26, 0xfe, 0x61, 0xc0, 0x8f, 0xe4, 0xef, 0x51, 0x4a, 0xa7,
-	0xe2, 0xe8, 0xea, 0x3e, 0xfe, 0x26, 0x63, 0xfc, 0x51, 0xa2, 0xee, 0xe7, 0x00, 0xa3, 0x4b, 0x9a,
-	0xfa, 0x27, 0x09, 0x9f, 0x5c, 0x92, 0xd7, 0xa0, 0x39, 0xe1, 0xf1, 0x34, 0x98, 0x7d, 0xc1, 0xd2,
-	0x8e, 0xb5, 0x6f, 0x1d, 0xd4, 0xbd, 0x92, 0x41, 0xee, 0x02, 0xcc, 0x58, 0xcc, 0x52, 0x2a, 0x02,
-	0x1e, 0x77, 0x6c, 0x1c, 0x36, 0x38, 0xee, 0xef, 0x2d, 0xd8, 0xf4, 0x58, 0x12, 0x06, 0x13, 0x4a,
-	0x5e, 0x05, 0x3b, 0xf0, 0xd5, 0x14, 0xc7, 0x1b, 0xcf, 0xbf, 0x7d, 0xdd, 0x1e, 0x0e, 0x3c, 0x3b,
-	0xf0, 0x49, 0x07, 0x36, 0x33, 0xc1, 0x53, 0x36, 0x1c, 0xe8, 0x09, 0x72, 0x92, 0xbc, 0x05, 0xf5,
-	0x94, 0x87, 0xac, 0x53, 0xdb, 0xb7, 0x0e, 0xb6, 0xef, 0xbd, 0x72, 0xa8, 0x0d, 0xa1, 0x27, 0xf4,
-	0x78, 0xc8, 0x3c, 0x14, 0x20, 0x6f, 0x40, 0x3b, 0x88, 0x03, 0x11, 0xd0, 0xf0, 0x11, 0x8b, 0xc6,
-	0x2c, 0xed, 0xd4, 0xf7, 0xad, 0x83, 0x86, 0x57, 0x65, 0xba, 0x14, 0xb6, 0xb4, 0xea, 0x48, 0x50,
-	0x91, 0x91, 0x23, 0xd8, 0x4c, 0x15, 0x8d, 0xbb, 0x6a, 0xdd, 0xdb, 0x59, 0x5a, 0xe1, 0xb8, 0xfe,
-	0xf5, 0xb7, 0xaf, 0xaf, 0x79, 0xb9, 0x14, 0xd9, 0x87, 0x96, 0xcf, 0xbf, 0x8a, 0x47, 0x6c, 0xc2,
-	0x63, 0x3f, 0xd3, 0xbb, 0x35, 0x59, 0xee, 0x11, 0xac, 0x9f, 0xd2, 0x31, 0x0b, 0x89, 0x03, 0xb5,
-	0xa7, 0x6c, 0x81, 0xf3, 0x36, 0x3d, 0xf9, 0x49, 0xee, 0xc0, 0xfa, 0x15, 0x0d, 0xe7, 0x0c, 0xd5,
-	0x9a, 0x9e, 0x22, 0xdc, 0x3f, 0xd9, 0xda, 0xda, 0x6a, 0x4b, 0xd2, 0x16, 0x92, 0x1a, 0x0e, 0xb4,
-	0xad, 0x73, 0x92, 0xb8, 0xb0, 0xf5, 0x55, 0x1a, 0x08, 0xc1, 0xe2, 0xe3, 0x85, 0x60, 0xf9, 0xe2,
-	0x15, 0x9e, 0xdc, 0x9f, 0xa6, 0x1f, 0xb2, 0x45, 0x86, 0x66, 0xab, 0x7b, 0x26, 0x4b, 0x7a, 0x33,
-	0x65, 0xd4, 0x57, 0x53, 0xd4, 0x95, 0x37, 0x0b, 0x06, 0xd9, 0x83, 0x86, 0x24, 0x50, 0x79, 0x1d,
-	0x07, 0x0b, 0x9a, 0x1c, 0xc0, 0x0e, 0x4d, 0x92, 0x94, 0x3f, 0x0b, 0x22, 0x2a, 0xd8, 0x28, 0xf8,
-	0x0d, 0xeb, 0x6c, 0xa0, 0xc8, 0x32, 0x7b, 0x49, 0x12, 0x27, 0xdb, 0x5c, 0x91, 0xc4, 0x39, 0xdf,
-	0x83, 0x46, 0x10, 0x0b, 0x96, 0x5e, 0xd1, 0xb0, 0xd3, 0x40, 0x0f, 0xdc, 0xc9, 0x3d, 0xf0, 0x24,
-	0x88, 0xd8, 0x50, 0x8f, 0x79, 0x85, 0x94, 0xfb, 0xd7, 0x75, 0x80, 0x91, 0x8c, 0x8e, 0xd2, 0x5c,
-	0x3a, 0x74, 0xac, 0x6a, 0xe8, 0xbc, 0x06, 0xcd, 0x4c, 0xd0, 0x54, 0xc8, 0x79, 0xb4, 0xad, 0x4a,
-	0x46, 0x65, 0xe1, 0xda, 0xcb, 0x2c, 0x2c, 0x4d, 0x33, 0xa1, 0x09, 0x9d, 0x04, 0x62, 0xa1, 0xed,
-	0x56, 0xd0, 0x72, 0x2d, 0x7a, 0x45, 0x83, 0x90, 0x8e, 0x43, 0xa6, 0xed, 0x56, 0x32, 0xa4, 0xe6,
-	0x3c, 0x63, 0xbe, 0x61, 0xb1, 0x82, 0x26, 0xaf, 0xc2, 0x46, 0x90, 0x1d, 0xcf, 0xb3, 0x05, 0x5a,
-	0xa8, 0xe1, 0x69, 0x4a, 0xa6, 0x15, 0xfa, 0xbd, 0xcf, 0xe7, 0xb1, 0x40, 0xd3, 0xd4, 0x3d, 0x83,
-	0x43, 0xba, 0xe0, 0x64, 0x2c, 0xf6, 0x83, 0x78, 0x36, 0x8a, 0x69, 0xa2, 0xa4, 0x9a, 0x28, 0xb5,
-	0xc2, 0x27, 0x87, 0x40, 0x52, 0x36, 0x61, 0xc1, 0x55, 0x45, 0x1a, 0x50, 0xfa, 0x9a, 0x11, 0xf2,
-	0x0e, 0xec, 0xd2, 0x24, 0x09, 0x17, 0x15, 0xf1, 0x16, 0x8a, 0xaf, 0x0e, 0xac, 0x84, 0xe5, 0xd6,
-	0x35, 0x61, 0x59, 0x09, 0xba, 0xf6, 0x72, 0xd0, 0x2d, 0x05, 0xed, 0xf6, 0x6a, 0xd0, 0x9a, 0x61,
-	0xb9, 0xb3, 0x14, 0x96, 0x1f, 0x40, 0x73, 0x92, 0xcc, 0x2f, 0x32, 0x3a, 0x63, 0x59, 0xc7, 0xd9,
-	0xaf, 0x1d, 0xb4, 0xee, 0x91, 0x32, 0x8b, 0x27, 0x3c, 0xf5, 0xcf, 0x69, 0x90, 0xea, 0x44, 0x2e,
-	0x45, 0xc9, 0xc7, 0xd0, 0x92, 0x73, 0x0c, 0x1f, 0x7b, 0x54, 0xee, 0x6a, 0xf7, 0x05, 0x9a, 0xa6,
-	0x30, 0xf9, 0x99, 0x3a, 0x33, 0xcb, 0x95, 0xc9, 0x0b, 0x94, 0x2b, 0xd2, 0xee, 0x03, 0x80, 0x52,
-	0xe2, 0x45, 0x75, 0xa2, 0x9e, 0xd7, 0x89, 0xcf, 0x60, 0x43, 0x55, 0xb1, 0x1b, 0xcb, 0x28, 0x81,
-	0x7a, 0x4c, 0xa3, 0xbc, 0xbc, 0xe0, 0xb7, 0xe4, 0x51, 0xdf, 0x4f, 0x31, 0xc6, 0x9b, 0x1e, 0x7e,
-	0xbb, 0x1e, 0x6c, 0x9f, 0xa7, 0x3c, 0xb9, 0x64, 0xa2, 0x1f, 0xce, 0x33, 0x71, 0xcb, 0x8c, 0x07,
-	0xb0, 0x13, 0xd1, 0x67, 0xba, 0x16, 0xaa, 0x38, 0x90, 0x93, 0xb7, 0xbd, 0x65, 0xb6, 0xfb, 0x01,
-	0x6c, 0x99, 0x79, 0x23, 0xcf, 0x80, 0xc9, 0xa6, 0xb3, 0x52, 0x11, 0xf2, 0xac, 0x2c, 0xf6, 0xf5,
-	0xb9, 0xe4, 0xa7, 0x1b, 0x42, 0xed, 0x73, 0x3e, 0x26, 0x3f, 0x84, 0xba, 0x58, 0x24, 0x0c, 0xa5,
-	0xb7, 0xcb, 0x2a, 0xfc, 0x39, 0x1f, 0x3f, 0x59, 0x24, 0xcc, 0xc3, 0x41, 0x99, 0xeb, 0x13, 0x1e,
-	0x0b, 0xa6, 0x77, 0xb1, 0xe5, 0xe5, 0x24, 0x79, 0x13, 0x57, 0x13, 0x79, 0x9f, 0x70, 0x0c, 0x7d,
-	0x59, 0x26, 0x98, 0xa7, 0x86, 0x5d, 0x06, 0xdb, 0x1e, 0x8b, 0xf8, 0x15, 0xc3, 0x82, 0x2b, 0x17,
-	0xde, 0x5f, 0x2a, 0xb7, 0xc5, 0xf1, 0x8b, 0xb2, 0xfb, 0x13, 0x19, 0x7b, 0x78, 0x52, 0x59, 0x72,
-	0x6b, 0x37, 0x37, 0x89, 0x42, 0xcc, 0x1d, 0xc0, 0x16, 0x2e, 0x70, 0xce, 0x79, 0x28, 0x17, 0x79,
-	0x00, 0xeb, 0x09, 0xe7, 0x61, 0xd6, 0xb1, 0x50, 0xbf, 0x93, 0xeb, 0x9b, 0x42, 0x8f, 0x98, 0xc8,
-	0x27, 0x52, 0xc2, 0xee, 0x14, 0x9c, 0x65, 0x01, 0x69, 0xd6, 0x59, 0xca, 0xe7, 0x49, 0x6e, 0x56,
-	0x24, 0x2a, 0xa5, 0xc9, 0x5e, 0x2a, 0x4d, 0xfb, 0xd0, 0x4a, 0x69, 0x3c, 0x63, 0xe7, 0x29, 0x9b,
-	0x06, 0xcf, 0xd0, 0x40, 0x5b, 0x9e, 0xc9, 0x72, 0xff, 0x63, 0x81, 0x33, 0x60, 0x99, 0x48, 0x39,
-	0x26, 0xb6, 0xa0, 0x62, 0x9e, 0xc9, 0x85, 0x82, 0xd8, 0x67, 0xcf, 0xf2, 0x85, 0x90, 0x20, 0xc7,
-	0x2b, 0xb6, 0x78, 0x33, 0x3f, 0xcb, 0xf2, 0x0c, 0xb9, 0x71, 0xb2, 0x93, 0x58, 0xa4, 0x8b, 0xd2,
-	0x38, 0xe4, 0xa0, 0xea, 0x2b, 0x52, 0x31, 0x86, 0xe9, 0x2d, 0x59, 0x03, 0x53, 0xf4, 0xd6, 0x80,
-	0x0a, 0xaa, 0x1b, 0xba, 0xc1, 0xd9, 0xfb, 0x29, 0xb4, 0x2b, 0x8b, 0x98, 0xa9, 0x54, 0xbf, 0x26,
-	0x95, 0x1a, 0x3a, 0x95, 0x3e, 0xb6, 0x3f, 0xb4, 0xdc, 0xbf, 0x59, 0x39, 0xc8, 0x79, 0x26, 0x52,
-	0x4a, 0x3e, 0x80, 0x8d, 0x50, 0xb6, 0xed, 0xdc, 0x47, 0x77, 0x2b, 0xdb, 0x42, 0x99, 0x43, 0xec,
-	0xeb, 0xfa, 0x3c, 0x5a, 0x9a, 0x0c, 0xc0, 0xf1, 0x97, 0x4e, 0x8e, 0x6b, 0x19, 0x5e, 0x5e, 0xb6,
-	0x8c, 0xb7, 0xa2, 0xb1, 0xf7, 0x11, 0xb4, 0x8c, 0xc9, 0x5f, 0x16, 0x3a, 0xe0, 0x39, 0x7e, 0x0b,
-	0xbb, 0xa3, 0xc9, 0x25, 0xf3, 0xe7, 0x21, 0xfb, 0x54, 0x06, 0x83, 0x37, 0x0f, 0xd9, 0x6d, 0x40,
-	0x0b, 0x23, 0xa6, 0x04, 0x5a, 0x9a, 0x2c, 0x6a, 0x47, 0xcd, 0xa8, 0x1d, 0x2e, 0x6c, 0xe1, 0xf0,
-	0xf1, 0x02, 0x37, 0x87, 0x1e, 0x68, 0x7a, 0x15, 0x9e, 0x3b, 0x04, 0xc7, 0xa3, 0x53, 0xf1, 0x88,
-	0x65, 0xb2, 0xaa, 0x1e, 0x53, 0x31, 0xb9, 0x24, 0xef, 0x43, 0x23, 0x52, 0x74, 0x6e, 0xcd, 0x12,
-	0xb8, 0x19, 0xb2, 0x3a, 0x6b, 0x72, 0x51, 0xf7, 0x2f, 0x35, 0x68, 0x19, 0xe3, 0xb7, 0x20, 0xa1,
-	0x22, 0x0b, 0x6c, 0x33, 0x0b, 0xde, 0x86, 0xfa, 0x34, 0xe5, 0x91, 0x6e, 0xe7, 0x37, 0x24, 0x29,
-	0x8a, 0x90, 0x1f, 0x81, 0x2d, 0x38, 0x9e, 0xe7, 0x46, 0x41, 0x5b, 0x70, 0x09, 0x0f, 0xf5, 0xee,
-	0xb0, 0xa9, 0x4b, 0x59, 0x05, 0x96, 0x0f, 0xab, 0x67, 0xc8, 0xa5, 0xc8, 0x87, 0xba, 0x6b, 0x23,
-	0x70, 0xc6, 0x5e, 0xdf, 0x5a, 0x0a, 0x70, 0x1c, 0xd1, 0x6a, 0x86, 0xac, 0x4c, 0xd3, 0x20, 0x7b,
-	0xc2, 0xa3, 0x71, 0x26, 0x78, 0xcc, 0x34, 0x18, 0x30, 0x59, 0x65, 0x45, 0x6d, 0x60, 0x0a, 0x57,
-	0x2b, 0x6a, 0x13, 0x79, 0xf2, 0x53, 0x22, 0x8a, 0x79, 0x1c, 0x7c, 0x39, 0x67, 0xd8, 0xe1, 0x9b,
-	0x9e, 0xa6, 0x30, 0x9b, 0xf2, 0x20, 0xc9, 0x3a, 0xad, 0xfd, 0xda, 0x41, 0xd3, 0x33, 0x38, 0x72,
-	0x07, 0x13, 0x1e, 0x45, 0x81, 0x18, 0x62, 0xde, 0xab, 0x36, 0x6e, 0xb2, 0x64, 0x99, 0x91, 0xd8,
-	0x02, 0x01, 0x95, 0x6a, 0xe2, 0x05, 0xed, 0xfe, 0xb3, 0x06, 0x6d, 0x89, 0x09, 0xb2, 0x4b, 0x2e,
-	0xfa, 0x97, 0xf3, 0xf8, 0xe9, 0x2d, 0xc8, 0xcc, 0x70, 0xac, 0x5d, 0x75, 0x2c, 0xe2, 0x04, 0xf4,
-	0xc2, 0x70, 0xa0, 0xc1, 0x6b, 0xc9, 0x90, 0x31, 0x8a, 0x0e, 0x56, 0xe8, 0x4b, 0x79, 0x52, 0xf6,
-	0x04, 0xb9, 0xdc, 0x70, 0xa0, 0x71, 0x57, 0x4e, 0xe2, 0xb5, 0x45, 0x7e, 0x1a, 0xb0, 0xab, 0x64,
-	0x48, 0x6b, 0x20, 0xa1, 0x9a, 0x9a, 0x42, 0xa7, 0x06, 0xa7, 0xac, 0x7f, 0x0d, 0xb3, 0xfe, 0x11,
-	0xa8, 0x0b, 0x96, 0x46, 0x1a, 0x69, 0xe1, 0xb7, 0xb4, 0xca, 0x34, 0x08, 0xd9, 0x39, 0x15, 0x97,
-	0xda, 0xe2, 0x05, 0x9d, 0x8f, 0xe1, 0x16, 0x14, 0x80, 0x2a, 0x68, 0x69, 0x6f, 0xf9, 0xdd, 0xd7,
-	0xbb, 0xd7, 0xf6, 0x36, 0x58, 0xe4, 0x4d, 0xd8, 0x2e, 0x48, 0xb5, 0x4f, 0x65, 0xf5, 0x25, 0xae,
-	0xdc, 0x95, 0x2f, 0x2b, 0xe4, 0x36, 0x06, 0x01, 0x7e, 0xcb, 0xfd, 0x33, 0x59, 0xb4, 0x10, 0x2e,
-	0x6d, 0x79, 0x8a, 0x20, 0xef, 0xab, 0xab, 0x1c, 0x56, 0xd9, 0x8e, 0x83, 0xe1, 0xb9, 0x9b, 0x87,
-	0x74, 0x3f, 0x1f, 0x28, 0xa0, 0x52, 0xce, 0x70, 0x07, 0x1a, 0x72, 0x0f, 0x7d, 0xd9, 0x6c, 0xa5,
-	0x61, 0x15, 0x6e, 0x28, 0x5c, 0x5b, 0x32, 0x6e, 0xbe, 0xcb, 0xb9, 0xff, 0xb0, 0x61, 0x1d, 0x73,
-	0xe0, 0xc6, 0xf2, 0x54, 0x84, 0xb8, 0x7d, 0x4d, 0x88, 0xd7, 0xca, 0x10, 0x3f, 0x84, 0x75, 0x86,
-	0x19, 0x56, 0x7f, 0x41, 0x86, 0x29, 0xb1, 0xb2, 0xe5, 0xac, 0xbf, 0xa8, 0xe5, 0x98, 0xcd, 0x7e,
-	0xe3, 0xa5, 0x9a, 0x7d, 0x59, 0x8c, 0x36, 0xcd, 0x62, 0x54, 0x66, 0x61, 0xe3, 0x96, 0x2c, 0x6c,
-	0xae, 0x64, 0xe1, 0x8f, 0x8b, 0x3e, 0x04, 0xb8, 0x7c, 0x3b, 0x5f, 0x1e, 0xcb, 0xad, 0x5e, 0x5c,
-	0x8b, 0xb8, 0x0f, 0xa0, 0x71, 0xca, 0x67, 0x2a, 0x39, 0xaf, 0x6f, 0xd8, 0x79, 0xc0, 0xda, 0x65,
-	0xc0, 0xba, 0xbf, 0xb3, 0xa0, 0x8d, 0x27, 0x97, 0x88, 0x02, 0x83, 0xe5, 0xe6, 0x4a, 0xbb, 0x07,
-	0x8d, 0x50, 0xaf, 0x90, 0x23, 0x8b, 0x9c, 0x26, 0x1f, 0xc9, 0x32, 0xaf, 0x66, 0xd0, 0x35, 0xf7,
-	0xbb, 0x15, 0xc3, 0x9e, 0xf2, 0x09, 0x0d, 0xcd, 0x88, 0x2a, 0xc4, 0xdd, 0x3f, 0x5b, 0xb0, 0xb3,
-	0x24, 0x43, 0xde, 0x86, 0x75, 0x5c, 0x55, 0xdf, 0xc4, 0xdb, 0x95, 0xb9, 0x72, 0x7f, 0xa2, 0x84,
-	0xf4, 0x67, 0xc8, 0x68, 0xc6, 0x74, 0xa7, 0x2d, 0xfc, 0x89, 0xae, 0x3f, 0x95, 0x23, 0x9e, 0x12,
-	0x20, 0xdd, 0x2a, 0xd8, 0xb8, 0xb3, 0xe4, 0xcc, 0xff, 0x07, 0x6e, 0xb8, 0xff, 0x95, 0xf1, 0x2b,
-	0x63, 0xf9, 0xc6, 0xf8, 0x45, 0xac, 0x35, 0x15, 0x3d, 0xdf, 0x4f, 0x59, 0x96, 0xe9, 0x5e, 0x6d,
-	0xb2, 0xc8, 0x1b, 0xd0, 0x9e, 0x84, 0x01, 0x8b, 0x0b, 0x19, 0xd5, 0x6f, 0xab, 0x4c, 0x23, 0x08,
-	0xea, 0x2f, 0x0c, 0x82, 0x9b, 0x83, 0x3b, 0xbf, 0x24, 0x17, 0x07, 0xac, 0xdc, 0x88, 0x65, 0x45,
-	0xac, 0x99, 0x37, 0xe2, 0x77, 0x60, 0x37, 0xa4, 0x99, 0xf8, 0x8c, 0xd1, 0x54, 0x8c, 0x19, 0x55,
-	0x52, 0x9b, 0x28, 0xb5, 0x3a, 0x20, 0x43, 0xe6, 0x8a, 0xa5, 0x59, 0xc0, 0x63, 0x1d, 0xe0, 0x39,
-	0x89, 0x60, 0x54, 0x35, 0x8d, 0x01, 0xd6, 0xc9, 0xa6, 0x57, 0xd0, 0xd2, 0xc4, 0x3e, 0x4b, 0x42,
-	0xbe, 0x30, 0xaa, 0xa5, 0xc1, 0x91, 0x3b, 0xd4, 0xd8, 0x88, 0xf9, 0x58, 0x30, 0x1b, 0x5e, 0xc9,
-	0x70, 0xff, 0x90, 0x43, 0xb6, 0x4c, 0x42, 0x62, 0x72, 0xbf, 0x8a, 0xaa, 0x7f, 0x50, 0x09, 0x18,
-	0x14, 0x39, 0x94, 0x7f, 0x34, 0x60, 0x53, 0xb2, 0x7b, 0x0f, 0x01, 0x4a, 0xe6, 0x35, 0x80, 0xf1,
-	0x2d, 0x13, 0x68, 0xc9, 0xea, 0xb8, 0x0c, 0xd5, 0x4d, 0xec, 0xf5, 0x77, 0x0b, 0x9a, 0xc5, 0x40,
-	0x05, 0x85, 0x5b, 0xb7, 0xa3, 0x70, 0x7b, 0x05, 0x85, 0x93, 0x4f, 0x60, 0x87, 0x86, 0x21, 0x9f,
-	0x50, 0xc1, 0x7c, 0x75, 0x82, 0x4e, 0x0d, 0xcf, 0xf5, 0x6a, 0xbe, 0x85, 0x5e, 0x65, 0xd8, 0x5b,
-	0x16, 0x97, 0x87, 0xc9, 0xd8, 0x97, 0xba, 0x3b, 0xca, 0x4f, 0x7c, 0x87, 0xc9, 0x85, 0x1e, 0x4f,
-	0xa7, 0x19, 0x13, 0xba, 0x49, 0x2e, 0xb3, 0xdd, 0x29, 0x6c, 0x57, 0xa7, 0xbf, 0xa5, 0x26, 0xec,
-	0x43, 0xab, 0x50, 0xef, 0x89, 0xfc, 0x0d, 0xcc, 0x60, 0x49, 0xdd, 0x64, 0x9e, 0x26, 0x3c, 0x63,
-	0xba, 0x6a, 0xe7, 0xa4, 0xfb, 0xc7, 0xbc, 0xf6, 0xa0, 0x7f, 0xfa, 0x91, 0x4f, 0xde, 0xad, 0xdc,
-	0xfc, 0xbe, 0xb7, 0xea, 0xc4, 0x7e, 0xe4, 0x1b, 0x77, 0xc0, 0xfb, 0xb0, 0x31, 0x49, 0x99, 0x0c,
-	0x77, 0xe5, 0xa0, 0xef, 0x5f, 0xa3, 0x80, 0xe3, 0xfd, 0xc8, 0xf7, 0xb4, 0x28, 0x79, 0x0f, 0xd6,
-	0x71, 0x7b, 0xba, 0x4c, 0xed, 0xad, 0xea, 0xe0, 0xe1, 0xa5, 0x8a, 0x12, 0x74, 0xbf, 0x03, 0xaf,
-	0x5c, 0x33, 0xa1, 0x3b, 0x00, 0xb2, 0xaa, 0x73, 0xc3, 0xa5, 0xcc, 0x30, 0x82, 0x5d, 0x35, 0xc2,
-	0xc7, 0xb0, 0x95, 0x43, 0xa5, 0x61, 0x3c, 0xe5, 0x65, 0xaf, 0xd6, 0xfa, 0xaa, 0x57, 0xdf, 0x81,
-	0x75, 0x7f, 0x1e, 0x45, 0x8b, 0xfc, 0xea, 0x82, 0x84, 0xfb, 0x09, 0x40, 0x59, 0xe5, 0x50, 0x13,
-	0x1b, 0x61, 0xae, 0x99, 0x3f, 0xd8, 0x96, 0x28, 0xca, 0x5e, 0x42, 0x51, 0xdd, 0xae, 0x8e, 0x59,
-	0x69, 0x54, 0xb2, 0x0d, 0x70, 0xca, 0xa8, 0xcf, 0xd2, 0xc7, 0x71, 0xb8, 0x70, 0xd6, 0x48, 0x1b,
-	0x9a, 0xbd, 0x30, 0x54, 0x67, 0x74, 0xac, 0xee, 0x3d, 0xe3, 0xad, 0x8d, 0x91, 0x0d, 0xb0, 0x2f,
-	0x12, 0x67, 0x8d, 0x34, 0xa0, 0x3e, 0xe0, 0x5f, 0xc5, 0x8e, 0x45, 0x08, 0x6c, 0xe3, 0x78, 0x81,
-	0x52, 0x1d, 0xbb, 0xfb, 0x0b, 0xe3, 0x39, 0x93, 0x91, 0x16, 0x6c, 0x7a, 0xf3, 0x38, 0x0e, 0xe2,
-	0x99, 0xb3, 0x46, 0xb6, 0xa0, 0x81, 0xb6, 0x94, 0x94, 0x25, 0xd7, 0x2e, 0xaf, 0x46, 0x8e, 0x2d,
-	0xd7, 0x1e, 0xe4, 0xb9, 0xee, 0xd4, 0xba, 0x23, 0x70, 0xfa, 0xf8, 0xca, 0xdc, 0xbf, 0x94, 0x69,
-	0x82, 0xdb, 0x6d, 0xc1, 0x66, 0xcf, 0xf7, 0xcf, 0xb8, 0xcf, 0x9c, 0x35, 0xa9, 0xaf, 0x2e, 0xf3,
-	0x48, 0xe3, 0x7c, 0x17, 0x89, 0x4f, 0x85, 0xa2, 0x6d, 0xb9, 0xb9, 0x9e, 0xef, 0x9f, 0x32, 0x9a,
-	0xc6, 0x2c, 0x45, 0x5e, 0xad, 0xfb, 0x10, 0x5a, 0xc6, 0xdb, 0x31, 0x69, 0xc2, 0xfa, 0x17, 0x5c,
-	0xb0, 0xd4, 0x59, 0x93, 0x53, 0x6b, 0x51, 0xc7, 0x22, 0xbb, 0xd0, 0x1e, 0xc6, 0x13, 0x1e, 0x05,
-	0xf1, 0x4c, 0x8d, 0xdb, 0x92, 0x35, 0x60, 0x11, 0x17, 0x05, 0xab, 0xd6, 0x7d, 0x00, 0xad, 0xfe,
-	0x25, 0x9b, 0x3c, 0x3d, 0xe7, 0x61, 0x30, 0x59, 0x48, 0xb3, 0x8c, 0xfa, 0xbd, 0x33, 0x67, 0x8d,
-	0xec, 0x40, 0xab, 0x77, 0x7e, 0xee, 0x3d, 0xfe, 0xd5, 0xf0, 0x51, 0xef, 0xc9, 0x89, 0x63, 0x11,
-	0x80, 0x8d, 0x8b, 0xd1, 0xc9, 0xc3, 0x93, 0x5f, 0x3b, 0x76, 0xf7, 0x1c, 0xb6, 0x1f, 0x27, 0x2c,
-	0xa5, 0x82, 0xa7, 0xfa, 0xae, 0xdd, 0x82, 0xcd, 0xd1, 0x45, 0xbf, 0x7f, 0x32, 0x1a, 0xa9, 0x7d,
-	0x3c, 0x19, 0x3e, 0x3a, 0x79, 0x7c, 0xf1, 0x44, 0xe9, 0xf5, 0x7b, 0x67, 0xfd, 0x93, 0x53, 0xc7,
-	0x46, 0x4b, 0x9e, 0x9c, 0x9f, 0xf6, 0xfa, 0x27, 0x4e, 0x0d, 0x89, 0x8b, 0xb3, 0xb3, 0xe1, 0xd9,
-	0xa7, 0x4e, 0xbd, 0x7b, 0x0c, 0x9b, 0xfa, 0xa1, 0x44, 0xae, 0x6c, 0x3c, 0x70, 0x38, 0x6b, 0xe4,
-	0x15, 0xd8, 0x51, 0xe1, 0x5b, 0xd4, 0x29, 0x75, 0xbc, 0xfe, 0x3c, 0x13, 0x3c, 0x1a, 0xc9, 0xea,
-	0xdf, 0x13, 0x8e, 0xdf, 0xbd, 0x0f, 0x8d, 0xfc, 0xb1, 0x44, 0x4e, 0xae, 0x74, 0x7c, 0xb5, 0x9f,
-	0x5f, 0xf2, 0xf4, 0xa9, 0x72, 0x59, 0x1b, 0x9a, 0x7d, 0x1e, 0x25, 0x21, 0x93, 0x63, 0x76, 0xf7,
-	0xe7, 0x95, 0xe7, 0x74, 0x26, 0xb7, 0x7b, 0xc6, 0xd3, 0x88, 0x86, 0xca, 0xd7, 0x3d, 0xfd, 0x56,
-	0xe8, 0x58, 0xe4, 0x0e, 0x38, 0x5a, 0xd2, 0x0c, 0x95, 0x07, 0xb0, 0xbb, 0x92, 0xe7, 0xf2, 0x08,
-	0xc6, 0x8e, 0x95, 0x9f, 0x31, 0xd5, 0x14, 0x6d, 0x1d, 0x3b, 0xdf, 0xfc, 0xfb, 0xae, 0xf5, 0xf5,
-	0xf3, 0xbb, 0xd6, 0x37, 0xcf, 0xef, 0x5a, 0xff, 0x7a, 0x7e, 0xd7, 0x1a, 0x6f, 0xe0, 0xbf, 0x2d,
-	0xee, 0xff, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x83, 0x65, 0x37, 0x77, 0x28, 0x19, 0x00, 0x00,
+	// 2447 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x59, 0xcf, 0x73, 0xe3, 0xb6,
+	0xf5, 0x37, 0x29, 0xd9, 0x96, 0x9e, 0x64, 0x9b, 0x46, 0xf6, 0x9b, 0xaf, 0xea, 0xa6, 0x1b, 0x0f,
+	0xdb, 0x26, 0x8e, 0x9a, 0xd8, 0xe9, 0xee, 0x26, 0x93, 0xa4, 0x9d, 0x4e, 0x64, 0xc9, 0x4d, 0x94,
+	0xf5, 0x7a, 0x3d, 0xd4, 0x3a, 0x4d, 0x8f, 0x90, 0x08, 0xc9, 0x9c, 0x25, 0x09, 0x86, 0x84, 0x9c,
+	0x55, 0x67, 0x3a, 0xd3, 0x73, 0x0f, 0xfd, 0x2f, 0x7a, 0xeb, 0xa9, 0x7f, 0x41, 0x2f, 0x9d, 0xe6,
+	0xd6, 0x9c, 0x7b, 0xc8, 0xb4, 0xfb, 0x2f, 0xf4, 0xde, 0xe9, 0xe0, 0x01, 0x24, 0x41, 0xc9, 0x3f,
+	0xd2, 0x8b, 0xc5, 0xf7, 0xf0, 0x00, 0x3c, 0xbc, 0x9f, 0x1f, 0xc0, 0xd0, 0x8e, 0x98, 0xa0, 0xc9,
+	0xf8, 0x30, 0x49, 0xb9, 0xe0, 0x64, 0x43, 0x51, 0x7b, 0xef, 0xcc, 0x02, 0x71, 0x39, 0x1f, 0x1f,
+	0x4e, 0x78, 0x74, 0x34, 0xe3, 0x33, 0x7e, 0x84, 0xc3, 0xe3, 0xf9, 0x14, 0x29, 0x24, 0xf0, 0x4b,
+	0x4d, 0xdb, 0x7b, 0x6b, 0xc6, 0x0f, 0x99, 0x98, 0xf8, 0x87, 0x01, 0x3f, 0x92, 0xbf, 0x47, 0x29,
+	0x9d, 0x8a, 0xa3, 0xab, 0x87, 0xf8, 0x9b, 0x8c, 0xf1, 0x47, 0x89, 0xba, 0x9f, 0x01, 0x8c, 0x2e,
+	0x69, 0xea, 0x9f, 0x24, 0x7c, 0x72, 0x49, 0x5e, 0x83, 0xe6, 0x84, 0xc7, 0xd3, 0x60, 0xf6, 0x39,
+	0x4b, 0x3b, 0xd6, 0xbe, 0x75, 0x50, 0xf7, 0x4a, 0x06, 0xb9, 0x0f, 0x30, 0x63, 0x31, 0x4b, 0xa9,
+	0x08, 0x78, 0xdc, 0xb1, 0x71, 0xd8, 0xe0, 0xb8, 0xbf, 0xb7, 0x60, 0xd3, 0x63, 0x49, 0x18, 0x4c,
+	0x28, 0x79, 0x15, 0xec, 0xc0, 0x57, 0x4b, 0x1c, 0x6f, 0xbc, 0xfc, 0xf6, 0x75, 0x7b, 0x38, 0xf0,
+	0xec, 0xc0, 0x27, 0x1d, 0xd8, 0xcc, 0x04, 0x4f, 0xd9, 0x70, 0xa0, 0x17, 0xc8, 0x49, 0xf2, 0x26,
+	0xd4, 0x53, 0x1e, 0xb2, 0x4e, 0x6d, 0xdf, 0x3a, 0xd8, 0x7e, 0xf0, 0xca, 0xa1, 0x36, 0x84, 0x5e,
+	0xd0, 0xe3, 0x21, 0xf3, 0x50, 0x80, 0xfc, 0x08, 0xb6, 0x82, 0x38, 0x10, 0x01, 0x0d, 0x9f, 0xb0,
+	0x68, 0xcc, 0xd2, 0x4e, 0x7d, 0xdf, 0x3a, 0x68, 0x78, 0x55, 0xa6, 0x4b, 0xa1, 0xad, 0xa7, 0x8e,
+	0x04, 0x15, 0x19, 0x39, 0x82, 0xcd, 0x54, 0xd1, 0xa8, 0x55, 0xeb, 0xc1, 0xce, 0xd2, 0x0e, 0xc7,
+	0xf5, 0xaf, 0xbf, 0x7d, 0x7d, 0xcd, 0xcb, 0xa5, 0xc8, 0x3e, 0xb4, 0x7c, 0xfe, 0x55, 0x3c, 0x62,
+	0x13, 0x1e, 0xfb, 0x99, 0xd6, 0xd6, 0x64, 0xb9, 0x47, 0xb0, 0x7e, 0x4a, 0xc7, 0x2c, 0x24, 0x0e,
+	0xd4, 0x9e, 0xb3, 0x05, 0xae, 0xdb, 0xf4, 0xe4, 0x27, 0xb9, 0x07, 0xeb, 0x57, 0x34, 0x9c, 0x33,
+	0x9c, 0xd6, 0xf4, 0x14, 0xe1, 0xfe, 0xc9, 0xd6, 0xd6, 0x56, 0x2a, 0x49, 0x5b, 0x48, 0x6a, 0x38,
+	0xd0, 0xb6, 0xce, 0x49, 0xe2, 0x42, 0xfb, 0xab, 0x34, 0x10, 0x82, 0xc5, 0xc7, 0x0b, 0xc1, 0xf2,
+	0xcd, 0x2b, 0x3c, 0xa9, 0x9f, 0xa6, 0x1f, 0xb3, 0x45, 0x86, 0x66, 0xab, 0x7b, 0x26, 0x4b, 0x7a,
+	0x33, 0x65, 0xd4, 0x57, 0x4b, 0xd4, 0x95, 0x37, 0x0b, 0x06, 0xd9, 0x83, 0x86, 0x24, 0x70, 0xf2,
+	0x3a, 0x0e, 0x16, 0x34, 0x39, 0x80, 0x1d, 0x9a, 0x24, 0x29, 0x7f, 0x11, 0x44, 0x54, 0xb0, 0x51,
+	0xf0, 0x1b, 0xd6, 0xd9, 0x40, 0x91, 0x65, 0xf6, 0x92, 0x24, 0x2e, 0xb6, 0xb9, 0x22, 0x89, 0x6b,
+	0xbe, 0x0b, 0x8d, 0x20, 0x16, 0x2c, 0xbd, 0xa2, 0x61, 0xa7, 0x81, 0x1e, 0xb8, 0x97, 0x7b, 0xe0,
+	0x59, 0x10, 0xb1, 0xa1, 0x1e, 0xf3, 0x0a, 0x29, 0xf7, 0x2f, 0xeb, 0x00, 0x23, 0x19, 0x1d, 0xa5,
+	0xb9, 0x74, 0xe8, 0x58, 0xd5, 0xd0, 0x79, 0x0d, 0x9a, 0x99, 0xa0, 0xa9, 0x90, 0xeb, 0x68, 0x5b,
+	0x95, 0x8c, 0xca, 0xc6, 0xb5, 0xef, 0xb2, 0xb1, 0x34, 0xcd, 0x84, 0x26, 0x74, 0x12, 0x88, 0x85,
+	0xb6, 0x5b, 0x41, 0xcb, 0xbd, 0xe8, 0x15, 0x0d, 0x42, 0x3a, 0x0e, 0x99, 0xb6, 0x5b, 0xc9, 0x90,
+	0x33, 0xe7, 0x19, 0xf3, 0x0d, 0x8b, 0x15, 0x34, 0x79, 0x15, 0x36, 0x82, 0xec, 0x78, 0x9e, 0x2d,
+	0xd0, 0x42, 0x0d, 0x4f, 0x53, 0x32, 0xad, 0xd0, 0xef, 0x7d, 0x3e, 0x8f, 0x05, 0x9a, 0xa6, 0xee,
+	0x19, 0x1c, 0xd2, 0x05, 0x27, 0x63, 0xb1, 0x1f, 0xc4, 0xb3, 0x51, 0x4c, 0x13, 0x25, 0xd5, 0x44,
+	0xa9, 0x15, 0x3e, 0x39, 0x04, 0x92, 0xb2, 0x09, 0x0b, 0xae, 0x2a, 0xd2, 0x80, 0xd2, 0xd7, 0x8c,
+	0x90, 0xb7, 0x61, 0x97, 0x26, 0x49, 0xb8, 0xa8, 0x88, 0xb7, 0x50, 0x7c, 0x75, 0x60, 0x25, 0x2c,
+	0xdb, 0xd7, 0x84, 0x65, 0x25, 0xe8, 0xb6, 0x96, 0x83, 0x6e, 0x29, 0x68, 0xb7, 0x57, 0x83, 0xd6,
+	0x0c, 0xcb, 0x9d, 0xa5, 0xb0, 0x7c, 0x1f, 0x9a, 0x93, 0x64, 0x7e, 0x91, 0xd1, 0x19, 0xcb, 0x3a,
+	0xce, 0x7e, 0xed, 0xa0, 0xf5, 0x80, 0x94, 0x59, 0x3c, 0xe1, 0xa9, 0x7f, 0x4e, 0x83, 0x54, 0x27,
+	0x72, 0x29, 0x4a, 0x3e, 0x82, 0x96, 0x5c, 0x63, 0xf8, 0xd4, 0xa3, 0x52, 0xab, 0xdd, 0x3b, 0x66,
+	0x9a, 0xc2, 0xe4, 0xe7, 0xea, 0xcc, 0x2c, 0x9f, 0x4c, 0xee, 0x98, 0x5c, 0x91, 0x76, 0x1f, 0x01,
+	0x94, 0x12, 0x77, 0xd5, 0x89, 0x7a, 0x5e, 0x27, 0x3e, 0x85, 0x0d, 0x55, 0xc5, 0x6e, 0x2c, 0xa3,
+	0x04, 0xea, 0x31, 0x8d, 0xf2, 0xf2, 0x82, 0xdf, 0x92, 0x47, 0x7d, 0x3f, 0xc5, 0x18, 0x6f, 0x7a,
+	0xf8, 0xed, 0x7a, 0xb0, 0x7d, 0x9e, 0xf2, 0xe4, 0x92, 0x89, 0x7e, 0x38, 0xcf, 0xc4, 0x2d, 0x2b,
+	0x1e, 0xc0, 0x4e, 0x44, 0x5f, 0xe8, 0x5a, 0xa8, 0xe2, 0x40, 0x2e, 0xbe, 0xe5, 0x2d, 0xb3, 0xdd,
+	0xf7, 0xa1, 0x6d, 0xe6, 0x8d, 0x3c, 0x03, 0x26, 0x9b, 0xce, 0x4a, 0x45, 0xc8, 0xb3, 0xb2, 0xd8,
+	0xd7, 0xe7, 0x92, 0x9f, 0x6e, 0x08, 0xb5, 0xcf, 0xf8, 0x98, 0xfc, 0x10, 0xea, 0x62, 0x91, 0x30,
+	0x94, 0xde, 0x2e, 0xab, 0xf0, 0x67, 0x7c, 0xfc, 0x6c, 0x91, 0x30, 0x0f, 0x07, 0x65, 0xae, 0x4f,
+	0x78, 0x2c, 0x98, 0xd6, 0xa2, 0xed, 0xe5, 0x24, 0x79, 0x03, 0x77, 0x13, 0x79, 0x9f, 0x70, 0x8c,
+	0xf9, 0xb2, 0x4c, 0x30, 0x4f, 0x0d, 0xbb, 0x0c, 0xb6, 0x3d, 0x16, 0xf1, 0x2b, 0x86, 0x05, 0x57,
+	0x6e, 0xbc, 0xbf, 0x54, 0x6e, 0x8b, 0xe3, 0xe7, 0x6c, 0xf2, 0x53, 0x19, 0x7b, 0x78, 0x52, 0x59,
+	0x72, 0x6b, 0x37, 0x37, 0x89, 0x42, 0xcc, 0x1d, 0x40, 0x1b, 0x37, 0x38, 0xe7, 0x3c, 0x94, 0x9b,
+	0x3c, 0x82, 0xf5, 0x84, 0xf3, 0x30, 0xeb, 0x58, 0x38, 0xbf, 0x93, 0xcf, 0x37, 0x85, 0x9e, 0x30,
+	0x91, 0x2f, 0xa4, 0x84, 0xdd, 0x29, 0x38, 0xcb, 0x02, 0xd2, 0xac, 0xb3, 0x94, 0xcf, 0x93, 0xdc,
+	0xac, 0x48, 0x54, 0x4a, 0x93, 0xbd, 0x54, 0x9a, 0xf6, 0xa1, 0x95, 0xd2, 0x78, 0xc6, 0xce, 0x53,
+	0x36, 0x0d, 0x5e, 0xa0, 0x81, 0xda, 0x9e, 0xc9, 0x72, 0xff, 0x6d, 0x81, 0x33, 0x60, 0x99, 0x48,
+	0x39, 0x26, 0xb6, 0xa0, 0x62, 0x9e, 0xc9, 0x8d, 0x82, 0xd8, 0x67, 0x2f, 0xf2, 0x8d, 0x90, 0x20,
+	0xc7, 0x2b, 0xb6, 0x78, 0x23, 0x3f, 0xcb, 0xf2, 0x0a, 0xb9, 0x71, 0xb2, 0x93, 0x58, 0xa4, 0x8b,
+	0xd2, 0x38, 0xe4, 0xa0, 0xea, 0x2b, 0x52, 0x31, 0x86, 0xe9, 0x2d, 0x59, 0x03, 0x53, 0xf4, 0xd6,
+	0x80, 0x0a, 0xaa, 0x1b, 0xba, 0xc1, 0xd9, 0xfb, 0x19, 0x6c, 0x55, 0x36, 0x31, 0x53, 0xa9, 0x7e,
+	0x4d, 0x2a, 0x35, 0x74, 0x2a, 0x7d, 0x64, 0x7f, 0x60, 0xb9, 0x7f, 0xb5, 0x72, 0x90, 0xf3, 0x42,
+	0xa4, 0x94, 0xbc, 0x0f, 0x1b, 0xa1, 0x6c, 0xdb, 0xb9, 0x8f, 0xee, 0x57, 0xd4, 0x42, 0x99, 0x43,
+	0xec, 0xeb, 0xfa, 0x3c, 0x5a, 0x9a, 0x0c, 0xc0, 0xf1, 0x97, 0x4e, 0x8e, 0x7b, 0x19, 0x5e, 0x5e,
+	0xb6, 0x8c, 0xb7, 0x32, 0x63, 0xef, 0x43, 0x68, 0x19, 0x8b, 0x7f, 0x57, 0xe8, 0x80, 0xe7, 0xf8,
+	0x2d, 0xec, 0x8e, 0x26, 0x97, 0xcc, 0x9f, 0x87, 0xec, 0x13, 0x19, 0x0c, 0xde, 0x3c, 0x64, 0xb7,
+	0x01, 0x2d, 0x8c, 0x98, 0x12, 0x68, 0x69, 0xb2, 0xa8, 0x1d, 0x35, 0xa3, 0x76, 0xb8, 0xd0, 0xc6,
+	0xe1, 0xe3, 0x05, 0x2a, 0x87, 0x1e, 0x68, 0x7a, 0x15, 0x9e, 0x3b, 0x04, 0xc7, 0xa3, 0x53, 0xf1,
+	0x84, 0x65, 0xb2, 0xaa, 0x1e, 0x53, 0x31, 0xb9, 0x24, 0xef, 0x41, 0x23, 0x52, 0x74, 0x6e, 0xcd,
+	0x12, 0xb8, 0x19, 0xb2, 0x3a, 0x6b, 0x72, 0x51, 0xf7, 0x1f, 0x35, 0x68, 0x19, 0xe3, 0xb7, 0x20,
+	0xa1, 0x22, 0x0b, 0x6c, 0x33, 0x0b, 0xde, 0x82, 0xfa, 0x34, 0xe5, 0x91, 0x6e, 0xe7, 0x37, 0x24,
+	0x29, 0x8a, 0x90, 0x1f, 0x83, 0x2d, 0x78, 0xa7, 0x7e, 0x9b, 0xa0, 0x2d, 0xb8, 0x84, 0x87, 0x5a,
+	0xbb, 0xce, 0xba, 0x96, 0x55, 0x60, 0xf9, 0xb0, 0x7a, 0x86, 0x5c, 0x8a, 0x7c, 0xa0, 0xbb, 0x36,
+	0x02, 0x67, 0xec, 0xf5, 0xad, 0xa5, 0x00, 0xc7, 0x11, 0x3d, 0xcd, 0x90, 0x95, 0x69, 0x1a, 0x64,
+	0xcf, 0x78, 0x34, 0xce, 0x04, 0x8f, 0x99, 0x06, 0x03, 0x26, 0xab, 0xac, 0xa8, 0x0d, 0x4c, 0xe1,
+	0x6a, 0x45, 0x6d, 0x22, 0x4f, 0x7e, 0x4a, 0x44, 0x31, 0x8f, 0x83, 0x2f, 0xe7, 0x0c, 0x3b, 0x7c,
+	0xd3, 0xd3, 0x14, 0x66, 0x53, 0x1e, 0x24, 0x59, 0xa7, 0xb5, 0x5f, 0x3b, 0x68, 0x7a, 0x06, 0x47,
+	0x6a, 0x30, 0xe1, 0x51, 0x14, 0x88, 0x21, 0xe6, 0xbd, 0x6a, 0xe3, 0x26, 0x4b, 0x96, 0x19, 0x89,
+	0x2d, 0x10, 0x50, 0xa9, 0x26, 0x5e, 0xd0, 0x72, 0xf5, 0x69, 0x90, 0x66, 0x7a, 0xb2, 0x6a, 0xe1,
+	0x06, 0x47, 0x3a, 0x77, 0x4b, 0x62, 0x86, 0xec, 0x92, 0x8b, 0xfe, 0xe5, 0x3c, 0x7e, 0x7e, 0x0b,
+	0x72, 0x33, 0x1c, 0x6f, 0x57, 0x1d, 0x8f, 0x38, 0x02, 0xbd, 0x34, 0x1c, 0x68, 0x70, 0x5b, 0x32,
+	0x64, 0x0c, 0x63, 0x00, 0x28, 0x74, 0x86, 0xdf, 0xd8, 0x33, 0xe4, 0x76, 0xc3, 0x81, 0xc6, 0x65,
+	0x39, 0x89, 0xd7, 0x1a, 0xf9, 0x69, 0xc0, 0xb2, 0x92, 0x21, 0xcf, 0x83, 0x84, 0x6a, 0x7a, 0x0a,
+	0xbd, 0x1a, 0x9c, 0xb2, 0x3e, 0x36, 0xcc, 0xfa, 0x48, 0xa0, 0x2e, 0x58, 0x1a, 0x69, 0x24, 0x86,
+	0xdf, 0xd2, 0x6a, 0xd3, 0x20, 0x64, 0xe7, 0x54, 0x5c, 0x6a, 0x8f, 0x14, 0x74, 0x3e, 0x86, 0x2a,
+	0x28, 0x80, 0x55, 0xd0, 0xd2, 0x1f, 0xf2, 0xbb, 0xaf, 0xb5, 0xd7, 0xfe, 0x30, 0x58, 0xe4, 0x0d,
+	0xd8, 0x2e, 0x48, 0xa5, 0xa7, 0xf2, 0xca, 0x12, 0x57, 0x6a, 0xe5, 0xcb, 0x0a, 0xba, 0x8d, 0x41,
+	0x82, 0xdf, 0x52, 0x7f, 0x26, 0x8b, 0x1a, 0xc2, 0xa9, 0xb6, 0xa7, 0x08, 0xf2, 0x9e, 0xba, 0xea,
+	0x61, 0x15, 0xee, 0x38, 0x18, 0xbe, 0xbb, 0x79, 0xc8, 0xf7, 0xf3, 0x81, 0x02, 0x4a, 0xe5, 0x0c,
+	0x77, 0xa0, 0x21, 0xf9, 0xd0, 0x97, 0xcd, 0x58, 0x1a, 0x56, 0xe1, 0x8a, 0xc2, 0xb5, 0x25, 0xe3,
+	0xe6, 0xbb, 0x9e, 0xfb, 0x77, 0x1b, 0xd6, 0x31, 0x47, 0x6e, 0x2c, 0x5f, 0x45, 0x0a, 0xd8, 0xd7,
+	0xa4, 0x40, 0xad, 0x4c, 0x81, 0x43, 0x58, 0x67, 0x98, 0x81, 0xf5, 0x3b, 0x32, 0x50, 0x89, 0x95,
+	0x2d, 0x69, 0xfd, 0xae, 0x96, 0x64, 0x82, 0x81, 0x8d, 0xef, 0x04, 0x06, 0xca, 0x62, 0xb5, 0x69,
+	0x16, 0xab, 0x32, 0x4b, 0x1b, 0xb7, 0x64, 0x69, 0x73, 0x25, 0x4b, 0x7f, 0x52, 0xf4, 0x29, 0xc0,
+	0xed, 0xb7, 0xf2, 0xed, 0xb1, 0x1c, 0xeb, 0xcd, 0xb5, 0x88, 0xfb, 0x08, 0x1a, 0xa7, 0x7c, 0xa6,
+	0x92, 0xf7, 0xfa, 0x86, 0x9e, 0x07, 0xac, 0x5d, 0x06, 0xac, 0xfb, 0x3b, 0x0b, 0xb6, 0xf0, 0xe4,
+	0x12, 0x71, 0x60, 0xb0, 0xdc, 0x5c, 0x89, 0xf7, 0xa0, 0x11, 0xea, 0x1d, 0x72, 0xe4, 0x91, 0xd3,
+	0xe4, 0x43, 0xd9, 0x06, 0xd4, 0x0a, 0xba, 0x26, 0xff, 0x7f, 0xc5, 0xb0, 0xa7, 0x7c, 0x42, 0x43,
+	0x33, 0xa2, 0x0a, 0x71, 0xf7, 0xcf, 0x16, 0xec, 0x2c, 0xc9, 0x90, 0xb7, 0x60, 0x1d, 0x77, 0xd5,
+	0x37, 0xf5, 0xad, 0xca, 0x5a, 0xb9, 0x3f, 0x51, 0x42, 0xfa, 0x33, 0x64, 0x34, 0x63, 0xba, 0x13,
+	0x17, 0xfe, 0x44, 0xd7, 0x9f, 0xca, 0x11, 0x4f, 0x09, 0x90, 0x6e, 0x15, 0x8c, 0xdc, 0x5b, 0x72,
+	0xe6, 0xff, 0x02, 0x47, 0xdc, 0xff, 0xc8, 0xf8, 0x95, 0xb1, 0x7c, 0x63, 0xfc, 0x22, 0x16, 0x9b,
+	0x8a, 0x9e, 0xef, 0xa7, 0x2c, 0xcb, 0x74, 0x2f, 0x37, 0x59, 0xf2, 0x19, 0x63, 0x12, 0x06, 0x2c,
+	0x2e, 0x64, 0x54, 0x3f, 0xae, 0x32, 0x8d, 0x20, 0xa8, 0xdf, 0x19, 0x04, 0x37, 0x07, 0x77, 0x7e,
+	0x89, 0x2e, 0x0e, 0x58, 0xb9, 0x31, 0xcb, 0x8a, 0x58, 0x33, 0x6f, 0xcc, 0x6f, 0xc3, 0x6e, 0x48,
+	0x33, 0xf1, 0x29, 0xa3, 0xa9, 0x18, 0x33, 0xaa, 0xa4, 0x36, 0x51, 0x6a, 0x75, 0x40, 0x86, 0xcc,
+	0x15, 0x4b, 0x33, 0xf9, 0x26, 0xa4, 0x02, 0x3c, 0x27, 0x11, 0xac, 0xaa, 0xa6, 0x32, 0xc0, 0x3a,
+	0xd9, 0xf4, 0x0a, 0x5a, 0x9a, 0xd8, 0x67, 0x49, 0xc8, 0x17, 0x46, 0xb5, 0x34, 0x38, 0x52, 0x43,
+	0x8d, 0x9d, 0x98, 0x8f, 0x05, 0xb3, 0xe1, 0x95, 0x0c, 0xf7, 0x0f, 0x39, 0xa4, 0xcb, 0x24, 0x64,
+	0x26, 0x0f, 0xab, 0xa8, 0xfb, 0x07, 0x95, 0x80, 0x41, 0x91, 0x43, 0xf9, 0x47, 0x03, 0x3a, 0x25,
+	0xbb, 0xf7, 0x18, 0xa0, 0x64, 0x5e, 0x03, 0x28, 0xdf, 0x34, 0x81, 0x98, 0xac, 0x8e, 0xcb, 0x50,
+	0xde, 0xc4, 0x66, 0x7f, 0xb3, 0xa0, 0x59, 0x0c, 0x54, 0x50, 0xba, 0x75, 0x3b, 0x4a, 0xb7, 0x57,
+	0x50, 0x3a, 0xf9, 0x18, 0x76, 0x68, 0x18, 0xf2, 0x09, 0x15, 0xcc, 0x57, 0x27, 0xe8, 0xd4, 0xf0,
+	0x5c, 0xaf, 0xe6, 0x2a, 0xf4, 0x2a, 0xc3, 0xde, 0xb2, 0xb8, 0x3c, 0x4c, 0xc6, 0xbe, 0xd4, 0xdd,
+	0x51, 0x7e, 0xe2, 0x3b, 0x4d, 0x2e, 0xf4, 0x74, 0x3a, 0xcd, 0x98, 0xd0, 0x4d, 0x72, 0x99, 0xed,
+	0x4e, 0x61, 0xbb, 0xba, 0xfc, 0x2d, 0x35, 0x61, 0x1f, 0x5a, 0xc5, 0xf4, 0x9e, 0xc8, 0xdf, 0xc8,
+	0x0c, 0x96, 0x9c, 0x9b, 0xcc, 0xd3, 0x84, 0x67, 0x4c, 0x57, 0xed, 0x9c, 0x74, 0xff, 0x98, 0xd7,
+	0x1e, 0xf4, 0x4f, 0x3f, 0xf2, 0xc9, 0x3b, 0x95, 0x9b, 0xe1, 0xf7, 0x56, 0x9d, 0xd8, 0x8f, 0x7c,
+	0xe3, 0x8e, 0xf8, 0x10, 0x36, 0x26, 0x29, 0x93, 0xe1, 0xae, 0x1c, 0xf4, 0xfd, 0x6b, 0x26, 0xe0,
+	0x78, 0x3f, 0xf2, 0x3d, 0x2d, 0x4a, 0xde, 0x85, 0x75, 0x54, 0x4f, 0x97, 0xa9, 0xbd, 0xd5, 0x39,
+	0x78, 0x78, 0x39, 0x45, 0x09, 0xba, 0xff, 0x07, 0xaf, 0x5c, 0xb3, 0xa0, 0x3b, 0x00, 0xb2, 0x3a,
+	0xe7, 0x86, 0x4b, 0x9b, 0x61, 0x04, 0xbb, 0x6a, 0x84, 0x2f, 0xa0, 0x9d, 0x43, 0xa5, 0x61, 0x3c,
+	0xe5, 0x65, 0xaf, 0xd6, 0xf3, 0x91, 0x90, 0x5c, 0x7f, 0x1e, 0x45, 0x8b, 0xfc, 0x6a, 0x83, 0x84,
+	0xcc, 0x10, 0xc1, 0x05, 0x0d, 0x11, 0x52, 0x68, 0x84, 0x54, 0x30, 0xdc, 0x8f, 0x01, 0xca, 0x1a,
+	0x88, 0xeb, 0x4a, 0xaa, 0x58, 0x37, 0x7f, 0xee, 0x2d, 0x31, 0x96, 0xbd, 0x84, 0xb1, 0xba, 0x5d,
+	0x1d, 0xd1, 0xd2, 0xe4, 0x64, 0x1b, 0xe0, 0x94, 0x51, 0x9f, 0xa5, 0x4f, 0xe3, 0x70, 0xe1, 0xac,
+	0x91, 0x2d, 0x68, 0xf6, 0xc2, 0x50, 0x59, 0xc0, 0xb1, 0xba, 0x0f, 0x8c, 0x97, 0x3a, 0x46, 0x36,
+	0xc0, 0xbe, 0x48, 0x9c, 0x35, 0xd2, 0x80, 0xfa, 0x80, 0x7f, 0x15, 0x3b, 0x16, 0x21, 0xb0, 0x8d,
+	0xe3, 0x05, 0xc6, 0x75, 0xec, 0xee, 0x2f, 0x8d, 0xc7, 0x50, 0x46, 0x5a, 0xb0, 0xe9, 0xcd, 0xe3,
+	0x38, 0x88, 0x67, 0xce, 0x1a, 0x69, 0x43, 0x03, 0x2d, 0x2d, 0x29, 0x4b, 0xee, 0x5d, 0x5e, 0xac,
+	0x1c, 0x5b, 0xee, 0x3d, 0xc8, 0x2b, 0x81, 0x53, 0xeb, 0x8e, 0xc0, 0xe9, 0xe3, 0x1b, 0x75, 0xff,
+	0x52, 0x26, 0x11, 0xaa, 0xdb, 0x82, 0xcd, 0x9e, 0xef, 0x9f, 0x71, 0x9f, 0x39, 0x6b, 0x72, 0xbe,
+	0x7a, 0x0a, 0x40, 0x1a, 0xd7, 0xbb, 0x48, 0x7c, 0x2a, 0x14, 0x6d, 0x4b, 0xe5, 0x7a, 0xbe, 0x7f,
+	0xca, 0x68, 0x1a, 0xb3, 0x14, 0x79, 0xb5, 0xee, 0x63, 0x68, 0x19, 0x2f, 0xcf, 0xa4, 0x09, 0xeb,
+	0x9f, 0x73, 0xc1, 0x52, 0x67, 0x4d, 0x2e, 0xad, 0x45, 0x1d, 0x8b, 0xec, 0xc2, 0xd6, 0x30, 0x9e,
+	0xf0, 0x28, 0x88, 0x67, 0x6a, 0xdc, 0x96, 0xac, 0x01, 0x8b, 0xb8, 0x28, 0x58, 0xb5, 0xee, 0x23,
+	0x68, 0xf5, 0x2f, 0xd9, 0xe4, 0xf9, 0x39, 0x0f, 0x83, 0xc9, 0x42, 0x9a, 0x65, 0xd4, 0xef, 0x9d,
+	0x39, 0x6b, 0x64, 0x07, 0x5a, 0xbd, 0xf3, 0x73, 0xef, 0xe9, 0x17, 0xc3, 0x27, 0xbd, 0x67, 0x27,
+	0x8e, 0x45, 0x00, 0x36, 0x2e, 0x46, 0x27, 0x8f, 0x4f, 0x7e, 0xed, 0xd8, 0xdd, 0x73, 0xd8, 0x7e,
+	0x9a, 0xb0, 0x94, 0x0a, 0x9e, 0xea, 0x9b, 0x7a, 0x0b, 0x36, 0x47, 0x17, 0xfd, 0xfe, 0xc9, 0x68,
+	0xa4, 0xf4, 0x78, 0x36, 0x7c, 0x72, 0xf2, 0xf4, 0xe2, 0x99, 0x9a, 0xd7, 0xef, 0x9d, 0xf5, 0x4f,
+	0x4e, 0x1d, 0x1b, 0x2d, 0x79, 0x72, 0x7e, 0xda, 0xeb, 0x9f, 0x38, 0x35, 0x24, 0x2e, 0xce, 0xce,
+	0x86, 0x67, 0x9f, 0x38, 0xf5, 0xee, 0x31, 0x6c, 0xea, 0x67, 0x16, 0xb9, 0xb3, 0xf1, 0x3c, 0xe2,
+	0xac, 0x91, 0x57, 0x60, 0x47, 0x05, 0x77, 0x51, 0xc5, 0xd4, 0xf1, 0xfa, 0xf3, 0x4c, 0xf0, 0x68,
+	0x24, 0x7b, 0x43, 0x4f, 0x38, 0x7e, 0xf7, 0x21, 0x34, 0xf2, 0xa7, 0x16, 0xb9, 0xb8, 0x9a, 0xe3,
+	0x2b, 0x7d, 0x7e, 0xc5, 0xd3, 0xe7, 0xca, 0x65, 0x5b, 0xd0, 0xec, 0xf3, 0x28, 0x09, 0x99, 0x1c,
+	0xb3, 0xbb, 0xbf, 0xa8, 0x3c, 0xc6, 0x33, 0xa9, 0xee, 0x19, 0x4f, 0x23, 0x1a, 0x2a, 0x5f, 0xf7,
+	0xf4, 0x4b, 0xa3, 0x63, 0x91, 0x7b, 0xe0, 0x68, 0x49, 0x33, 0x54, 0x1e, 0xc1, 0xee, 0x4a, 0x15,
+	0x90, 0x47, 0x30, 0x34, 0x56, 0x7e, 0xc6, 0x44, 0x54, 0xb4, 0x75, 0xec, 0x7c, 0xf3, 0xaf, 0xfb,
+	0xd6, 0xd7, 0x2f, 0xef, 0x5b, 0xdf, 0xbc, 0xbc, 0x6f, 0xfd, 0xf3, 0xe5, 0x7d, 0x6b, 0xbc, 0x81,
+	0xff, 0xf4, 0x78, 0xf8, 0xdf, 0x01, 0x00, 0x51, 0x28, 0x31, 0xc0, 0x66, 0x19, 0x00, 0x00,
 }
 
 func (m *ShardEpoch) Marshal() (dAtA []byte, err error) {
@@ -4061,6 +4079,11 @@ func (m *RaftMessage) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMetapb(dAtA, i, uint64(m.SendTime))
 	}
+	if m.FirstIndex != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.FirstIndex))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4793,6 +4816,11 @@ func (m *SnapshotInfo) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.TotalSize != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.TotalSize))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -5336,6 +5364,9 @@ func (m *RaftMessage) Size() (n int) {
 	if m.SendTime != 0 {
 		n += 1 + sovMetapb(uint64(m.SendTime))
 	}
+	if m.FirstIndex != 0 {
+		n += 1 + sovMetapb(uint64(m.FirstIndex))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5734,6 +5765,9 @@ func (m *SnapshotInfo) Size() (n int) {
 	if m.Dummy {
 		n += 2
 	}
+	if m.TotalSize != 0 {
+		n += 1 + sovMetapb(uint64(m.TotalSize))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -8836,6 +8870,25 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstIndex", wireType)
+			}
+			m.FirstIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FirstIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -11283,6 +11336,25 @@ func (m *SnapshotInfo) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Dummy = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalSize", wireType)
+			}
+			m.TotalSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
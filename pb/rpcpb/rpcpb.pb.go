@@ -4,6 +4,7 @@
 package rpcpb
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	io "io"
 	math "math"
@@ -72,6 +73,10 @@ const (
 	TypeAddScheduleGroupRuleRsp Type = 38
 	TypeGetScheduleGroupRuleReq Type = 39
 	TypeGetScheduleGroupRuleRsp Type = 40
+	TypeShardHeartbeatsReq      Type = 41
+	TypeShardHeartbeatsRsp      Type = 42
+	TypeShardKeepaliveReq       Type = 43
+	TypeShardKeepaliveRsp       Type = 44
 )
 
 var Type_name = map[int32]string{
@@ -116,6 +121,10 @@ var Type_name = map[int32]string{
 	38: "TypeAddScheduleGroupRuleRsp",
 	39: "TypeGetScheduleGroupRuleReq",
 	40: "TypeGetScheduleGroupRuleRsp",
+	41: "TypeShardHeartbeatsReq",
+	42: "TypeShardHeartbeatsRsp",
+	43: "TypeShardKeepaliveReq",
+	44: "TypeShardKeepaliveRsp",
 }
 
 var Type_value = map[string]int32{
@@ -160,6 +169,10 @@ var Type_value = map[string]int32{
 	"TypeAddScheduleGroupRuleRsp": 38,
 	"TypeGetScheduleGroupRuleReq": 39,
 	"TypeGetScheduleGroupRuleRsp": 40,
+	"TypeShardHeartbeatsReq":      41,
+	"TypeShardHeartbeatsRsp":      42,
+	"TypeShardKeepaliveReq":       43,
+	"TypeShardKeepaliveRsp":       44,
 }
 
 func (x Type) String() string {
@@ -325,6 +338,8 @@ const (
 	CmdKVScan InternalCmd = 207
 	// CmdKVBatchMixedWrite mixed all kv write request
 	CmdKVBatchMixedWrite InternalCmd = 208
+	// CmdKVConditionalSet kv conditional set command, write type
+	CmdKVConditionalSet InternalCmd = 209
 	// CmdReserved cube reserved cmd type value, all custom cmd type read and
 	// write cmd type can not use the value below the reserved value.
 	CmdReserved InternalCmd = 1000
@@ -352,6 +367,7 @@ var InternalCmd_name = map[int32]string{
 	206:  "CmdKVRangeDelete",
 	207:  "CmdKVScan",
 	208:  "CmdKVBatchMixedWrite",
+	209:  "CmdKVConditionalSet",
 	1000: "CmdReserved",
 }
 
@@ -377,6 +393,7 @@ var InternalCmd_value = map[string]int32{
 	"CmdKVRangeDelete":     206,
 	"CmdKVScan":            207,
 	"CmdKVBatchMixedWrite": 208,
+	"CmdKVConditionalSet":  209,
 	"CmdReserved":          1000,
 }
 
@@ -481,6 +498,8 @@ type ProphetRequest struct {
 	ExecuteJob           ExecuteJobReq           `protobuf:"bytes,21,opt,name=executeJob,proto3" json:"executeJob"`
 	AddScheduleGroupRule AddScheduleGroupRuleReq `protobuf:"bytes,22,opt,name=addScheduleGroupRule,proto3" json:"addScheduleGroupRule"`
 	GetScheduleGroupRule GetScheduleGroupRuleReq `protobuf:"bytes,23,opt,name=getScheduleGroupRule,proto3" json:"getScheduleGroupRule"`
+	ShardHeartbeats      ShardHeartbeatsReq      `protobuf:"bytes,24,opt,name=shardHeartbeats,proto3" json:"shardHeartbeats"`
+	ShardKeepalive       ShardKeepaliveReq       `protobuf:"bytes,25,opt,name=shardKeepalive,proto3" json:"shardKeepalive"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
 	XXX_sizecache        int32                   `json:"-"`
@@ -680,6 +699,20 @@ func (m *ProphetRequest) GetGetScheduleGroupRule() GetScheduleGroupRuleReq {
 	return GetScheduleGroupRuleReq{}
 }
 
+func (m *ProphetRequest) GetShardHeartbeats() ShardHeartbeatsReq {
+	if m != nil {
+		return m.ShardHeartbeats
+	}
+	return ShardHeartbeatsReq{}
+}
+
+func (m *ProphetRequest) GetShardKeepalive() ShardKeepaliveReq {
+	if m != nil {
+		return m.ShardKeepalive
+	}
+	return ShardKeepaliveReq{}
+}
+
 // ProphetResponse the prophet rpc response
 type ProphetResponse struct {
 	ID                   uint64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -706,6 +739,8 @@ type ProphetResponse struct {
 	ExecuteJob           ExecuteJobRsp           `protobuf:"bytes,22,opt,name=executeJob,proto3" json:"executeJob"`
 	AddScheduleGroupRule AddScheduleGroupRuleRsp `protobuf:"bytes,23,opt,name=addScheduleGroupRule,proto3" json:"addScheduleGroupRule"`
 	GetScheduleGroupRule GetScheduleGroupRuleRsp `protobuf:"bytes,24,opt,name=getScheduleGroupRule,proto3" json:"getScheduleGroupRule"`
+	ShardHeartbeats      ShardHeartbeatsRsp      `protobuf:"bytes,25,opt,name=shardHeartbeats,proto3" json:"shardHeartbeats"`
+	ShardKeepalive       ShardKeepaliveRsp       `protobuf:"bytes,26,opt,name=shardKeepalive,proto3" json:"shardKeepalive"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
 	XXX_sizecache        int32                   `json:"-"`
@@ -912,21 +947,45 @@ func (m *ProphetResponse) GetGetScheduleGroupRule() GetScheduleGroupRuleRsp {
 	return GetScheduleGroupRuleRsp{}
 }
 
+func (m *ProphetResponse) GetShardHeartbeats() ShardHeartbeatsRsp {
+	if m != nil {
+		return m.ShardHeartbeats
+	}
+	return ShardHeartbeatsRsp{}
+}
+
+func (m *ProphetResponse) GetShardKeepalive() ShardKeepaliveRsp {
+	if m != nil {
+		return m.ShardKeepalive
+	}
+	return ShardKeepaliveRsp{}
+}
+
 // ShardHeartbeatReq shard heartbeat request
 type ShardHeartbeatReq struct {
 	StoreID uint64 `protobuf:"varint,1,opt,name=storeID,proto3" json:"storeID,omitempty"`
 	Shard   []byte `protobuf:"bytes,2,opt,name=shard,proto3" json:"shard,omitempty"`
 	// Term is the term of raft group.
-	Term                 uint64                `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
-	Leader               *metapb.Replica       `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
-	DownReplicas         []metapb.ReplicaStats `protobuf:"bytes,5,rep,name=downReplicas,proto3" json:"downReplicas"`
-	PendingReplicas      []metapb.Replica      `protobuf:"bytes,6,rep,name=pendingReplicas,proto3" json:"pendingReplicas"`
-	Stats                metapb.ShardStats     `protobuf:"bytes,7,opt,name=stats,proto3" json:"stats"`
-	GroupKey             string                `protobuf:"bytes,8,opt,name=groupKey,proto3" json:"groupKey,omitempty"`
-	Lease                *metapb.EpochLease    `protobuf:"bytes,9,opt,name=lease,proto3" json:"lease,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Term            uint64                `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
+	Leader          *metapb.Replica       `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
+	DownReplicas    []metapb.ReplicaStats `protobuf:"bytes,5,rep,name=downReplicas,proto3" json:"downReplicas"`
+	PendingReplicas []metapb.Replica      `protobuf:"bytes,6,rep,name=pendingReplicas,proto3" json:"pendingReplicas"`
+	Stats           metapb.ShardStats     `protobuf:"bytes,7,opt,name=stats,proto3" json:"stats"`
+	GroupKey        string                `protobuf:"bytes,8,opt,name=groupKey,proto3" json:"groupKey,omitempty"`
+	Lease           *metapb.EpochLease    `protobuf:"bytes,9,opt,name=lease,proto3" json:"lease,omitempty"`
+	// StoreDiskUsage is the disk usage percentage of the store sending the
+	// heartbeat, sampled at most once per store heartbeat interval.
+	StoreDiskUsage float64 `protobuf:"fixed64,10,opt,name=storeDiskUsage,proto3" json:"storeDiskUsage,omitempty"`
+	// StoreCPULoad is the recent CPU usage percentage of the store sending the
+	// heartbeat, sampled together with StoreDiskUsage.
+	StoreCPULoad float64 `protobuf:"fixed64,11,opt,name=storeCPULoad,proto3" json:"storeCPULoad,omitempty"`
+	// ReadQPS and WriteQPS are the number of keys read and written per second
+	// of the shard since the last heartbeat.
+	ReadQPS              float64  `protobuf:"fixed64,12,opt,name=readQPS,proto3" json:"readQPS,omitempty"`
+	WriteQPS             float64  `protobuf:"fixed64,13,opt,name=writeQPS,proto3" json:"writeQPS,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ShardHeartbeatReq) Reset()         { *m = ShardHeartbeatReq{} }
@@ -1025,6 +1084,34 @@ func (m *ShardHeartbeatReq) GetLease() *metapb.EpochLease {
 	return nil
 }
 
+func (m *ShardHeartbeatReq) GetStoreDiskUsage() float64 {
+	if m != nil {
+		return m.StoreDiskUsage
+	}
+	return 0
+}
+
+func (m *ShardHeartbeatReq) GetStoreCPULoad() float64 {
+	if m != nil {
+		return m.StoreCPULoad
+	}
+	return 0
+}
+
+func (m *ShardHeartbeatReq) GetReadQPS() float64 {
+	if m != nil {
+		return m.ReadQPS
+	}
+	return 0
+}
+
+func (m *ShardHeartbeatReq) GetWriteQPS() float64 {
+	if m != nil {
+		return m.WriteQPS
+	}
+	return 0
+}
+
 // ShardHeartbeatRsp shard heartbeat response.
 type ShardHeartbeatRsp struct {
 	ShardID    uint64            `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
@@ -1161,6 +1248,219 @@ func (m *ShardHeartbeatRsp) GetDestroyDirectly() bool {
 	return false
 }
 
+// ShardHeartbeatsReq the heartbeats of many shards sent by a store in a single
+// request.
+type ShardHeartbeatsReq struct {
+	Heartbeats           []ShardHeartbeatReq `protobuf:"bytes,1,rep,name=heartbeats,proto3" json:"heartbeats"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ShardHeartbeatsReq) Reset()         { *m = ShardHeartbeatsReq{} }
+func (m *ShardHeartbeatsReq) String() string { return proto.CompactTextString(m) }
+func (*ShardHeartbeatsReq) ProtoMessage()    {}
+func (*ShardHeartbeatsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{4}
+}
+func (m *ShardHeartbeatsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardHeartbeatsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardHeartbeatsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShardHeartbeatsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardHeartbeatsReq.Merge(m, src)
+}
+func (m *ShardHeartbeatsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardHeartbeatsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardHeartbeatsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardHeartbeatsReq proto.InternalMessageInfo
+
+func (m *ShardHeartbeatsReq) GetHeartbeats() []ShardHeartbeatReq {
+	if m != nil {
+		return m.Heartbeats
+	}
+	return nil
+}
+
+// ShardHeartbeatsRsp the results of the heartbeats in the ShardHeartbeatsReq,
+// in the same order, empty if the heartbeat is handled.
+type ShardHeartbeatsRsp struct {
+	Errors               []string `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShardHeartbeatsRsp) Reset()         { *m = ShardHeartbeatsRsp{} }
+func (m *ShardHeartbeatsRsp) String() string { return proto.CompactTextString(m) }
+func (*ShardHeartbeatsRsp) ProtoMessage()    {}
+func (*ShardHeartbeatsRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{5}
+}
+func (m *ShardHeartbeatsRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardHeartbeatsRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardHeartbeatsRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShardHeartbeatsRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardHeartbeatsRsp.Merge(m, src)
+}
+func (m *ShardHeartbeatsRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardHeartbeatsRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardHeartbeatsRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardHeartbeatsRsp proto.InternalMessageInfo
+
+func (m *ShardHeartbeatsRsp) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+// ShardKeepaliveReq the lightweight heartbeat sent by the leader of a shard
+// unchanged since the last full heartbeat. Prophet rejects it if the cached
+// shard is not the one described, the leader sends a full heartbeat then.
+type ShardKeepaliveReq struct {
+	ShardID uint64            `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Epoch   metapb.ShardEpoch `protobuf:"bytes,2,opt,name=epoch,proto3" json:"epoch"`
+	// Term is the term of raft group.
+	Term                 uint64         `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
+	Leader               metapb.Replica `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ShardKeepaliveReq) Reset()         { *m = ShardKeepaliveReq{} }
+func (m *ShardKeepaliveReq) String() string { return proto.CompactTextString(m) }
+func (*ShardKeepaliveReq) ProtoMessage()    {}
+func (*ShardKeepaliveReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{6}
+}
+func (m *ShardKeepaliveReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardKeepaliveReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardKeepaliveReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShardKeepaliveReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardKeepaliveReq.Merge(m, src)
+}
+func (m *ShardKeepaliveReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardKeepaliveReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardKeepaliveReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardKeepaliveReq proto.InternalMessageInfo
+
+func (m *ShardKeepaliveReq) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
+	}
+	return 0
+}
+
+func (m *ShardKeepaliveReq) GetEpoch() metapb.ShardEpoch {
+	if m != nil {
+		return m.Epoch
+	}
+	return metapb.ShardEpoch{}
+}
+
+func (m *ShardKeepaliveReq) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *ShardKeepaliveReq) GetLeader() metapb.Replica {
+	if m != nil {
+		return m.Leader
+	}
+	return metapb.Replica{}
+}
+
+// ShardKeepaliveRsp shard keepalive response.
+type ShardKeepaliveRsp struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShardKeepaliveRsp) Reset()         { *m = ShardKeepaliveRsp{} }
+func (m *ShardKeepaliveRsp) String() string { return proto.CompactTextString(m) }
+func (*ShardKeepaliveRsp) ProtoMessage()    {}
+func (*ShardKeepaliveRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{7}
+}
+func (m *ShardKeepaliveRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardKeepaliveRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardKeepaliveRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShardKeepaliveRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardKeepaliveRsp.Merge(m, src)
+}
+func (m *ShardKeepaliveRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardKeepaliveRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardKeepaliveRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardKeepaliveRsp proto.InternalMessageInfo
+
 // PutStoreReq put store request
 type PutStoreReq struct {
 	Store                []byte   `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
@@ -1173,7 +1473,7 @@ func (m *PutStoreReq) Reset()         { *m = PutStoreReq{} }
 func (m *PutStoreReq) String() string { return proto.CompactTextString(m) }
 func (*PutStoreReq) ProtoMessage()    {}
 func (*PutStoreReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{4}
+	return fileDescriptor_25e491924c678914, []int{8}
 }
 func (m *PutStoreReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1221,7 +1521,7 @@ func (m *PutStoreRsp) Reset()         { *m = PutStoreRsp{} }
 func (m *PutStoreRsp) String() string { return proto.CompactTextString(m) }
 func (*PutStoreRsp) ProtoMessage()    {}
 func (*PutStoreRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{5}
+	return fileDescriptor_25e491924c678914, []int{9}
 }
 func (m *PutStoreRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1270,7 +1570,7 @@ func (m *StoreHeartbeatReq) Reset()         { *m = StoreHeartbeatReq{} }
 func (m *StoreHeartbeatReq) String() string { return proto.CompactTextString(m) }
 func (*StoreHeartbeatReq) ProtoMessage()    {}
 func (*StoreHeartbeatReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{6}
+	return fileDescriptor_25e491924c678914, []int{10}
 }
 func (m *StoreHeartbeatReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1325,7 +1625,7 @@ func (m *StoreHeartbeatRsp) Reset()         { *m = StoreHeartbeatRsp{} }
 func (m *StoreHeartbeatRsp) String() string { return proto.CompactTextString(m) }
 func (*StoreHeartbeatRsp) ProtoMessage()    {}
 func (*StoreHeartbeatRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{7}
+	return fileDescriptor_25e491924c678914, []int{11}
 }
 func (m *StoreHeartbeatRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1373,7 +1673,7 @@ func (m *GetStoreReq) Reset()         { *m = GetStoreReq{} }
 func (m *GetStoreReq) String() string { return proto.CompactTextString(m) }
 func (*GetStoreReq) ProtoMessage()    {}
 func (*GetStoreReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{8}
+	return fileDescriptor_25e491924c678914, []int{12}
 }
 func (m *GetStoreReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1422,7 +1722,7 @@ func (m *GetStoreRsp) Reset()         { *m = GetStoreRsp{} }
 func (m *GetStoreRsp) String() string { return proto.CompactTextString(m) }
 func (*GetStoreRsp) ProtoMessage()    {}
 func (*GetStoreRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{9}
+	return fileDescriptor_25e491924c678914, []int{13}
 }
 func (m *GetStoreRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1476,7 +1776,7 @@ func (m *AllocIDReq) Reset()         { *m = AllocIDReq{} }
 func (m *AllocIDReq) String() string { return proto.CompactTextString(m) }
 func (*AllocIDReq) ProtoMessage()    {}
 func (*AllocIDReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{10}
+	return fileDescriptor_25e491924c678914, []int{14}
 }
 func (m *AllocIDReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1517,7 +1817,7 @@ func (m *AllocIDRsp) Reset()         { *m = AllocIDRsp{} }
 func (m *AllocIDRsp) String() string { return proto.CompactTextString(m) }
 func (*AllocIDRsp) ProtoMessage()    {}
 func (*AllocIDRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{11}
+	return fileDescriptor_25e491924c678914, []int{15}
 }
 func (m *AllocIDRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1566,7 +1866,7 @@ func (m *AskBatchSplitReq) Reset()         { *m = AskBatchSplitReq{} }
 func (m *AskBatchSplitReq) String() string { return proto.CompactTextString(m) }
 func (*AskBatchSplitReq) ProtoMessage()    {}
 func (*AskBatchSplitReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{12}
+	return fileDescriptor_25e491924c678914, []int{16}
 }
 func (m *AskBatchSplitReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1621,7 +1921,7 @@ func (m *AskBatchSplitRsp) Reset()         { *m = AskBatchSplitRsp{} }
 func (m *AskBatchSplitRsp) String() string { return proto.CompactTextString(m) }
 func (*AskBatchSplitRsp) ProtoMessage()    {}
 func (*AskBatchSplitRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{13}
+	return fileDescriptor_25e491924c678914, []int{17}
 }
 func (m *AskBatchSplitRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1672,7 +1972,7 @@ func (m *CreateDestroyingReq) Reset()         { *m = CreateDestroyingReq{} }
 func (m *CreateDestroyingReq) String() string { return proto.CompactTextString(m) }
 func (*CreateDestroyingReq) ProtoMessage()    {}
 func (*CreateDestroyingReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{14}
+	return fileDescriptor_25e491924c678914, []int{18}
 }
 func (m *CreateDestroyingReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1741,7 +2041,7 @@ func (m *CreateDestroyingRsp) Reset()         { *m = CreateDestroyingRsp{} }
 func (m *CreateDestroyingRsp) String() string { return proto.CompactTextString(m) }
 func (*CreateDestroyingRsp) ProtoMessage()    {}
 func (*CreateDestroyingRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{15}
+	return fileDescriptor_25e491924c678914, []int{19}
 }
 func (m *CreateDestroyingRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1789,7 +2089,7 @@ func (m *GetDestroyingReq) Reset()         { *m = GetDestroyingReq{} }
 func (m *GetDestroyingReq) String() string { return proto.CompactTextString(m) }
 func (*GetDestroyingReq) ProtoMessage()    {}
 func (*GetDestroyingReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{16}
+	return fileDescriptor_25e491924c678914, []int{20}
 }
 func (m *GetDestroyingReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1837,7 +2137,7 @@ func (m *GetDestroyingRsp) Reset()         { *m = GetDestroyingRsp{} }
 func (m *GetDestroyingRsp) String() string { return proto.CompactTextString(m) }
 func (*GetDestroyingRsp) ProtoMessage()    {}
 func (*GetDestroyingRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{17}
+	return fileDescriptor_25e491924c678914, []int{21}
 }
 func (m *GetDestroyingRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1886,7 +2186,7 @@ func (m *ReportDestroyedReq) Reset()         { *m = ReportDestroyedReq{} }
 func (m *ReportDestroyedReq) String() string { return proto.CompactTextString(m) }
 func (*ReportDestroyedReq) ProtoMessage()    {}
 func (*ReportDestroyedReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{18}
+	return fileDescriptor_25e491924c678914, []int{22}
 }
 func (m *ReportDestroyedReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1941,7 +2241,7 @@ func (m *ReportDestroyedRsp) Reset()         { *m = ReportDestroyedRsp{} }
 func (m *ReportDestroyedRsp) String() string { return proto.CompactTextString(m) }
 func (*ReportDestroyedRsp) ProtoMessage()    {}
 func (*ReportDestroyedRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{19}
+	return fileDescriptor_25e491924c678914, []int{23}
 }
 func (m *ReportDestroyedRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1990,7 +2290,7 @@ func (m *SplitID) Reset()         { *m = SplitID{} }
 func (m *SplitID) String() string { return proto.CompactTextString(m) }
 func (*SplitID) ProtoMessage()    {}
 func (*SplitID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{20}
+	return fileDescriptor_25e491924c678914, []int{24}
 }
 func (m *SplitID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2045,7 +2345,7 @@ func (m *CreateWatcherReq) Reset()         { *m = CreateWatcherReq{} }
 func (m *CreateWatcherReq) String() string { return proto.CompactTextString(m) }
 func (*CreateWatcherReq) ProtoMessage()    {}
 func (*CreateWatcherReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{21}
+	return fileDescriptor_25e491924c678914, []int{25}
 }
 func (m *CreateWatcherReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2094,7 +2394,7 @@ func (m *CreateShardsReq) Reset()         { *m = CreateShardsReq{} }
 func (m *CreateShardsReq) String() string { return proto.CompactTextString(m) }
 func (*CreateShardsReq) ProtoMessage()    {}
 func (*CreateShardsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{22}
+	return fileDescriptor_25e491924c678914, []int{26}
 }
 func (m *CreateShardsReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2148,7 +2448,7 @@ func (m *CreateShardsRsp) Reset()         { *m = CreateShardsRsp{} }
 func (m *CreateShardsRsp) String() string { return proto.CompactTextString(m) }
 func (*CreateShardsRsp) ProtoMessage()    {}
 func (*CreateShardsRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{23}
+	return fileDescriptor_25e491924c678914, []int{27}
 }
 func (m *CreateShardsRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2189,7 +2489,7 @@ func (m *RemoveShardsReq) Reset()         { *m = RemoveShardsReq{} }
 func (m *RemoveShardsReq) String() string { return proto.CompactTextString(m) }
 func (*RemoveShardsReq) ProtoMessage()    {}
 func (*RemoveShardsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{24}
+	return fileDescriptor_25e491924c678914, []int{28}
 }
 func (m *RemoveShardsReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2236,7 +2536,7 @@ func (m *RemoveShardsRsp) Reset()         { *m = RemoveShardsRsp{} }
 func (m *RemoveShardsRsp) String() string { return proto.CompactTextString(m) }
 func (*RemoveShardsRsp) ProtoMessage()    {}
 func (*RemoveShardsRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{25}
+	return fileDescriptor_25e491924c678914, []int{29}
 }
 func (m *RemoveShardsRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2277,7 +2577,7 @@ func (m *CheckShardStateReq) Reset()         { *m = CheckShardStateReq{} }
 func (m *CheckShardStateReq) String() string { return proto.CompactTextString(m) }
 func (*CheckShardStateReq) ProtoMessage()    {}
 func (*CheckShardStateReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{26}
+	return fileDescriptor_25e491924c678914, []int{30}
 }
 func (m *CheckShardStateReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2326,7 +2626,7 @@ func (m *CheckShardStateRsp) Reset()         { *m = CheckShardStateRsp{} }
 func (m *CheckShardStateRsp) String() string { return proto.CompactTextString(m) }
 func (*CheckShardStateRsp) ProtoMessage()    {}
 func (*CheckShardStateRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{27}
+	return fileDescriptor_25e491924c678914, []int{31}
 }
 func (m *CheckShardStateRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2381,7 +2681,7 @@ func (m *PutPlacementRuleReq) Reset()         { *m = PutPlacementRuleReq{} }
 func (m *PutPlacementRuleReq) String() string { return proto.CompactTextString(m) }
 func (*PutPlacementRuleReq) ProtoMessage()    {}
 func (*PutPlacementRuleReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{28}
+	return fileDescriptor_25e491924c678914, []int{32}
 }
 func (m *PutPlacementRuleReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2428,7 +2728,7 @@ func (m *PutPlacementRuleRsp) Reset()         { *m = PutPlacementRuleRsp{} }
 func (m *PutPlacementRuleRsp) String() string { return proto.CompactTextString(m) }
 func (*PutPlacementRuleRsp) ProtoMessage()    {}
 func (*PutPlacementRuleRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{29}
+	return fileDescriptor_25e491924c678914, []int{33}
 }
 func (m *PutPlacementRuleRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2469,7 +2769,7 @@ func (m *GetAppliedRulesReq) Reset()         { *m = GetAppliedRulesReq{} }
 func (m *GetAppliedRulesReq) String() string { return proto.CompactTextString(m) }
 func (*GetAppliedRulesReq) ProtoMessage()    {}
 func (*GetAppliedRulesReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{30}
+	return fileDescriptor_25e491924c678914, []int{34}
 }
 func (m *GetAppliedRulesReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2517,7 +2817,7 @@ func (m *GetAppliedRulesRsp) Reset()         { *m = GetAppliedRulesRsp{} }
 func (m *GetAppliedRulesRsp) String() string { return proto.CompactTextString(m) }
 func (*GetAppliedRulesRsp) ProtoMessage()    {}
 func (*GetAppliedRulesRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{31}
+	return fileDescriptor_25e491924c678914, []int{35}
 }
 func (m *GetAppliedRulesRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2565,7 +2865,7 @@ func (m *CreateJobReq) Reset()         { *m = CreateJobReq{} }
 func (m *CreateJobReq) String() string { return proto.CompactTextString(m) }
 func (*CreateJobReq) ProtoMessage()    {}
 func (*CreateJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{32}
+	return fileDescriptor_25e491924c678914, []int{36}
 }
 func (m *CreateJobReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2612,7 +2912,7 @@ func (m *CreateJobRsp) Reset()         { *m = CreateJobRsp{} }
 func (m *CreateJobRsp) String() string { return proto.CompactTextString(m) }
 func (*CreateJobRsp) ProtoMessage()    {}
 func (*CreateJobRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{33}
+	return fileDescriptor_25e491924c678914, []int{37}
 }
 func (m *CreateJobRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2653,7 +2953,7 @@ func (m *RemoveJobReq) Reset()         { *m = RemoveJobReq{} }
 func (m *RemoveJobReq) String() string { return proto.CompactTextString(m) }
 func (*RemoveJobReq) ProtoMessage()    {}
 func (*RemoveJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{34}
+	return fileDescriptor_25e491924c678914, []int{38}
 }
 func (m *RemoveJobReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2700,7 +3000,7 @@ func (m *RemoveJobRsp) Reset()         { *m = RemoveJobRsp{} }
 func (m *RemoveJobRsp) String() string { return proto.CompactTextString(m) }
 func (*RemoveJobRsp) ProtoMessage()    {}
 func (*RemoveJobRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{35}
+	return fileDescriptor_25e491924c678914, []int{39}
 }
 func (m *RemoveJobRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2742,7 +3042,7 @@ func (m *ExecuteJobReq) Reset()         { *m = ExecuteJobReq{} }
 func (m *ExecuteJobReq) String() string { return proto.CompactTextString(m) }
 func (*ExecuteJobReq) ProtoMessage()    {}
 func (*ExecuteJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{36}
+	return fileDescriptor_25e491924c678914, []int{40}
 }
 func (m *ExecuteJobReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2797,7 +3097,7 @@ func (m *ExecuteJobRsp) Reset()         { *m = ExecuteJobRsp{} }
 func (m *ExecuteJobRsp) String() string { return proto.CompactTextString(m) }
 func (*ExecuteJobRsp) ProtoMessage()    {}
 func (*ExecuteJobRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{37}
+	return fileDescriptor_25e491924c678914, []int{41}
 }
 func (m *ExecuteJobRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2844,7 +3144,7 @@ func (m *AddScheduleGroupRuleReq) Reset()         { *m = AddScheduleGroupRuleReq
 func (m *AddScheduleGroupRuleReq) String() string { return proto.CompactTextString(m) }
 func (*AddScheduleGroupRuleReq) ProtoMessage()    {}
 func (*AddScheduleGroupRuleReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{38}
+	return fileDescriptor_25e491924c678914, []int{42}
 }
 func (m *AddScheduleGroupRuleReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2890,7 +3190,7 @@ func (m *AddScheduleGroupRuleRsp) Reset()         { *m = AddScheduleGroupRuleRsp
 func (m *AddScheduleGroupRuleRsp) String() string { return proto.CompactTextString(m) }
 func (*AddScheduleGroupRuleRsp) ProtoMessage()    {}
 func (*AddScheduleGroupRuleRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{39}
+	return fileDescriptor_25e491924c678914, []int{43}
 }
 func (m *AddScheduleGroupRuleRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2929,7 +3229,7 @@ func (m *GetScheduleGroupRuleReq) Reset()         { *m = GetScheduleGroupRuleReq
 func (m *GetScheduleGroupRuleReq) String() string { return proto.CompactTextString(m) }
 func (*GetScheduleGroupRuleReq) ProtoMessage()    {}
 func (*GetScheduleGroupRuleReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{40}
+	return fileDescriptor_25e491924c678914, []int{44}
 }
 func (m *GetScheduleGroupRuleReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2969,7 +3269,7 @@ func (m *GetScheduleGroupRuleRsp) Reset()         { *m = GetScheduleGroupRuleRsp
 func (m *GetScheduleGroupRuleRsp) String() string { return proto.CompactTextString(m) }
 func (*GetScheduleGroupRuleRsp) ProtoMessage()    {}
 func (*GetScheduleGroupRuleRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{41}
+	return fileDescriptor_25e491924c678914, []int{45}
 }
 func (m *GetScheduleGroupRuleRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3023,7 +3323,7 @@ func (m *EventNotify) Reset()         { *m = EventNotify{} }
 func (m *EventNotify) String() string { return proto.CompactTextString(m) }
 func (*EventNotify) ProtoMessage()    {}
 func (*EventNotify) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{42}
+	return fileDescriptor_25e491924c678914, []int{46}
 }
 func (m *EventNotify) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3116,7 +3416,7 @@ func (m *InitEventData) Reset()         { *m = InitEventData{} }
 func (m *InitEventData) String() string { return proto.CompactTextString(m) }
 func (*InitEventData) ProtoMessage()    {}
 func (*InitEventData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{43}
+	return fileDescriptor_25e491924c678914, []int{47}
 }
 func (m *InitEventData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3189,7 +3489,7 @@ func (m *ShardEventData) Reset()         { *m = ShardEventData{} }
 func (m *ShardEventData) String() string { return proto.CompactTextString(m) }
 func (*ShardEventData) ProtoMessage()    {}
 func (*ShardEventData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{44}
+	return fileDescriptor_25e491924c678914, []int{48}
 }
 func (m *ShardEventData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3265,7 +3565,7 @@ func (m *StoreEventData) Reset()         { *m = StoreEventData{} }
 func (m *StoreEventData) String() string { return proto.CompactTextString(m) }
 func (*StoreEventData) ProtoMessage()    {}
 func (*StoreEventData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{45}
+	return fileDescriptor_25e491924c678914, []int{49}
 }
 func (m *StoreEventData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3314,7 +3614,7 @@ func (m *ConfigChange) Reset()         { *m = ConfigChange{} }
 func (m *ConfigChange) String() string { return proto.CompactTextString(m) }
 func (*ConfigChange) ProtoMessage()    {}
 func (*ConfigChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{46}
+	return fileDescriptor_25e491924c678914, []int{50}
 }
 func (m *ConfigChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3369,7 +3669,7 @@ func (m *TransferLeader) Reset()         { *m = TransferLeader{} }
 func (m *TransferLeader) String() string { return proto.CompactTextString(m) }
 func (*TransferLeader) ProtoMessage()    {}
 func (*TransferLeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{47}
+	return fileDescriptor_25e491924c678914, []int{51}
 }
 func (m *TransferLeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3417,7 +3717,7 @@ func (m *TransferLease) Reset()         { *m = TransferLease{} }
 func (m *TransferLease) String() string { return proto.CompactTextString(m) }
 func (*TransferLease) ProtoMessage()    {}
 func (*TransferLease) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{48}
+	return fileDescriptor_25e491924c678914, []int{52}
 }
 func (m *TransferLease) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3466,7 +3766,7 @@ func (m *ConfigChangeV2) Reset()         { *m = ConfigChangeV2{} }
 func (m *ConfigChangeV2) String() string { return proto.CompactTextString(m) }
 func (*ConfigChangeV2) ProtoMessage()    {}
 func (*ConfigChangeV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{49}
+	return fileDescriptor_25e491924c678914, []int{53}
 }
 func (m *ConfigChangeV2) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3515,7 +3815,7 @@ func (m *Merge) Reset()         { *m = Merge{} }
 func (m *Merge) String() string { return proto.CompactTextString(m) }
 func (*Merge) ProtoMessage()    {}
 func (*Merge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{50}
+	return fileDescriptor_25e491924c678914, []int{54}
 }
 func (m *Merge) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3564,7 +3864,7 @@ func (m *SplitShard) Reset()         { *m = SplitShard{} }
 func (m *SplitShard) String() string { return proto.CompactTextString(m) }
 func (*SplitShard) ProtoMessage()    {}
 func (*SplitShard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{51}
+	return fileDescriptor_25e491924c678914, []int{55}
 }
 func (m *SplitShard) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3621,7 +3921,7 @@ func (m *LabelConstraint) Reset()         { *m = LabelConstraint{} }
 func (m *LabelConstraint) String() string { return proto.CompactTextString(m) }
 func (*LabelConstraint) ProtoMessage()    {}
 func (*LabelConstraint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{52}
+	return fileDescriptor_25e491924c678914, []int{56}
 }
 func (m *LabelConstraint) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3702,7 +4002,7 @@ func (m *PlacementRule) Reset()         { *m = PlacementRule{} }
 func (m *PlacementRule) String() string { return proto.CompactTextString(m) }
 func (*PlacementRule) ProtoMessage()    {}
 func (*PlacementRule) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{53}
+	return fileDescriptor_25e491924c678914, []int{57}
 }
 func (m *PlacementRule) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3823,7 +4123,7 @@ func (m *RequestBatchHeader) Reset()         { *m = RequestBatchHeader{} }
 func (m *RequestBatchHeader) String() string { return proto.CompactTextString(m) }
 func (*RequestBatchHeader) ProtoMessage()    {}
 func (*RequestBatchHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{54}
+	return fileDescriptor_25e491924c678914, []int{58}
 }
 func (m *RequestBatchHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3892,7 +4192,7 @@ func (m *ResponseBatchHeader) Reset()         { *m = ResponseBatchHeader{} }
 func (m *ResponseBatchHeader) String() string { return proto.CompactTextString(m) }
 func (*ResponseBatchHeader) ProtoMessage()    {}
 func (*ResponseBatchHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{55}
+	return fileDescriptor_25e491924c678914, []int{59}
 }
 func (m *ResponseBatchHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3949,7 +4249,7 @@ func (m *RequestBatch) Reset()         { *m = RequestBatch{} }
 func (m *RequestBatch) String() string { return proto.CompactTextString(m) }
 func (*RequestBatch) ProtoMessage()    {}
 func (*RequestBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{56}
+	return fileDescriptor_25e491924c678914, []int{60}
 }
 func (m *RequestBatch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4005,7 +4305,7 @@ func (m *ResponseBatch) Reset()         { *m = ResponseBatch{} }
 func (m *ResponseBatch) String() string { return proto.CompactTextString(m) }
 func (*ResponseBatch) ProtoMessage()    {}
 func (*ResponseBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{57}
+	return fileDescriptor_25e491924c678914, []int{61}
 }
 func (m *ResponseBatch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4084,7 +4384,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{58}
+	return fileDescriptor_25e491924c678914, []int{62}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4261,7 +4561,7 @@ func (m *Range) Reset()         { *m = Range{} }
 func (m *Range) String() string { return proto.CompactTextString(m) }
 func (*Range) ProtoMessage()    {}
 func (*Range) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{59}
+	return fileDescriptor_25e491924c678914, []int{63}
 }
 func (m *Range) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4328,7 +4628,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{60}
+	return fileDescriptor_25e491924c678914, []int{64}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4443,18 +4743,20 @@ func (m *Response) GetCleanTxnMVCCData() *CleanTxnMVCCDataRequest {
 
 type ConfigChangeRequest struct {
 	// This can be only called in internal RaftStore now.
-	ChangeType           metapb.ConfigChangeType `protobuf:"varint,1,opt,name=changeType,proto3,enum=metapb.ConfigChangeType" json:"changeType,omitempty"`
-	Replica              metapb.Replica          `protobuf:"bytes,2,opt,name=replica,proto3" json:"replica"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	ChangeType metapb.ConfigChangeType `protobuf:"varint,1,opt,name=changeType,proto3,enum=metapb.ConfigChangeType" json:"changeType,omitempty"`
+	Replica    metapb.Replica          `protobuf:"bytes,2,opt,name=replica,proto3" json:"replica"`
+	// Force skip the quorum check, only used for disaster recovery.
+	Force                bool     `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ConfigChangeRequest) Reset()         { *m = ConfigChangeRequest{} }
 func (m *ConfigChangeRequest) String() string { return proto.CompactTextString(m) }
 func (*ConfigChangeRequest) ProtoMessage()    {}
 func (*ConfigChangeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{61}
+	return fileDescriptor_25e491924c678914, []int{65}
 }
 func (m *ConfigChangeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4497,6 +4799,13 @@ func (m *ConfigChangeRequest) GetReplica() metapb.Replica {
 	return metapb.Replica{}
 }
 
+func (m *ConfigChangeRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 // ConfigChangeResponse change peer response
 type ConfigChangeResponse struct {
 	Shard                metapb.Shard `protobuf:"bytes,1,opt,name=shard,proto3" json:"shard"`
@@ -4509,7 +4818,7 @@ func (m *ConfigChangeResponse) Reset()         { *m = ConfigChangeResponse{} }
 func (m *ConfigChangeResponse) String() string { return proto.CompactTextString(m) }
 func (*ConfigChangeResponse) ProtoMessage()    {}
 func (*ConfigChangeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{62}
+	return fileDescriptor_25e491924c678914, []int{66}
 }
 func (m *ConfigChangeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4557,7 +4866,7 @@ func (m *CompactLogRequest) Reset()         { *m = CompactLogRequest{} }
 func (m *CompactLogRequest) String() string { return proto.CompactTextString(m) }
 func (*CompactLogRequest) ProtoMessage()    {}
 func (*CompactLogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{63}
+	return fileDescriptor_25e491924c678914, []int{67}
 }
 func (m *CompactLogRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4604,7 +4913,7 @@ func (m *CompactLogResponse) Reset()         { *m = CompactLogResponse{} }
 func (m *CompactLogResponse) String() string { return proto.CompactTextString(m) }
 func (*CompactLogResponse) ProtoMessage()    {}
 func (*CompactLogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{64}
+	return fileDescriptor_25e491924c678914, []int{68}
 }
 func (m *CompactLogResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4645,7 +4954,7 @@ func (m *TransferLeaderRequest) Reset()         { *m = TransferLeaderRequest{} }
 func (m *TransferLeaderRequest) String() string { return proto.CompactTextString(m) }
 func (*TransferLeaderRequest) ProtoMessage()    {}
 func (*TransferLeaderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{65}
+	return fileDescriptor_25e491924c678914, []int{69}
 }
 func (m *TransferLeaderRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4691,7 +5000,7 @@ func (m *TransferLeaderResponse) Reset()         { *m = TransferLeaderResponse{}
 func (m *TransferLeaderResponse) String() string { return proto.CompactTextString(m) }
 func (*TransferLeaderResponse) ProtoMessage()    {}
 func (*TransferLeaderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{66}
+	return fileDescriptor_25e491924c678914, []int{70}
 }
 func (m *TransferLeaderResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4736,7 +5045,7 @@ func (m *BatchSplitRequest) Reset()         { *m = BatchSplitRequest{} }
 func (m *BatchSplitRequest) String() string { return proto.CompactTextString(m) }
 func (*BatchSplitRequest) ProtoMessage()    {}
 func (*BatchSplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{67}
+	return fileDescriptor_25e491924c678914, []int{71}
 }
 func (m *BatchSplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4797,7 +5106,7 @@ func (m *SplitRequest) Reset()         { *m = SplitRequest{} }
 func (m *SplitRequest) String() string { return proto.CompactTextString(m) }
 func (*SplitRequest) ProtoMessage()    {}
 func (*SplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{68}
+	return fileDescriptor_25e491924c678914, []int{72}
 }
 func (m *SplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4865,7 +5174,7 @@ func (m *BatchSplitResponse) Reset()         { *m = BatchSplitResponse{} }
 func (m *BatchSplitResponse) String() string { return proto.CompactTextString(m) }
 func (*BatchSplitResponse) ProtoMessage()    {}
 func (*BatchSplitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{69}
+	return fileDescriptor_25e491924c678914, []int{73}
 }
 func (m *BatchSplitResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4912,7 +5221,7 @@ func (m *UpdateMetadataRequest) Reset()         { *m = UpdateMetadataRequest{} }
 func (m *UpdateMetadataRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateMetadataRequest) ProtoMessage()    {}
 func (*UpdateMetadataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{70}
+	return fileDescriptor_25e491924c678914, []int{74}
 }
 func (m *UpdateMetadataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4958,7 +5267,7 @@ func (m *UpdateMetadataResponse) Reset()         { *m = UpdateMetadataResponse{}
 func (m *UpdateMetadataResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateMetadataResponse) ProtoMessage()    {}
 func (*UpdateMetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{71}
+	return fileDescriptor_25e491924c678914, []int{75}
 }
 func (m *UpdateMetadataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4999,7 +5308,7 @@ func (m *UpdateLabelsRequest) Reset()         { *m = UpdateLabelsRequest{} }
 func (m *UpdateLabelsRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateLabelsRequest) ProtoMessage()    {}
 func (*UpdateLabelsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{72}
+	return fileDescriptor_25e491924c678914, []int{76}
 }
 func (m *UpdateLabelsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5052,7 +5361,7 @@ func (m *UpdateLabelsResponse) Reset()         { *m = UpdateLabelsResponse{} }
 func (m *UpdateLabelsResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateLabelsResponse) ProtoMessage()    {}
 func (*UpdateLabelsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{73}
+	return fileDescriptor_25e491924c678914, []int{77}
 }
 func (m *UpdateLabelsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5093,7 +5402,7 @@ func (m *UpdateEpochLeaseRequest) Reset()         { *m = UpdateEpochLeaseRequest
 func (m *UpdateEpochLeaseRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateEpochLeaseRequest) ProtoMessage()    {}
 func (*UpdateEpochLeaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{74}
+	return fileDescriptor_25e491924c678914, []int{78}
 }
 func (m *UpdateEpochLeaseRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5146,7 +5455,7 @@ func (m *UpdateEpochLeaseResponse) Reset()         { *m = UpdateEpochLeaseRespon
 func (m *UpdateEpochLeaseResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateEpochLeaseResponse) ProtoMessage()    {}
 func (*UpdateEpochLeaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{75}
+	return fileDescriptor_25e491924c678914, []int{79}
 }
 func (m *UpdateEpochLeaseResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5187,7 +5496,7 @@ func (m *UpdateTxnRecordRequest) Reset()         { *m = UpdateTxnRecordRequest{}
 func (m *UpdateTxnRecordRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateTxnRecordRequest) ProtoMessage()    {}
 func (*UpdateTxnRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{76}
+	return fileDescriptor_25e491924c678914, []int{80}
 }
 func (m *UpdateTxnRecordRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5235,7 +5544,7 @@ func (m *UpdateTxnRecordResponse) Reset()         { *m = UpdateTxnRecordResponse
 func (m *UpdateTxnRecordResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateTxnRecordResponse) ProtoMessage()    {}
 func (*UpdateTxnRecordResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{77}
+	return fileDescriptor_25e491924c678914, []int{81}
 }
 func (m *UpdateTxnRecordResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5284,7 +5593,7 @@ func (m *DeleteTxnRecordRequest) Reset()         { *m = DeleteTxnRecordRequest{}
 func (m *DeleteTxnRecordRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteTxnRecordRequest) ProtoMessage()    {}
 func (*DeleteTxnRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{78}
+	return fileDescriptor_25e491924c678914, []int{82}
 }
 func (m *DeleteTxnRecordRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5338,7 +5647,7 @@ func (m *DeleteTxnRecordResponse) Reset()         { *m = DeleteTxnRecordResponse
 func (m *DeleteTxnRecordResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteTxnRecordResponse) ProtoMessage()    {}
 func (*DeleteTxnRecordResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{79}
+	return fileDescriptor_25e491924c678914, []int{83}
 }
 func (m *DeleteTxnRecordResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5380,7 +5689,7 @@ func (m *CommitTxnWriteDataRequest) Reset()         { *m = CommitTxnWriteDataReq
 func (m *CommitTxnWriteDataRequest) String() string { return proto.CompactTextString(m) }
 func (*CommitTxnWriteDataRequest) ProtoMessage()    {}
 func (*CommitTxnWriteDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{80}
+	return fileDescriptor_25e491924c678914, []int{84}
 }
 func (m *CommitTxnWriteDataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5434,7 +5743,7 @@ func (m *CommitTxnWriteDataResponse) Reset()         { *m = CommitTxnWriteDataRe
 func (m *CommitTxnWriteDataResponse) String() string { return proto.CompactTextString(m) }
 func (*CommitTxnWriteDataResponse) ProtoMessage()    {}
 func (*CommitTxnWriteDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{81}
+	return fileDescriptor_25e491924c678914, []int{85}
 }
 func (m *CommitTxnWriteDataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5476,7 +5785,7 @@ func (m *RollbackTxnWriteDataRequest) Reset()         { *m = RollbackTxnWriteDat
 func (m *RollbackTxnWriteDataRequest) String() string { return proto.CompactTextString(m) }
 func (*RollbackTxnWriteDataRequest) ProtoMessage()    {}
 func (*RollbackTxnWriteDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{82}
+	return fileDescriptor_25e491924c678914, []int{86}
 }
 func (m *RollbackTxnWriteDataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5530,7 +5839,7 @@ func (m *RollbackTxnWriteDataResponse) Reset()         { *m = RollbackTxnWriteDa
 func (m *RollbackTxnWriteDataResponse) String() string { return proto.CompactTextString(m) }
 func (*RollbackTxnWriteDataResponse) ProtoMessage()    {}
 func (*RollbackTxnWriteDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{83}
+	return fileDescriptor_25e491924c678914, []int{87}
 }
 func (m *RollbackTxnWriteDataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5571,7 +5880,7 @@ func (m *CleanTxnMVCCDataRequest) Reset()         { *m = CleanTxnMVCCDataRequest
 func (m *CleanTxnMVCCDataRequest) String() string { return proto.CompactTextString(m) }
 func (*CleanTxnMVCCDataRequest) ProtoMessage()    {}
 func (*CleanTxnMVCCDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{84}
+	return fileDescriptor_25e491924c678914, []int{88}
 }
 func (m *CleanTxnMVCCDataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5618,7 +5927,7 @@ func (m *CleanTxnMVCCDataResponse) Reset()         { *m = CleanTxnMVCCDataRespon
 func (m *CleanTxnMVCCDataResponse) String() string { return proto.CompactTextString(m) }
 func (*CleanTxnMVCCDataResponse) ProtoMessage()    {}
 func (*CleanTxnMVCCDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{85}
+	return fileDescriptor_25e491924c678914, []int{89}
 }
 func (m *CleanTxnMVCCDataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5660,7 +5969,7 @@ func (m *KVSetRequest) Reset()         { *m = KVSetRequest{} }
 func (m *KVSetRequest) String() string { return proto.CompactTextString(m) }
 func (*KVSetRequest) ProtoMessage()    {}
 func (*KVSetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{86}
+	return fileDescriptor_25e491924c678914, []int{90}
 }
 func (m *KVSetRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5714,7 +6023,7 @@ func (m *KVSetResponse) Reset()         { *m = KVSetResponse{} }
 func (m *KVSetResponse) String() string { return proto.CompactTextString(m) }
 func (*KVSetResponse) ProtoMessage()    {}
 func (*KVSetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{87}
+	return fileDescriptor_25e491924c678914, []int{91}
 }
 func (m *KVSetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5756,7 +6065,7 @@ func (m *KVBatchSetRequest) Reset()         { *m = KVBatchSetRequest{} }
 func (m *KVBatchSetRequest) String() string { return proto.CompactTextString(m) }
 func (*KVBatchSetRequest) ProtoMessage()    {}
 func (*KVBatchSetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{88}
+	return fileDescriptor_25e491924c678914, []int{92}
 }
 func (m *KVBatchSetRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5810,7 +6119,7 @@ func (m *KVBatchSetResponse) Reset()         { *m = KVBatchSetResponse{} }
 func (m *KVBatchSetResponse) String() string { return proto.CompactTextString(m) }
 func (*KVBatchSetResponse) ProtoMessage()    {}
 func (*KVBatchSetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{89}
+	return fileDescriptor_25e491924c678914, []int{93}
 }
 func (m *KVBatchSetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5851,7 +6160,7 @@ func (m *KVGetRequest) Reset()         { *m = KVGetRequest{} }
 func (m *KVGetRequest) String() string { return proto.CompactTextString(m) }
 func (*KVGetRequest) ProtoMessage()    {}
 func (*KVGetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{90}
+	return fileDescriptor_25e491924c678914, []int{94}
 }
 func (m *KVGetRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5899,7 +6208,7 @@ func (m *KVGetResponse) Reset()         { *m = KVGetResponse{} }
 func (m *KVGetResponse) String() string { return proto.CompactTextString(m) }
 func (*KVGetResponse) ProtoMessage()    {}
 func (*KVGetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{91}
+	return fileDescriptor_25e491924c678914, []int{95}
 }
 func (m *KVGetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5951,7 +6260,7 @@ func (m *KVBatchGetRequest) Reset()         { *m = KVBatchGetRequest{} }
 func (m *KVBatchGetRequest) String() string { return proto.CompactTextString(m) }
 func (*KVBatchGetRequest) ProtoMessage()    {}
 func (*KVBatchGetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{92}
+	return fileDescriptor_25e491924c678914, []int{96}
 }
 func (m *KVBatchGetRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6007,7 +6316,7 @@ func (m *KVBatchGetResponse) Reset()         { *m = KVBatchGetResponse{} }
 func (m *KVBatchGetResponse) String() string { return proto.CompactTextString(m) }
 func (*KVBatchGetResponse) ProtoMessage()    {}
 func (*KVBatchGetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{93}
+	return fileDescriptor_25e491924c678914, []int{97}
 }
 func (m *KVBatchGetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6062,7 +6371,7 @@ func (m *KVDeleteRequest) Reset()         { *m = KVDeleteRequest{} }
 func (m *KVDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*KVDeleteRequest) ProtoMessage()    {}
 func (*KVDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{94}
+	return fileDescriptor_25e491924c678914, []int{98}
 }
 func (m *KVDeleteRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6109,7 +6418,7 @@ func (m *KVDeleteResponse) Reset()         { *m = KVDeleteResponse{} }
 func (m *KVDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*KVDeleteResponse) ProtoMessage()    {}
 func (*KVDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{95}
+	return fileDescriptor_25e491924c678914, []int{99}
 }
 func (m *KVDeleteResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6150,7 +6459,7 @@ func (m *KVBatchDeleteRequest) Reset()         { *m = KVBatchDeleteRequest{} }
 func (m *KVBatchDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*KVBatchDeleteRequest) ProtoMessage()    {}
 func (*KVBatchDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{96}
+	return fileDescriptor_25e491924c678914, []int{100}
 }
 func (m *KVBatchDeleteRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6197,7 +6506,7 @@ func (m *KVBatchDeleteResponse) Reset()         { *m = KVBatchDeleteResponse{} }
 func (m *KVBatchDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*KVBatchDeleteResponse) ProtoMessage()    {}
 func (*KVBatchDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{97}
+	return fileDescriptor_25e491924c678914, []int{101}
 }
 func (m *KVBatchDeleteResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6241,7 +6550,7 @@ func (m *KVRangeDeleteRequest) Reset()         { *m = KVRangeDeleteRequest{} }
 func (m *KVRangeDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*KVRangeDeleteRequest) ProtoMessage()    {}
 func (*KVRangeDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{98}
+	return fileDescriptor_25e491924c678914, []int{102}
 }
 func (m *KVRangeDeleteRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6295,7 +6604,7 @@ func (m *KVRangeDeleteResponse) Reset()         { *m = KVRangeDeleteResponse{} }
 func (m *KVRangeDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*KVRangeDeleteResponse) ProtoMessage()    {}
 func (*KVRangeDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{99}
+	return fileDescriptor_25e491924c678914, []int{103}
 }
 func (m *KVRangeDeleteResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6347,7 +6656,7 @@ func (m *KVScanRequest) Reset()         { *m = KVScanRequest{} }
 func (m *KVScanRequest) String() string { return proto.CompactTextString(m) }
 func (*KVScanRequest) ProtoMessage()    {}
 func (*KVScanRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{100}
+	return fileDescriptor_25e491924c678914, []int{104}
 }
 func (m *KVScanRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6439,7 +6748,7 @@ func (m *KVScanResponse) Reset()         { *m = KVScanResponse{} }
 func (m *KVScanResponse) String() string { return proto.CompactTextString(m) }
 func (*KVScanResponse) ProtoMessage()    {}
 func (*KVScanResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{101}
+	return fileDescriptor_25e491924c678914, []int{105}
 }
 func (m *KVScanResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6515,7 +6824,7 @@ func (m *KVBatchMixedWriteRequest) Reset()         { *m = KVBatchMixedWriteReque
 func (m *KVBatchMixedWriteRequest) String() string { return proto.CompactTextString(m) }
 func (*KVBatchMixedWriteRequest) ProtoMessage()    {}
 func (*KVBatchMixedWriteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{102}
+	return fileDescriptor_25e491924c678914, []int{106}
 }
 func (m *KVBatchMixedWriteRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6563,7 +6872,7 @@ func (m *KVBatchMixedWriteResponse) Reset()         { *m = KVBatchMixedWriteResp
 func (m *KVBatchMixedWriteResponse) String() string { return proto.CompactTextString(m) }
 func (*KVBatchMixedWriteResponse) ProtoMessage()    {}
 func (*KVBatchMixedWriteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{103}
+	return fileDescriptor_25e491924c678914, []int{107}
 }
 func (m *KVBatchMixedWriteResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6614,7 +6923,7 @@ func (m *KVMixedWriteRequest) Reset()         { *m = KVMixedWriteRequest{} }
 func (m *KVMixedWriteRequest) String() string { return proto.CompactTextString(m) }
 func (*KVMixedWriteRequest) ProtoMessage()    {}
 func (*KVMixedWriteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{104}
+	return fileDescriptor_25e491924c678914, []int{108}
 }
 func (m *KVMixedWriteRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6686,7 +6995,7 @@ func (m *KVMixedWriteResponse) Reset()         { *m = KVMixedWriteResponse{} }
 func (m *KVMixedWriteResponse) String() string { return proto.CompactTextString(m) }
 func (*KVMixedWriteResponse) ProtoMessage()    {}
 func (*KVMixedWriteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{105}
+	return fileDescriptor_25e491924c678914, []int{109}
 }
 func (m *KVMixedWriteResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -6743,6 +7052,121 @@ func (m *KVMixedWriteResponse) GetRangeDelete() KVRangeDeleteRequest {
 	return KVRangeDeleteRequest{}
 }
 
+// KVConditionalSetRequest kv conditional set request
+type KVConditionalSetRequest struct {
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Expected the value must equal to the current value of the key, empty
+	// means the key must not exist
+	Expected             []byte   `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVConditionalSetRequest) Reset()         { *m = KVConditionalSetRequest{} }
+func (m *KVConditionalSetRequest) String() string { return proto.CompactTextString(m) }
+func (*KVConditionalSetRequest) ProtoMessage()    {}
+func (*KVConditionalSetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{110}
+}
+func (m *KVConditionalSetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVConditionalSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVConditionalSetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KVConditionalSetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVConditionalSetRequest.Merge(m, src)
+}
+func (m *KVConditionalSetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVConditionalSetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVConditionalSetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVConditionalSetRequest proto.InternalMessageInfo
+
+func (m *KVConditionalSetRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *KVConditionalSetRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *KVConditionalSetRequest) GetExpected() []byte {
+	if m != nil {
+		return m.Expected
+	}
+	return nil
+}
+
+// KVConditionalSetResponse kv conditional set response
+type KVConditionalSetResponse struct {
+	// Applied true if the condition matched and the value was written
+	Applied              bool     `protobuf:"varint,1,opt,name=applied,proto3" json:"applied,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVConditionalSetResponse) Reset()         { *m = KVConditionalSetResponse{} }
+func (m *KVConditionalSetResponse) String() string { return proto.CompactTextString(m) }
+func (*KVConditionalSetResponse) ProtoMessage()    {}
+func (*KVConditionalSetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{111}
+}
+func (m *KVConditionalSetResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVConditionalSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVConditionalSetResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KVConditionalSetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVConditionalSetResponse.Merge(m, src)
+}
+func (m *KVConditionalSetResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVConditionalSetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVConditionalSetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVConditionalSetResponse proto.InternalMessageInfo
+
+func (m *KVConditionalSetResponse) GetApplied() bool {
+	if m != nil {
+		return m.Applied
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterEnum("rpcpb.Type", Type_name, Type_value)
 	proto.RegisterEnum("rpcpb.ReplicaRoleType", ReplicaRoleType_name, ReplicaRoleType_value)
@@ -6755,6 +7179,10 @@ func init() {
 	proto.RegisterType((*ProphetResponse)(nil), "rpcpb.ProphetResponse")
 	proto.RegisterType((*ShardHeartbeatReq)(nil), "rpcpb.ShardHeartbeatReq")
 	proto.RegisterType((*ShardHeartbeatRsp)(nil), "rpcpb.ShardHeartbeatRsp")
+	proto.RegisterType((*ShardHeartbeatsReq)(nil), "rpcpb.ShardHeartbeatsReq")
+	proto.RegisterType((*ShardHeartbeatsRsp)(nil), "rpcpb.ShardHeartbeatsRsp")
+	proto.RegisterType((*ShardKeepaliveReq)(nil), "rpcpb.ShardKeepaliveReq")
+	proto.RegisterType((*ShardKeepaliveRsp)(nil), "rpcpb.ShardKeepaliveRsp")
 	proto.RegisterType((*PutStoreReq)(nil), "rpcpb.PutStoreReq")
 	proto.RegisterType((*PutStoreRsp)(nil), "rpcpb.PutStoreRsp")
 	proto.RegisterType((*StoreHeartbeatReq)(nil), "rpcpb.StoreHeartbeatReq")
@@ -6857,284 +7285,304 @@ func init() {
 	proto.RegisterType((*KVBatchMixedWriteResponse)(nil), "rpcpb.KVBatchMixedWriteResponse")
 	proto.RegisterType((*KVMixedWriteRequest)(nil), "rpcpb.KVMixedWriteRequest")
 	proto.RegisterType((*KVMixedWriteResponse)(nil), "rpcpb.KVMixedWriteResponse")
+	proto.RegisterType((*KVConditionalSetRequest)(nil), "rpcpb.KVConditionalSetRequest")
+	proto.RegisterType((*KVConditionalSetResponse)(nil), "rpcpb.KVConditionalSetResponse")
 }
 
 func init() { proto.RegisterFile("rpcpb.proto", fileDescriptor_25e491924c678914) }
 
 var fileDescriptor_25e491924c678914 = []byte{
-	// 4351 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3b, 0xcb, 0x72, 0x1c, 0xc9,
-	0x56, 0xae, 0x7e, 0xa9, 0xfb, 0xf4, 0x2b, 0x95, 0x6a, 0x49, 0x6d, 0xd9, 0xd7, 0x36, 0xe5, 0x79,
-	0x08, 0xf9, 0x22, 0x73, 0xed, 0x3b, 0xf8, 0xce, 0x65, 0x18, 0x5f, 0xb9, 0xe5, 0x91, 0xe5, 0xd7,
-	0x28, 0x4a, 0x46, 0x73, 0x89, 0xb8, 0x9b, 0x52, 0x57, 0xba, 0xd5, 0xb8, 0xbb, 0xaa, 0xa6, 0xaa,
-	0x64, 0x4b, 0x1b, 0x20, 0x82, 0x1d, 0x41, 0x04, 0x11, 0xec, 0x59, 0xb0, 0x21, 0x02, 0x3e, 0x80,
-	0x2f, 0x60, 0x31, 0xbc, 0x87, 0x15, 0xac, 0x26, 0xc0, 0x2b, 0xfe, 0x80, 0x2d, 0x91, 0xaf, 0xca,
-	0xcc, 0x7a, 0x48, 0x6d, 0x76, 0x6c, 0xac, 0xce, 0xf3, 0xca, 0x93, 0x27, 0x4f, 0x9e, 0x3c, 0xe7,
-	0x64, 0x19, 0xda, 0x51, 0x38, 0x0e, 0x8f, 0xb7, 0xc3, 0x28, 0x48, 0x02, 0x5c, 0x67, 0x83, 0x8d,
-	0xdf, 0x9e, 0x4c, 0x93, 0x93, 0xd3, 0xe3, 0xed, 0x71, 0x30, 0xbf, 0x3b, 0x77, 0x93, 0x68, 0x7a,
-	0x16, 0x44, 0xd3, 0xc9, 0xd4, 0x17, 0x83, 0xf1, 0xe9, 0x31, 0xb9, 0x1b, 0x1e, 0xdf, 0x25, 0x51,
-	0x14, 0x44, 0xea, 0x2f, 0x97, 0xb1, 0xf1, 0xf9, 0x62, 0xcc, 0x73, 0x92, 0xb8, 0xe9, 0x1f, 0xc1,
-	0xfa, 0x60, 0x31, 0xd6, 0xe4, 0xcc, 0x97, 0xff, 0x0a, 0xc6, 0x05, 0x15, 0x3e, 0x99, 0x8d, 0x29,
-	0xe3, 0x74, 0x4e, 0xe2, 0xc4, 0x9d, 0x87, 0x82, 0xf9, 0x37, 0x34, 0xe6, 0x49, 0x30, 0x09, 0xee,
-	0x32, 0xf0, 0xf1, 0xe9, 0x6b, 0x36, 0x62, 0x03, 0xf6, 0x8b, 0x93, 0xdb, 0x7f, 0xd7, 0x86, 0xde,
-	0x41, 0x14, 0x84, 0x27, 0x24, 0x71, 0xc8, 0xb7, 0xa7, 0x24, 0x4e, 0xf0, 0x1a, 0x54, 0xa6, 0xde,
-	0xd0, 0xba, 0x65, 0x6d, 0xd6, 0x1e, 0x35, 0xde, 0xff, 0x70, 0xb3, 0xb2, 0xbf, 0xeb, 0x54, 0xa6,
-	0x1e, 0x1e, 0xc2, 0x52, 0x9c, 0x04, 0x11, 0xd9, 0xdf, 0x1d, 0x56, 0x28, 0xd2, 0x91, 0x43, 0x7c,
-	0x13, 0x6a, 0xc9, 0x79, 0x48, 0x86, 0xd5, 0x5b, 0xd6, 0x66, 0xef, 0x5e, 0x7b, 0x9b, 0x6f, 0xc2,
-	0xab, 0xf3, 0x90, 0x38, 0x0c, 0x81, 0xbf, 0x82, 0x5e, 0x7c, 0xe2, 0x46, 0xde, 0x13, 0xe2, 0x46,
-	0xc9, 0x31, 0x71, 0x93, 0x61, 0xed, 0x96, 0xb5, 0xd9, 0xbe, 0x37, 0x14, 0xa4, 0x87, 0x06, 0xd2,
-	0x21, 0xdf, 0x3e, 0xaa, 0x7d, 0xf7, 0xc3, 0xcd, 0x2b, 0x4e, 0x86, 0x8b, 0xc9, 0xa1, 0x73, 0x2a,
-	0x39, 0x75, 0x53, 0x8e, 0x81, 0xd4, 0xe5, 0x18, 0x08, 0xfc, 0x53, 0x68, 0x86, 0xa7, 0x09, 0xa3,
-	0x1e, 0x36, 0x98, 0x04, 0x2c, 0x24, 0x1c, 0x08, 0xb0, 0xe2, 0x4d, 0x29, 0x29, 0xd7, 0x84, 0x08,
-	0xae, 0x25, 0x83, 0x6b, 0x8f, 0xe4, 0xb8, 0x24, 0x25, 0xfe, 0x09, 0x2c, 0xb9, 0xb3, 0x59, 0x30,
-	0xde, 0xdf, 0x1d, 0x36, 0x19, 0xd3, 0xb2, 0x60, 0xda, 0xe1, 0x50, 0xc5, 0x23, 0xe9, 0xf0, 0x08,
-	0xba, 0x6e, 0xfc, 0xe6, 0x91, 0x9b, 0x8c, 0x4f, 0x0e, 0xc3, 0xd9, 0x34, 0x19, 0xb6, 0x18, 0xe3,
-	0xba, 0x64, 0xd4, 0x71, 0x8a, 0xdd, 0xe4, 0xc1, 0xcf, 0x01, 0x8d, 0x23, 0xe2, 0x26, 0x64, 0x97,
-	0xc4, 0x49, 0x14, 0x9c, 0x4f, 0xfd, 0xc9, 0x10, 0x98, 0x9c, 0x0d, 0x21, 0x67, 0x94, 0x41, 0x2b,
-	0x51, 0x39, 0x4e, 0xbc, 0x0f, 0x7d, 0x87, 0x84, 0x41, 0x94, 0x08, 0x18, 0xf1, 0x86, 0x6d, 0x26,
-	0xec, 0xaa, 0x10, 0x96, 0xc1, 0x2a, 0x59, 0x59, 0x3e, 0xba, 0xba, 0x09, 0x49, 0x34, 0xad, 0x3a,
-	0xc6, 0xea, 0xf6, 0x74, 0x9c, 0xb6, 0x3a, 0x83, 0x87, 0x0a, 0xe1, 0x3a, 0x7e, 0x43, 0x57, 0x4c,
-	0xa2, 0x61, 0xd7, 0x10, 0x32, 0xd2, 0x71, 0x9a, 0x10, 0x83, 0x07, 0xff, 0x02, 0x3a, 0x1c, 0xc0,
-	0xfc, 0x2f, 0x1e, 0xf6, 0x98, 0x8c, 0x35, 0x43, 0x06, 0x47, 0x29, 0x11, 0x06, 0x07, 0x95, 0x10,
-	0x91, 0x79, 0xf0, 0x56, 0x4a, 0xe8, 0x1b, 0x12, 0x1c, 0x0d, 0xa5, 0x49, 0xd0, 0x39, 0xa8, 0x61,
-	0xc7, 0x27, 0x64, 0xfc, 0x86, 0x0d, 0x0f, 0x13, 0x37, 0x21, 0x43, 0x64, 0x18, 0x76, 0x64, 0x62,
-	0x35, 0xc3, 0x66, 0xf8, 0xe8, 0x8e, 0x87, 0xa7, 0xc9, 0xc1, 0xcc, 0x1d, 0x93, 0x39, 0xf1, 0x13,
-	0xe7, 0x74, 0x46, 0x86, 0xcb, 0xc6, 0x8e, 0x1f, 0x64, 0xd0, 0xda, 0x8e, 0x67, 0x39, 0xa9, 0x62,
-	0x13, 0x92, 0xec, 0x84, 0xe1, 0x6c, 0x4a, 0x3c, 0x0a, 0x89, 0x87, 0xd8, 0x50, 0x6c, 0xcf, 0xc4,
-	0x6a, 0x8a, 0x65, 0xf8, 0xf0, 0x03, 0x68, 0x71, 0xab, 0x3d, 0x0d, 0x8e, 0x87, 0x2b, 0x4c, 0xc8,
-	0x8a, 0x61, 0xe4, 0xa7, 0xc1, 0xb1, 0x62, 0x57, 0xb4, 0x94, 0x91, 0x1b, 0x8b, 0x32, 0x0e, 0x0c,
-	0x46, 0x47, 0xc2, 0x35, 0xc6, 0x94, 0x16, 0xff, 0x1c, 0x80, 0x9c, 0x91, 0xf1, 0x29, 0x9f, 0x72,
-	0x95, 0x71, 0x0e, 0x04, 0xe7, 0xe3, 0x14, 0xa1, 0x58, 0x35, 0x6a, 0xfc, 0x4b, 0x18, 0xb8, 0x9e,
-	0x77, 0x38, 0x3e, 0x21, 0xde, 0xe9, 0x8c, 0xec, 0x45, 0xc1, 0x69, 0xc8, 0x4c, 0xb9, 0xc6, 0xa4,
-	0xdc, 0x90, 0x87, 0xb0, 0x80, 0x44, 0xc9, 0x2b, 0x94, 0x40, 0x25, 0xd3, 0xb0, 0x90, 0x93, 0xbc,
-	0x6e, 0x48, 0xde, 0x2b, 0x20, 0xd1, 0x24, 0x17, 0x49, 0xa0, 0x61, 0xbc, 0x9f, 0x86, 0xf1, 0x38,
-	0x0c, 0xfc, 0x98, 0x94, 0xc6, 0x71, 0x19, 0xad, 0x2b, 0x65, 0xd1, 0x7a, 0x00, 0x75, 0x76, 0x09,
-	0xb2, 0x78, 0xde, 0x72, 0xf8, 0x00, 0xaf, 0x41, 0x63, 0x46, 0x5c, 0x8f, 0x44, 0x2c, 0x76, 0xb7,
-	0x1c, 0x31, 0x2a, 0x88, 0xed, 0xf5, 0x8b, 0x62, 0x7b, 0x1c, 0x2e, 0x1c, 0xdb, 0x1b, 0x17, 0xc5,
-	0x76, 0x4d, 0x4e, 0x79, 0x6c, 0x5f, 0x2a, 0x8e, 0xed, 0x29, 0x6f, 0x71, 0x6c, 0x6f, 0x16, 0xc7,
-	0x76, 0xc5, 0x55, 0x14, 0xdb, 0x5b, 0x85, 0xb1, 0x3d, 0xe5, 0x29, 0x8f, 0xed, 0x70, 0x41, 0x6c,
-	0x4f, 0xd9, 0x17, 0x88, 0xed, 0xed, 0x8b, 0x63, 0x7b, 0x2a, 0x6a, 0xa1, 0xd8, 0xde, 0xb9, 0x30,
-	0xb6, 0xa7, 0xb2, 0x2e, 0x8f, 0xed, 0xdd, 0x0b, 0x62, 0xbb, 0x5a, 0x9d, 0x19, 0xdb, 0xb7, 0xa1,
-	0x4e, 0xde, 0x12, 0x3f, 0x11, 0xf1, 0x58, 0x6e, 0xc4, 0x63, 0x0a, 0x7b, 0x19, 0x24, 0xd3, 0xd7,
-	0xe7, 0x82, 0x8f, 0x93, 0xe5, 0xc2, 0x78, 0xbf, 0x3c, 0x8c, 0xa7, 0x53, 0x5e, 0x1c, 0xc6, 0x51,
-	0x79, 0x18, 0x57, 0x12, 0x2e, 0x0b, 0xe3, 0xcb, 0x17, 0x86, 0x71, 0x65, 0xc3, 0x45, 0xc2, 0x38,
-	0xbe, 0x38, 0x8c, 0xab, 0xcd, 0x5d, 0x24, 0x8c, 0xaf, 0x5c, 0x18, 0xc6, 0x95, 0x62, 0x17, 0x86,
-	0xf1, 0x41, 0x49, 0x18, 0x4f, 0xd9, 0xcb, 0xc2, 0xf8, 0x6a, 0x49, 0x18, 0x57, 0x8c, 0x65, 0x61,
-	0x7c, 0xad, 0x2c, 0x8c, 0xa7, 0xac, 0x8b, 0x84, 0xf1, 0xf5, 0xcb, 0xc3, 0x78, 0x2a, 0xef, 0xc3,
-	0xc2, 0xf8, 0xf0, 0xf2, 0x30, 0xae, 0x24, 0x17, 0x86, 0xf1, 0xff, 0xa9, 0xc0, 0x72, 0x2e, 0x17,
-	0xd6, 0x13, 0x6f, 0xcb, 0x4c, 0xbc, 0x07, 0x50, 0x67, 0x51, 0x94, 0xc5, 0xf2, 0x8e, 0xc3, 0x07,
-	0x18, 0x43, 0x2d, 0x21, 0xd1, 0x9c, 0x85, 0xef, 0x9a, 0xc3, 0x7e, 0xe3, 0x4f, 0x8d, 0xe8, 0xdd,
-	0xbe, 0xd7, 0xdf, 0x16, 0xb5, 0x8a, 0x43, 0xc2, 0xd9, 0x74, 0xec, 0xa6, 0xe1, 0xfc, 0x4b, 0xe8,
-	0x78, 0xc1, 0x3b, 0x5f, 0x80, 0xe3, 0x61, 0xfd, 0x56, 0x95, 0x19, 0xdd, 0x24, 0xa7, 0x9e, 0x1a,
-	0xcb, 0x83, 0xa0, 0xd3, 0xe3, 0x87, 0xd0, 0x0f, 0x89, 0xef, 0xb1, 0xdc, 0x4d, 0x88, 0x68, 0x30,
-	0x11, 0xd9, 0x19, 0xa5, 0x97, 0x65, 0xa8, 0xe9, 0xe9, 0x8f, 0xa9, 0xf4, 0x34, 0x78, 0x0b, 0xb6,
-	0xf4, 0x84, 0xc8, 0x79, 0x39, 0x19, 0xde, 0x80, 0xe6, 0x84, 0x1a, 0xf0, 0x19, 0x39, 0x67, 0x91,
-	0xbb, 0xe5, 0xa4, 0x63, 0xbc, 0x09, 0xf5, 0x19, 0x71, 0x63, 0x22, 0xa2, 0x73, 0x2a, 0xeb, 0x71,
-	0x18, 0x8c, 0x4f, 0x9e, 0x53, 0x8c, 0xc3, 0x09, 0xec, 0x3f, 0xaf, 0xe5, 0x2c, 0x1f, 0x87, 0xcc,
-	0xf2, 0x14, 0xa8, 0x59, 0x9e, 0x0f, 0xf1, 0xcf, 0x00, 0xd8, 0x4f, 0x26, 0x89, 0x99, 0x3f, 0xab,
-	0x2a, 0xc3, 0x48, 0xbf, 0x54, 0xb4, 0xf8, 0x33, 0xe8, 0x26, 0x6e, 0x34, 0xa1, 0x17, 0x35, 0x5b,
-	0x31, 0xdb, 0xa6, 0x82, 0x0d, 0x31, 0xa9, 0xf0, 0x03, 0xe8, 0x8c, 0x03, 0xff, 0xf5, 0x74, 0x32,
-	0x3a, 0x71, 0xfd, 0x09, 0x11, 0xdb, 0x98, 0x9e, 0x3f, 0x0d, 0xe5, 0x18, 0x84, 0xf8, 0x77, 0xa0,
-	0x97, 0x44, 0xae, 0x1f, 0xbf, 0x26, 0xd1, 0x73, 0xee, 0x01, 0xfc, 0x7e, 0x5e, 0x95, 0x17, 0xbf,
-	0x81, 0x74, 0x32, 0xc4, 0xd8, 0x86, 0xfa, 0x9c, 0x44, 0x13, 0x59, 0x27, 0x75, 0x04, 0xd7, 0x0b,
-	0x0a, 0x73, 0x38, 0x0a, 0xff, 0x04, 0x20, 0xa6, 0xf7, 0x12, 0x5b, 0xb7, 0xd8, 0x37, 0x79, 0x13,
-	0x1e, 0xa6, 0x08, 0x47, 0x23, 0xa2, 0x5a, 0xe9, 0x5a, 0x1e, 0xdd, 0x13, 0xb7, 0xee, 0x6a, 0xc1,
-	0x82, 0x8e, 0xee, 0x39, 0x19, 0x62, 0xfc, 0x73, 0xe8, 0x6a, 0x7a, 0xa6, 0x1b, 0x3c, 0xc8, 0xaf,
-	0x29, 0x26, 0x8e, 0x49, 0x8a, 0x37, 0xa1, 0xef, 0xf1, 0xcb, 0x66, 0x77, 0x1a, 0x91, 0x71, 0x32,
-	0x3b, 0x67, 0x77, 0x70, 0xd3, 0xc9, 0x82, 0xed, 0xdb, 0xd0, 0xd6, 0xea, 0x41, 0x76, 0xda, 0x58,
-	0x82, 0x60, 0x89, 0xd3, 0x46, 0x07, 0xf6, 0x7d, 0x8d, 0x28, 0x0e, 0xf1, 0x47, 0xd0, 0x15, 0x62,
-	0xc4, 0x5d, 0xc2, 0x89, 0x4d, 0xa0, 0xfd, 0x0d, 0x2c, 0xe7, 0x6a, 0x55, 0xe5, 0xf9, 0x56, 0xc6,
-	0x9d, 0x28, 0x65, 0x81, 0xe7, 0x63, 0xa8, 0x79, 0x6e, 0xe2, 0x8a, 0xc3, 0xcf, 0x7e, 0xdb, 0x9f,
-	0xe6, 0x04, 0xc7, 0x61, 0x4a, 0x68, 0x69, 0x84, 0x1f, 0x43, 0x5b, 0xab, 0x5a, 0xcb, 0x92, 0x45,
-	0xfb, 0x99, 0x46, 0x56, 0x2c, 0x89, 0x1e, 0x32, 0xae, 0x76, 0xa5, 0x4c, 0x6d, 0xa1, 0xb0, 0xdd,
-	0x01, 0x50, 0x45, 0xaf, 0xfd, 0x91, 0x1a, 0xc5, 0x61, 0xa9, 0x02, 0x5f, 0x00, 0xca, 0xd6, 0xbb,
-	0x85, 0x5a, 0x0c, 0xa0, 0x3e, 0x0e, 0x4e, 0xfd, 0x84, 0x69, 0xd1, 0x75, 0xf8, 0xc0, 0xde, 0xcd,
-	0x72, 0xc7, 0x21, 0xfe, 0x4d, 0x68, 0x32, 0x47, 0xdc, 0xdf, 0xa5, 0x96, 0xa6, 0xa1, 0xa9, 0xa7,
-	0xfb, 0xea, 0xfe, 0xae, 0x4c, 0xf3, 0x24, 0x95, 0xfd, 0x87, 0xb0, 0x52, 0x50, 0x2b, 0x97, 0x26,
-	0xd8, 0x03, 0xa8, 0x4f, 0x7d, 0x8f, 0x9c, 0x89, 0x36, 0x09, 0x1f, 0xd0, 0x38, 0x15, 0xc9, 0x88,
-	0x58, 0xbd, 0x55, 0xdd, 0xac, 0x39, 0xe9, 0x18, 0xdf, 0x00, 0xe0, 0x97, 0xde, 0x2e, 0x5d, 0x56,
-	0x8d, 0x79, 0xa3, 0x06, 0xb1, 0x1f, 0x16, 0x28, 0x10, 0x87, 0xd2, 0xf2, 0xdc, 0x21, 0x7b, 0x05,
-	0xa1, 0x92, 0x70, 0xcb, 0x13, 0x7b, 0x0b, 0x50, 0xb6, 0xae, 0x2e, 0xb5, 0xf8, 0x6e, 0x96, 0x96,
-	0xd9, 0xac, 0x41, 0x05, 0x9d, 0x4a, 0xdf, 0x1c, 0xca, 0xa9, 0x14, 0xd9, 0x21, 0xc3, 0x3b, 0x82,
-	0xce, 0x7e, 0x0a, 0x38, 0xdf, 0x12, 0x28, 0x35, 0xd9, 0x75, 0x9a, 0x21, 0x30, 0x63, 0xa4, 0xdd,
-	0x25, 0x05, 0xb0, 0xbf, 0xcc, 0xcb, 0xfa, 0xa0, 0xd5, 0x3f, 0x86, 0x25, 0xb1, 0xb5, 0x74, 0x6f,
-	0x7c, 0xf2, 0x2e, 0x8d, 0xe7, 0x7c, 0x40, 0x0f, 0xad, 0x4f, 0xde, 0x39, 0x72, 0x42, 0xea, 0xca,
-	0x74, 0x83, 0x4c, 0xa0, 0xfd, 0x09, 0xa0, 0x6c, 0x5f, 0x81, 0xba, 0xe2, 0xeb, 0x99, 0x3b, 0x61,
-	0xe2, 0xba, 0x0e, 0xfb, 0x6d, 0x7f, 0x0d, 0xfd, 0x4c, 0xef, 0x80, 0x16, 0x4f, 0xb1, 0x0c, 0x07,
-	0xd5, 0xcd, 0x8e, 0x23, 0x46, 0x74, 0x62, 0x7a, 0xff, 0x24, 0xe9, 0x5d, 0x29, 0x26, 0x36, 0x80,
-	0xf6, 0x72, 0x46, 0x60, 0x1c, 0xda, 0x3f, 0xa6, 0x39, 0xbb, 0xd1, 0x5d, 0xc0, 0x57, 0xa1, 0x3a,
-	0x15, 0x13, 0xd4, 0x1e, 0x2d, 0xbd, 0xff, 0xe1, 0x66, 0x75, 0x7f, 0x37, 0x76, 0x28, 0x8c, 0x0a,
-	0xc8, 0x24, 0xb1, 0xf6, 0x5d, 0xc0, 0xf9, 0xce, 0x82, 0x92, 0x61, 0x6d, 0x76, 0x32, 0x32, 0x9c,
-	0x3c, 0x43, 0x1c, 0xd2, 0x8d, 0xf3, 0xd2, 0xaa, 0x81, 0x9f, 0x47, 0x05, 0xa0, 0x7e, 0xed, 0xa9,
-	0x5a, 0x80, 0xc7, 0x29, 0x0d, 0x62, 0x3f, 0x86, 0x95, 0x82, 0x96, 0x04, 0xde, 0x86, 0x5a, 0x44,
-	0x13, 0x2a, 0xcb, 0x08, 0xea, 0x06, 0x99, 0x38, 0xa3, 0x8c, 0xce, 0x5e, 0x2d, 0x10, 0x13, 0x87,
-	0xf6, 0x36, 0xe0, 0x7c, 0x8f, 0xa2, 0xfc, 0x4e, 0xb7, 0xbf, 0xca, 0xd3, 0x33, 0xd7, 0xaf, 0x47,
-	0x2c, 0x6d, 0xb6, 0x44, 0x26, 0x54, 0xae, 0x0d, 0x27, 0xb4, 0xef, 0x43, 0x47, 0x6f, 0x6b, 0xe0,
-	0xdb, 0x50, 0xfd, 0xfd, 0xe0, 0x58, 0xac, 0xa6, 0x2d, 0xdd, 0xf4, 0x69, 0x70, 0x2c, 0xd8, 0x28,
-	0xd6, 0xee, 0xe9, 0x4c, 0x71, 0x48, 0x85, 0xe8, 0x2d, 0x8e, 0x85, 0x85, 0xe8, 0x09, 0xb5, 0xfd,
-	0x04, 0xba, 0x46, 0xb7, 0x63, 0x21, 0x29, 0x85, 0xf7, 0xca, 0x6d, 0x43, 0x52, 0xc9, 0x9d, 0xf2,
-	0x12, 0xd6, 0x4b, 0xda, 0x22, 0xf8, 0xbe, 0xb1, 0xa5, 0x57, 0xd3, 0xb3, 0x9a, 0xa5, 0x35, 0xf6,
-	0xf5, 0x6a, 0x89, 0xbc, 0x38, 0xa4, 0xa8, 0x92, 0x3e, 0x89, 0x7d, 0x50, 0x82, 0x8a, 0x43, 0xfc,
-	0x99, 0xb9, 0x97, 0x97, 0xaa, 0x21, 0x36, 0xf4, 0xdf, 0x2a, 0xd0, 0xd6, 0xaa, 0x4f, 0x8c, 0xa0,
-	0x1a, 0x93, 0x6f, 0x85, 0xfb, 0xd0, 0x9f, 0x2c, 0xe5, 0x96, 0x3d, 0x95, 0xae, 0x68, 0xa3, 0xdc,
-	0x83, 0xd6, 0xd4, 0x9f, 0x26, 0x8c, 0x51, 0x24, 0x79, 0xd2, 0x79, 0xf6, 0x25, 0x9c, 0x46, 0x77,
-	0x47, 0x91, 0xe1, 0xcf, 0x64, 0x5a, 0xc9, 0x98, 0x6a, 0x46, 0x4a, 0x74, 0x98, 0x22, 0x18, 0x97,
-	0x46, 0xc8, 0xd8, 0xe8, 0x6d, 0xcb, 0xd9, 0xcc, 0xfc, 0xee, 0x30, 0x45, 0x08, 0xb6, 0x74, 0x8c,
-	0xbf, 0x80, 0x7e, 0x9c, 0x66, 0xd5, 0x9c, 0xb7, 0x51, 0x96, 0x74, 0x3b, 0x59, 0x52, 0xc6, 0x9d,
-	0x5e, 0xf1, 0x9c, 0x7b, 0xa9, 0x34, 0x03, 0xc8, 0x92, 0xda, 0x7f, 0x61, 0x41, 0xd7, 0x30, 0x43,
-	0x69, 0x8c, 0xa4, 0x70, 0xca, 0xcc, 0x83, 0x23, 0x85, 0xb3, 0x11, 0xde, 0x02, 0xc4, 0x6b, 0x16,
-	0x2d, 0x6e, 0xf3, 0x8b, 0x35, 0x07, 0xa7, 0xf7, 0x17, 0xcb, 0xf3, 0xe3, 0x61, 0x8d, 0xed, 0x7c,
-	0x41, 0x25, 0x20, 0xb6, 0x5c, 0xd0, 0xd9, 0x7f, 0x63, 0x41, 0xcf, 0xb4, 0x78, 0x49, 0xf2, 0xd3,
-	0xcf, 0x4c, 0x26, 0xae, 0xaf, 0x2c, 0x58, 0xd5, 0x22, 0xd5, 0x4b, 0x6a, 0x11, 0x1a, 0xa1, 0xf8,
-	0xdd, 0xef, 0x89, 0x54, 0x40, 0x0e, 0xa9, 0x29, 0x78, 0x55, 0xcd, 0xf6, 0xb8, 0xe9, 0x88, 0x91,
-	0xfd, 0x11, 0xf4, 0xcc, 0x6d, 0x2e, 0x3c, 0x9e, 0xe7, 0xd0, 0xd1, 0xd3, 0x6a, 0x7c, 0x97, 0xce,
-	0xc3, 0x6b, 0x10, 0xab, 0xb0, 0x06, 0x91, 0xbd, 0x2b, 0x41, 0x45, 0x8b, 0x9e, 0x31, 0x63, 0x7d,
-	0xa5, 0xfa, 0x87, 0x69, 0x26, 0xa0, 0x8b, 0x66, 0xcd, 0x44, 0x8d, 0xd6, 0xde, 0x81, 0x9e, 0x59,
-	0x67, 0x7c, 0xf0, 0xe4, 0xf6, 0x43, 0xe8, 0x1a, 0x69, 0x3d, 0x4d, 0x97, 0xb9, 0x41, 0xad, 0x32,
-	0x83, 0xca, 0x53, 0xcc, 0x4b, 0xbc, 0xc7, 0xd0, 0x33, 0xab, 0x0a, 0x7c, 0x1f, 0x96, 0xb8, 0x8e,
-	0x32, 0x20, 0x14, 0x95, 0x53, 0x52, 0x0f, 0x41, 0x69, 0xdf, 0x84, 0x3a, 0x2b, 0x7e, 0xe8, 0x66,
-	0xf0, 0x12, 0x4d, 0x18, 0x59, 0x8c, 0xec, 0x17, 0x00, 0xaa, 0xe8, 0xc1, 0x77, 0xa0, 0x11, 0x06,
-	0xb3, 0xe9, 0xf8, 0x5c, 0xa4, 0x29, 0x2b, 0xa9, 0xbd, 0xe8, 0x65, 0x7a, 0xc0, 0x50, 0x8e, 0x20,
-	0xa1, 0xbb, 0xf6, 0x86, 0x9c, 0x4b, 0x47, 0x67, 0xbf, 0x6d, 0x02, 0xfd, 0xe7, 0xee, 0x31, 0x99,
-	0x8d, 0x02, 0x3f, 0x4e, 0x22, 0x77, 0xea, 0x27, 0x34, 0xfe, 0xbc, 0x21, 0x5c, 0x60, 0xcb, 0xa1,
-	0x3f, 0xf1, 0x26, 0x54, 0x82, 0x30, 0xdd, 0x11, 0xbe, 0x88, 0x0c, 0xd7, 0xd7, 0xa1, 0x53, 0x09,
-	0x68, 0x9e, 0xdd, 0x78, 0xeb, 0xce, 0x4e, 0x09, 0x3f, 0x2b, 0x2d, 0x47, 0x8c, 0xec, 0x3f, 0xae,
-	0x42, 0xd7, 0xec, 0x1c, 0xa9, 0x5c, 0xad, 0x95, 0x7d, 0x07, 0x64, 0x05, 0xb6, 0x70, 0xf5, 0x96,
-	0x23, 0x87, 0x2a, 0xf1, 0xad, 0xf2, 0x1c, 0x3c, 0x4d, 0x7c, 0x83, 0xb7, 0x24, 0x8a, 0xa6, 0x1e,
-	0x11, 0xfe, 0x9c, 0x8e, 0x29, 0x2e, 0x4e, 0xdc, 0x28, 0xa1, 0xc5, 0x7b, 0x9d, 0x59, 0x31, 0x1d,
-	0x53, 0x4d, 0x89, 0xef, 0x51, 0x4c, 0x83, 0xdb, 0x97, 0x8f, 0xf0, 0x16, 0xd4, 0xa2, 0x60, 0xc6,
-	0x9b, 0xbb, 0x3d, 0xad, 0x49, 0xc7, 0xcb, 0xe6, 0x60, 0xc6, 0xbd, 0x8f, 0xd1, 0xa8, 0xaa, 0xa0,
-	0xa9, 0x55, 0x05, 0xf8, 0x09, 0xa0, 0x99, 0x69, 0x9c, 0x78, 0xd8, 0x62, 0x0e, 0xb0, 0x56, 0x6c,
-	0x3b, 0xd9, 0x5d, 0xcb, 0x72, 0xe1, 0x4f, 0xa0, 0x37, 0x0b, 0xc6, 0x6e, 0x32, 0x0d, 0x7c, 0xc6,
-	0x12, 0x0f, 0x81, 0x59, 0x35, 0x03, 0xa5, 0x74, 0xd3, 0x38, 0x98, 0x71, 0x10, 0x79, 0x4b, 0x66,
-	0xac, 0x5d, 0xdb, 0x72, 0x32, 0x50, 0xfb, 0x2f, 0x2d, 0x9a, 0xea, 0xb2, 0x77, 0x58, 0x56, 0xb4,
-	0x3c, 0xe1, 0x87, 0x45, 0x6d, 0x45, 0x27, 0xf7, 0x24, 0x2b, 0x72, 0x99, 0x8a, 0xd9, 0x9f, 0xd0,
-	0x8e, 0x57, 0x75, 0xa1, 0xb3, 0x9d, 0x86, 0xa7, 0xda, 0x65, 0xad, 0x92, 0xdf, 0x83, 0x15, 0xf9,
-	0xc6, 0xb0, 0x88, 0x8e, 0x5b, 0xf2, 0x35, 0x81, 0x97, 0x87, 0xbd, 0x6d, 0xf9, 0xc0, 0xfe, 0x98,
-	0xfe, 0x4d, 0x3b, 0xb9, 0x74, 0x40, 0x23, 0x94, 0xbe, 0x7a, 0xfc, 0x00, 0x1a, 0x27, 0xbc, 0x67,
-	0x61, 0x65, 0x1a, 0xd2, 0x59, 0x13, 0xc9, 0xe8, 0xcd, 0xc9, 0x69, 0x8d, 0x17, 0x71, 0x1a, 0x7e,
-	0x98, 0x54, 0x8d, 0x27, 0x59, 0x45, 0x8d, 0x27, 0xa9, 0xec, 0x3f, 0x80, 0xae, 0xb1, 0x2a, 0xfc,
-	0xb3, 0xcc, 0xdc, 0x1b, 0xa9, 0x80, 0xdc, 0xda, 0x33, 0x93, 0xdf, 0xa7, 0xc5, 0x0c, 0x27, 0x92,
-	0xb3, 0xf7, 0xb3, 0xcc, 0x69, 0xab, 0x53, 0xd0, 0xd9, 0x7f, 0xbb, 0x04, 0x4b, 0xf9, 0x17, 0xf8,
-	0x4e, 0xb6, 0xb0, 0x64, 0x47, 0x4d, 0x16, 0x96, 0x6c, 0x80, 0x6d, 0xe3, 0xf5, 0x5d, 0xae, 0x73,
-	0x34, 0xf7, 0xb4, 0x27, 0x9d, 0x1b, 0x00, 0xe3, 0xd3, 0x38, 0x09, 0xe6, 0x2c, 0x72, 0xd7, 0x18,
-	0xbb, 0x06, 0x91, 0x11, 0x85, 0x1f, 0x41, 0x16, 0x51, 0x10, 0x54, 0xc7, 0x73, 0x4f, 0x1c, 0x3d,
-	0xfa, 0x93, 0xd6, 0x06, 0xe1, 0x94, 0xb7, 0x77, 0xaa, 0xbc, 0x36, 0x38, 0xd8, 0xdf, 0x75, 0x28,
-	0x8c, 0xfa, 0x61, 0x12, 0xf0, 0xee, 0x4f, 0x93, 0xfb, 0xa1, 0x18, 0xd2, 0x4b, 0x7a, 0x3a, 0xf1,
-	0xe9, 0xd5, 0x44, 0xfd, 0x88, 0xc5, 0x3c, 0xd6, 0xab, 0x69, 0x3a, 0x39, 0x38, 0xeb, 0xfb, 0xb3,
-	0x76, 0x1a, 0x5c, 0xd2, 0x4e, 0xe3, 0x64, 0xca, 0x65, 0xdb, 0x97, 0xdd, 0xa8, 0x5b, 0xd0, 0xa2,
-	0xb1, 0xd4, 0x61, 0x9d, 0xb3, 0x8e, 0xd1, 0xc8, 0x62, 0x30, 0x47, 0xa1, 0xf1, 0x73, 0x58, 0x11,
-	0x67, 0xe2, 0x90, 0xcc, 0xc8, 0x38, 0xe1, 0x21, 0x9a, 0x3d, 0x64, 0xf4, 0x34, 0x27, 0xc8, 0x51,
-	0x38, 0x45, 0x6c, 0xf8, 0x17, 0xd0, 0x4f, 0xce, 0x7c, 0xe6, 0x2b, 0x62, 0x77, 0xd3, 0x57, 0x66,
-	0xfe, 0xc9, 0xc7, 0x2b, 0x13, 0xeb, 0x64, 0xc9, 0xf1, 0x0b, 0xe8, 0x9f, 0x86, 0x9e, 0x9b, 0x90,
-	0x57, 0x67, 0xbe, 0x43, 0xc6, 0x41, 0xe4, 0x89, 0x07, 0x8e, 0x1f, 0x09, 0x5d, 0x7e, 0xd7, 0xc4,
-	0x9a, 0x0e, 0x9e, 0xe5, 0xa5, 0xe2, 0x3c, 0x32, 0x23, 0xba, 0x38, 0x64, 0x88, 0xdb, 0x35, 0xb1,
-	0x19, 0x71, 0x19, 0x5e, 0x7c, 0x04, 0x78, 0x1c, 0xcc, 0xe7, 0xd3, 0xe4, 0xd5, 0x99, 0xff, 0x4d,
-	0x34, 0x4d, 0x78, 0x07, 0x83, 0x3f, 0x7d, 0xdc, 0x4a, 0x6f, 0xd3, 0x2c, 0x81, 0x29, 0xb4, 0x40,
-	0x02, 0x3e, 0x82, 0xe5, 0x28, 0x98, 0xcd, 0x8e, 0xdd, 0xf1, 0x1b, 0xa5, 0x28, 0x7f, 0x05, 0xb1,
-	0xe5, 0x1e, 0x28, 0x7c, 0x89, 0xe0, 0xbc, 0x08, 0x7c, 0x00, 0x68, 0x3c, 0x23, 0xae, 0xff, 0xea,
-	0xcc, 0x7f, 0x71, 0x34, 0x1a, 0x31, 0x6d, 0x57, 0x8c, 0xbe, 0xfd, 0x28, 0x83, 0x36, 0x45, 0xe6,
-	0xb8, 0xed, 0x3b, 0x50, 0xe7, 0x8e, 0x83, 0xa1, 0xf6, 0x3a, 0x0a, 0xe6, 0x32, 0xe5, 0xa2, 0xbf,
-	0x71, 0x0f, 0x2a, 0x49, 0x20, 0x0a, 0xa9, 0x4a, 0x12, 0xd8, 0x7f, 0x52, 0x87, 0x66, 0xc1, 0x03,
-	0xad, 0x79, 0xcc, 0x6d, 0xe3, 0x81, 0x76, 0x91, 0x03, 0x5d, 0xcd, 0x1d, 0xe8, 0x01, 0xd4, 0xd9,
-	0xc5, 0xce, 0xce, 0x7a, 0xc7, 0xe1, 0x03, 0x79, 0x84, 0xeb, 0x05, 0x47, 0x38, 0x0d, 0xd3, 0x8d,
-	0x4b, 0xc3, 0x34, 0x1e, 0x01, 0x52, 0x5e, 0xca, 0x17, 0x23, 0x52, 0xff, 0xf5, 0x9c, 0x57, 0x73,
-	0xb4, 0x93, 0x63, 0xc0, 0x7b, 0x79, 0xbf, 0x6e, 0x2e, 0xe0, 0xd7, 0x79, 0x8f, 0xde, 0xcb, 0x7b,
-	0x74, 0x6b, 0x01, 0x8f, 0xce, 0xfb, 0xf2, 0x41, 0xa1, 0x2f, 0xc3, 0x62, 0xbe, 0x5c, 0xe8, 0xc5,
-	0x07, 0x45, 0x5e, 0xdc, 0x5e, 0xd4, 0x8b, 0x8b, 0xfc, 0xf7, 0x69, 0x81, 0xff, 0x76, 0x16, 0xf1,
-	0xdf, 0x02, 0xcf, 0xfd, 0x23, 0x0b, 0x56, 0x8c, 0x87, 0x03, 0x11, 0x71, 0xcc, 0x34, 0xdf, 0x5a,
-	0x3c, 0xcd, 0xd7, 0xb3, 0x8e, 0xca, 0x42, 0x49, 0xfd, 0x0e, 0x0c, 0x4c, 0x0d, 0x84, 0x73, 0xfc,
-	0xba, 0x7c, 0xd8, 0xe2, 0x77, 0x6f, 0xd7, 0xb8, 0x0a, 0xd2, 0x2e, 0x38, 0x1d, 0xd8, 0x0f, 0x60,
-	0x79, 0x14, 0xcc, 0x43, 0x77, 0x9c, 0x3c, 0x0f, 0x26, 0x72, 0x09, 0x36, 0x74, 0xc6, 0x1c, 0xb8,
-	0xcf, 0x12, 0x52, 0x5e, 0xaa, 0x1b, 0x30, 0x7b, 0x00, 0x58, 0x67, 0xe4, 0x33, 0xdb, 0x4f, 0x60,
-	0x35, 0xf3, 0x22, 0x22, 0x44, 0x7e, 0x70, 0xc1, 0x32, 0x84, 0xb5, 0xac, 0x24, 0x31, 0x87, 0x07,
-	0xcb, 0x46, 0x43, 0x9b, 0xc9, 0xff, 0x4c, 0x4b, 0x59, 0xcc, 0x6a, 0x44, 0x27, 0xcb, 0xe6, 0x2d,
-	0xf4, 0xea, 0x1d, 0x07, 0x7e, 0x42, 0xce, 0x12, 0x11, 0x66, 0xe4, 0xd0, 0xfe, 0x33, 0x0b, 0x3a,
-	0xc6, 0x0c, 0xec, 0xfd, 0xc2, 0x8d, 0x12, 0xf5, 0x7e, 0xe1, 0x46, 0xac, 0x98, 0x20, 0xbe, 0x7c,
-	0x41, 0xa4, 0x3f, 0x69, 0x6c, 0xf1, 0xc9, 0xbb, 0x43, 0x91, 0x58, 0x8a, 0xd8, 0xa2, 0x20, 0xf8,
-	0x01, 0xb4, 0x55, 0x63, 0x54, 0x56, 0xd4, 0x25, 0xd6, 0xd0, 0x29, 0xed, 0x1d, 0xc0, 0xfa, 0xba,
-	0xc5, 0x5e, 0xdf, 0x31, 0xea, 0xfe, 0x92, 0xcd, 0x16, 0x24, 0xb6, 0x03, 0xab, 0x3c, 0x2e, 0xbc,
-	0x20, 0x89, 0xeb, 0x29, 0xf7, 0xc6, 0x9f, 0x43, 0x73, 0x2e, 0x40, 0x62, 0x7f, 0xd6, 0x0d, 0x39,
-	0xcf, 0x83, 0xb1, 0x3b, 0x63, 0x6d, 0x4b, 0x69, 0x42, 0x49, 0x4e, 0x37, 0x2a, 0x2b, 0x53, 0x6c,
-	0x54, 0x00, 0x2b, 0x1c, 0xc3, 0xd3, 0x78, 0x39, 0xd7, 0x1d, 0x68, 0xcc, 0x78, 0xb6, 0x9f, 0xd1,
-	0x98, 0x91, 0xa5, 0x8d, 0x04, 0x9e, 0xfa, 0xab, 0x02, 0xb0, 0x22, 0x0a, 0x40, 0x3d, 0xbc, 0x99,
-	0x05, 0xa0, 0xbd, 0x06, 0x03, 0x73, 0x42, 0xa1, 0xc8, 0x18, 0xd6, 0x39, 0x5c, 0xcb, 0x6d, 0x84,
-	0x32, 0xe5, 0x6f, 0x94, 0x69, 0x81, 0x5c, 0x59, 0xac, 0x40, 0xde, 0x80, 0x61, 0x7e, 0x12, 0xa1,
-	0xc0, 0x4b, 0x69, 0xa3, 0x6c, 0x18, 0xc5, 0x3f, 0x85, 0x56, 0x92, 0xc6, 0x36, 0x6e, 0x79, 0xa4,
-	0x6e, 0x01, 0x0e, 0x97, 0xe9, 0x6e, 0x4a, 0x68, 0x7f, 0x2d, 0x17, 0xa4, 0xc9, 0x13, 0xfe, 0xf0,
-	0x7f, 0x13, 0xf8, 0x2b, 0x58, 0x2b, 0x8e, 0xf3, 0xf8, 0xc7, 0xb0, 0x9c, 0x92, 0x39, 0xc1, 0x69,
-	0x42, 0x9e, 0x89, 0xda, 0xb9, 0xe3, 0xe4, 0x11, 0xf4, 0x90, 0x24, 0x67, 0xbe, 0x28, 0xa8, 0x3a,
-	0x0e, 0x1f, 0xd8, 0x57, 0x61, 0x3d, 0x27, 0x5d, 0x58, 0x66, 0x0e, 0x57, 0x4b, 0x2f, 0x05, 0x7c,
-	0x1d, 0x5a, 0xfc, 0xdb, 0x5d, 0x35, 0xa7, 0x02, 0xe0, 0x7b, 0xd0, 0x14, 0x97, 0xc6, 0xa1, 0xd8,
-	0x23, 0xb4, 0xcd, 0xbe, 0xea, 0xdd, 0x7e, 0x25, 0xbf, 0xea, 0x95, 0xce, 0x2a, 0xe9, 0xec, 0xeb,
-	0xb0, 0x51, 0x34, 0x9d, 0x50, 0xe6, 0x5b, 0xb8, 0x76, 0xc1, 0x85, 0x72, 0x89, 0x3a, 0xd4, 0xf0,
-	0x72, 0xde, 0x4b, 0xf4, 0x51, 0x84, 0xf6, 0x0d, 0xb8, 0x5e, 0x3c, 0xa5, 0x50, 0xe9, 0x6b, 0x58,
-	0x2f, 0xb9, 0x92, 0xcc, 0x09, 0xad, 0x45, 0x27, 0xdc, 0x80, 0x61, 0x5e, 0xa0, 0x98, 0xec, 0xb7,
-	0xa0, 0xf3, 0xec, 0xe8, 0x50, 0x7d, 0xcb, 0xac, 0x75, 0x4a, 0x44, 0x5d, 0x93, 0x26, 0x46, 0x15,
-	0x2d, 0x31, 0xb2, 0xfb, 0xd0, 0x15, 0x7c, 0x42, 0xd0, 0x43, 0x58, 0x7e, 0x76, 0xc4, 0x83, 0x95,
-	0x92, 0x26, 0xdb, 0x33, 0x96, 0x6a, 0xcf, 0x68, 0xfd, 0x14, 0xd1, 0x9d, 0x14, 0xfd, 0x94, 0x01,
-	0x60, 0x5d, 0x80, 0x10, 0x7b, 0x8b, 0xea, 0xb7, 0x77, 0x81, 0x7e, 0xf6, 0xc7, 0x54, 0x93, 0x3d,
-	0xed, 0x33, 0xbe, 0x54, 0x61, 0x4b, 0x57, 0x78, 0x27, 0xd5, 0x6f, 0xef, 0x62, 0xfd, 0x86, 0xb0,
-	0xc4, 0xda, 0x30, 0x44, 0xbe, 0x2d, 0xc9, 0xa1, 0xfd, 0x55, 0xaa, 0xe1, 0x9e, 0xf1, 0xd5, 0xa0,
-	0x5c, 0x8f, 0xa5, 0xaf, 0xe7, 0x02, 0x39, 0xb7, 0xa1, 0xff, 0xec, 0x88, 0x9f, 0x8e, 0xf2, 0x65,
-	0x61, 0x40, 0x8a, 0x48, 0x18, 0x63, 0x0b, 0x06, 0x42, 0x01, 0x93, 0xbb, 0x60, 0x19, 0xf6, 0x3a,
-	0xac, 0x66, 0x68, 0x85, 0x90, 0x2f, 0xa9, 0x10, 0x96, 0x80, 0x9b, 0x42, 0x16, 0xbc, 0xec, 0xb8,
-	0x60, 0x83, 0x5f, 0x08, 0xfe, 0x6b, 0x8b, 0xf9, 0xc4, 0xd8, 0xf5, 0x3f, 0xf4, 0xfe, 0x1c, 0x40,
-	0x7d, 0x36, 0x9d, 0x4f, 0x13, 0x71, 0x75, 0xf2, 0x01, 0xbd, 0x55, 0xd9, 0x8f, 0x47, 0xe7, 0x09,
-	0x6b, 0x43, 0xb3, 0x5b, 0x55, 0x41, 0xe8, 0xd9, 0x7c, 0x37, 0x4d, 0x4e, 0x8e, 0xd8, 0x5e, 0xf3,
-	0xf6, 0xae, 0x02, 0xb0, 0x93, 0xeb, 0xcf, 0xce, 0x47, 0xac, 0x99, 0xd5, 0xe0, 0xd8, 0x14, 0x60,
-	0xff, 0xa9, 0x05, 0x3d, 0xa9, 0xab, 0xd8, 0xc7, 0x0f, 0xf0, 0x55, 0xd5, 0x25, 0x13, 0x0a, 0xf3,
-	0x2e, 0xd9, 0x75, 0x68, 0xd1, 0x7c, 0x89, 0x1a, 0x45, 0x36, 0xa2, 0x15, 0x80, 0x75, 0xee, 0x58,
-	0x5d, 0xee, 0x7b, 0x69, 0xe7, 0x4e, 0x8c, 0xed, 0x5f, 0xc2, 0x50, 0x6c, 0xd6, 0x8b, 0xe9, 0x19,
-	0xf1, 0x58, 0x4c, 0x90, 0x46, 0xfc, 0x22, 0x97, 0xe6, 0xc8, 0x9a, 0xfa, 0xd9, 0x51, 0x8e, 0x3a,
-	0xd7, 0xa5, 0xf9, 0x15, 0x5c, 0x2d, 0x90, 0x2c, 0x96, 0xfc, 0x30, 0xdf, 0x77, 0xb9, 0x56, 0x28,
-	0xbb, 0xac, 0x07, 0xf3, 0xef, 0x16, 0xac, 0x14, 0x68, 0xc1, 0x72, 0x2c, 0x5e, 0x7d, 0xc9, 0x2b,
-	0x56, 0x0c, 0xf1, 0x1d, 0xa8, 0xc6, 0x24, 0x11, 0xc1, 0x72, 0x25, 0x9d, 0x4c, 0xc5, 0x0c, 0xf9,
-	0xae, 0x16, 0x13, 0x1a, 0xee, 0x1a, 0xbc, 0xe4, 0x10, 0x2d, 0xb9, 0xb5, 0x94, 0xde, 0x70, 0x5d,
-	0x99, 0x3f, 0x70, 0x5a, 0x3c, 0x82, 0x76, 0xa4, 0xdc, 0x53, 0xb4, 0xe7, 0xd4, 0xba, 0xf2, 0xae,
-	0x2f, 0x33, 0x2f, 0x8d, 0xcb, 0xfe, 0x0f, 0x8b, 0x1e, 0x93, 0x02, 0x9b, 0xfd, 0xbf, 0x5f, 0xda,
-	0xd6, 0x5f, 0x35, 0xa1, 0xc6, 0x14, 0x5e, 0x85, 0x65, 0x56, 0x90, 0x90, 0xc9, 0x34, 0x4e, 0x48,
-	0xc4, 0x1e, 0x44, 0xd0, 0x15, 0x7c, 0x15, 0x56, 0x29, 0x38, 0xf7, 0x59, 0x1d, 0xb2, 0x4a, 0x50,
-	0x71, 0x88, 0x2a, 0x29, 0x2a, 0xfb, 0x91, 0x0e, 0xaa, 0x96, 0xa0, 0xe2, 0x10, 0xd5, 0xf0, 0x0a,
-	0xf4, 0x29, 0x4a, 0xfb, 0x68, 0x08, 0xd5, 0x73, 0xc0, 0x38, 0x44, 0x0d, 0x09, 0xd4, 0x3e, 0xc1,
-	0x41, 0x4b, 0x39, 0x60, 0x1c, 0xa2, 0x26, 0xc6, 0xd0, 0xa3, 0x40, 0xf5, 0xe1, 0x0c, 0x6a, 0x65,
-	0x61, 0x71, 0x88, 0x00, 0x0f, 0x61, 0xc0, 0x60, 0x99, 0x8f, 0x65, 0x50, 0xbb, 0x18, 0x13, 0x87,
-	0xa8, 0x83, 0xaf, 0xc1, 0x3a, 0xc5, 0x14, 0x7c, 0xdc, 0x82, 0xba, 0xa5, 0xc8, 0x38, 0x44, 0x3d,
-	0xbc, 0x01, 0x6b, 0xdc, 0xd8, 0xd9, 0x4f, 0x3c, 0x50, 0xbf, 0x0c, 0x17, 0x87, 0x08, 0x49, 0x5d,
-	0xb2, 0x1f, 0xa3, 0xa0, 0xe5, 0x62, 0x4c, 0x1c, 0x22, 0x2c, 0x31, 0xd9, 0x6f, 0x2f, 0xd0, 0x8a,
-	0x34, 0x98, 0xf6, 0x36, 0x8b, 0x06, 0x78, 0x1d, 0x56, 0x14, 0x79, 0xfa, 0x79, 0x04, 0x5a, 0x2d,
-	0x44, 0xc4, 0x21, 0x5a, 0x93, 0x88, 0xcc, 0x07, 0x15, 0x68, 0xbd, 0x10, 0x11, 0x87, 0x68, 0x28,
-	0x97, 0x98, 0xff, 0x82, 0x02, 0x5d, 0x2d, 0xc3, 0xc5, 0x21, 0xda, 0x90, 0x36, 0x2d, 0xf8, 0xe8,
-	0x01, 0x5d, 0x2b, 0x45, 0xc6, 0x21, 0xba, 0x2e, 0xa5, 0xe6, 0x3f, 0x68, 0x40, 0x3f, 0x2a, 0xc3,
-	0xc5, 0x21, 0xba, 0x81, 0x07, 0x80, 0xd4, 0xa2, 0xf9, 0x57, 0x00, 0xe8, 0x66, 0x1e, 0x1a, 0x87,
-	0xe8, 0x96, 0x84, 0xea, 0xdf, 0x1d, 0xa0, 0x5f, 0xcb, 0x43, 0xe3, 0x10, 0xd9, 0xf2, 0xb4, 0x19,
-	0x9f, 0x17, 0xa0, 0xdb, 0x05, 0xe0, 0x38, 0x44, 0x1f, 0xe1, 0x9b, 0x70, 0x8d, 0xb9, 0x60, 0xf1,
-	0xd7, 0x01, 0xe8, 0xe3, 0x0b, 0x09, 0xe2, 0x10, 0x7d, 0x22, 0x09, 0x4a, 0x1e, 0xfd, 0xd1, 0xa7,
-	0x17, 0x12, 0xc4, 0x21, 0xda, 0xdc, 0x1a, 0xb1, 0xcf, 0xdc, 0xf5, 0x47, 0x22, 0xdc, 0x82, 0xfa,
-	0x51, 0x90, 0x90, 0x08, 0x5d, 0xc1, 0x00, 0x0d, 0x5e, 0xa5, 0x23, 0x0b, 0x77, 0xa0, 0xf9, 0x55,
-	0x30, 0x9b, 0x05, 0xef, 0x48, 0x84, 0x2a, 0xb8, 0x0d, 0x4b, 0xcf, 0x89, 0x1b, 0xf9, 0x24, 0x42,
-	0xd5, 0xad, 0x1d, 0x58, 0xce, 0xbd, 0xab, 0xe1, 0x06, 0x54, 0xf6, 0x7d, 0x74, 0x85, 0x8a, 0x7b,
-	0x19, 0x24, 0xfb, 0x3e, 0xb2, 0xa8, 0xb8, 0xc7, 0x67, 0xd3, 0x38, 0x89, 0x51, 0x05, 0x77, 0xa1,
-	0xf5, 0x32, 0x48, 0xc4, 0xb0, 0xba, 0x75, 0x0f, 0x96, 0x44, 0x2f, 0x8f, 0x32, 0xb0, 0x70, 0x8c,
-	0xae, 0xe0, 0x26, 0xd4, 0x1c, 0xe2, 0x7a, 0xc8, 0xa2, 0xc0, 0x1d, 0x6f, 0x3e, 0xf5, 0x51, 0x05,
-	0x2f, 0x41, 0xf5, 0xd5, 0x99, 0x8f, 0xaa, 0x5b, 0x3f, 0x54, 0xa1, 0xbd, 0xef, 0x27, 0x24, 0xf2,
-	0xdd, 0xd9, 0x68, 0xee, 0x51, 0xc7, 0x1f, 0xcd, 0x3d, 0xbd, 0x75, 0x82, 0xae, 0xe0, 0x65, 0xe8,
-	0x32, 0xa0, 0xec, 0x69, 0x20, 0x8b, 0x6e, 0x07, 0x9d, 0xcb, 0x68, 0x43, 0xa0, 0x8a, 0xa0, 0x54,
-	0xd1, 0x00, 0xd5, 0x05, 0xa5, 0x59, 0x07, 0xf3, 0x38, 0x95, 0x82, 0x79, 0x4d, 0x8a, 0x96, 0xe8,
-	0xb1, 0x48, 0x81, 0xaa, 0x56, 0x44, 0x4d, 0xbc, 0x06, 0x38, 0x45, 0xa4, 0x95, 0x12, 0xf2, 0x04,
-	0x3c, 0x53, 0x41, 0x21, 0x9a, 0xdb, 0x22, 0xae, 0x31, 0xaf, 0x67, 0x68, 0x2a, 0x8f, 0x5e, 0x0b,
-	0x6a, 0xad, 0xa8, 0x60, 0xf0, 0x89, 0x98, 0x36, 0x9b, 0xfb, 0xa3, 0x13, 0xdc, 0x85, 0xe6, 0x68,
-	0xee, 0xb1, 0xbb, 0x09, 0x7d, 0x67, 0x61, 0xcc, 0x56, 0xa7, 0xb2, 0x6f, 0xf4, 0xf7, 0x56, 0x4a,
-	0xb2, 0x47, 0x12, 0xf4, 0x0f, 0x19, 0x12, 0x0a, 0xfb, 0x47, 0x0b, 0x23, 0x68, 0x33, 0x18, 0x57,
-	0x13, 0xfd, 0x13, 0xb5, 0x1e, 0x52, 0x54, 0x02, 0xfc, 0xcf, 0x0a, 0xac, 0xdd, 0x4f, 0xe8, 0x5f,
-	0x2c, 0xdc, 0x83, 0x16, 0xd7, 0x62, 0xec, 0xfa, 0xe8, 0x5f, 0xe9, 0xed, 0x32, 0x50, 0xdc, 0xea,
-	0xea, 0x45, 0xdf, 0xcb, 0xa9, 0x1c, 0x12, 0x93, 0xe8, 0x2d, 0xf1, 0xd0, 0x7f, 0x2f, 0x6d, 0x7d,
-	0x0e, 0x1d, 0xbd, 0x21, 0x40, 0x77, 0x7e, 0xc7, 0xf3, 0xb8, 0x5f, 0xf2, 0x93, 0xc7, 0x3d, 0x83,
-	0xf2, 0x24, 0xa8, 0x42, 0x7f, 0x52, 0x43, 0x50, 0x97, 0x3c, 0x80, 0x95, 0x82, 0xe7, 0x08, 0x8c,
-	0xa0, 0xc3, 0xc7, 0x62, 0xd7, 0xaf, 0x28, 0x88, 0xe3, 0xfa, 0x5e, 0x30, 0xe7, 0xee, 0x91, 0xd2,
-	0xc4, 0xe4, 0x49, 0x30, 0x63, 0xee, 0xf1, 0x08, 0x7d, 0xff, 0x5f, 0x37, 0xae, 0x7c, 0xf7, 0xfe,
-	0x86, 0xf5, 0xfd, 0xfb, 0x1b, 0xd6, 0x7f, 0xbe, 0xbf, 0x61, 0x1d, 0x37, 0xd8, 0xff, 0x16, 0xbd,
-	0xff, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x1f, 0xb4, 0xdd, 0x76, 0x60, 0x3b, 0x00, 0x00,
+	// 4633 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3b, 0x4b, 0x73, 0x1c, 0x49,
+	0x5a, 0xae, 0x7e, 0xa8, 0xbb, 0xbf, 0x7e, 0x28, 0x95, 0x6a, 0x49, 0x25, 0x79, 0xd6, 0x12, 0xe5,
+	0x79, 0x68, 0xe5, 0x59, 0x99, 0xb5, 0x67, 0xf0, 0xce, 0x32, 0x8c, 0xd7, 0x6e, 0x79, 0x64, 0xf9,
+	0x35, 0xa2, 0xe4, 0xd1, 0x2c, 0x11, 0xcb, 0xa1, 0xd4, 0x95, 0x6e, 0x35, 0xee, 0xae, 0xaa, 0xa9,
+	0x2a, 0xd9, 0xd2, 0x05, 0x0e, 0xdc, 0x08, 0x22, 0x88, 0x20, 0x08, 0x6e, 0x44, 0xc0, 0x85, 0x08,
+	0xf8, 0x01, 0xfc, 0x86, 0xe1, 0x3d, 0x7b, 0x1a, 0x4e, 0x0e, 0xf0, 0x89, 0x9f, 0xc0, 0x91, 0xc8,
+	0x57, 0x55, 0x66, 0x3d, 0x5a, 0x6d, 0x6e, 0x5c, 0xac, 0xce, 0xef, 0x95, 0x5f, 0x66, 0x7e, 0xf9,
+	0xbd, 0x2a, 0x0d, 0xed, 0x30, 0x18, 0x06, 0x27, 0xbb, 0x41, 0xe8, 0xc7, 0x3e, 0xae, 0xb3, 0xc1,
+	0xc6, 0x6f, 0x8f, 0xc6, 0xf1, 0xe9, 0xd9, 0xc9, 0xee, 0xd0, 0x9f, 0xde, 0x9c, 0x3a, 0x71, 0x38,
+	0x3e, 0xf7, 0xc3, 0xf1, 0x68, 0xec, 0x89, 0xc1, 0xf0, 0xec, 0x84, 0xdc, 0x0c, 0x4e, 0x6e, 0x92,
+	0x30, 0xf4, 0xc3, 0xf4, 0x2f, 0x97, 0xb1, 0xf1, 0xd9, 0x7c, 0xcc, 0x53, 0x12, 0x3b, 0xc9, 0x1f,
+	0xc1, 0x7a, 0x67, 0x3e, 0xd6, 0xf8, 0xdc, 0x93, 0xff, 0x0a, 0xc6, 0x39, 0x15, 0x3e, 0x9d, 0x0c,
+	0x29, 0xe3, 0x78, 0x4a, 0xa2, 0xd8, 0x99, 0x06, 0x82, 0xf9, 0x27, 0x0a, 0xf3, 0xc8, 0x1f, 0xf9,
+	0x37, 0x19, 0xf8, 0xe4, 0xec, 0x05, 0x1b, 0xb1, 0x01, 0xfb, 0xc5, 0xc9, 0xad, 0x1f, 0x3a, 0xd0,
+	0x3b, 0x0c, 0xfd, 0xe0, 0x94, 0xc4, 0x36, 0xf9, 0xf6, 0x8c, 0x44, 0x31, 0x5e, 0x85, 0xca, 0xd8,
+	0x35, 0x8d, 0x2d, 0x63, 0xbb, 0x76, 0x7f, 0xe1, 0xed, 0x9b, 0xcd, 0xca, 0xc1, 0x9e, 0x5d, 0x19,
+	0xbb, 0xd8, 0x84, 0x46, 0x14, 0xfb, 0x21, 0x39, 0xd8, 0x33, 0x2b, 0x14, 0x69, 0xcb, 0x21, 0xde,
+	0x84, 0x5a, 0x7c, 0x11, 0x10, 0xb3, 0xba, 0x65, 0x6c, 0xf7, 0x6e, 0xb5, 0x77, 0xf9, 0x21, 0x3c,
+	0xbf, 0x08, 0x88, 0xcd, 0x10, 0xf8, 0x4b, 0xe8, 0x45, 0xa7, 0x4e, 0xe8, 0x3e, 0x24, 0x4e, 0x18,
+	0x9f, 0x10, 0x27, 0x36, 0x6b, 0x5b, 0xc6, 0x76, 0xfb, 0x96, 0x29, 0x48, 0x8f, 0x34, 0xa4, 0x4d,
+	0xbe, 0xbd, 0x5f, 0xfb, 0xee, 0xcd, 0xe6, 0x15, 0x3b, 0xc3, 0xc5, 0xe4, 0xd0, 0x39, 0x53, 0x39,
+	0x75, 0x5d, 0x8e, 0x86, 0x54, 0xe5, 0x68, 0x08, 0xfc, 0x09, 0x34, 0x83, 0xb3, 0x98, 0x51, 0x9b,
+	0x0b, 0x4c, 0x02, 0x16, 0x12, 0x0e, 0x05, 0x38, 0xe5, 0x4d, 0x28, 0x29, 0xd7, 0x88, 0x08, 0xae,
+	0x86, 0xc6, 0xb5, 0x4f, 0x72, 0x5c, 0x92, 0x12, 0xff, 0x14, 0x1a, 0xce, 0x64, 0xe2, 0x0f, 0x0f,
+	0xf6, 0xcc, 0x26, 0x63, 0x5a, 0x12, 0x4c, 0xf7, 0x38, 0x34, 0xe5, 0x91, 0x74, 0x78, 0x00, 0x5d,
+	0x27, 0x7a, 0x79, 0xdf, 0x89, 0x87, 0xa7, 0x47, 0xc1, 0x64, 0x1c, 0x9b, 0x2d, 0xc6, 0xb8, 0x26,
+	0x19, 0x55, 0x5c, 0xca, 0xae, 0xf3, 0xe0, 0x27, 0x80, 0x86, 0x21, 0x71, 0x62, 0xb2, 0x47, 0xa2,
+	0x38, 0xf4, 0x2f, 0xc6, 0xde, 0xc8, 0x04, 0x26, 0x67, 0x43, 0xc8, 0x19, 0x64, 0xd0, 0xa9, 0xa8,
+	0x1c, 0x27, 0x3e, 0x80, 0x45, 0x9b, 0x04, 0x7e, 0x18, 0x0b, 0x18, 0x71, 0xcd, 0x36, 0x13, 0xb6,
+	0x2e, 0x84, 0x65, 0xb0, 0xa9, 0xac, 0x2c, 0x1f, 0x5d, 0xdd, 0x88, 0xc4, 0x8a, 0x56, 0x1d, 0x6d,
+	0x75, 0xfb, 0x2a, 0x4e, 0x59, 0x9d, 0xc6, 0x43, 0x85, 0x70, 0x1d, 0xbf, 0xa1, 0x2b, 0x26, 0xa1,
+	0xd9, 0xd5, 0x84, 0x0c, 0x54, 0x9c, 0x22, 0x44, 0xe3, 0xc1, 0xbf, 0x80, 0x0e, 0x07, 0x30, 0xfb,
+	0x8b, 0xcc, 0x1e, 0x93, 0xb1, 0xaa, 0xc9, 0xe0, 0xa8, 0x54, 0x84, 0xc6, 0x41, 0x25, 0x84, 0x64,
+	0xea, 0xbf, 0x92, 0x12, 0x16, 0x35, 0x09, 0xb6, 0x82, 0x52, 0x24, 0xa8, 0x1c, 0x74, 0x63, 0x87,
+	0xa7, 0x64, 0xf8, 0x92, 0x0d, 0x8f, 0x62, 0x27, 0x26, 0x26, 0xd2, 0x36, 0x76, 0xa0, 0x63, 0x95,
+	0x8d, 0xcd, 0xf0, 0xd1, 0x13, 0x0f, 0xce, 0xe2, 0xc3, 0x89, 0x33, 0x24, 0x53, 0xe2, 0xc5, 0xf6,
+	0xd9, 0x84, 0x98, 0x4b, 0xda, 0x89, 0x1f, 0x66, 0xd0, 0xca, 0x89, 0x67, 0x39, 0xa9, 0x62, 0x23,
+	0x12, 0xdf, 0x0b, 0x82, 0xc9, 0x98, 0xb8, 0x14, 0x12, 0x99, 0x58, 0x53, 0x6c, 0x5f, 0xc7, 0x2a,
+	0x8a, 0x65, 0xf8, 0xf0, 0x1d, 0x68, 0xf1, 0x5d, 0x7b, 0xe4, 0x9f, 0x98, 0xcb, 0x4c, 0xc8, 0xb2,
+	0xb6, 0xc9, 0x8f, 0xfc, 0x93, 0x94, 0x3d, 0xa5, 0xa5, 0x8c, 0x7c, 0xb3, 0x28, 0x63, 0x5f, 0x63,
+	0xb4, 0x25, 0x5c, 0x61, 0x4c, 0x68, 0xf1, 0xcf, 0x01, 0xc8, 0x39, 0x19, 0x9e, 0xf1, 0x29, 0x57,
+	0x18, 0x67, 0x5f, 0x70, 0x3e, 0x48, 0x10, 0x29, 0xab, 0x42, 0x8d, 0x7f, 0x09, 0x7d, 0xc7, 0x75,
+	0x8f, 0x86, 0xa7, 0xc4, 0x3d, 0x9b, 0x90, 0xfd, 0xd0, 0x3f, 0x0b, 0xd8, 0x56, 0xae, 0x32, 0x29,
+	0xd7, 0xe4, 0x25, 0x2c, 0x20, 0x49, 0xe5, 0x15, 0x4a, 0xa0, 0x92, 0xa9, 0x5b, 0xc8, 0x49, 0x5e,
+	0xd3, 0x24, 0xef, 0x93, 0x78, 0x96, 0xe4, 0x22, 0x09, 0xf4, 0xb0, 0x74, 0x57, 0x19, 0x99, 0xa6,
+	0x76, 0x58, 0xba, 0x87, 0x55, 0x0f, 0x2b, 0xc3, 0x97, 0xf8, 0xea, 0xc7, 0x84, 0x04, 0xce, 0x64,
+	0xfc, 0x8a, 0x98, 0xeb, 0x79, 0x5f, 0x9d, 0x20, 0xb3, 0xbe, 0x3a, 0x41, 0xd0, 0xc8, 0xb2, 0x98,
+	0x44, 0x96, 0x28, 0xf0, 0xbd, 0x88, 0x94, 0x86, 0x16, 0x19, 0x40, 0x2a, 0x65, 0x01, 0xa4, 0x0f,
+	0x75, 0x16, 0x97, 0x59, 0x88, 0x69, 0xd9, 0x7c, 0x80, 0x57, 0x61, 0x61, 0x42, 0x1c, 0x97, 0x84,
+	0x2c, 0x9c, 0xb4, 0x6c, 0x31, 0x2a, 0x08, 0x37, 0xf5, 0x59, 0xe1, 0x26, 0x0a, 0xe6, 0x0e, 0x37,
+	0x0b, 0xb3, 0xc2, 0x8d, 0x22, 0xa7, 0x3c, 0xdc, 0x34, 0x8a, 0xc3, 0x4d, 0xc2, 0x5b, 0x1c, 0x6e,
+	0x9a, 0xc5, 0xe1, 0x26, 0xe5, 0x2a, 0x0a, 0x37, 0xad, 0xc2, 0x70, 0x93, 0xf0, 0x94, 0x87, 0x1b,
+	0x98, 0x11, 0x6e, 0x12, 0xf6, 0x39, 0xc2, 0x4d, 0x7b, 0x76, 0xb8, 0x49, 0x44, 0xcd, 0x15, 0x6e,
+	0x3a, 0x33, 0xc3, 0x4d, 0x22, 0xeb, 0xf2, 0x70, 0xd3, 0x9d, 0x11, 0x6e, 0xd2, 0xd5, 0x69, 0x3c,
+	0x78, 0x17, 0xea, 0xe4, 0x15, 0xf1, 0x62, 0xb3, 0xa7, 0x1d, 0xc4, 0x03, 0x0a, 0x7b, 0xe6, 0xc7,
+	0xe3, 0x17, 0x17, 0x82, 0x8f, 0x93, 0xe5, 0x22, 0xcb, 0x62, 0x79, 0x64, 0x49, 0xa6, 0x9c, 0x1d,
+	0x59, 0x50, 0x79, 0x64, 0x49, 0x25, 0x5c, 0x16, 0x59, 0x96, 0x66, 0x46, 0x96, 0x74, 0x0f, 0xe7,
+	0x89, 0x2c, 0x78, 0x76, 0x64, 0x49, 0x0f, 0x77, 0x9e, 0xc8, 0xb2, 0x3c, 0x33, 0xb2, 0xa4, 0x8a,
+	0xcd, 0x8c, 0x2c, 0xfd, 0x92, 0xc8, 0x92, 0xb0, 0x97, 0x45, 0x96, 0x95, 0x92, 0xc8, 0x92, 0x32,
+	0x96, 0x45, 0x96, 0xd5, 0xb2, 0xc8, 0x92, 0xb0, 0xce, 0x13, 0x59, 0xd6, 0x2e, 0x8f, 0x2c, 0x89,
+	0xbc, 0x77, 0x8b, 0x2c, 0xe6, 0xe5, 0x91, 0x25, 0x95, 0x3c, 0x6f, 0x64, 0x59, 0x9f, 0x19, 0x59,
+	0xd2, 0xc3, 0xba, 0x3c, 0xb2, 0x6c, 0xcc, 0x8a, 0x2c, 0x19, 0xb7, 0x9c, 0x46, 0x96, 0xbf, 0xad,
+	0xc1, 0x52, 0xae, 0x62, 0x50, 0xcb, 0x13, 0x43, 0x2f, 0x4f, 0xfa, 0x50, 0x67, 0x12, 0x58, 0x78,
+	0xe9, 0xd8, 0x7c, 0x80, 0x31, 0xd4, 0x62, 0x12, 0x4e, 0x59, 0x44, 0xa9, 0xd9, 0xec, 0x37, 0xfe,
+	0x48, 0x0b, 0x28, 0xed, 0x5b, 0x8b, 0xbb, 0xa2, 0xa2, 0xb3, 0x49, 0x30, 0x19, 0x0f, 0x9d, 0x24,
+	0xc2, 0x7c, 0x01, 0x1d, 0xd7, 0x7f, 0xed, 0x09, 0x70, 0x64, 0xd6, 0xb7, 0xaa, 0xcc, 0x0e, 0x74,
+	0x72, 0x7a, 0x79, 0x22, 0x79, 0x37, 0x55, 0x7a, 0x7c, 0x17, 0x16, 0x03, 0xe2, 0xb9, 0x2c, 0xc3,
+	0x15, 0x22, 0x16, 0xb6, 0xaa, 0x05, 0x33, 0xca, 0xbd, 0xcc, 0x50, 0x53, 0x87, 0x14, 0x51, 0xe9,
+	0x49, 0x3c, 0x11, 0x6c, 0xc9, 0xa5, 0x95, 0xf3, 0x72, 0x32, 0xbc, 0x01, 0xcd, 0x11, 0x3d, 0xd3,
+	0xc7, 0xe4, 0x82, 0x05, 0x93, 0x96, 0x9d, 0x8c, 0xf1, 0x36, 0xd4, 0x27, 0xc4, 0x89, 0x88, 0xd9,
+	0xd2, 0x65, 0x3d, 0x08, 0xfc, 0xe1, 0xe9, 0x13, 0x8a, 0xb1, 0x39, 0x01, 0xfe, 0x50, 0x04, 0xc4,
+	0xbd, 0x71, 0xf4, 0xf2, 0xeb, 0xc8, 0x19, 0x11, 0x16, 0x2a, 0x0c, 0x3b, 0x03, 0xc5, 0x9f, 0x40,
+	0x87, 0x41, 0x06, 0x87, 0x5f, 0x3f, 0xf1, 0x1d, 0x5e, 0x2a, 0x18, 0xf7, 0xd1, 0xdb, 0x37, 0x9b,
+	0x9d, 0x23, 0x05, 0x6e, 0x6b, 0x54, 0xf8, 0x03, 0x68, 0x84, 0xc4, 0x71, 0x7f, 0xf7, 0xf0, 0x88,
+	0x39, 0x7b, 0xe3, 0x7e, 0xfb, 0xed, 0x9b, 0xcd, 0x86, 0xcd, 0x41, 0xb6, 0xc4, 0xe1, 0x6d, 0x68,
+	0xbe, 0x0e, 0xc7, 0x31, 0xa1, 0x74, 0x5d, 0x46, 0xd7, 0x79, 0xfb, 0x66, 0xb3, 0xf9, 0x8d, 0x80,
+	0xd9, 0x09, 0xd6, 0xfa, 0xf3, 0xbc, 0xa1, 0x44, 0x01, 0x33, 0x14, 0x0a, 0x54, 0x0c, 0x85, 0x0f,
+	0xf1, 0xcf, 0x00, 0xd8, 0x4f, 0xb6, 0x70, 0xb3, 0xa2, 0xef, 0xc6, 0x51, 0x82, 0x91, 0x37, 0x3b,
+	0xa5, 0xc5, 0x9f, 0x42, 0x37, 0x76, 0xc2, 0x11, 0x89, 0xc5, 0x01, 0x31, 0xab, 0x2a, 0xb0, 0x1f,
+	0x9d, 0x0a, 0xdf, 0x81, 0xce, 0xd0, 0xf7, 0x5e, 0x8c, 0x47, 0x83, 0x53, 0xc7, 0x1b, 0x11, 0xb3,
+	0xa6, 0x39, 0xa2, 0x81, 0x82, 0xb2, 0x35, 0x42, 0xfc, 0x3b, 0xd0, 0x8b, 0x43, 0xc7, 0x8b, 0x5e,
+	0x90, 0xf0, 0x09, 0x37, 0x58, 0x9e, 0xe1, 0xac, 0xc8, 0xd4, 0x49, 0x43, 0xda, 0x19, 0x62, 0x6c,
+	0x41, 0x7d, 0x4a, 0xc2, 0x91, 0x2c, 0x7e, 0x3b, 0x82, 0xeb, 0x29, 0x85, 0xd9, 0x1c, 0x85, 0x7f,
+	0x0a, 0x10, 0xd1, 0xc8, 0xce, 0xd6, 0x6d, 0x36, 0xb4, 0x5c, 0xe2, 0x28, 0x41, 0xd8, 0x0a, 0x11,
+	0xd5, 0x4a, 0xd5, 0xf2, 0xf8, 0x96, 0xd9, 0xd4, 0xb4, 0x1a, 0x68, 0x48, 0x3b, 0x43, 0x8c, 0x7f,
+	0x0e, 0x5d, 0x45, 0xcf, 0xc4, 0x1e, 0xfb, 0xf9, 0x35, 0x45, 0xc4, 0xd6, 0x49, 0xf1, 0x36, 0x2c,
+	0xba, 0x3c, 0x5c, 0xef, 0x8d, 0x43, 0x32, 0x8c, 0x27, 0x17, 0xcc, 0x34, 0x9b, 0x76, 0x16, 0x6c,
+	0x3d, 0x07, 0x9c, 0x4f, 0x86, 0xf1, 0x17, 0x00, 0xa7, 0x09, 0xc0, 0x34, 0xb6, 0xaa, 0x59, 0xbf,
+	0x54, 0xd0, 0x55, 0x50, 0x38, 0xac, 0x8f, 0xf3, 0x52, 0xa3, 0x80, 0x26, 0xa8, 0x2c, 0x53, 0xe5,
+	0x12, 0x5b, 0xb6, 0x18, 0x59, 0x7f, 0x6d, 0x08, 0xc3, 0x54, 0xf3, 0xe8, 0x19, 0x86, 0x49, 0xd3,
+	0x8f, 0xb9, 0x6c, 0x92, 0x93, 0x15, 0xfa, 0xb6, 0x9f, 0x5c, 0xe2, 0xdb, 0x84, 0x04, 0x41, 0x64,
+	0x2d, 0xe7, 0x34, 0x8c, 0x02, 0xeb, 0x3a, 0xb4, 0x95, 0x06, 0x09, 0x73, 0xac, 0xf4, 0xb7, 0x69,
+	0x08, 0xc7, 0x4a, 0x07, 0xd6, 0x6d, 0x85, 0x28, 0x0a, 0xf0, 0xfb, 0xd0, 0x15, 0x47, 0x20, 0x32,
+	0x19, 0x4e, 0xac, 0x03, 0xad, 0x6f, 0x60, 0x29, 0xd7, 0xbc, 0x49, 0x9d, 0x9c, 0x91, 0x59, 0x36,
+	0xa5, 0x2c, 0x70, 0x72, 0x18, 0x6a, 0xae, 0x13, 0x3b, 0xc2, 0xcf, 0xb3, 0xdf, 0xd6, 0x47, 0x39,
+	0xc1, 0x51, 0x90, 0x10, 0x1a, 0x0a, 0xe1, 0x07, 0xd0, 0x56, 0xda, 0x38, 0x65, 0xa5, 0x8a, 0xf5,
+	0x58, 0x21, 0x2b, 0x96, 0x44, 0xfd, 0x29, 0x57, 0xbb, 0x52, 0xa6, 0xb6, 0x50, 0xd8, 0xea, 0x00,
+	0xa4, 0x5d, 0x20, 0xeb, 0xfd, 0x74, 0x14, 0x05, 0xa5, 0x0a, 0x7c, 0x0e, 0x28, 0xdb, 0x00, 0x2a,
+	0xd4, 0xa2, 0x0f, 0xf5, 0xa1, 0x7f, 0xe6, 0xc5, 0x4c, 0x8b, 0xae, 0xcd, 0x07, 0xd6, 0x5e, 0x96,
+	0x3b, 0x0a, 0xf0, 0x6f, 0x42, 0x93, 0x5d, 0xe2, 0x83, 0x3d, 0x69, 0xf9, 0x3d, 0xf5, 0x9e, 0x1f,
+	0xec, 0xc9, 0x22, 0x43, 0x52, 0x59, 0x7f, 0x04, 0xcb, 0x05, 0xcd, 0xa3, 0xd2, 0xf2, 0xae, 0x0f,
+	0xf5, 0xb1, 0xe7, 0x92, 0x73, 0xd1, 0x37, 0xe4, 0x03, 0x1a, 0x92, 0x42, 0x19, 0xfc, 0xaa, 0x5b,
+	0xd5, 0xed, 0x9a, 0x9d, 0x8c, 0xf1, 0x35, 0x00, 0x9e, 0x72, 0xed, 0xd1, 0x65, 0xd5, 0xd8, 0x4d,
+	0x56, 0x20, 0xd6, 0xdd, 0x02, 0x05, 0xa2, 0x40, 0xee, 0x3c, 0x37, 0xc8, 0x5e, 0x41, 0x54, 0x24,
+	0x7c, 0xe7, 0x89, 0xb5, 0x03, 0x28, 0xdb, 0x68, 0x2a, 0xdd, 0xf1, 0xbd, 0x2c, 0x2d, 0xdb, 0xb3,
+	0x05, 0x2a, 0xe8, 0x4c, 0xda, 0xa6, 0x29, 0xa7, 0x4a, 0xc9, 0x8e, 0x18, 0xde, 0x16, 0x74, 0xd6,
+	0x23, 0xc0, 0xf9, 0x1e, 0x59, 0xe9, 0x96, 0xbd, 0x07, 0x2d, 0xb1, 0x19, 0x49, 0xbb, 0x35, 0x05,
+	0x58, 0x5f, 0xe4, 0x65, 0xbd, 0xd3, 0xea, 0x1f, 0x40, 0x43, 0x1c, 0x2d, 0x3d, 0x1b, 0x8f, 0xbc,
+	0x4e, 0x5c, 0x0e, 0x1f, 0xd0, 0x4b, 0xeb, 0x91, 0xd7, 0xb6, 0x9c, 0x90, 0x9a, 0x32, 0x3d, 0x20,
+	0x1d, 0x68, 0x7d, 0x08, 0x28, 0xdb, 0x68, 0xa3, 0xa6, 0xf8, 0x62, 0xe2, 0x8c, 0x98, 0xb8, 0xae,
+	0xcd, 0x7e, 0x5b, 0x5f, 0xc1, 0x62, 0xa6, 0x99, 0x46, 0x3d, 0x63, 0x24, 0xdd, 0x41, 0x75, 0xbb,
+	0x63, 0x8b, 0x11, 0x9d, 0x98, 0xa6, 0x1a, 0x71, 0x92, 0x16, 0x89, 0x89, 0x35, 0xa0, 0xb5, 0x94,
+	0x11, 0x18, 0x05, 0xd6, 0xc7, 0xb4, 0x62, 0xd4, 0xda, 0x6d, 0x78, 0x1d, 0xaa, 0x63, 0x31, 0x41,
+	0xed, 0x7e, 0xe3, 0xed, 0x9b, 0xcd, 0xea, 0xc1, 0x5e, 0x64, 0x53, 0x98, 0xb5, 0x94, 0xa1, 0x8e,
+	0x02, 0xeb, 0x26, 0xe0, 0x7c, 0xab, 0x2d, 0x95, 0x61, 0x6c, 0x77, 0x32, 0x32, 0xec, 0x3c, 0x43,
+	0x14, 0xd0, 0x83, 0x73, 0x93, 0x9a, 0x95, 0xdf, 0xc7, 0x14, 0x40, 0xed, 0xda, 0x4d, 0x2b, 0x51,
+	0xee, 0xa7, 0x14, 0x88, 0xf5, 0x00, 0x96, 0x0b, 0x7a, 0x74, 0x78, 0x17, 0x6a, 0x21, 0x4d, 0xe7,
+	0x0d, 0x2d, 0x20, 0x6a, 0x64, 0xe2, 0x8e, 0x32, 0x3a, 0x6b, 0xa5, 0x40, 0x4c, 0x14, 0x58, 0xbb,
+	0x80, 0xf3, 0x4d, 0xbb, 0xf2, 0xb0, 0x63, 0x7d, 0x99, 0xa7, 0x67, 0xa6, 0x5f, 0xa7, 0x93, 0x48,
+	0x5f, 0x31, 0x4b, 0x1b, 0x4e, 0x68, 0xdd, 0x86, 0x8e, 0xda, 0xe7, 0xc3, 0xd7, 0xa1, 0xfa, 0x07,
+	0xfe, 0x89, 0x58, 0x4d, 0x5b, 0x9a, 0xe9, 0x23, 0xff, 0x44, 0xb0, 0x51, 0xac, 0xd5, 0x53, 0x99,
+	0xa2, 0x80, 0x0a, 0x51, 0x7b, 0x7e, 0x73, 0x0b, 0x51, 0xcb, 0x39, 0xeb, 0x21, 0x74, 0xb5, 0xf6,
+	0xdf, 0x5c, 0x52, 0x0a, 0xe3, 0xca, 0x75, 0x4d, 0x52, 0x49, 0x4c, 0x79, 0x06, 0x6b, 0x25, 0x7d,
+	0x42, 0x7c, 0x5b, 0x3b, 0xd2, 0xf5, 0xe4, 0xae, 0x66, 0x69, 0xb5, 0x73, 0x5d, 0x2f, 0x91, 0x17,
+	0x05, 0x14, 0x55, 0xd2, 0x38, 0xb4, 0x0e, 0x4b, 0x50, 0x51, 0x80, 0x3f, 0xd5, 0xcf, 0xf2, 0x52,
+	0x35, 0xc4, 0x81, 0xfe, 0xba, 0x02, 0x6d, 0xa5, 0xf7, 0x81, 0x11, 0x54, 0x23, 0xf2, 0xad, 0x30,
+	0x1f, 0xfa, 0x13, 0x63, 0xa5, 0xa3, 0xd7, 0x15, 0x4d, 0xbc, 0x5b, 0xd0, 0x1a, 0x7b, 0xe3, 0x98,
+	0x31, 0x8a, 0x04, 0x59, 0x1a, 0xcf, 0x81, 0x84, 0x53, 0xef, 0x6e, 0xa7, 0x64, 0xf8, 0x53, 0x99,
+	0x92, 0x33, 0xa6, 0x9a, 0x96, 0x4e, 0x1e, 0x25, 0x08, 0xc6, 0xa5, 0x10, 0x32, 0x36, 0x1a, 0x6d,
+	0x39, 0x9b, 0x9e, 0x1b, 0x1f, 0x25, 0x08, 0xc1, 0x96, 0x8c, 0xf1, 0xe7, 0xa2, 0xd8, 0x65, 0x41,
+	0x9a, 0xf3, 0x2e, 0x94, 0xd5, 0x57, 0x76, 0x96, 0x94, 0x71, 0x27, 0x21, 0x9e, 0x73, 0x37, 0x4a,
+	0x33, 0x80, 0x2c, 0xa9, 0xf5, 0x57, 0x06, 0x74, 0xb5, 0x6d, 0x28, 0xf5, 0x91, 0x14, 0x4e, 0x99,
+	0xb9, 0x73, 0xec, 0xd8, 0x62, 0x84, 0x77, 0x00, 0xf1, 0xe4, 0x4d, 0xf1, 0xdb, 0x3c, 0xb0, 0xe6,
+	0xe0, 0x34, 0x7e, 0xb1, 0x92, 0x2e, 0x32, 0x6b, 0x5b, 0x55, 0x55, 0xc5, 0xb4, 0xe8, 0x53, 0x12,
+	0xc2, 0x88, 0x44, 0xd6, 0xdf, 0x1b, 0xd0, 0xd3, 0x77, 0xbc, 0x24, 0xf9, 0x59, 0xcc, 0x4c, 0x26,
+	0xc2, 0x57, 0x16, 0x9c, 0x96, 0x9d, 0xd5, 0xcb, 0xca, 0x4e, 0x93, 0x16, 0x86, 0xf4, 0x16, 0xbb,
+	0x22, 0x15, 0x90, 0x43, 0xba, 0x15, 0xbc, 0xa7, 0xc3, 0xce, 0xb8, 0x69, 0x8b, 0x91, 0xf5, 0x3e,
+	0xf4, 0xf4, 0x63, 0x2e, 0xbc, 0x9e, 0x17, 0xd0, 0x51, 0x4b, 0x12, 0x7c, 0x93, 0xce, 0xc3, 0xeb,
+	0x37, 0x63, 0x56, 0x8e, 0x2c, 0xa9, 0x68, 0xc1, 0x38, 0x64, 0xac, 0xcf, 0xd3, 0xee, 0x75, 0x92,
+	0x09, 0xa8, 0xa2, 0x29, 0xde, 0x56, 0x68, 0xad, 0x7b, 0xd0, 0xd3, 0x6b, 0xb4, 0x77, 0x9e, 0xdc,
+	0xba, 0x0b, 0x5d, 0xad, 0x24, 0xa2, 0xe9, 0x32, 0xdf, 0x50, 0xa3, 0x6c, 0x43, 0xe5, 0x2d, 0x66,
+	0x64, 0xd6, 0x03, 0xe8, 0xe9, 0x15, 0x19, 0xbe, 0x0d, 0x0d, 0xae, 0xa3, 0x74, 0x08, 0x45, 0xa5,
+	0xa8, 0xd4, 0x43, 0x50, 0x5a, 0x9b, 0x50, 0x67, 0x85, 0x23, 0x3d, 0x0c, 0x5e, 0xde, 0x8a, 0x4d,
+	0x16, 0x23, 0xeb, 0x29, 0x40, 0x5a, 0x30, 0xe2, 0x1b, 0xb0, 0x10, 0xf8, 0x93, 0xf1, 0xf0, 0x42,
+	0xa4, 0x29, 0xcb, 0xc9, 0x7e, 0xd1, 0x60, 0x7a, 0xc8, 0x50, 0xb6, 0x20, 0xa1, 0xa7, 0xf6, 0x92,
+	0x5c, 0x48, 0x43, 0x67, 0xbf, 0x2d, 0x02, 0x8b, 0x4f, 0x9c, 0x13, 0x32, 0x19, 0xf8, 0x5e, 0x14,
+	0x87, 0xce, 0xd8, 0x8b, 0xa9, 0xff, 0x79, 0x49, 0xb8, 0xc0, 0x96, 0x4d, 0x7f, 0xe2, 0x6d, 0xa8,
+	0xf8, 0x41, 0x72, 0x22, 0x7c, 0x11, 0x19, 0xae, 0xaf, 0x02, 0xbb, 0xe2, 0xb3, 0x1a, 0xed, 0x95,
+	0x33, 0x39, 0x23, 0xfc, 0xae, 0xb4, 0x6c, 0x31, 0xb2, 0xfe, 0xb8, 0x0a, 0x5d, 0xbd, 0x6f, 0x99,
+	0xe6, 0x6a, 0xad, 0xec, 0x87, 0x71, 0xd6, 0x4b, 0x11, 0xa6, 0xde, 0xb2, 0xe5, 0x30, 0x4d, 0x7c,
+	0xab, 0x3c, 0x07, 0x4f, 0x12, 0x5f, 0xff, 0x15, 0x09, 0xc3, 0xb1, 0x4b, 0x84, 0x3d, 0x27, 0x63,
+	0x8a, 0x8b, 0x62, 0x27, 0x8c, 0x69, 0x9f, 0xa6, 0xce, 0x76, 0x31, 0x19, 0x53, 0x4d, 0x89, 0xe7,
+	0x52, 0xcc, 0x02, 0xdf, 0x5f, 0x3e, 0xc2, 0x3b, 0x50, 0x0b, 0xfd, 0x09, 0xff, 0xb4, 0xd0, 0x53,
+	0x5a, 0xc4, 0xbc, 0xe5, 0xe0, 0x4f, 0xb8, 0xf5, 0x31, 0x9a, 0xb4, 0x2a, 0x68, 0x2a, 0x55, 0x01,
+	0x7e, 0x08, 0x68, 0xa2, 0x6f, 0x4e, 0x64, 0xb6, 0x98, 0x01, 0xac, 0x16, 0xef, 0x9d, 0xec, 0xed,
+	0x66, 0xb9, 0x68, 0x87, 0x68, 0xe2, 0x0f, 0x9d, 0x78, 0xec, 0x7b, 0x8c, 0x25, 0x32, 0x81, 0xed,
+	0x6a, 0x06, 0x4a, 0xe9, 0xc6, 0x91, 0x3f, 0xe1, 0x20, 0xf2, 0x8a, 0x4c, 0x58, 0x8f, 0xa8, 0x65,
+	0x67, 0xa0, 0xd6, 0xdf, 0x18, 0x80, 0xc5, 0xc3, 0x04, 0x56, 0xb4, 0x3c, 0xe4, 0x97, 0x25, 0x3d,
+	0x8a, 0x4e, 0xf6, 0x28, 0x64, 0x2e, 0x53, 0xd1, 0x4b, 0x68, 0xe5, 0x7a, 0x55, 0xe7, 0xba, 0xdb,
+	0x89, 0x7b, 0xaa, 0x5d, 0xe2, 0x9e, 0xac, 0xdf, 0x83, 0x65, 0xf9, 0x85, 0x6b, 0x1e, 0x1d, 0x77,
+	0xe4, 0xb7, 0x2c, 0x5e, 0x1e, 0xf6, 0x76, 0xe5, 0x8b, 0x93, 0x07, 0xf4, 0x6f, 0x52, 0xc8, 0xd3,
+	0x01, 0xf5, 0x50, 0xea, 0xea, 0xf1, 0x1d, 0x58, 0x38, 0xe5, 0x45, 0xbc, 0x91, 0xf9, 0x1c, 0x92,
+	0xdd, 0x22, 0xe9, 0xbd, 0x39, 0x39, 0xad, 0xf1, 0x42, 0x4e, 0xc3, 0x2f, 0x53, 0x5a, 0xe3, 0x49,
+	0x56, 0x51, 0xe3, 0x49, 0x2a, 0xeb, 0x0f, 0xa1, 0xab, 0xad, 0x0a, 0xff, 0x2c, 0x33, 0xf7, 0x46,
+	0x22, 0x20, 0xb7, 0xf6, 0xcc, 0xe4, 0xb7, 0x69, 0x31, 0xc3, 0x89, 0xe4, 0xec, 0x8b, 0x59, 0xe6,
+	0xa4, 0xd1, 0x2e, 0xe8, 0xac, 0x7f, 0x68, 0x40, 0x23, 0xff, 0x24, 0xa5, 0x93, 0x2d, 0x2c, 0xd9,
+	0x55, 0x93, 0x85, 0x25, 0x1b, 0x60, 0x4b, 0x7b, 0x8e, 0x22, 0xd7, 0x39, 0x98, 0xba, 0xca, 0x07,
+	0xc5, 0x6b, 0x00, 0xc3, 0xb3, 0x28, 0xf6, 0xa7, 0x14, 0xc6, 0x8e, 0xb8, 0x66, 0x2b, 0x10, 0xe9,
+	0x51, 0xf8, 0x15, 0xa4, 0x3f, 0x29, 0x64, 0x38, 0x75, 0xc5, 0xd5, 0xa3, 0x3f, 0x69, 0x6d, 0x10,
+	0x8c, 0x79, 0x6b, 0xac, 0xca, 0x6b, 0x83, 0xc3, 0x83, 0x3d, 0xbb, 0x1a, 0x70, 0x3b, 0x8c, 0x7d,
+	0xde, 0x39, 0x6b, 0x72, 0x3b, 0x14, 0x43, 0x1a, 0xa4, 0xc7, 0x23, 0x8f, 0x86, 0x26, 0x6a, 0x47,
+	0xcc, 0xe7, 0xb1, 0x3e, 0x57, 0xd3, 0xce, 0xc1, 0xd3, 0xb6, 0x0f, 0xcc, 0xd7, 0xf6, 0x49, 0x4c,
+	0xb6, 0x7d, 0x59, 0x44, 0xdd, 0x81, 0x16, 0xf5, 0xa5, 0x36, 0xeb, 0x3a, 0x76, 0xb4, 0x26, 0x20,
+	0x83, 0xd9, 0x29, 0x1a, 0x3f, 0x81, 0x65, 0x71, 0x27, 0x8e, 0xc8, 0x84, 0x0c, 0x63, 0xee, 0xa2,
+	0x59, 0xeb, 0xb5, 0xa7, 0x18, 0x41, 0x8e, 0xc2, 0x2e, 0x62, 0xc3, 0xbf, 0x80, 0xc5, 0xf8, 0xdc,
+	0x63, 0xb6, 0x22, 0x4e, 0x37, 0x79, 0x76, 0xc1, 0xdf, 0x40, 0x3d, 0xd7, 0xb1, 0x76, 0x96, 0x1c,
+	0x3f, 0x85, 0xc5, 0xb3, 0xc0, 0x75, 0x62, 0xf2, 0xfc, 0xdc, 0xb3, 0xc9, 0xd0, 0x0f, 0x5d, 0xf1,
+	0x79, 0xed, 0x47, 0x42, 0x97, 0xaf, 0x75, 0xac, 0x6e, 0xe0, 0x59, 0x5e, 0x2a, 0xce, 0x25, 0x13,
+	0xa2, 0x8a, 0x43, 0x9a, 0xb8, 0x3d, 0x1d, 0x9b, 0x11, 0x97, 0xe1, 0xc5, 0xc7, 0x80, 0x87, 0xfe,
+	0x74, 0x3a, 0x8e, 0x9f, 0x9f, 0x7b, 0xac, 0x25, 0xcd, 0x3a, 0x18, 0xfc, 0xc3, 0xdb, 0x56, 0x12,
+	0x4d, 0xb3, 0x04, 0xba, 0xd0, 0x02, 0x09, 0xf8, 0x18, 0x96, 0x42, 0x7f, 0x32, 0x39, 0x71, 0x86,
+	0x2f, 0x53, 0x45, 0xf9, 0x37, 0x38, 0x4b, 0x9e, 0x41, 0x8a, 0x2f, 0x11, 0x9c, 0x17, 0x81, 0x0f,
+	0x01, 0x0d, 0x27, 0xc4, 0xf1, 0x9e, 0x9f, 0x7b, 0x4f, 0x8f, 0x07, 0x03, 0xa6, 0xed, 0xb2, 0xf6,
+	0xd5, 0x68, 0x90, 0x41, 0xeb, 0x22, 0x73, 0xdc, 0xd6, 0x0d, 0xa8, 0x73, 0xc3, 0xa1, 0xad, 0x80,
+	0xd0, 0x9f, 0xca, 0x94, 0x8b, 0xfe, 0xc6, 0x3d, 0xa8, 0xc4, 0xbe, 0x28, 0xa4, 0x2a, 0xb1, 0x6f,
+	0xfd, 0x49, 0x1d, 0x9a, 0x05, 0xcf, 0x03, 0xf4, 0x6b, 0x6e, 0x69, 0xcf, 0x03, 0xe6, 0xb9, 0xd0,
+	0xd5, 0xdc, 0x85, 0xee, 0x43, 0x9d, 0x05, 0x76, 0x76, 0xd7, 0x3b, 0x36, 0x1f, 0xc8, 0x2b, 0x5c,
+	0x2f, 0xb8, 0xc2, 0x89, 0x9b, 0x5e, 0xb8, 0xd4, 0x4d, 0xe3, 0x01, 0xa0, 0xd4, 0x4a, 0xf9, 0x62,
+	0x44, 0xea, 0xbf, 0x96, 0xb3, 0x6a, 0x8e, 0xb6, 0x73, 0x0c, 0x78, 0x3f, 0x6f, 0xd7, 0xcd, 0x39,
+	0xec, 0x3a, 0x6f, 0xd1, 0xfb, 0x79, 0x8b, 0x6e, 0xcd, 0x61, 0xd1, 0x79, 0x5b, 0x3e, 0x2c, 0xb4,
+	0x65, 0x98, 0xcf, 0x96, 0x0b, 0xad, 0xf8, 0xb0, 0xc8, 0x8a, 0xdb, 0xf3, 0x5a, 0x71, 0x91, 0xfd,
+	0x3e, 0x2a, 0xb0, 0xdf, 0xce, 0x3c, 0xf6, 0x5b, 0x60, 0xb9, 0x7f, 0x69, 0xc0, 0xb2, 0xf6, 0xd1,
+	0x85, 0x53, 0x66, 0xd2, 0x7c, 0x63, 0xfe, 0x34, 0x5f, 0xcd, 0x3a, 0x2a, 0x73, 0x65, 0x1d, 0x7d,
+	0xa8, 0xbf, 0xf0, 0xc3, 0x21, 0xb7, 0xe0, 0xa6, 0xcd, 0x07, 0xd6, 0x3d, 0xe8, 0xeb, 0x7a, 0x09,
+	0x93, 0xf9, 0xb1, 0xfc, 0xb2, 0xc9, 0x23, 0x72, 0x57, 0x0b, 0x10, 0x49, 0x6f, 0x9c, 0x0e, 0xac,
+	0x3b, 0xb0, 0x34, 0xf0, 0xa7, 0x81, 0x33, 0x8c, 0x9f, 0xf8, 0x23, 0xb9, 0x30, 0x8b, 0x7e, 0x7f,
+	0x62, 0xc0, 0x03, 0x96, 0xa6, 0xf2, 0x02, 0x5e, 0x83, 0x59, 0x7d, 0xc0, 0x2a, 0x23, 0x9f, 0xd9,
+	0x7a, 0x08, 0x2b, 0x99, 0x6f, 0x4c, 0x42, 0xe4, 0x3b, 0x97, 0x31, 0x26, 0xac, 0x66, 0x25, 0x89,
+	0x39, 0x5c, 0x58, 0xd2, 0xda, 0xdc, 0x4c, 0xfe, 0xa7, 0x4a, 0x22, 0xa3, 0xd7, 0x28, 0x2a, 0x59,
+	0x36, 0x9b, 0xa1, 0x01, 0x79, 0xe8, 0x7b, 0x31, 0x39, 0x8f, 0x85, 0xf3, 0x91, 0x43, 0xeb, 0xcf,
+	0x0c, 0xe8, 0x68, 0x33, 0xb0, 0xaf, 0x1a, 0x4e, 0x18, 0xa7, 0x5f, 0x35, 0x9c, 0x90, 0x95, 0x18,
+	0xc4, 0x93, 0x9f, 0x90, 0xe9, 0x4f, 0xea, 0x71, 0x3c, 0xf2, 0xfa, 0x48, 0xa4, 0x9b, 0xc2, 0xe3,
+	0xa4, 0x10, 0x7c, 0x07, 0xda, 0x69, 0xbb, 0x54, 0xd6, 0xd9, 0x25, 0xbb, 0xa1, 0x52, 0x5a, 0xf7,
+	0x00, 0xab, 0xeb, 0x16, 0x67, 0x7d, 0x43, 0xeb, 0x06, 0x94, 0x1c, 0xb6, 0x20, 0xb1, 0x6c, 0x58,
+	0xe1, 0xde, 0xe2, 0x29, 0x89, 0x1d, 0x37, 0x35, 0x7a, 0xfc, 0x19, 0x34, 0xa7, 0x02, 0x24, 0xce,
+	0x67, 0x4d, 0x93, 0xf3, 0xc4, 0x1f, 0x3a, 0x13, 0xd6, 0xcc, 0x94, 0x5b, 0x28, 0xc9, 0xe9, 0x41,
+	0x65, 0x65, 0x8a, 0x83, 0xf2, 0x61, 0x99, 0x63, 0x78, 0x72, 0x2f, 0xe7, 0xba, 0x01, 0x0b, 0xac,
+	0x3e, 0xc8, 0x69, 0xcc, 0xc8, 0x92, 0xf6, 0x02, 0x23, 0x51, 0xca, 0xc2, 0x8a, 0x28, 0x0b, 0x55,
+	0xa7, 0xa7, 0x97, 0x85, 0xd6, 0x2a, 0xf4, 0xf5, 0x09, 0x85, 0x22, 0x43, 0x58, 0xe3, 0x70, 0x25,
+	0xe3, 0x11, 0xca, 0xcc, 0xfc, 0xb8, 0xc6, 0xb3, 0xa6, 0xca, 0x7c, 0x65, 0xf3, 0x06, 0x98, 0xf9,
+	0x49, 0x84, 0x02, 0xcf, 0xe4, 0x1e, 0x65, 0x9d, 0x2b, 0xfe, 0x04, 0x5a, 0xb1, 0x84, 0x89, 0x9d,
+	0x47, 0x69, 0x6c, 0xe0, 0x70, 0x99, 0x04, 0x27, 0x84, 0xd6, 0x57, 0x72, 0x41, 0x8a, 0x3c, 0x61,
+	0x0f, 0xff, 0x37, 0x81, 0xbf, 0x82, 0xd5, 0x62, 0xef, 0x8f, 0x3f, 0x86, 0xa5, 0x84, 0xcc, 0xf6,
+	0xcf, 0x62, 0xf2, 0x58, 0x54, 0xd4, 0x1d, 0x3b, 0x8f, 0xa0, 0x97, 0x24, 0x3e, 0xf7, 0x44, 0x99,
+	0xd5, 0xb1, 0xf9, 0x80, 0x36, 0x21, 0x73, 0xd2, 0xc5, 0xce, 0x4c, 0x61, 0xbd, 0x34, 0x54, 0xd0,
+	0xa6, 0x39, 0x7f, 0xe2, 0x9e, 0xce, 0x99, 0x02, 0xf0, 0x2d, 0x68, 0x8a, 0x50, 0x72, 0x24, 0xce,
+	0x08, 0xed, 0xb2, 0xc7, 0xef, 0xbb, 0xcf, 0xe5, 0xe3, 0x77, 0x69, 0xac, 0x92, 0xce, 0x7a, 0x0f,
+	0x36, 0x8a, 0xa6, 0x13, 0xca, 0x7c, 0x0b, 0x57, 0x67, 0x84, 0x99, 0x4b, 0xd4, 0xa1, 0x1b, 0x2f,
+	0xe7, 0xbd, 0x44, 0x9f, 0x94, 0xd0, 0xba, 0x06, 0xef, 0x15, 0x4f, 0x29, 0x54, 0xfa, 0x0a, 0xd6,
+	0x4a, 0x02, 0x95, 0x3e, 0xa1, 0x31, 0xef, 0x84, 0x1b, 0x60, 0xe6, 0x05, 0x8a, 0xc9, 0x7e, 0x0b,
+	0x3a, 0x8f, 0x8f, 0x8f, 0xd2, 0x27, 0xff, 0x4a, 0xff, 0x44, 0x54, 0x3b, 0x49, 0xba, 0x54, 0x51,
+	0xd2, 0x25, 0x6b, 0x11, 0xba, 0x82, 0x4f, 0x08, 0xba, 0x0b, 0x4b, 0x8f, 0x8f, 0xb9, 0xb3, 0x4a,
+	0xa5, 0xc9, 0xa6, 0x8d, 0x91, 0x36, 0x6d, 0x94, 0x2e, 0x8b, 0xe8, 0x59, 0xf2, 0x11, 0x8d, 0x2e,
+	0xaa, 0x00, 0x21, 0x76, 0x8b, 0xea, 0xb7, 0x3f, 0x43, 0x3f, 0xeb, 0x03, 0xe8, 0x0a, 0x0a, 0x71,
+	0x1d, 0x12, 0x85, 0x0d, 0x55, 0xe1, 0x7b, 0x89, 0x7e, 0xfb, 0xb3, 0xf5, 0x33, 0xa1, 0xc1, 0x9a,
+	0x33, 0x44, 0x7e, 0x71, 0x92, 0x43, 0xfa, 0x11, 0x44, 0x15, 0x91, 0xa4, 0xaa, 0x72, 0x3d, 0x86,
+	0xba, 0x9e, 0x19, 0x72, 0xae, 0xc3, 0xe2, 0xe3, 0x63, 0x7e, 0x3b, 0xca, 0x97, 0x85, 0x01, 0xa5,
+	0x44, 0x62, 0x33, 0x76, 0xa0, 0x2f, 0x14, 0xd0, 0xb9, 0x0b, 0x96, 0x61, 0xad, 0xc1, 0x4a, 0x86,
+	0x56, 0x08, 0xf9, 0x82, 0x0a, 0x61, 0x69, 0xb9, 0x2e, 0x64, 0xce, 0x60, 0xc7, 0x05, 0x6b, 0xfc,
+	0x42, 0xf0, 0xdf, 0x19, 0xcc, 0x26, 0x86, 0x8e, 0xf7, 0xae, 0xf1, 0xb3, 0x0f, 0xf5, 0xc9, 0x78,
+	0x3a, 0x8e, 0x45, 0xe8, 0xe4, 0x03, 0x1a, 0x55, 0xd9, 0x8f, 0xfb, 0x17, 0x31, 0x6b, 0x4e, 0x53,
+	0x94, 0x02, 0xa1, 0x77, 0xf3, 0xf5, 0x38, 0x3e, 0x3d, 0x66, 0x67, 0xcd, 0x9b, 0xbe, 0x29, 0x80,
+	0x62, 0x7d, 0x6f, 0x72, 0x31, 0x60, 0x2d, 0xae, 0x05, 0x8e, 0x4d, 0x00, 0xd6, 0x9f, 0x1a, 0xd0,
+	0x93, 0xba, 0x8a, 0x73, 0x7c, 0x07, 0x5b, 0x4d, 0x7b, 0x67, 0x42, 0x61, 0x36, 0xa0, 0x53, 0xd2,
+	0x7c, 0x89, 0x6e, 0x8a, 0x6c, 0x4f, 0xa7, 0x00, 0xd6, 0xcf, 0x63, 0xd5, 0xba, 0xe7, 0x26, 0xfd,
+	0x3c, 0x31, 0xb6, 0x7e, 0x09, 0xa6, 0x38, 0xac, 0xa7, 0xe3, 0x73, 0xe2, 0x32, 0x9f, 0x20, 0x37,
+	0xf1, 0xf3, 0x5c, 0x9a, 0x23, 0x2b, 0xed, 0xc7, 0xc7, 0x39, 0xea, 0x5c, 0xef, 0xe6, 0x57, 0xb0,
+	0x5e, 0x20, 0x59, 0x2c, 0xf9, 0x6e, 0xbe, 0x1b, 0x73, 0xb5, 0x50, 0x76, 0x59, 0x67, 0xe6, 0x07,
+	0x03, 0x96, 0x0b, 0xb4, 0x60, 0x39, 0x16, 0xaf, 0xc9, 0x64, 0x88, 0x15, 0x43, 0x7c, 0x83, 0x7e,
+	0x1f, 0x8a, 0x85, 0xb3, 0x5c, 0x4e, 0x26, 0x4b, 0x7d, 0x86, 0xfc, 0xda, 0x16, 0x11, 0xea, 0xee,
+	0x16, 0x78, 0x21, 0x22, 0x1a, 0x75, 0xab, 0x09, 0xbd, 0x66, 0xba, 0x32, 0x7f, 0xe0, 0xb4, 0x78,
+	0x00, 0xed, 0x30, 0x35, 0x4f, 0xd1, 0xb4, 0x4b, 0xd7, 0x95, 0x37, 0x7d, 0x99, 0x79, 0x29, 0x5c,
+	0xd6, 0x7f, 0x18, 0xd0, 0xd7, 0x57, 0x26, 0xf6, 0xec, 0xff, 0xff, 0xd2, 0x7e, 0x1f, 0xd6, 0x1e,
+	0x1f, 0x0f, 0x7c, 0xcf, 0x1d, 0xd3, 0xe6, 0xaa, 0x33, 0x79, 0x77, 0xef, 0x4f, 0x6d, 0x99, 0x9c,
+	0x07, 0x64, 0x48, 0x0d, 0xbd, 0xca, 0x6d, 0x59, 0x8e, 0xad, 0x4f, 0xc0, 0xcc, 0x8b, 0x4f, 0x37,
+	0xcf, 0xe1, 0xdf, 0x90, 0xd9, 0x1c, 0x4d, 0x5b, 0x0e, 0x77, 0xfe, 0xa2, 0x05, 0x35, 0xb6, 0x8b,
+	0x2b, 0xb0, 0x44, 0xff, 0xda, 0x64, 0x34, 0x8e, 0x62, 0x12, 0xb2, 0x6f, 0x37, 0xe8, 0x0a, 0x5e,
+	0x87, 0x15, 0x0a, 0xce, 0x3d, 0xc0, 0x42, 0x46, 0x09, 0x2a, 0x0a, 0x50, 0x25, 0x41, 0x65, 0xdf,
+	0x13, 0xa1, 0x6a, 0x09, 0x2a, 0x0a, 0x50, 0x0d, 0x2f, 0xc3, 0x22, 0x45, 0x29, 0xef, 0x9b, 0x50,
+	0x3d, 0x07, 0x8c, 0x02, 0xb4, 0x20, 0x81, 0xca, 0x6b, 0x21, 0xd4, 0xc8, 0x01, 0xa3, 0x00, 0x35,
+	0x31, 0x86, 0x1e, 0x05, 0xa6, 0x6f, 0x7c, 0x50, 0x2b, 0x0b, 0x8b, 0x02, 0x04, 0xd8, 0x84, 0x3e,
+	0x83, 0x65, 0xde, 0xf5, 0xa0, 0x76, 0x31, 0x26, 0x0a, 0x50, 0x07, 0x5f, 0x85, 0x35, 0x8a, 0x29,
+	0x78, 0x87, 0x83, 0xba, 0xa5, 0xc8, 0x28, 0x40, 0x3d, 0xbc, 0x01, 0xab, 0x7c, 0xb3, 0xb3, 0xaf,
+	0x51, 0xd0, 0x62, 0x19, 0x2e, 0x0a, 0x10, 0x92, 0xba, 0x64, 0xdf, 0xcd, 0xa0, 0xa5, 0x62, 0x4c,
+	0x14, 0x20, 0x2c, 0x31, 0xd9, 0x67, 0x22, 0x68, 0x59, 0x6e, 0x98, 0xf2, 0x19, 0x19, 0xf5, 0xf1,
+	0x1a, 0x2c, 0xa7, 0xe4, 0xc9, 0x4b, 0x0e, 0xb4, 0x52, 0x88, 0x88, 0x02, 0xb4, 0x2a, 0x11, 0x99,
+	0xb7, 0x1f, 0x68, 0xad, 0x10, 0x11, 0x05, 0xc8, 0x94, 0x4b, 0xcc, 0x3f, 0xf6, 0x40, 0xeb, 0x65,
+	0xb8, 0x28, 0x40, 0x1b, 0x72, 0x4f, 0x0b, 0xde, 0x67, 0xa0, 0xab, 0xa5, 0xc8, 0x28, 0x40, 0xef,
+	0x49, 0xa9, 0xf9, 0xb7, 0x17, 0xe8, 0x47, 0x65, 0xb8, 0x28, 0x40, 0xd7, 0x70, 0x1f, 0x50, 0xba,
+	0x68, 0xfe, 0x60, 0x01, 0x6d, 0xe6, 0xa1, 0x51, 0x80, 0xb6, 0x24, 0x54, 0x7d, 0x22, 0x81, 0x7e,
+	0x23, 0x0f, 0x8d, 0x02, 0x64, 0xc9, 0xdb, 0xa6, 0xbd, 0x84, 0x40, 0xd7, 0x0b, 0xc0, 0x51, 0x80,
+	0xde, 0xc7, 0x9b, 0x70, 0x95, 0x99, 0x60, 0xf1, 0x43, 0x06, 0xf4, 0xc1, 0x4c, 0x82, 0x28, 0x40,
+	0x1f, 0x4a, 0x82, 0x92, 0xf7, 0x09, 0xe8, 0xa3, 0x99, 0x04, 0x51, 0x80, 0xb6, 0xe5, 0x2e, 0xe5,
+	0x1f, 0x6e, 0xa2, 0x1f, 0x97, 0xe1, 0xa2, 0x00, 0xed, 0x68, 0x5e, 0x42, 0x7d, 0x6b, 0x89, 0x6e,
+	0x94, 0xa0, 0xa2, 0x00, 0x7d, 0xbc, 0x33, 0x80, 0x45, 0x51, 0x8c, 0xcb, 0xaf, 0x67, 0xb8, 0x05,
+	0xf5, 0x63, 0x3f, 0x26, 0x21, 0xba, 0x82, 0x01, 0x16, 0x78, 0xa3, 0x02, 0x19, 0xb8, 0x03, 0xcd,
+	0x2f, 0xfd, 0xc9, 0xc4, 0x7f, 0x4d, 0x42, 0x54, 0xc1, 0x6d, 0x68, 0x3c, 0x21, 0x4e, 0xe8, 0x91,
+	0x10, 0x55, 0x77, 0xee, 0xc1, 0x52, 0xee, 0x83, 0x23, 0x5e, 0x80, 0xca, 0x81, 0x87, 0xae, 0x50,
+	0x71, 0xcf, 0xfc, 0xf8, 0xc0, 0x43, 0x06, 0x15, 0xf7, 0xe0, 0x7c, 0x1c, 0xc5, 0x11, 0xaa, 0xe0,
+	0x2e, 0xb4, 0x9e, 0xf9, 0xb1, 0x18, 0x56, 0x77, 0x6e, 0x41, 0x43, 0x34, 0x39, 0x29, 0x03, 0x8b,
+	0x48, 0xe8, 0x0a, 0x6e, 0x42, 0xcd, 0x26, 0x8e, 0x8b, 0x0c, 0x0a, 0xbc, 0xe7, 0x4e, 0xc7, 0x1e,
+	0xaa, 0xe0, 0x06, 0x54, 0x9f, 0x9f, 0x7b, 0xa8, 0xba, 0xf3, 0x3f, 0x55, 0x68, 0x1f, 0x78, 0x31,
+	0x09, 0x3d, 0x67, 0x32, 0x98, 0xba, 0xf4, 0x9a, 0x0d, 0xa6, 0xae, 0xda, 0x3d, 0x42, 0x57, 0xf0,
+	0x12, 0x74, 0x19, 0x50, 0xb6, 0x75, 0x90, 0x41, 0x0f, 0x9f, 0xce, 0xa5, 0x75, 0x62, 0x50, 0x45,
+	0x50, 0xa6, 0xbe, 0x07, 0xd5, 0x05, 0xa5, 0xde, 0x0a, 0xe0, 0x5e, 0x31, 0x01, 0xf3, 0xb2, 0x1c,
+	0x35, 0xe8, 0x25, 0x4c, 0x80, 0x69, 0xb9, 0x8c, 0x9a, 0x78, 0x15, 0x70, 0x82, 0x48, 0x8a, 0x45,
+	0xe4, 0x0a, 0x78, 0xa6, 0x88, 0x44, 0x34, 0xbd, 0x47, 0x5c, 0x63, 0x5e, 0xd2, 0xd1, 0x6a, 0x06,
+	0xbd, 0x10, 0xd4, 0x4a, 0x5d, 0xc5, 0xe0, 0x23, 0x31, 0x6d, 0xb6, 0xfc, 0x41, 0xa7, 0xb8, 0x0b,
+	0xcd, 0xc1, 0xd4, 0x65, 0xe1, 0x19, 0x7d, 0x67, 0x60, 0xcc, 0x56, 0x97, 0x16, 0x20, 0xe8, 0x1f,
+	0x8d, 0x84, 0x64, 0x9f, 0xc4, 0xe8, 0x9f, 0x32, 0x24, 0x14, 0xf6, 0xcf, 0x06, 0x46, 0xd0, 0x66,
+	0x30, 0xae, 0x26, 0xfa, 0x17, 0xba, 0x7b, 0x28, 0xa5, 0x12, 0xe0, 0x7f, 0x4d, 0xc1, 0x4a, 0x88,
+	0x46, 0xff, 0x66, 0xe0, 0x1e, 0xb4, 0xb8, 0x16, 0x43, 0xc7, 0x43, 0xff, 0x4e, 0x63, 0x59, 0x3f,
+	0xe5, 0x4e, 0xb3, 0x0f, 0xf4, 0xbd, 0x81, 0x4d, 0xb6, 0x92, 0x6c, 0x68, 0x45, 0xbf, 0x96, 0x4a,
+	0xd8, 0x24, 0x22, 0xe1, 0x2b, 0xe2, 0xa2, 0xff, 0x6e, 0xec, 0x7c, 0x06, 0x1d, 0xb5, 0x5b, 0x42,
+	0x6d, 0xe2, 0x9e, 0xeb, 0x72, 0x8b, 0xe5, 0x1e, 0x80, 0xdb, 0x0c, 0xe5, 0x89, 0x51, 0x85, 0xfe,
+	0xa4, 0x5b, 0x44, 0x8d, 0xf5, 0x10, 0x96, 0x85, 0xc5, 0x6b, 0x1f, 0x6b, 0x10, 0x74, 0xf8, 0x58,
+	0xd8, 0xc3, 0x95, 0x14, 0x62, 0x3b, 0x9e, 0xeb, 0x4f, 0xb9, 0xe1, 0x24, 0x34, 0x11, 0x79, 0xe8,
+	0x4f, 0x98, 0xe1, 0xdc, 0x47, 0xdf, 0xff, 0xd7, 0xb5, 0x2b, 0xdf, 0xbd, 0xbd, 0x66, 0x7c, 0xff,
+	0xf6, 0x9a, 0xf1, 0x9f, 0x6f, 0xaf, 0x19, 0x27, 0x0b, 0xec, 0x7f, 0x9c, 0xdf, 0xfe, 0xdf, 0x01,
+	0x00, 0x3a, 0x94, 0xc6, 0x94, 0xa4, 0x3f, 0x00, 0x00,
 }
 
 func (m *ProphetRequest) Marshal() (dAtA []byte, err error) {
@@ -7343,6 +7791,26 @@ func (m *ProphetRequest) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n20
+	dAtA[i] = 0xc2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardHeartbeats.Size()))
+	n21, err := m.ShardHeartbeats.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n21
+	dAtA[i] = 0xca
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardKeepalive.Size()))
+	n22, err := m.ShardKeepalive.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n22
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -7389,182 +7857,202 @@ func (m *ProphetResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x2a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardHeartbeat.Size()))
-	n21, err := m.ShardHeartbeat.MarshalTo(dAtA[i:])
+	n23, err := m.ShardHeartbeat.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n21
+	i += n23
 	dAtA[i] = 0x32
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreHeartbeat.Size()))
-	n22, err := m.StoreHeartbeat.MarshalTo(dAtA[i:])
+	n24, err := m.StoreHeartbeat.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n22
+	i += n24
 	dAtA[i] = 0x3a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutStore.Size()))
-	n23, err := m.PutStore.MarshalTo(dAtA[i:])
+	n25, err := m.PutStore.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n23
+	i += n25
 	dAtA[i] = 0x42
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetStore.Size()))
-	n24, err := m.GetStore.MarshalTo(dAtA[i:])
+	n26, err := m.GetStore.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n24
+	i += n26
 	dAtA[i] = 0x4a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocID.Size()))
-	n25, err := m.AllocID.MarshalTo(dAtA[i:])
+	n27, err := m.AllocID.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n25
+	i += n27
 	dAtA[i] = 0x52
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskBatchSplit.Size()))
-	n26, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
+	n28, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n26
+	i += n28
 	dAtA[i] = 0x5a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateDestroying.Size()))
-	n27, err := m.CreateDestroying.MarshalTo(dAtA[i:])
+	n29, err := m.CreateDestroying.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n27
+	i += n29
 	dAtA[i] = 0x62
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.ReportDestroyed.Size()))
-	n28, err := m.ReportDestroyed.MarshalTo(dAtA[i:])
+	n30, err := m.ReportDestroyed.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n28
+	i += n30
 	dAtA[i] = 0x6a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetDestroying.Size()))
-	n29, err := m.GetDestroying.MarshalTo(dAtA[i:])
+	n31, err := m.GetDestroying.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n29
+	i += n31
 	dAtA[i] = 0x72
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Event.Size()))
-	n30, err := m.Event.MarshalTo(dAtA[i:])
+	n32, err := m.Event.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n30
+	i += n32
 	dAtA[i] = 0x7a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateShards.Size()))
-	n31, err := m.CreateShards.MarshalTo(dAtA[i:])
+	n33, err := m.CreateShards.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n31
+	i += n33
 	dAtA[i] = 0x82
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveShards.Size()))
-	n32, err := m.RemoveShards.MarshalTo(dAtA[i:])
+	n34, err := m.RemoveShards.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n32
+	i += n34
 	dAtA[i] = 0x8a
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckShardState.Size()))
-	n33, err := m.CheckShardState.MarshalTo(dAtA[i:])
+	n35, err := m.CheckShardState.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n33
+	i += n35
 	dAtA[i] = 0x92
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutPlacementRule.Size()))
-	n34, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
+	n36, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n34
+	i += n36
 	dAtA[i] = 0x9a
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetAppliedRules.Size()))
-	n35, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
+	n37, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n35
+	i += n37
 	dAtA[i] = 0xa2
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateJob.Size()))
-	n36, err := m.CreateJob.MarshalTo(dAtA[i:])
+	n38, err := m.CreateJob.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n36
+	i += n38
 	dAtA[i] = 0xaa
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveJob.Size()))
-	n37, err := m.RemoveJob.MarshalTo(dAtA[i:])
+	n39, err := m.RemoveJob.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n37
+	i += n39
 	dAtA[i] = 0xb2
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.ExecuteJob.Size()))
-	n38, err := m.ExecuteJob.MarshalTo(dAtA[i:])
+	n40, err := m.ExecuteJob.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n38
+	i += n40
 	dAtA[i] = 0xba
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.AddScheduleGroupRule.Size()))
-	n39, err := m.AddScheduleGroupRule.MarshalTo(dAtA[i:])
+	n41, err := m.AddScheduleGroupRule.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n39
+	i += n41
 	dAtA[i] = 0xc2
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetScheduleGroupRule.Size()))
-	n40, err := m.GetScheduleGroupRule.MarshalTo(dAtA[i:])
+	n42, err := m.GetScheduleGroupRule.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n40
-	if m.XXX_unrecognized != nil {
+	i += n42
+	dAtA[i] = 0xca
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardHeartbeats.Size()))
+	n43, err := m.ShardHeartbeats.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n43
+	dAtA[i] = 0xd2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardKeepalive.Size()))
+	n44, err := m.ShardKeepalive.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n44
+	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
@@ -7605,11 +8093,11 @@ func (m *ShardHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Leader.Size()))
-		n41, err := m.Leader.MarshalTo(dAtA[i:])
+		n45, err := m.Leader.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n41
+		i += n45
 	}
 	if len(m.DownReplicas) > 0 {
 		for _, msg := range m.DownReplicas {
@@ -7638,11 +8126,11 @@ func (m *ShardHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x3a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
-	n42, err := m.Stats.MarshalTo(dAtA[i:])
+	n46, err := m.Stats.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n42
+	i += n46
 	if len(m.GroupKey) > 0 {
 		dAtA[i] = 0x42
 		i++
@@ -7653,11 +8141,35 @@ func (m *ShardHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-		n43, err := m.Lease.MarshalTo(dAtA[i:])
+		n47, err := m.Lease.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n43
+		i += n47
+	}
+	if m.StoreDiskUsage != 0 {
+		dAtA[i] = 0x51
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.StoreDiskUsage))))
+		i += 8
+	}
+	if m.StoreCPULoad != 0 {
+		dAtA[i] = 0x59
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.StoreCPULoad))))
+		i += 8
+	}
+	if m.ReadQPS != 0 {
+		dAtA[i] = 0x61
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ReadQPS))))
+		i += 8
+	}
+	if m.WriteQPS != 0 {
+		dAtA[i] = 0x69
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.WriteQPS))))
+		i += 8
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -7688,80 +8200,80 @@ func (m *ShardHeartbeatRsp) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardEpoch.Size()))
-	n44, err := m.ShardEpoch.MarshalTo(dAtA[i:])
+	n48, err := m.ShardEpoch.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n44
+	i += n48
 	if m.TargetReplica != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.TargetReplica.Size()))
-		n45, err := m.TargetReplica.MarshalTo(dAtA[i:])
+		n49, err := m.TargetReplica.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n45
+		i += n49
 	}
 	if m.ConfigChange != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ConfigChange.Size()))
-		n46, err := m.ConfigChange.MarshalTo(dAtA[i:])
+		n50, err := m.ConfigChange.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n46
+		i += n50
 	}
 	if m.TransferLeader != nil {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n47, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		n51, err := m.TransferLeader.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n47
+		i += n51
 	}
 	if m.Merge != nil {
 		dAtA[i] = 0x32
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Merge.Size()))
-		n48, err := m.Merge.MarshalTo(dAtA[i:])
+		n52, err := m.Merge.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n48
+		i += n52
 	}
 	if m.SplitShard != nil {
 		dAtA[i] = 0x3a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.SplitShard.Size()))
-		n49, err := m.SplitShard.MarshalTo(dAtA[i:])
+		n53, err := m.SplitShard.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n49
+		i += n53
 	}
 	if m.ConfigChangeV2 != nil {
 		dAtA[i] = 0x42
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ConfigChangeV2.Size()))
-		n50, err := m.ConfigChangeV2.MarshalTo(dAtA[i:])
+		n54, err := m.ConfigChangeV2.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n50
+		i += n54
 	}
 	if m.TransferLease != nil {
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLease.Size()))
-		n51, err := m.TransferLease.MarshalTo(dAtA[i:])
+		n55, err := m.TransferLease.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n51
+		i += n55
 	}
 	if m.DestroyDirectly {
 		dAtA[i] = 0x50
@@ -7779,6 +8291,143 @@ func (m *ShardHeartbeatRsp) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ShardHeartbeatsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardHeartbeatsReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Heartbeats) > 0 {
+		for _, msg := range m.Heartbeats {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ShardHeartbeatsRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardHeartbeatsRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ShardKeepaliveReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardKeepaliveReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Epoch.Size()))
+	n56, err := m.Epoch.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n56
+	if m.Term != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Term))
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Leader.Size()))
+	n57, err := m.Leader.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n57
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ShardKeepaliveRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardKeepaliveRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *PutStoreReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -7851,11 +8500,11 @@ func (m *StoreHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
-	n52, err := m.Stats.MarshalTo(dAtA[i:])
+	n58, err := m.Stats.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n52
+	i += n58
 	if len(m.Data) > 0 {
 		dAtA[i] = 0x12
 		i++
@@ -7946,11 +8595,11 @@ func (m *GetStoreRsp) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
-		n53, err := m.Stats.MarshalTo(dAtA[i:])
+		n59, err := m.Stats.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n53
+		i += n59
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8096,21 +8745,21 @@ func (m *CreateDestroyingReq) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
 	}
 	if len(m.Replicas) > 0 {
-		dAtA55 := make([]byte, len(m.Replicas)*10)
-		var j54 int
+		dAtA61 := make([]byte, len(m.Replicas)*10)
+		var j60 int
 		for _, num := range m.Replicas {
 			for num >= 1<<7 {
-				dAtA55[j54] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA61[j60] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j54++
+				j60++
 			}
-			dAtA55[j54] = uint8(num)
-			j54++
+			dAtA61[j60] = uint8(num)
+			j60++
 		}
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j54))
-		i += copy(dAtA[i:], dAtA55[:j54])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j60))
+		i += copy(dAtA[i:], dAtA61[:j60])
 	}
 	if m.RemoveData {
 		dAtA[i] = 0x20
@@ -8199,11 +8848,11 @@ func (m *GetDestroyingRsp) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Status.Size()))
-		n56, err := m.Status.MarshalTo(dAtA[i:])
+		n62, err := m.Status.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n56
+		i += n62
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8289,21 +8938,21 @@ func (m *SplitID) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.NewID))
 	}
 	if len(m.NewReplicaIDs) > 0 {
-		dAtA58 := make([]byte, len(m.NewReplicaIDs)*10)
-		var j57 int
+		dAtA64 := make([]byte, len(m.NewReplicaIDs)*10)
+		var j63 int
 		for _, num := range m.NewReplicaIDs {
 			for num >= 1<<7 {
-				dAtA58[j57] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA64[j63] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j57++
+				j63++
 			}
-			dAtA58[j57] = uint8(num)
-			j57++
+			dAtA64[j63] = uint8(num)
+			j63++
 		}
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j57))
-		i += copy(dAtA[i:], dAtA58[:j57])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j63))
+		i += copy(dAtA[i:], dAtA64[:j63])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8361,21 +9010,21 @@ func (m *CreateShardsReq) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.LeastReplicas) > 0 {
-		dAtA60 := make([]byte, len(m.LeastReplicas)*10)
-		var j59 int
+		dAtA66 := make([]byte, len(m.LeastReplicas)*10)
+		var j65 int
 		for _, num := range m.LeastReplicas {
 			for num >= 1<<7 {
-				dAtA60[j59] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA66[j65] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j59++
+				j65++
 			}
-			dAtA60[j59] = uint8(num)
-			j59++
+			dAtA66[j65] = uint8(num)
+			j65++
 		}
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j59))
-		i += copy(dAtA[i:], dAtA60[:j59])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j65))
+		i += copy(dAtA[i:], dAtA66[:j65])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8420,21 +9069,21 @@ func (m *RemoveShardsReq) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.IDs) > 0 {
-		dAtA62 := make([]byte, len(m.IDs)*10)
-		var j61 int
+		dAtA68 := make([]byte, len(m.IDs)*10)
+		var j67 int
 		for _, num := range m.IDs {
 			for num >= 1<<7 {
-				dAtA62[j61] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA68[j67] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j61++
+				j67++
 			}
-			dAtA62[j61] = uint8(num)
-			j61++
+			dAtA68[j67] = uint8(num)
+			j67++
 		}
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j61))
-		i += copy(dAtA[i:], dAtA62[:j61])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j67))
+		i += copy(dAtA[i:], dAtA68[:j67])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8541,11 +9190,11 @@ func (m *PutPlacementRuleReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Rule.Size()))
-	n63, err := m.Rule.MarshalTo(dAtA[i:])
+	n69, err := m.Rule.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n63
+	i += n69
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -8650,11 +9299,11 @@ func (m *CreateJobReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
-	n64, err := m.Job.MarshalTo(dAtA[i:])
+	n70, err := m.Job.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n64
+	i += n70
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -8700,11 +9349,11 @@ func (m *RemoveJobReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
-	n65, err := m.Job.MarshalTo(dAtA[i:])
+	n71, err := m.Job.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n65
+	i += n71
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -8750,11 +9399,11 @@ func (m *ExecuteJobReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
-	n66, err := m.Job.MarshalTo(dAtA[i:])
+	n72, err := m.Job.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n66
+	i += n72
 	if len(m.Data) > 0 {
 		dAtA[i] = 0x12
 		i++
@@ -8812,11 +9461,11 @@ func (m *AddScheduleGroupRuleReq) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Rule.Size()))
-	n67, err := m.Rule.MarshalTo(dAtA[i:])
+	n73, err := m.Rule.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n67
+	i += n73
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -8927,51 +9576,51 @@ func (m *EventNotify) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.InitEvent.Size()))
-		n68, err := m.InitEvent.MarshalTo(dAtA[i:])
+		n74, err := m.InitEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n68
+		i += n74
 	}
 	if m.ShardEvent != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardEvent.Size()))
-		n69, err := m.ShardEvent.MarshalTo(dAtA[i:])
+		n75, err := m.ShardEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n69
+		i += n75
 	}
 	if m.StoreEvent != nil {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreEvent.Size()))
-		n70, err := m.StoreEvent.MarshalTo(dAtA[i:])
+		n76, err := m.StoreEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n70
+		i += n76
 	}
 	if m.ShardStatsEvent != nil {
 		dAtA[i] = 0x32
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardStatsEvent.Size()))
-		n71, err := m.ShardStatsEvent.MarshalTo(dAtA[i:])
+		n77, err := m.ShardStatsEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n71
+		i += n77
 	}
 	if m.StoreStatsEvent != nil {
 		dAtA[i] = 0x3a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreStatsEvent.Size()))
-		n72, err := m.StoreStatsEvent.MarshalTo(dAtA[i:])
+		n78, err := m.StoreStatsEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n72
+		i += n78
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9011,21 +9660,21 @@ func (m *InitEventData) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.LeaderReplicaIDs) > 0 {
-		dAtA74 := make([]byte, len(m.LeaderReplicaIDs)*10)
-		var j73 int
+		dAtA80 := make([]byte, len(m.LeaderReplicaIDs)*10)
+		var j79 int
 		for _, num := range m.LeaderReplicaIDs {
 			for num >= 1<<7 {
-				dAtA74[j73] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA80[j79] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j73++
+				j79++
 			}
-			dAtA74[j73] = uint8(num)
-			j73++
+			dAtA80[j79] = uint8(num)
+			j79++
 		}
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j73))
-		i += copy(dAtA[i:], dAtA74[:j73])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j79))
+		i += copy(dAtA[i:], dAtA80[:j79])
 	}
 	if len(m.Leases) > 0 {
 		for _, msg := range m.Leases {
@@ -9075,11 +9724,11 @@ func (m *ShardEventData) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-		n75, err := m.Lease.MarshalTo(dAtA[i:])
+		n81, err := m.Lease.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n75
+		i += n81
 	}
 	if m.Removed {
 		dAtA[i] = 0x20
@@ -9152,11 +9801,11 @@ func (m *ConfigChange) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n76, err := m.Replica.MarshalTo(dAtA[i:])
+	n82, err := m.Replica.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n76
+	i += n82
 	if m.ChangeType != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -9186,11 +9835,11 @@ func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n77, err := m.Replica.MarshalTo(dAtA[i:])
+	n83, err := m.Replica.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n77
+	i += n83
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -9215,11 +9864,11 @@ func (m *TransferLease) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-	n78, err := m.Lease.MarshalTo(dAtA[i:])
+	n84, err := m.Lease.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n78
+	i += n84
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -9499,20 +10148,20 @@ func (m *RequestBatchHeader) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n79, err := m.Replica.MarshalTo(dAtA[i:])
+	n85, err := m.Replica.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n79
+	i += n85
 	if m.Lease != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-		n80, err := m.Lease.MarshalTo(dAtA[i:])
+		n86, err := m.Lease.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n80
+		i += n86
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9544,11 +10193,11 @@ func (m *ResponseBatchHeader) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Error.Size()))
-	n81, err := m.Error.MarshalTo(dAtA[i:])
+	n87, err := m.Error.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n81
+	i += n87
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -9573,11 +10222,11 @@ func (m *RequestBatch) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Header.Size()))
-	n82, err := m.Header.MarshalTo(dAtA[i:])
+	n88, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n82
+	i += n88
 	if len(m.Requests) > 0 {
 		for _, msg := range m.Requests {
 			dAtA[i] = 0x12
@@ -9614,11 +10263,11 @@ func (m *ResponseBatch) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Header.Size()))
-	n83, err := m.Header.MarshalTo(dAtA[i:])
+	n89, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n83
+	i += n89
 	if len(m.Responses) > 0 {
 		for _, msg := range m.Responses {
 			dAtA[i] = 0x12
@@ -9708,30 +10357,30 @@ func (m *Request) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x52
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Epoch.Size()))
-	n84, err := m.Epoch.MarshalTo(dAtA[i:])
+	n90, err := m.Epoch.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n84
+	i += n90
 	if m.Lease != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-		n85, err := m.Lease.MarshalTo(dAtA[i:])
+		n91, err := m.Lease.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n85
+		i += n91
 	}
 	if m.KeysRange != nil {
 		dAtA[i] = 0x62
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.KeysRange.Size()))
-		n86, err := m.KeysRange.MarshalTo(dAtA[i:])
+		n92, err := m.KeysRange.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n86
+		i += n92
 	}
 	if m.ReplicaSelectPolicy != 0 {
 		dAtA[i] = 0x68
@@ -9742,60 +10391,60 @@ func (m *Request) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x72
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnBatchRequest.Size()))
-		n87, err := m.TxnBatchRequest.MarshalTo(dAtA[i:])
+		n93, err := m.TxnBatchRequest.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n87
+		i += n93
 	}
 	dAtA[i] = 0x7a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.UpdateTxnRecord.Size()))
-	n88, err := m.UpdateTxnRecord.MarshalTo(dAtA[i:])
+	n94, err := m.UpdateTxnRecord.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n88
+	i += n94
 	dAtA[i] = 0x82
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.DeleteTxnRecord.Size()))
-	n89, err := m.DeleteTxnRecord.MarshalTo(dAtA[i:])
+	n95, err := m.DeleteTxnRecord.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n89
+	i += n95
 	dAtA[i] = 0x8a
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTxnWriteData.Size()))
-	n90, err := m.CommitTxnWriteData.MarshalTo(dAtA[i:])
+	n96, err := m.CommitTxnWriteData.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n90
+	i += n96
 	dAtA[i] = 0x92
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.RollbackTxnRecord.Size()))
-	n91, err := m.RollbackTxnRecord.MarshalTo(dAtA[i:])
+	n97, err := m.RollbackTxnRecord.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n91
+	i += n97
 	dAtA[i] = 0x9a
 	i++
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.CleanTxnMVCCData.Size()))
-	n92, err := m.CleanTxnMVCCData.MarshalTo(dAtA[i:])
+	n98, err := m.CleanTxnMVCCData.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n92
+	i += n98
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -9880,70 +10529,70 @@ func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x32
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Error.Size()))
-	n93, err := m.Error.MarshalTo(dAtA[i:])
+	n99, err := m.Error.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n93
+	i += n99
 	if m.TxnBatchResponse != nil {
 		dAtA[i] = 0x3a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnBatchResponse.Size()))
-		n94, err := m.TxnBatchResponse.MarshalTo(dAtA[i:])
+		n100, err := m.TxnBatchResponse.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n94
+		i += n100
 	}
 	if m.UpdateTxnRecord != nil {
 		dAtA[i] = 0x42
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.UpdateTxnRecord.Size()))
-		n95, err := m.UpdateTxnRecord.MarshalTo(dAtA[i:])
+		n101, err := m.UpdateTxnRecord.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n95
+		i += n101
 	}
 	if m.DeleteTxnRecord != nil {
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.DeleteTxnRecord.Size()))
-		n96, err := m.DeleteTxnRecord.MarshalTo(dAtA[i:])
+		n102, err := m.DeleteTxnRecord.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n96
+		i += n102
 	}
 	if m.CommitTxnWriteData != nil {
 		dAtA[i] = 0x52
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTxnWriteData.Size()))
-		n97, err := m.CommitTxnWriteData.MarshalTo(dAtA[i:])
+		n103, err := m.CommitTxnWriteData.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n97
+		i += n103
 	}
 	if m.RollbackTxnRecord != nil {
 		dAtA[i] = 0x5a
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.RollbackTxnRecord.Size()))
-		n98, err := m.RollbackTxnRecord.MarshalTo(dAtA[i:])
+		n104, err := m.RollbackTxnRecord.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n98
+		i += n104
 	}
 	if m.CleanTxnMVCCData != nil {
 		dAtA[i] = 0x62
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.CleanTxnMVCCData.Size()))
-		n99, err := m.CleanTxnMVCCData.MarshalTo(dAtA[i:])
+		n105, err := m.CleanTxnMVCCData.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n99
+		i += n105
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9974,11 +10623,21 @@ func (m *ConfigChangeRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n100, err := m.Replica.MarshalTo(dAtA[i:])
+	n106, err := m.Replica.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n100
+	i += n106
+	if m.Force {
+		dAtA[i] = 0x18
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10003,11 +10662,11 @@ func (m *ConfigChangeResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Shard.Size()))
-	n101, err := m.Shard.MarshalTo(dAtA[i:])
+	n107, err := m.Shard.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n101
+	i += n107
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10079,11 +10738,11 @@ func (m *TransferLeaderRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n102, err := m.Replica.MarshalTo(dAtA[i:])
+	n108, err := m.Replica.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n102
+	i += n108
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10251,11 +10910,11 @@ func (m *UpdateMetadataRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Metadata.Size()))
-	n103, err := m.Metadata.MarshalTo(dAtA[i:])
+	n109, err := m.Metadata.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n103
+	i += n109
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10365,11 +11024,11 @@ func (m *UpdateEpochLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-	n104, err := m.Lease.MarshalTo(dAtA[i:])
+	n110, err := m.Lease.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n104
+	i += n110
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10415,11 +11074,11 @@ func (m *UpdateTxnRecordRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnRecord.Size()))
-	n105, err := m.TxnRecord.MarshalTo(dAtA[i:])
+	n111, err := m.TxnRecord.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n105
+	i += n111
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10444,11 +11103,11 @@ func (m *UpdateTxnRecordResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnRecord.Size()))
-	n106, err := m.TxnRecord.MarshalTo(dAtA[i:])
+	n112, err := m.TxnRecord.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n106
+	i += n112
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10533,11 +11192,11 @@ func (m *CommitTxnWriteDataRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTS.Size()))
-	n107, err := m.CommitTS.MarshalTo(dAtA[i:])
+	n113, err := m.CommitTS.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n107
+	i += n113
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10589,11 +11248,11 @@ func (m *RollbackTxnWriteDataRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Timestamp.Size()))
-	n108, err := m.Timestamp.MarshalTo(dAtA[i:])
+	n114, err := m.Timestamp.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n108
+	i += n114
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10639,11 +11298,11 @@ func (m *CleanTxnMVCCDataRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Timestamp.Size()))
-	n109, err := m.Timestamp.MarshalTo(dAtA[i:])
+	n115, err := m.Timestamp.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n109
+	i += n115
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -10861,21 +11520,21 @@ func (m *KVBatchGetRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.Indexes) > 0 {
-		dAtA111 := make([]byte, len(m.Indexes)*10)
-		var j110 int
+		dAtA117 := make([]byte, len(m.Indexes)*10)
+		var j116 int
 		for _, num := range m.Indexes {
 			for num >= 1<<7 {
-				dAtA111[j110] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA117[j116] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j110++
+				j116++
 			}
-			dAtA111[j110] = uint8(num)
-			j110++
+			dAtA117[j116] = uint8(num)
+			j116++
 		}
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j110))
-		i += copy(dAtA[i:], dAtA111[:j110])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j116))
+		i += copy(dAtA[i:], dAtA117[:j116])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -10907,21 +11566,21 @@ func (m *KVBatchGetResponse) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.Indexes) > 0 {
-		dAtA113 := make([]byte, len(m.Indexes)*10)
-		var j112 int
+		dAtA119 := make([]byte, len(m.Indexes)*10)
+		var j118 int
 		for _, num := range m.Indexes {
 			for num >= 1<<7 {
-				dAtA113[j112] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA119[j118] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j112++
+				j118++
 			}
-			dAtA113[j112] = uint8(num)
-			j112++
+			dAtA119[j118] = uint8(num)
+			j118++
 		}
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j112))
-		i += copy(dAtA[i:], dAtA113[:j112])
+		i = encodeVarintRpcpb(dAtA, i, uint64(j118))
+		i += copy(dAtA[i:], dAtA119[:j118])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -11291,27 +11950,27 @@ func (m *KVMixedWriteRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Set.Size()))
-	n114, err := m.Set.MarshalTo(dAtA[i:])
+	n120, err := m.Set.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n114
+	i += n120
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Delete.Size()))
-	n115, err := m.Delete.MarshalTo(dAtA[i:])
+	n121, err := m.Delete.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n115
+	i += n121
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.RangeDelete.Size()))
-	n116, err := m.RangeDelete.MarshalTo(dAtA[i:])
+	n122, err := m.RangeDelete.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n116
+	i += n122
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -11341,27 +12000,97 @@ func (m *KVMixedWriteResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Set.Size()))
-	n117, err := m.Set.MarshalTo(dAtA[i:])
+	n123, err := m.Set.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n117
+	i += n123
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Delete.Size()))
-	n118, err := m.Delete.MarshalTo(dAtA[i:])
+	n124, err := m.Delete.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n118
+	i += n124
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.RangeDelete.Size()))
-	n119, err := m.RangeDelete.MarshalTo(dAtA[i:])
+	n125, err := m.RangeDelete.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n119
+	i += n125
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *KVConditionalSetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KVConditionalSetRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if len(m.Expected) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Expected)))
+		i += copy(dAtA[i:], m.Expected)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *KVConditionalSetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KVConditionalSetResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Applied {
+		dAtA[i] = 0x8
+		i++
+		if m.Applied {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -11432,6 +12161,10 @@ func (m *ProphetRequest) Size() (n int) {
 	n += 2 + l + sovRpcpb(uint64(l))
 	l = m.GetScheduleGroupRule.Size()
 	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ShardHeartbeats.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ShardKeepalive.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -11498,6 +12231,10 @@ func (m *ProphetResponse) Size() (n int) {
 	n += 2 + l + sovRpcpb(uint64(l))
 	l = m.GetScheduleGroupRule.Size()
 	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ShardHeartbeats.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ShardKeepalive.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -11546,6 +12283,18 @@ func (m *ShardHeartbeatReq) Size() (n int) {
 		l = m.Lease.Size()
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
+	if m.StoreDiskUsage != 0 {
+		n += 9
+	}
+	if m.StoreCPULoad != 0 {
+		n += 9
+	}
+	if m.ReadQPS != 0 {
+		n += 9
+	}
+	if m.WriteQPS != 0 {
+		n += 9
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -11600,6 +12349,76 @@ func (m *ShardHeartbeatRsp) Size() (n int) {
 	return n
 }
 
+func (m *ShardHeartbeatsReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Heartbeats) > 0 {
+		for _, e := range m.Heartbeats {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardHeartbeatsRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			l = len(s)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardKeepaliveReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ShardID))
+	}
+	l = m.Epoch.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.Term != 0 {
+		n += 1 + sovRpcpb(uint64(m.Term))
+	}
+	l = m.Leader.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardKeepaliveRsp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *PutStoreReq) Size() (n int) {
 	if m == nil {
 		return 0
@@ -12753,6 +13572,9 @@ func (m *ConfigChangeRequest) Size() (n int) {
 	}
 	l = m.Replica.Size()
 	n += 1 + l + sovRpcpb(uint64(l))
+	if m.Force {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -13513,6 +14335,45 @@ func (m *KVMixedWriteResponse) Size() (n int) {
 	return n
 }
 
+func (m *KVConditionalSetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.Expected)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVConditionalSetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Applied {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovRpcpb(x uint64) (n int) {
 	for {
 		n++
@@ -14272,6 +15133,72 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardHeartbeats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ShardHeartbeats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardKeepalive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ShardKeepalive.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -15088,6 +16015,72 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardHeartbeats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ShardHeartbeats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardKeepalive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ShardKeepalive.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -15419,6 +16412,50 @@ func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreDiskUsage", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.StoreDiskUsage = float64(math.Float64frombits(v))
+		case 11:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreCPULoad", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.StoreCPULoad = float64(math.Float64frombits(v))
+		case 12:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadQPS", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ReadQPS = float64(math.Float64frombits(v))
+		case 13:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteQPS", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.WriteQPS = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -15796,7 +16833,393 @@ func (m *ShardHeartbeatRsp) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.DestroyDirectly = bool(v != 0)
+			m.DestroyDirectly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardHeartbeatsReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardHeartbeatsReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardHeartbeatsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heartbeats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Heartbeats = append(m.Heartbeats, ShardHeartbeatReq{})
+			if err := m.Heartbeats[len(m.Heartbeats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardHeartbeatsRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardHeartbeatsRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardHeartbeatsRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardKeepaliveReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardKeepaliveReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardKeepaliveReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Epoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardKeepaliveRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardKeepaliveRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardKeepaliveRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -22819,6 +24242,26 @@ func (m *ConfigChangeRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -27104,6 +28547,236 @@ func (m *KVMixedWriteResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *KVConditionalSetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KVConditionalSetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KVConditionalSetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expected", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Expected = append(m.Expected[:0], dAtA[iNdEx:postIndex]...)
+			if m.Expected == nil {
+				m.Expected = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KVConditionalSetResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KVConditionalSetResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KVConditionalSetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Applied", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Applied = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipRpcpb(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -153,6 +153,14 @@ func (c *batch) respLargeRaftEntrySize(shardID uint64, size uint64) {
 	c.resp(rsp)
 }
 
+func (c *batch) respServerIsBusy() {
+	rsp := errorPbResp(c.getRequestID(), errorpb.Error{
+		Message:      errServerIsBusy.Error(),
+		ServerIsBusy: &errorpb.ServerIsBusy{},
+	})
+	c.resp(rsp)
+}
+
 func (c *batch) respOtherError(err error) {
 	rsp := errorOtherCMDResp(err)
 	c.resp(rsp)
@@ -199,6 +207,45 @@ func respStoreNotMatch(err error, req rpcpb.Request, cb func(rpcpb.ResponseBatch
 	cb(rsp)
 }
 
+func respServerIsBusy(err error, req rpcpb.Request, cb func(rpcpb.ResponseBatch)) {
+	rsp := errorPbResp(uuid.NewV4().Bytes(), errorpb.Error{
+		Message:      err.Error(),
+		ServerIsBusy: &errorpb.ServerIsBusy{},
+	})
+	resp := rpcpb.Response{
+		ID:  req.ID,
+		PID: req.PID,
+	}
+	rsp.Responses = append(rsp.Responses, resp)
+	cb(rsp)
+}
+
+func respRaftEntryTooLarge(shardID uint64, size uint64, req rpcpb.Request, cb func(rpcpb.ResponseBatch)) {
+	rsp := errorPbResp(uuid.NewV4().Bytes(), errorpb.Error{
+		Message:           errLargeRaftEntrySize.Error(),
+		RaftEntryTooLarge: &errorpb.RaftEntryTooLarge{ShardID: shardID, EntrySize: size},
+	})
+	resp := rpcpb.Response{
+		ID:  req.ID,
+		PID: req.PID,
+	}
+	rsp.Responses = append(rsp.Responses, resp)
+	cb(rsp)
+}
+
+func respShardQuarantined(id uint64, req rpcpb.Request, cb func(responseBatch rpcpb.ResponseBatch)) {
+	rsp := errorPbResp(uuid.NewV4().Bytes(), errorpb.Error{
+		Message:          fmt.Sprintf("shard %d is quarantined: %s", id, ErrReplicaQuarantined),
+		ShardUnavailable: &errorpb.ShardUnavailable{ShardID: id},
+	})
+	resp := rpcpb.Response{
+		ID:  req.ID,
+		PID: req.PID,
+	}
+	rsp.Responses = append(rsp.Responses, resp)
+	cb(rsp)
+}
+
 func respMissingLease(shardID, replicaID uint64, req rpcpb.Request, cb func(rpcpb.ResponseBatch)) {
 	rsp := errorPbResp(uuid.NewV4().Bytes(), errorpb.Error{
 		Message:      fmt.Sprintf("shard %d missing lease on replcia %d", shardID, replicaID),
@@ -0,0 +1,136 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+	"time"
+)
+
+// clock provides the current time to the time dependent logic of replicas,
+// e.g. the replica heartbeat timestamps, the raft tick scheduling and the
+// deadlines, so that tests can advance the time deterministically.
+type clock interface {
+	Now() time.Time
+	// NewTimer creates a timer which fires once the clock reaches d from now.
+	NewTimer(d time.Duration) clockTimer
+}
+
+// clockTimer is the timer created by a clock.
+type clockTimer interface {
+	// C returns the channel which receives the time when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing.
+	Stop()
+}
+
+var (
+	_ clock = realClock{}
+	_ clock = (*mockClock)(nil)
+)
+
+// realClock is the clock backed by the system time.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) clockTimer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+func (t realTimer) Stop() {
+	t.Timer.Stop()
+}
+
+// mockClock is a clock that only moves when advanced manually, its timers fire
+// when it is advanced past their deadlines.
+type mockClock struct {
+	sync.Mutex
+	now    time.Time
+	timers []*mockTimer
+}
+
+func newMockClock(now time.Time) *mockClock {
+	return &mockClock{now: now}
+}
+
+func (c *mockClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+	return c.now
+}
+
+func (c *mockClock) NewTimer(d time.Duration) clockTimer {
+	c.Lock()
+	defer c.Unlock()
+	t := &mockTimer{clock: c, deadline: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+		return t
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d and fires the timers reaching their
+// deadlines.
+func (c *mockClock) Advance(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.now = c.now.Add(d)
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		if c.now.Before(t.deadline) {
+			timers = append(timers, t)
+			continue
+		}
+		t.c <- c.now
+	}
+	c.timers = timers
+}
+
+func (c *mockClock) removeTimer(t *mockTimer) {
+	c.Lock()
+	defer c.Unlock()
+	for i, v := range c.timers {
+		if v == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+type mockTimer struct {
+	clock    *mockClock
+	deadline time.Time
+	c        chan time.Time
+}
+
+func (t *mockTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) Stop() {
+	t.clock.removeTimer(t)
+}
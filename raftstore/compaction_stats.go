@@ -0,0 +1,92 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+// CompactionStats is the aggregated compaction statistics of the shard
+// replicas of a group on the current store.
+type CompactionStats struct {
+	// Count is the number of log compactions.
+	Count uint64
+	// RemovedEntries is the number of raft log entries removed by the log
+	// compactions.
+	RemovedEntries uint64
+	// ReclaimedBytes is the bytes of the snapshots removed by the snapshot
+	// compactions.
+	ReclaimedBytes uint64
+}
+
+// groupCompactionStats aggregates the compaction statistics by shard group, it
+// is updated by the event workers of all replicas. A nil groupCompactionStats
+// is valid and records nothing.
+type groupCompactionStats struct {
+	sync.Mutex
+	groups map[uint64]CompactionStats
+}
+
+func newGroupCompactionStats(enabled bool) *groupCompactionStats {
+	if !enabled {
+		return nil
+	}
+	return &groupCompactionStats{
+		groups: make(map[uint64]CompactionStats),
+	}
+}
+
+func (s *groupCompactionStats) enabled() bool {
+	return s != nil
+}
+
+func (s *groupCompactionStats) addLogCompaction(group, removedEntries uint64) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	v := s.groups[group]
+	v.Count++
+	v.RemovedEntries += removedEntries
+	s.groups[group] = v
+	metric.AddGroupLogCompaction(group, removedEntries)
+}
+
+func (s *groupCompactionStats) addReclaimedBytes(group, bytes uint64) {
+	if s == nil || bytes == 0 {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	v := s.groups[group]
+	v.ReclaimedBytes += bytes
+	s.groups[group] = v
+	metric.AddGroupCompactionReclaimedBytes(group, bytes)
+}
+
+func (s *groupCompactionStats) get() map[uint64]CompactionStats {
+	values := make(map[uint64]CompactionStats)
+	if s == nil {
+		return values
+	}
+	s.Lock()
+	defer s.Unlock()
+	for group, v := range s.groups {
+		values[group] = v
+	}
+	return values
+}
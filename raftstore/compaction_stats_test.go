@@ -0,0 +1,86 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestGroupCompactionStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var disabled *groupCompactionStats
+	assert.False(t, disabled.enabled())
+	disabled.addLogCompaction(1, 10)
+	disabled.addReclaimedBytes(1, 10)
+	assert.Empty(t, disabled.get())
+
+	s := newGroupCompactionStats(true)
+	assert.True(t, s.enabled())
+	s.addLogCompaction(1, 10)
+	s.addLogCompaction(1, 5)
+	s.addLogCompaction(2, 1)
+	s.addReclaimedBytes(2, 1024)
+	s.addReclaimedBytes(3, 0)
+	assert.Equal(t, map[uint64]CompactionStats{
+		1: {Count: 2, RemovedEntries: 15},
+		2: {Count: 1, RemovedEntries: 1, ReclaimedBytes: 1024},
+	}, s.get())
+}
+
+func TestLogCompactionUpdatesGroupCompactionStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	assert.Empty(t, s.GroupCompactionStats())
+	s.compactionStats = newGroupCompactionStats(true)
+
+	newReplicaWithLogs := func(shardID, group uint64) *replica {
+		r := Replica{ID: shardID, StoreID: s.Meta().ID}
+		pr := newTestReplica(Shard{ID: shardID, Group: group, Replicas: []Replica{r}}, r, s)
+		var entries []raftpb.Entry
+		for i := uint64(1); i <= 10; i++ {
+			entries = append(entries, raftpb.Entry{Index: i, Term: 1})
+		}
+		wc := pr.logdb.NewWorkerContext()
+		defer wc.Close()
+		require.NoError(t, pr.logdb.SaveRaftState(pr.shardID, pr.replicaID,
+			raft.Ready{Entries: entries}, wc))
+		require.NoError(t, pr.lr.Append(entries))
+		return pr
+	}
+
+	pr1 := newReplicaWithLogs(1, 1)
+	pr2 := newReplicaWithLogs(2, 1)
+	pr3 := newReplicaWithLogs(3, 2)
+	require.NoError(t, pr1.doLogCompaction(5))
+	require.NoError(t, pr1.doLogCompaction(8))
+	require.NoError(t, pr2.doLogCompaction(2))
+	require.NoError(t, pr3.doLogCompaction(10))
+	// nothing compacted
+	require.NoError(t, pr3.doLogCompaction(0))
+
+	assert.Equal(t, map[uint64]CompactionStats{
+		1: {Count: 3, RemovedEntries: 10},
+		2: {Count: 1, RemovedEntries: 10},
+	}, s.GroupCompactionStats())
+}
@@ -0,0 +1,140 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// ConfigChangeRecord is a record of an applied config change of a shard.
+type ConfigChangeRecord struct {
+	// Index is the raft log index of the config change.
+	Index uint64
+	// Time is the local time when the config change was applied.
+	Time time.Time
+	// Changes is the applied config change requests, a single empty request
+	// means leaving the joint state.
+	Changes []rpcpb.ConfigChangeRequest
+}
+
+// configChangeHistory is a bounded ring buffer of the most recently applied
+// config changes of a shard. It is written by the apply routine and read by
+// the store API, so it is protected by a mutex. A nil configChangeHistory is
+// valid and records nothing.
+type configChangeHistory struct {
+	sync.Mutex
+	records []ConfigChangeRecord
+	next    int
+	full    bool
+}
+
+func newConfigChangeHistory(capacity int) *configChangeHistory {
+	if capacity <= 0 {
+		return nil
+	}
+	return &configChangeHistory{
+		records: make([]ConfigChangeRecord, capacity),
+	}
+}
+
+// add records the applied config change, the oldest record is overwritten if
+// the capacity is exceeded.
+func (h *configChangeHistory) add(record ConfigChangeRecord) {
+	if h == nil {
+		return
+	}
+	h.Lock()
+	defer h.Unlock()
+	h.records[h.next] = record
+	h.next++
+	if h.next == len(h.records) {
+		h.next = 0
+		h.full = true
+	}
+}
+
+// get returns the recorded config changes, ordered from the oldest to the
+// most recent.
+func (h *configChangeHistory) get() []ConfigChangeRecord {
+	if h == nil {
+		return nil
+	}
+	h.Lock()
+	defer h.Unlock()
+	if !h.full {
+		return append([]ConfigChangeRecord(nil), h.records[:h.next]...)
+	}
+	values := make([]ConfigChangeRecord, 0, len(h.records))
+	values = append(values, h.records[h.next:]...)
+	return append(values, h.records[:h.next]...)
+}
+
+// minConfigChangeRepeatsWindow is the min number of recently proposed config
+// changes remembered by configChangeRepeats
+const minConfigChangeRepeatsWindow = 32
+
+// configChangeRepeats remembers the fingerprints of the recently proposed
+// config changes of a shard, to detect the same config change being proposed
+// again and again. Only accessed in the event worker. A nil configChangeRepeats
+// is valid and detects nothing.
+type configChangeRepeats struct {
+	threshold    int
+	fingerprints []string
+	next         int
+}
+
+func newConfigChangeRepeats(threshold int) *configChangeRepeats {
+	if threshold <= 0 {
+		return nil
+	}
+	window := minConfigChangeRepeatsWindow
+	if threshold > window {
+		window = threshold
+	}
+	return &configChangeRepeats{
+		threshold:    threshold,
+		fingerprints: make([]string, 0, window),
+	}
+}
+
+// add records the proposed config change and returns the number of times it
+// is found among the remembered ones, and whether the threshold is reached.
+func (r *configChangeRepeats) add(req rpcpb.ConfigChangeRequest) (int, bool) {
+	if r == nil {
+		return 0, false
+	}
+	fp := configChangeFingerprint(req)
+	if len(r.fingerprints) < cap(r.fingerprints) {
+		r.fingerprints = append(r.fingerprints, fp)
+	} else {
+		r.fingerprints[r.next] = fp
+		r.next = (r.next + 1) % len(r.fingerprints)
+	}
+	n := 0
+	for _, v := range r.fingerprints {
+		if v == fp {
+			n++
+		}
+	}
+	return n, n >= r.threshold
+}
+
+func configChangeFingerprint(req rpcpb.ConfigChangeRequest) string {
+	return fmt.Sprintf("%s/%d/%d/%s", req.ChangeType,
+		req.Replica.ID, req.Replica.StoreID, req.Replica.Role)
+}
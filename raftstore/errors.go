@@ -33,6 +33,8 @@ var (
 	errLargeRaftEntrySize = errors.New("raft entry is too large")
 	errKeyNotInShard      = errors.New("key not in shard")
 	errStoreNotMatch      = errors.New("store not match")
+	errServerIsBusy       = errors.New("server is busy")
+	errStoreDestroyed     = errors.New("store is physically destroyed")
 
 	infoStaleCMD  = new(errorpb.StaleCommand)
 	storeMismatch = new(errorpb.StoreMismatch)
@@ -41,6 +43,36 @@ var (
 	ErrTimeout = errors.New("exec timeout")
 	// ErrKeysNotInShard keys not in shard, request data needs to be split
 	ErrKeysNotInShard = errors.New("keys not in shard, request data needs to be split")
+	// ErrReplicaStopped the replica is stopped and can not accept requests
+	ErrReplicaStopped = errors.New("replica stopped")
+	// ErrRequestQueueFull too many pending requests of the shard, the request
+	// can be retried later
+	ErrRequestQueueFull = errors.New("request queue is full")
+	// ErrActionQueueFull too many pending actions of the shard, the action is
+	// dropped
+	ErrActionQueueFull = errors.New("action queue is full")
+	// ErrFreezeTimeout the replica did not get frozen in time
+	ErrFreezeTimeout = errors.New("freeze timeout")
+	// ErrFreezeLost the frozen replica applied entries before it was unfrozen,
+	// e.g. the max freeze duration was exceeded
+	ErrFreezeLost = errors.New("freeze lost")
+	// ErrStaleReadNotAllowed the stale read is not served, stale reads are only
+	// allowed on the removed but not yet destroyed replicas
+	ErrStaleReadNotAllowed = errors.New("stale read not allowed")
+	// ErrRateLimited the request bytes of the shard exceed the rate limit, the
+	// request can be retried later
+	ErrRateLimited = errors.New("request rate limited")
+	// ErrReplicaQuarantined the replica failed to apply a committed log too many
+	// times and stopped serving requests, see Raft.MaxApplyFailures
+	ErrReplicaQuarantined = errors.New("replica quarantined")
+	// ErrRequestTooLarge the request is larger than the max raft entry size, the
+	// request will never succeed
+	ErrRequestTooLarge = errors.New("request is too large")
+	// ErrStoreStarted the operation is only allowed before the store is started
+	ErrStoreStarted = errors.New("store already started")
+	// ErrDataStorageRegistered the data storage of the shard group is already
+	// registered
+	ErrDataStorageRegistered = errors.New("data storage of the group already registered")
 )
 
 type ShardLeaseMismatchErr struct {
@@ -34,6 +34,7 @@ type writeContext struct {
 	buf          *buf.ByteBuf
 	batch        storage.Batch
 	responses    [][]byte
+	errors       []error
 	writtenBytes uint64
 	diffBytes    int64
 }
@@ -73,6 +74,23 @@ func (ctx *writeContext) Batch() storage.Batch {
 
 func (ctx *writeContext) AppendResponse(resp []byte) {
 	ctx.responses = append(ctx.responses, resp)
+	ctx.errors = append(ctx.errors, nil)
+}
+
+func (ctx *writeContext) AppendError(err error) {
+	ctx.responses = append(ctx.responses, nil)
+	ctx.errors = append(ctx.errors, err)
+}
+
+// failUnapplied sets the specified error as the result of all requests in the
+// batch without an appended response or error.
+func (ctx *writeContext) failUnapplied(err error) int {
+	failed := 0
+	for len(ctx.errors) < len(ctx.batch.Requests) {
+		ctx.AppendError(err)
+		failed++
+	}
+	return failed
 }
 
 func (ctx *writeContext) SetWrittenBytes(value uint64) {
@@ -88,6 +106,7 @@ func (ctx *writeContext) initialize(shard Shard, index uint64) {
 	ctx.shard = shard
 	ctx.batch = storage.Batch{Index: index}
 	ctx.responses = ctx.responses[:0]
+	ctx.errors = ctx.errors[:0]
 	ctx.writtenBytes = 0
 	ctx.diffBytes = 0
 }
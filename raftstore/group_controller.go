@@ -20,6 +20,14 @@ import (
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 )
 
+// shardGroupKeyGetter returns the group key of the shard used by the prophet
+// scheduler to bucket shards.
+type shardGroupKeyGetter interface {
+	getShardGroupKey(shard Shard) string
+}
+
+var _ shardGroupKeyGetter = (*replicaGroupController)(nil)
+
 type replicaGroupController struct {
 	sync.RWMutex
 	rules map[uint64][]metapb.ScheduleGroupRule
@@ -47,3 +55,36 @@ func (rc *replicaGroupController) getShardGroupKey(shard Shard) string {
 	defer rc.RUnlock()
 	return util.EncodeGroupKey(shard.Group, rc.rules[shard.Group], shard.Labels)
 }
+
+// customShardGroupKeyGetter returns the group key of the shard with the
+// user-defined func, the default getter is used if the func returns an empty
+// key.
+type customShardGroupKeyGetter struct {
+	fn            func(shard Shard) string
+	defaultGetter shardGroupKeyGetter
+}
+
+func newCustomShardGroupKeyGetter(fn func(shard Shard) string,
+	defaultGetter shardGroupKeyGetter) shardGroupKeyGetter {
+	if fn == nil {
+		return defaultGetter
+	}
+	return customShardGroupKeyGetter{fn: fn, defaultGetter: defaultGetter}
+}
+
+func (g customShardGroupKeyGetter) getShardGroupKey(shard Shard) string {
+	if key := g.fn(shard); key != "" {
+		return key
+	}
+	return g.defaultGetter.getShardGroupKey(shard)
+}
+
+// isValidGroupKey returns true if the group key starts with the encoded group.
+func isValidGroupKey(key string, group uint64) bool {
+	return len(key) >= 8 && util.DecodeGroupKey(key) == group
+}
+
+// getFallbackGroupKey returns the group key without any schedule group rules.
+func getFallbackGroupKey(shard Shard) string {
+	return util.EncodeGroupKey(shard.Group, nil, nil)
+}
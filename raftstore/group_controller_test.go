@@ -20,6 +20,8 @@ import (
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestSetRules(t *testing.T) {
@@ -47,3 +49,29 @@ func TestGetShardGroupKey(t *testing.T) {
 	shard := Shard{Labels: []metapb.Label{{Key: "l1", Value: "v1"}}}
 	assert.Equal(t, util.EncodeGroupKey(0, rules, shard.Labels), gc.getShardGroupKey(shard))
 }
+
+type testShardGroupKeyGetter struct {
+	key string
+}
+
+func (g testShardGroupKeyGetter) getShardGroupKey(shard Shard) string {
+	return g.key
+}
+
+func TestInvalidShardGroupKeyFallback(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	core, logs := observer.New(zap.WarnLevel)
+	shard := Shard{Group: 1}
+	pr := &replica{logger: zap.New(core)}
+
+	pr.groupController = testShardGroupKeyGetter{key: util.EncodeGroupKey(1, nil, nil)}
+	assert.Equal(t, util.EncodeGroupKey(1, nil, nil), pr.getShardGroupKey(shard))
+	assert.Equal(t, 0, logs.Len())
+
+	for _, key := range []string{"", "invalid", util.EncodeGroupKey(2, nil, nil)} {
+		pr.groupController = testShardGroupKeyGetter{key: key}
+		assert.Equal(t, getFallbackGroupKey(shard), pr.getShardGroupKey(shard))
+	}
+	assert.Equal(t, 3, logs.FilterMessage("invalid shard group key, use the fallback key").Len())
+}
@@ -3,6 +3,7 @@ package raftstore
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/config"
@@ -10,6 +11,7 @@ import (
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLeaseUpdate(t *testing.T) {
@@ -26,9 +28,22 @@ func TestLeaseUpdate(t *testing.T) {
 
 	c.WaitLeadersByCount(1, testWaitTimeout)
 	shard := c.GetShardByIndex(0, 0)
-	store := c.GetShardLeaderStore(shard.ID)
-	leaderReplicaID := findReplica(shard, store.Meta().ID).ID
+	leaderStore := c.GetShardLeaderStore(shard.ID)
+	leaderReplicaID := findReplica(shard, leaderStore.Meta().ID).ID
 	c.WaitShardLeaseChangedTo(shard.ID, &metapb.EpochLease{Epoch: 1, ReplicaID: leaderReplicaID}, testWaitTimeout)
+
+	// the lease holder is the current leader
+	c.EveryStore(func(i int, s Store) {
+		pr := s.(*store).getReplica(shard.ID, false)
+		require.NotNil(t, pr)
+		id, _ := pr.LeaseHolder()
+		assert.Equal(t, leaderReplicaID, id)
+	})
+	pr := leaderStore.(*store).getReplica(shard.ID, false)
+	assert.Eventually(t, func() bool {
+		_, valid := pr.LeaseHolder()
+		return valid
+	}, testWaitTimeout, time.Millisecond*10)
 }
 
 func TestLeaseUpdateWithRestart(t *testing.T) {
@@ -14,13 +14,18 @@
 package raftstore
 
 import (
+	"context"
+	"fmt"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestScheduleReplicasWithRules(t *testing.T) {
@@ -58,3 +63,293 @@ func TestScheduleReplicasWithRules(t *testing.T) {
 	assert.NoError(t, err)
 	c.WaitShardByCounts([]int{2, 2, 1}, testWaitTimeout)
 }
+
+func TestAddLearnerAndRemoveReplica(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, DisableScheduleTestCluster,
+		WithAppendTestClusterAdjustConfigFunc(func(i int, cfg *config.Config) {
+			cfg.Prophet.Replication.MaxReplicas = 1
+		}))
+	c.Start()
+	defer c.Stop()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	var leader *store
+	var shard Shard
+	c.EveryStore(func(i int, s Store) {
+		s.(*store).forEachReplica(func(pr *replica) bool {
+			if pr.isLeader() {
+				leader = s.(*store)
+				shard = pr.getShard()
+			}
+			return true
+		})
+	})
+	require.NotNil(t, leader)
+
+	var other *store
+	c.EveryStore(func(i int, s Store) {
+		if s.Meta().ID != leader.Meta().ID {
+			other = s.(*store)
+		}
+	})
+	target := Replica{ID: leader.MustAllocID(), StoreID: other.Meta().ID}
+	assert.Equal(t, errShardNotFound, other.AddLearner(shard.ID, target))
+
+	hasReplica := func(role metapb.ReplicaRole) bool {
+		pr := leader.getReplica(shard.ID, false)
+		r := findReplica(pr.getShard(), target.StoreID)
+		return r != nil && r.ID == target.ID && r.Role == role
+	}
+
+	require.NoError(t, leader.AddLearner(shard.ID, target))
+	timeoutC := time.After(testWaitTimeout)
+	for !hasReplica(metapb.ReplicaRole_Learner) {
+		select {
+		case <-timeoutC:
+			assert.FailNow(t, "wait learner added timeout")
+		default:
+			time.Sleep(time.Millisecond * 10)
+		}
+	}
+
+	require.NoError(t, leader.RemoveReplica(shard.ID, target))
+	timeoutC = time.After(testWaitTimeout)
+	for hasReplica(metapb.ReplicaRole_Learner) {
+		select {
+		case <-timeoutC:
+			assert.FailNow(t, "wait learner removed timeout")
+		default:
+			time.Sleep(time.Millisecond * 10)
+		}
+	}
+}
+
+func TestDemoteVoter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t, DisableScheduleTestCluster,
+		WithAppendTestClusterAdjustConfigFunc(func(i int, cfg *config.Config) {
+			cfg.Prophet.Replication.MaxReplicas = 2
+			// the replica checker must not race with the config changes of the
+			// test, e.g. promote the demoted learner again
+			cfg.Prophet.Schedule.PatrolShardInterval.Duration = time.Hour
+		}))
+	c.Start()
+	defer c.Stop()
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	var leader *store
+	var shard Shard
+	c.EveryStore(func(i int, s Store) {
+		s.(*store).forEachReplica(func(pr *replica) bool {
+			if pr.isLeader() {
+				leader = s.(*store)
+				shard = pr.getShard()
+			}
+			return true
+		})
+	})
+	require.NotNil(t, leader)
+
+	var other *store
+	c.EveryStore(func(i int, s Store) {
+		if s.Meta().ID != leader.Meta().ID {
+			other = s.(*store)
+		}
+	})
+	target := Replica{ID: leader.MustAllocID(), StoreID: other.Meta().ID}
+	leaderReplica := findReplica(shard, leader.Meta().ID)
+	require.NotNil(t, leaderReplica)
+	assert.Equal(t, ErrNoVoterLeft, leader.DemoteVoter(shard.ID, *leaderReplica))
+	assert.Equal(t, ErrInvalidConfigChangeRequest, leader.DemoteVoter(shard.ID, target))
+
+	waitRole := func(role metapb.ReplicaRole) {
+		timeoutC := time.After(testWaitTimeout)
+		for {
+			pr := leader.getReplica(shard.ID, false)
+			r := findReplica(pr.getShard(), target.StoreID)
+			if r != nil && r.ID == target.ID && r.Role == role {
+				return
+			}
+			select {
+			case <-timeoutC:
+				assert.FailNow(t, "wait replica role timeout", "role %s", role.String())
+			default:
+				time.Sleep(time.Millisecond * 10)
+			}
+		}
+	}
+
+	// add a voter
+	require.NoError(t, leader.AddLearner(shard.ID, target))
+	waitRole(metapb.ReplicaRole_Learner)
+	require.NoError(t, leader.addConfigChange(shard.ID, rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_AddNode,
+		Replica:    Replica{ID: target.ID, StoreID: target.StoreID, Role: metapb.ReplicaRole_Voter},
+	}))
+	waitRole(metapb.ReplicaRole_Voter)
+
+	rolesC := make(chan metapb.ReplicaRole, 4)
+	leader.OnConfigChange(func(shardID uint64, result ConfigChangeResult) {
+		if r := findReplica(result.Shard, target.StoreID); r != nil {
+			select {
+			case rolesC <- r.Role:
+			default:
+			}
+		}
+	})
+	require.NoError(t, leader.DemoteVoter(shard.ID, target))
+	for _, role := range []metapb.ReplicaRole{metapb.ReplicaRole_DemotingVoter, metapb.ReplicaRole_Learner} {
+		select {
+		case v := <-rolesC:
+			assert.Equal(t, role, v)
+		case <-time.After(testWaitTimeout):
+			assert.FailNow(t, "wait config change timeout")
+		}
+	}
+	waitRole(metapb.ReplicaRole_Learner)
+}
+
+func TestShardProgress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+	shard := c.GetShardByIndex(0, 0)
+	leader := c.GetShardLeaderStore(shard.ID)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, kv.Set(fmt.Sprintf("k%d", i), "v", testWaitTimeout))
+	}
+
+	// the followers do not track the progress
+	c.EveryStore(func(i int, s Store) {
+		if s.Meta().ID != leader.Meta().ID {
+			_, ok := s.ShardProgress(context.Background(), shard.ID)
+			assert.False(t, ok)
+		}
+	})
+
+	// the match index of every replica catches up with its applied index
+	assert.Eventually(t, func() bool {
+		progress, ok := leader.ShardProgress(context.Background(), shard.ID)
+		if !ok || len(progress) != len(shard.Replicas) {
+			return false
+		}
+		matched := true
+		c.EveryStore(func(i int, s Store) {
+			pr := s.(*store).getReplica(shard.ID, false)
+			applied, _ := pr.sm.getAppliedIndexTerm()
+			p, ok := progress[pr.replicaID]
+			if !ok || p.Match != applied || p.Next != applied+1 ||
+				p.State != "StateReplicate" {
+				matched = false
+			}
+		})
+		return matched
+	}, testWaitTimeout, time.Millisecond*100)
+}
+
+func TestLeaderChangeNotification(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t)
+
+	type leaderChange struct {
+		leaderID uint64
+		term     uint64
+	}
+	var mu sync.Mutex
+	changes := make(map[int][]leaderChange)
+	c.EveryStore(func(i int, s Store) {
+		s.OnLeaderChange(func(shardID uint64, newLeaderID uint64, term uint64) {
+			mu.Lock()
+			defer mu.Unlock()
+			changes[i] = append(changes[i], leaderChange{newLeaderID, term})
+		})
+	})
+	// waitLeader waits until every store observed the leader, and returns the
+	// term in which it is observed
+	waitLeader := func(leaderID uint64, retry func()) uint64 {
+		var term uint64
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			terms := make(map[uint64]struct{})
+			for i := 0; i < 3; i++ {
+				for _, change := range changes[i] {
+					if change.leaderID == leaderID {
+						terms[change.term] = struct{}{}
+						term = change.term
+					}
+				}
+			}
+			if len(terms) == 1 && len(changes) == 3 {
+				for i := 0; i < 3; i++ {
+					if changes[i][len(changes[i])-1].leaderID != leaderID {
+						return false
+					}
+				}
+				return true
+			}
+			if retry != nil {
+				retry()
+			}
+			return false
+		}, testWaitTimeout, time.Millisecond*100)
+		return term
+	}
+
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+	shard := c.GetShardByIndex(0, 0)
+	leader := c.GetShardLeaderStore(shard.ID)
+	leaderReplica := findReplica(shard, leader.Meta().ID)
+	require.NotNil(t, leaderReplica)
+	term := waitLeader(leaderReplica.ID, nil)
+	assert.True(t, term > 0)
+
+	// transfer the leadership to trigger an election, the change is observed by
+	// all the replicas including the old leader
+	var target Replica
+	for _, r := range shard.Replicas {
+		if r.ID != leaderReplica.ID {
+			target = r
+			break
+		}
+	}
+	pr := leader.(*store).getReplica(shard.ID, false)
+	require.NotNil(t, pr)
+	newTerm := waitLeader(target.ID, func() {
+		_ = pr.tryAddAdminRequest(rpcpb.CmdTransferLeader,
+			&rpcpb.TransferLeaderRequest{Replica: target})
+	})
+	assert.True(t, newTerm > term)
+}
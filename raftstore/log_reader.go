@@ -339,6 +339,15 @@ func (lr *LogReader) SetRange(firstIndex uint64, length uint64) {
 	}
 }
 
+// resetRange resets the LogReader to the entries between (markerIndex, markerIndex+length).
+func (lr *LogReader) resetRange(markerIndex, markerTerm, length uint64) {
+	lr.Lock()
+	defer lr.Unlock()
+	lr.markerIndex = markerIndex
+	lr.markerTerm = markerTerm
+	lr.length = length
+}
+
 // SetState sets the persistent state.
 func (lr *LogReader) SetState(s pb.HardState) {
 	lr.Lock()
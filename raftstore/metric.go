@@ -35,6 +35,13 @@ type localMetrics struct {
 	admin   raftAdminMetrics
 }
 
+func (m *localMetrics) incBy(by localMetrics) {
+	m.ready.incBy(by.ready)
+	m.message.incBy(by.message)
+	m.propose.incBy(by.propose)
+	m.admin.incBy(by.admin)
+}
+
 func (m *localMetrics) flush() {
 	m.ready.flush()
 	m.message.flush()
@@ -49,6 +56,13 @@ type raftReadyMetrics struct {
 	snapshort uint64
 }
 
+func (m *raftReadyMetrics) incBy(by raftReadyMetrics) {
+	m.message += by.message
+	m.commit += by.commit
+	m.append += by.append
+	m.snapshort += by.snapshort
+}
+
 func (m *raftReadyMetrics) flush() {
 	if m.message > 0 {
 		metric.AddRaftReadySendCount(m.message)
@@ -82,6 +96,17 @@ type raftMessageMetrics struct {
 	transferLeader uint64
 }
 
+func (m *raftMessageMetrics) incBy(by raftMessageMetrics) {
+	m.append += by.append
+	m.appendResp += by.appendResp
+	m.vote += by.vote
+	m.voteResp += by.voteResp
+	m.snapshot += by.snapshot
+	m.heartbeat += by.heartbeat
+	m.heartbeatResp += by.heartbeatResp
+	m.transferLeader += by.transferLeader
+}
+
 func (m *raftMessageMetrics) flush() {
 	if m.append > 0 {
 		metric.AddRaftAppendMsgsCount(m.append)
@@ -132,6 +157,14 @@ type raftProposeMetrics struct {
 	confChange     uint64
 }
 
+func (m *raftProposeMetrics) incBy(by raftProposeMetrics) {
+	m.readLocal += by.readLocal
+	m.readIndex += by.readIndex
+	m.normal += by.normal
+	m.transferLeader += by.transferLeader
+	m.confChange += by.confChange
+}
+
 func (m *raftProposeMetrics) flush() {
 	if m.readLocal > 0 {
 		metric.AddRaftProposalReadLocalCount(m.readLocal)
@@ -170,6 +203,8 @@ type raftAdminMetrics struct {
 	removePeerSucceed uint64
 	splitSucceed      uint64
 	compactSucceed    uint64
+	// removed replicas on the decommissioning stores
+	decommissionReplicaRemoved uint64
 }
 
 func (m *raftAdminMetrics) incBy(by raftAdminMetrics) {
@@ -183,6 +218,7 @@ func (m *raftAdminMetrics) incBy(by raftAdminMetrics) {
 	m.compact += by.compact
 	m.compactSucceed += by.compactSucceed
 	m.updateMetadata += by.updateMetadata
+	m.decommissionReplicaRemoved += by.decommissionReplicaRemoved
 }
 
 func (m *raftAdminMetrics) flush() {
@@ -216,4 +252,9 @@ func (m *raftAdminMetrics) flush() {
 		metric.AddRaftAdminCommandCompactSucceedCount(m.compactSucceed)
 		m.compactSucceed = 0
 	}
+
+	if m.decommissionReplicaRemoved > 0 {
+		metric.AddDecommissionReplicaRemoved(m.decommissionReplicaRemoved)
+		m.decommissionReplicaRemoved = 0
+	}
 }
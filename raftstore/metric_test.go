@@ -0,0 +1,64 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+// getMetric returns the registered metric with the full name and the label
+// values, nil if there is no such metric.
+func getMetric(t *testing.T, name string, labels map[string]string) *dto.Metric {
+	families, err := metric.Gatherer().Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	OUTER:
+		for _, m := range family.GetMetric() {
+			matched := 0
+			for _, label := range m.GetLabel() {
+				if v, ok := labels[label.GetName()]; ok {
+					if v != label.GetValue() {
+						continue OUTER
+					}
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+// getMetricValue returns the value of the registered gauge or counter with the
+// full name and the label values, false if there is no such metric.
+func getMetricValue(t *testing.T, name string, labels map[string]string) (float64, bool) {
+	m := getMetric(t, name, labels)
+	if m == nil {
+		return 0, false
+	}
+	if m.GetGauge() != nil {
+		return m.GetGauge().GetValue(), true
+	}
+	return m.GetCounter().GetValue(), true
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lni/goutils/syncutil"
+)
+
+// metricsAggregator accumulates the local metrics of all replicas of the store
+// and flushes them to the metric backend every interval, so the replicas do
+// not write the metric backend on every tick. The deltas accumulated after the
+// last flush are flushed when the aggregator is closed. A nil metricsAggregator
+// flushes the local metrics immediately.
+type metricsAggregator struct {
+	interval time.Duration
+	stopper  *syncutil.Stopper
+
+	mu struct {
+		sync.Mutex
+		metrics localMetrics
+	}
+}
+
+func newMetricsAggregator(interval time.Duration) *metricsAggregator {
+	return &metricsAggregator{
+		interval: interval,
+		stopper:  syncutil.NewStopper(),
+	}
+}
+
+func (a *metricsAggregator) start() {
+	a.stopper.RunWorker(func() {
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-a.stopper.ShouldStop():
+				return
+			case <-ticker.C:
+				a.flush()
+			}
+		}
+	})
+}
+
+// close stops the worker and flushes the remaining deltas, it must be called
+// after all replicas are shutdown.
+func (a *metricsAggregator) close() {
+	a.stopper.Stop()
+	a.flush()
+}
+
+// add accumulates the deltas of the replica local metrics, which are reset.
+func (a *metricsAggregator) add(m *localMetrics) {
+	if a == nil {
+		m.flush()
+		return
+	}
+
+	a.mu.Lock()
+	a.mu.metrics.incBy(*m)
+	a.mu.Unlock()
+	*m = localMetrics{}
+}
+
+func (a *metricsAggregator) flush() {
+	a.mu.Lock()
+	m := a.mu.metrics
+	a.mu.metrics = localMetrics{}
+	a.mu.Unlock()
+	m.flush()
+}
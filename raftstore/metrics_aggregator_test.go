@@ -0,0 +1,81 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestMetricsAggregator(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	var replicas []*replica
+	for id := uint64(1); id <= 100; id++ {
+		r := Replica{ID: id + 100}
+		replicas = append(replicas, newTestReplica(Shard{ID: id, Replicas: []Replica{r}}, r, s))
+	}
+
+	var expected localMetrics
+	var wg sync.WaitGroup
+	for i, pr := range replicas {
+		n := uint64(i + 1)
+		pr.metrics.ready.commit = n
+		pr.metrics.message.heartbeat = 2 * n
+		pr.metrics.propose.normal = 3 * n
+		pr.metrics.admin.split = n % 3
+		expected.incBy(pr.metrics)
+
+		wg.Add(1)
+		go func(pr *replica) {
+			defer wg.Done()
+			// flushed when there is no tick to handle
+			pr.handleTick(nil)
+		}(pr)
+	}
+	wg.Wait()
+
+	assert.Equal(t, expected, s.metricsAggregator.mu.metrics)
+	for _, pr := range replicas {
+		assert.Equal(t, localMetrics{}, pr.metrics)
+	}
+
+	s.metricsAggregator.flush()
+	assert.Equal(t, localMetrics{}, s.metricsAggregator.mu.metrics)
+}
+
+func TestMetricsAggregatorFlushesOnClose(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	a := newMetricsAggregator(time.Hour)
+	a.start()
+	a.add(&localMetrics{ready: raftReadyMetrics{commit: 1}})
+	assert.Equal(t, uint64(1), a.mu.metrics.ready.commit)
+	a.close()
+	assert.Equal(t, localMetrics{}, a.mu.metrics)
+
+	// the metrics are flushed directly without an aggregator
+	var nilAggregator *metricsAggregator
+	m := localMetrics{ready: raftReadyMetrics{commit: 1}}
+	nilAggregator.add(&m)
+	assert.Equal(t, localMetrics{}, m)
+}
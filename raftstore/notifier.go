@@ -0,0 +1,147 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+
+	"github.com/lni/goutils/syncutil"
+
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+const (
+	// maxPendingNotifications is the max number of events waiting for the
+	// observers in a notifier
+	maxPendingNotifications = 1024
+)
+
+// ConfigChangeResult is the result of an applied config change.
+type ConfigChangeResult struct {
+	// Shard is the shard metadata after the config change applied.
+	Shard Shard
+	// Changes is the applied config change requests.
+	Changes []rpcpb.ConfigChangeRequest
+}
+
+// ConfigChangeObserver is the callback invoked after a config change of a
+// shard is applied.
+type ConfigChangeObserver func(shardID uint64, result ConfigChangeResult)
+
+// ShardUnavailableObserver is the callback invoked when a shard is found
+// unavailable by its leader on the current store.
+type ShardUnavailableObserver func(shardID uint64)
+
+// LeaderChangeObserver is the callback invoked when a shard replica observes a
+// new leader of the shard. newLeaderID is the replica ID of the new leader, 0
+// if the leader is unknown, e.g. during an election. term is the raft term in
+// which the change is observed.
+type LeaderChangeObserver func(shardID uint64, newLeaderID uint64, term uint64)
+
+type configChangeEvent struct {
+	shardID uint64
+	result  ConfigChangeResult
+}
+
+type leaderChangeEvent struct {
+	shardID  uint64
+	leaderID uint64
+	term     uint64
+}
+
+// notifier invokes the registered observers of the store events in a dedicated
+// worker, the events are added by the event workers of the replicas, which must
+// not wait for slow observers. At most maxPending events are queued, the oldest
+// event is dropped to make room for a new one once the queue is full.
+type notifier struct {
+	name       string
+	maxPending int
+	stopper    *syncutil.Stopper
+	notifyC    chan struct{}
+
+	mu struct {
+		sync.Mutex
+		observers []func(event interface{})
+		pending   []interface{}
+	}
+}
+
+func newNotifier(name string, maxPending int) *notifier {
+	return &notifier{
+		name:       name,
+		maxPending: maxPending,
+		stopper:    syncutil.NewStopper(),
+		notifyC:    make(chan struct{}, 1),
+	}
+}
+
+func (n *notifier) start() {
+	n.stopper.RunWorker(func() {
+		for {
+			select {
+			case <-n.stopper.ShouldStop():
+				return
+			case <-n.notifyC:
+				n.notify()
+			}
+		}
+	})
+}
+
+func (n *notifier) close() {
+	n.stopper.Stop()
+}
+
+func (n *notifier) addObserver(observer func(event interface{})) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.mu.observers = append(n.mu.observers, observer)
+}
+
+// addEvent adds an event to be passed to all observers, it is skipped if there
+// is no observer.
+func (n *notifier) addEvent(event interface{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.mu.observers) == 0 {
+		return
+	}
+	if len(n.mu.pending) >= n.maxPending {
+		n.mu.pending = n.mu.pending[1:]
+		metric.IncDroppedNotification(n.name)
+	}
+	n.mu.pending = append(n.mu.pending, event)
+	select {
+	case n.notifyC <- struct{}{}:
+	default:
+	}
+}
+
+func (n *notifier) getEvents() ([]interface{}, []func(event interface{})) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	events := n.mu.pending
+	n.mu.pending = nil
+	return events, n.mu.observers
+}
+
+func (n *notifier) notify() {
+	events, observers := n.getEvents()
+	for _, e := range events {
+		for _, observer := range observers {
+			observer(e)
+		}
+	}
+}
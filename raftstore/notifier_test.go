@@ -0,0 +1,41 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifierDropsOldestEventsOnceFull(t *testing.T) {
+	n := newNotifier("test-bounded", 2)
+	// skipped without any observer
+	n.addEvent(uint64(1))
+	events, _ := n.getEvents()
+	assert.Empty(t, events)
+
+	var notified []interface{}
+	n.addObserver(func(event interface{}) {
+		notified = append(notified, event)
+	})
+	for i := uint64(1); i <= 4; i++ {
+		n.addEvent(i)
+	}
+	n.notify()
+	assert.Equal(t, []interface{}{uint64(3), uint64(4)}, notified)
+	v, _ := getMetricValue(t, "matrixcube_raftstore_notification_dropped_total",
+		map[string]string{"type": "test-bounded"})
+	assert.Equal(t, float64(2), v)
+}
@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"bytes"
+	"sync/atomic"
 
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 )
@@ -22,6 +23,8 @@ import (
 type pendingProposals struct {
 	cmds          []batch
 	confChangeCmd batch
+	// size the thread safe copy of the number of pending proposals, see len
+	size int64
 }
 
 func newPendingProposals() *pendingProposals {
@@ -37,6 +40,7 @@ func (p *pendingProposals) close() {
 	p.confChangeCmd.notifyShardRemoved()
 	p.confChangeCmd = emptyCMD
 	p.cmds = p.cmds[:0]
+	p.updateSize()
 }
 
 func (p *pendingProposals) clear() {
@@ -48,6 +52,7 @@ func (p *pendingProposals) clear() {
 	}
 	p.confChangeCmd = emptyCMD
 	p.cmds = p.cmds[:0]
+	p.updateSize()
 }
 
 func (p *pendingProposals) pop() (batch, bool) {
@@ -58,11 +63,13 @@ func (p *pendingProposals) pop() (batch, bool) {
 	c := p.cmds[0]
 	p.cmds[0] = emptyCMD
 	p.cmds = p.cmds[1:]
+	p.updateSize()
 	return c, true
 }
 
 func (p *pendingProposals) append(c batch) {
 	p.cmds = append(p.cmds, c)
+	p.updateSize()
 }
 
 func (p *pendingProposals) setConfigChange(c batch) {
@@ -71,6 +78,7 @@ func (p *pendingProposals) setConfigChange(c batch) {
 		panic("not a config change request")
 	}
 	p.confChangeCmd = c
+	p.updateSize()
 }
 
 func (p *pendingProposals) getConfigChange() batch {
@@ -85,6 +93,7 @@ func (p *pendingProposals) notify(id []byte,
 			buildID(id, &resp)
 			c.resp(resp)
 			p.confChangeCmd = emptyCMD
+			p.updateSize()
 		}
 		return
 	}
@@ -102,3 +111,17 @@ func (p *pendingProposals) notify(id []byte,
 		c.notifyStaleCmd()
 	}
 }
+
+// len returns the number of pending proposals, it is safe to be called outside
+// the event worker.
+func (p *pendingProposals) len() int {
+	return int(atomic.LoadInt64(&p.size))
+}
+
+func (p *pendingProposals) updateSize() {
+	size := int64(len(p.cmds))
+	if !p.confChangeCmd.requestBatch.IsEmpty() {
+		size++
+	}
+	atomic.StoreInt64(&p.size, size)
+}
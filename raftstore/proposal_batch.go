@@ -14,6 +14,7 @@
 package raftstore
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/matrixorigin/matrixcube/components/log"
@@ -41,6 +42,8 @@ type reqCtx struct {
 	reqType int
 	req     rpcpb.Request
 	cb      func(rpcpb.ResponseBatch)
+	// ctx holds the span of the request if it is traced, see requestTracer
+	ctx context.Context
 }
 
 func newReqCtx(req rpcpb.Request, cb func(rpcpb.ResponseBatch)) reqCtx {
@@ -0,0 +1,80 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync/atomic"
+
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+// queueMetrics tracks the latest reported size and the high water mark of each
+// kind of replica event queue on the store and reports them as metrics. All
+// replicas of the store share the same queueMetrics, a nil queueMetrics only
+// reports the queue size.
+type queueMetrics struct {
+	sizes      map[string]*int64
+	highWaters map[string]*int64
+}
+
+func newQueueMetrics() *queueMetrics {
+	m := &queueMetrics{
+		sizes:      make(map[string]*int64),
+		highWaters: make(map[string]*int64),
+	}
+	for _, queue := range []string{metric.RaftStepQueue, metric.RaftTickQueue,
+		metric.RaftReportQueue, metric.RaftRequestQueue, metric.RaftActionQueue} {
+		m.sizes[queue] = new(int64)
+		m.highWaters[queue] = new(int64)
+	}
+	return m
+}
+
+// update reports the current size of the specified queue of a replica.
+func (m *queueMetrics) update(queue string, size int64) {
+	metric.SetRaftQueueMetric(queue, size)
+	if m == nil {
+		return
+	}
+
+	atomic.StoreInt64(m.sizes[queue], size)
+	highWater := m.highWaters[queue]
+	for {
+		current := atomic.LoadInt64(highWater)
+		if size <= current {
+			return
+		}
+		if atomic.CompareAndSwapInt64(highWater, current, size) {
+			metric.SetRaftQueueHighWaterMetric(queue, size)
+			return
+		}
+	}
+}
+
+// size returns the latest reported size of the specified queue.
+func (m *queueMetrics) size(queue string) int64 {
+	return atomic.LoadInt64(m.sizes[queue])
+}
+
+// highWater returns the max reported size of the specified queue.
+func (m *queueMetrics) highWater(queue string) int64 {
+	return atomic.LoadInt64(m.highWaters[queue])
+}
+
+// updateQueueMetric reports the current size of the specified queue of the
+// replica to the store queue metrics and the metrics of the shard.
+func (pr *replica) updateQueueMetric(queue string, size int64) {
+	pr.queueMetrics.update(queue, size)
+	pr.shardMetrics.SetQueueSize(queue, size)
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestQueueMetrics(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var m *queueMetrics
+	m.update(metric.RaftActionQueue, 1)
+
+	m = newQueueMetrics()
+	m.update(metric.RaftActionQueue, 10)
+	m.update(metric.RaftActionQueue, 5)
+	assert.Equal(t, int64(5), m.size(metric.RaftActionQueue))
+	assert.Equal(t, int64(10), m.highWater(metric.RaftActionQueue))
+	assert.Equal(t, int64(0), m.highWater(metric.RaftRequestQueue))
+}
+
+func TestReplicaActionQueueMetrics(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.initialized = true
+	r.queueMetrics = newQueueMetrics()
+	setTestStore(r)
+
+	for i := 0; i < 10; i++ {
+		r.addAction(action{actionType: checkPendingReadsAction})
+	}
+	assert.Equal(t, int64(10), r.queueMetrics.size(metric.RaftActionQueue))
+	assert.Equal(t, int64(10), r.queueMetrics.highWater(metric.RaftActionQueue))
+
+	r.batchSize = newAdaptiveBatchSize(0, 4)
+	_, err := r.handleAction(r.items)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), r.queueMetrics.size(metric.RaftActionQueue))
+	assert.Equal(t, int64(10), r.queueMetrics.highWater(metric.RaftActionQueue))
+
+	for r.actions.Len() > 0 {
+		_, err := r.handleAction(r.items)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int64(0), r.queueMetrics.size(metric.RaftActionQueue))
+	assert.Equal(t, int64(10), r.queueMetrics.highWater(metric.RaftActionQueue))
+}
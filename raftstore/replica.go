@@ -16,17 +16,20 @@ package raftstore
 import (
 	"context"
 	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/fagongzi/util/protoc"
 	"github.com/juju/ratelimit"
 	"github.com/matrixorigin/matrixcube/aware"
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/components/prophet"
 	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/logdb"
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -37,6 +40,7 @@ import (
 	"github.com/matrixorigin/matrixcube/util/task"
 	"go.etcd.io/etcd/raft/v3"
 	"go.etcd.io/etcd/raft/v3/raftpb"
+	trackerPkg "go.etcd.io/etcd/raft/v3/tracker"
 	"go.uber.org/zap"
 )
 
@@ -72,19 +76,26 @@ type replica struct {
 	replicaHeartbeatsMap sync.Map
 	snapshotter          *snapshotter
 	incomingProposals    *proposalBatch
+	tracer               *requestTracer
 	pendingReads         *readIndexQueue
 	pendingProposals     *pendingProposals
 	readStopper          *stop.Stopper
 	sm                   *stateMachine
 	prophetClient        prophet.Client
-	groupController      *replicaGroupController
+	groupController      shardGroupKeyGetter
+	queueMetrics         *queueMetrics
+	shardMetrics         *metric.ShardMetrics // nil if disabled
+	clock                clock
 	ticks                *task.Queue
 	messages             *task.Queue
 	feedbacks            *task.Queue
-	snapshotStatus       *task.Queue
+	snapshotStatus       *snapshotStatusQueue
 	requests             *task.Queue
 	actions              *task.Queue
+	priorityActions      *task.Queue
 	items                []interface{}
+	batchSize            adaptiveBatchSize
+	queueBudget          eventQueueBudget
 	appliedIndex         uint64
 	// lease requires a minimum applied index, which is used to ensure that all
 	// previous writes have been applied to the state machine. Consider two scenarios:
@@ -103,20 +114,41 @@ type replica struct {
 	pushedIndex uint64
 	stats       *replicaStats
 	metrics     localMetrics
+	// metricsAggregator the store aggregator flushing the metrics, nil to flush
+	// the metrics directly
+	metricsAggregator *metricsAggregator
 
 	limiter *ratelimit.Bucket
 
 	initialized bool
-	closedC     chan struct{}
-	unloadedC   chan struct{}
-	destroyedC  chan struct{}
-	stopOnce    sync.Once
+	// initializedState is set to 1 once the initial snapshot is successfully
+	// handled, it is the thread safe copy of initialized read by Initialized
+	initializedState uint32
+	// suppressElection 1: the replica does not tick and campaign, updated by
+	// updateSuppressElection
+	suppressElection uint32
+	closedC          chan struct{}
+	unloadedC        chan struct{}
+	destroyedC       chan struct{}
+	stopOnce         sync.Once
 
 	// committedIndexes the committed value of all replicas is recorded, and this information is not
 	// necessarily up-to-date.
 	// this map must access in event worker
 	committedIndexes map[uint64]uint64 // replica-id -> committed index(saved into logdb)
-	// lastCommittedIndex last committed log
+	// committedIndexesChanged whether committedIndexes changed since it was
+	// saved into logdb
+	committedIndexesChanged bool
+	// compactionLags the number of raft log entries compacted by the leader but
+	// not by the follower, only updated when Raft.ReportCompactionLag is enabled.
+	// this map must access in event worker
+	compactionLags map[uint64]uint64 // replica-id -> compaction lag
+	// laggingReplicas the replicas marked as lagging by the leader, see
+	// updateLaggingReplicas.
+	// this map must access in event worker
+	laggingReplicas map[uint64]struct{}
+	// lastCommittedIndex last committed log, only updated in the event worker,
+	// it is atomically updated for DebugState
 	lastCommittedIndex uint64
 
 	destroyTaskFactory destroyReplicaTaskFactory
@@ -128,7 +160,78 @@ type replica struct {
 
 	tickTotalCount   uint64
 	tickHandledCount uint64
-	feature          storage.Feature
+	// lastProgress is the unix nano time of the last successful round of the
+	// event loop
+	lastProgress int64
+	// lastActive is the unix nano time of the last round of the event loop
+	// which handled any event
+	lastActive int64
+	// tickedUntil is the wall-clock time covered by the raft ticks fired so far,
+	// see ticksToFire. Only accessed in onRaftTick.
+	tickedUntil time.Time
+	// notifyPending is 1 when the worker has been notified but has not started
+	// to handle the events yet, redundant notifications are skipped.
+	notifyPending uint32
+	// notifyCount is the number of notifications actually sent to the worker
+	notifyCount uint64
+	// actionDroppedCount and actionRetryCount are the number of dropped actions
+	// and the number of retries of the critical actions.
+	actionDroppedCount uint64
+	actionRetryCount   uint64
+	// messageDroppedCount is the number of raft messages dropped because of the
+	// messages queue size.
+	messageDroppedCount uint64
+	feature             storage.Feature
+	// jointStateSince when the leader found the shard in the joint state, zero if
+	// the shard is not in the joint state. Only accessed in the event worker.
+	jointStateSince time.Time
+	// leaderSince when the replica became the leader, zero if it is not the
+	// leader. Only accessed in the event worker.
+	leaderSince time.Time
+	// unavailability tracks the commit progress to detect the unavailable shard.
+	// Only accessed in the event worker.
+	unavailability shardUnavailability
+	// confChangeRepeats detects the same config change proposed repeatedly, and
+	// repeatedConfChanges is the number of times it is detected. Only accessed in
+	// the event worker.
+	confChangeRepeats   *configChangeRepeats
+	repeatedConfChanges uint64
+	// lastErrorMu the most recent non-fatal error encountered during the event
+	// handling, it is read outside the event worker by the health APIs.
+	lastErrorMu struct {
+		sync.Mutex
+		err error
+		at  time.Time
+	}
+	// heartbeatFailures the number of consecutive failed shard heartbeats, see
+	// shardHeartbeatSent
+	heartbeatFailures uint64
+	// lastHeartbeat the last shard heartbeat sent to prophet, and keepalives the
+	// number of keepalives sent since then, see shouldSendKeepalive. They are
+	// only accessed by the event worker.
+	lastHeartbeat shardHeartbeat
+	keepalives    int
+	// heartbeatOutdated is set to 1 if the last heartbeat failed to be sent
+	heartbeatOutdated uint32
+	// inflightSnapshots the snapshots being sent to the followers, and
+	// deferredCompactIndex the log compaction deferred by them, see
+	// deferLogCompaction. They are only accessed by the event worker.
+	inflightSnapshots    map[uint64]inflightSnapshot
+	deferredCompactIndex uint64
+	// freezeMu the freeze requested by Freeze, it is applied by the event worker
+	// in handleFreeze. lost is set when the freeze ends before Unfreeze.
+	freezeMu struct {
+		sync.Mutex
+		requested bool
+		lost      bool
+		until     time.Time
+		acks      []chan uint64
+	}
+	// frozen is true when the event worker stops proposing and applying because
+	// of a freeze, the entries committed meanwhile are kept in frozenEntries and
+	// applied once unfrozen. Only accessed in the event worker.
+	frozen        bool
+	frozenEntries []raftpb.Entry
 }
 
 // createReplica called in:
@@ -150,6 +253,8 @@ func newReplica(store *store, shard Shard, r Replica, reason string) (*replica,
 	snapshotter := newSnapshotter(shard.ID, r.ID,
 		l.Named("snapshotter"), store.GetReplicaSnapshotDir, store.logdb, store.cfg.FS)
 	maxBatchSize := uint64(store.cfg.Raft.MaxEntryBytes)
+	batchSize := newAdaptiveBatchSize(store.cfg.Raft.TargetBatchLatency.Duration,
+		store.cfg.Raft.ReadyBatchSize)
 	pr := &replica{
 		logger:            l,
 		store:             store,
@@ -157,7 +262,11 @@ func newReplica(store *store, shard Shard, r Replica, reason string) (*replica,
 		logdb:             store.logdb,
 		cfg:               *store.cfg,
 		aware:             store.aware,
-		groupController:   store.groupController,
+		groupController:   newCustomShardGroupKeyGetter(store.cfg.Customize.CustomGroupKeyFunc, store.groupController),
+		queueMetrics:      store.queueMetrics,
+		metricsAggregator: store.metricsAggregator,
+		shardMetrics:      store.shardMetrics.Shard(shard.ID),
+		clock:             store.clock,
 		replica:           r,
 		replicaID:         r.ID,
 		shardID:           shard.ID,
@@ -174,13 +283,18 @@ func newReplica(store *store, shard Shard, r Replica, reason string) (*replica,
 		messages:          task.New(32),
 		requests:          task.New(32),
 		actions:           task.New(32),
+		priorityActions:   task.New(32),
 		feedbacks:         task.New(32),
-		snapshotStatus:    task.New(32),
-		items:             make([]interface{}, readyBatchSize),
+		snapshotStatus:    newSnapshotStatusQueue(store.cfg.Raft.MaxSnapshotStatusQueueSize),
+		items:             make([]interface{}, batchSize.max()),
+		batchSize:         batchSize,
+		queueBudget:       newEventQueueBudget(store.cfg.Raft.EventQueueBudget),
 		closedC:           make(chan struct{}),
 		unloadedC:         make(chan struct{}),
 		destroyedC:        make(chan struct{}),
 		committedIndexes:  make(map[uint64]uint64),
+		compactionLags:    make(map[uint64]uint64),
+		laggingReplicas:   make(map[uint64]struct{}),
 		limiter: ratelimit.NewBucketWithRate(float64(store.cfg.Raft.LimitRequestBytesPerShard),
 			int64(store.cfg.Raft.LimitRequestBytesPerShard)),
 	}
@@ -196,6 +310,22 @@ func newReplica(store *store, shard Shard, r Replica, reason string) (*replica,
 			return newReplicaCreator(store)
 		},
 		pr.store.aware)
+	pr.sm.dedup = newRequestDedup(store.cfg.Raft.WriteDedupWindow)
+	pr.sm.configChangeHistory = newConfigChangeHistory(store.cfg.Raft.MaxConfigChangeHistory)
+	pr.confChangeRepeats = newConfigChangeRepeats(store.cfg.Raft.MaxRepeatedConfigChanges)
+	pr.sm.isDecommissioningStore = store.isDecommissioningStore
+	pr.sm.allowPartialWrite = store.cfg.Raft.AllowPartialWrite
+	pr.sm.maxApplyFailures = store.cfg.Raft.MaxApplyFailures
+	pr.sm.writeThrough = store.writeThrough
+	pr.sm.applyBurstEntries = store.cfg.Raft.ApplyBurstEntries
+	pr.sm.preApplyHook = store.cfg.Customize.PreApplyHook
+	pr.tracer = newRequestTracer(store.cfg.Customize.Tracer, shard.ID)
+	pr.sm.tracer = pr.tracer
+	pr.sm.shardMetrics = pr.shardMetrics
+	pr.sm.writeBatch.maxEntries = store.cfg.Raft.ApplyWriteBatch.MaxEntries
+	pr.sm.writeBatch.maxBytes = uint64(store.cfg.Raft.ApplyWriteBatch.MaxBytes)
+	pr.sm.enforceKeyRange = store.cfg.Raft.EnforceKeyRange
+	pr.sm.maxReplicas = store.cfg.Raft.MaxReplicasPerShard
 	pr.destroyTaskFactory = newDefaultDestroyReplicaTaskFactory(pr.addAction,
 		pr.prophetClient, defaultCheckInterval)
 	pr.feature = storage.Feature()
@@ -227,6 +357,14 @@ func (pr *replica) start(campaign bool) {
 		pr.logger.Fatal("failed to initialize log state",
 			zap.Error(err))
 	}
+	if err := pr.initCommittedIndexes(); err != nil {
+		pr.logger.Fatal("failed to initialize committed indexes",
+			zap.Error(err))
+	}
+	if err := pr.initRequestDedup(); err != nil {
+		pr.logger.Fatal("failed to initialize request dedup",
+			zap.Error(err))
+	}
 	c := getRaftConfig(pr.replicaID, pr.appliedIndex, pr.lr, &pr.cfg, pr.logger)
 	rn, err := raft.NewRawNode(c)
 	if err != nil {
@@ -242,6 +380,7 @@ func (pr *replica) start(campaign bool) {
 	}
 
 	pr.maybeSetLeaseReadReady()
+	pr.updateSuppressElection()
 	pr.setStarted()
 	// If this shard has only one replica and I am the one, campaign directly.
 	if campaign {
@@ -433,13 +572,166 @@ func (pr *replica) initLogState() (bool, error) {
 		zap.Uint64("first-index", rs.FirstIndex),
 		zap.Uint64("commit-index", rs.State.Commit),
 		zap.Uint64("term", rs.State.Term))
-	pr.lr.SetRange(rs.FirstIndex, rs.EntryCount)
-	pr.lastCommittedIndex = rs.State.Commit
+	// the persisted entries not continuous with the LogReader are left to
+	// repairLogReader
+	if lastIndex, _ := pr.lr.LastIndex(); rs.FirstIndex <= lastIndex+1 {
+		pr.lr.SetRange(rs.FirstIndex, rs.EntryCount)
+	}
+	if err := pr.repairLogReader(ss, rs); err != nil {
+		return false, err
+	}
+	atomic.StoreUint64(&pr.lastCommittedIndex, rs.State.Commit)
 	pr.leaseLeastAppliedIndex = rs.State.Commit
 	pr.sm.setFirstIndex(rs.FirstIndex)
 	return !(rs.EntryCount > 0 || hasRaftHardState), nil
 }
 
+// initCommittedIndexes restores the committed indexes of the replicas saved
+// before the restart, so the decisions relying on them don't have to wait for
+// the raft messages to refill them. The replicas no longer in the shard are
+// ignored.
+func (pr *replica) initCommittedIndexes() error {
+	indexes, err := pr.logdb.GetCommittedIndexes(pr.shardID)
+	if err != nil {
+		return err
+	}
+	for _, r := range pr.getShard().Replicas {
+		if index, ok := indexes[r.ID]; ok {
+			pr.committedIndexes[r.ID] = index
+		}
+	}
+	return nil
+}
+
+// saveCommittedIndexes saves the committed indexes into logdb if they changed
+// since the last save.
+func (pr *replica) saveCommittedIndexes() {
+	if !pr.committedIndexesChanged {
+		return
+	}
+	if err := pr.logdb.SaveCommittedIndexes(pr.shardID, pr.committedIndexes); err != nil {
+		pr.logger.Error("failed to save committed indexes",
+			zap.Error(err))
+		return
+	}
+	pr.committedIndexesChanged = false
+}
+
+// repairLogReader cross-checks the range of the LogReader against the raft log
+// entries persisted in logdb and the snapshot marker, the range is rebuilt if
+// they disagree, e.g. after an unclean shutdown. It is safe to be called on
+// every startup.
+func (pr *replica) repairLogReader(ss raftpb.Snapshot, rs logdb.RaftState) error {
+	markerIndex, markerTerm := ss.Metadata.Index, ss.Metadata.Term
+	first, last := markerIndex+1, markerIndex
+	if rs.EntryCount > 0 {
+		if v := rs.FirstIndex + rs.EntryCount - 1; v > last {
+			last = v
+		}
+		// entries between the snapshot and the first persisted entry are
+		// missing, the first persisted entry becomes the marker.
+		if rs.FirstIndex > first {
+			first = rs.FirstIndex + 1
+		}
+	}
+	lrFirst, _ := pr.lr.FirstIndex()
+	lrLast, _ := pr.lr.LastIndex()
+	if lrFirst == first && lrLast == last {
+		return nil
+	}
+
+	if first-1 > markerIndex {
+		entries, _, err := pr.logdb.IterateEntries(nil, 0, pr.shardID,
+			pr.replicaID, first-1, first, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("raft log entry %d not found", first-1)
+		}
+		markerIndex, markerTerm = first-1, entries[0].Term
+	}
+	pr.logger.Warn("LogReader range mismatched with logdb, rebuild the range",
+		zap.Uint64("first-index", lrFirst),
+		zap.Uint64("last-index", lrLast),
+		zap.Uint64("logdb-first-index", first),
+		zap.Uint64("logdb-last-index", last),
+		zap.Uint64("marker-index", markerIndex),
+		zap.Uint64("marker-term", markerTerm),
+		log.SnapshotField(ss))
+	pr.lr.resetRange(markerIndex, markerTerm, last-markerIndex+1)
+	return nil
+}
+
+// initRequestDedup rebuilds the recently applied write requests, it is called
+// after the restart and after a snapshot is applied. The requests are loaded
+// from the data storage if it implements storage.RequestDedupStorage, otherwise
+// they are rebuilt from the applied raft logs, which are not available after
+// the logs are compacted. Only the request IDs are restored, the responses of
+// these requests are not available, retries of them get empty responses.
+func (pr *replica) initRequestDedup() error {
+	if pr.sm.dedup == nil {
+		return nil
+	}
+
+	pr.sm.dedup = newRequestDedup(pr.cfg.Raft.WriteDedupWindow)
+	if s, ok := pr.sm.dataStorage.(storage.RequestDedupStorage); ok {
+		ids, err := s.GetAppliedRequests(pr.shardID)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			pr.sm.dedup.add(id, nil)
+		}
+		// already persisted
+		pr.sm.dedup.takeChanges()
+		pr.logger.Info("request dedup loaded",
+			zap.Int("requests", pr.sm.dedup.len()))
+		return nil
+	}
+
+	window := uint64(pr.cfg.Raft.WriteDedupWindow)
+
+	low, _ := pr.lr.FirstIndex()
+	high, _ := pr.lr.LastIndex()
+	high++
+	if pr.appliedIndex+1 < high {
+		high = pr.appliedIndex + 1
+	}
+	if high > window && high-window > low {
+		low = high - window
+	}
+	if low >= high {
+		return nil
+	}
+
+	ents, err := pr.lr.Entries(low, high, math.MaxUint64)
+	if err == raft.ErrCompacted || err == raft.ErrUnavailable {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, ent := range ents {
+		if ent.Type != raftpb.EntryNormal || len(ent.Data) == 0 {
+			continue
+		}
+		var req rpcpb.RequestBatch
+		protoc.MustUnmarshal(&req, ent.Data)
+		if req.IsAdmin() || !pr.sm.checkEpoch(req) {
+			continue
+		}
+		for _, r := range req.Requests {
+			if !r.IsTransaction() {
+				pr.sm.dedup.add(r.ID, nil)
+			}
+		}
+	}
+	pr.logger.Info("request dedup initialized",
+		zap.Int("requests", pr.sm.dedup.len()))
+	return nil
+}
+
 func (pr *replica) getReplicaRecord(id uint64) (Replica, bool) {
 	rec, ok := pr.store.getReplicaRecord(id)
 	if ok {
@@ -487,11 +779,96 @@ func (pr *replica) leaseReadReady() bool {
 	return atomic.LoadUint32(&pr.leaseReadActived) == 1
 }
 
+// LeaseHolder returns the replica holding the read lease of the shard in the
+// view of the current replica. valid is false if there is no lease, or the
+// current replica holds the lease but it can not serve lease based reads yet.
+func (pr *replica) LeaseHolder() (replicaID uint64, valid bool) {
+	lease := pr.getLease()
+	if lease == nil || lease.ReplicaID == 0 {
+		return 0, false
+	}
+	if lease.ReplicaID == pr.replicaID {
+		return lease.ReplicaID, pr.leaseReadReady()
+	}
+	return lease.ReplicaID, true
+}
+
+// RaftLogSize returns the estimated size in bytes of the raft log retained by
+// the replica. It is accumulated with the committed entries and reduced by the
+// compacted entries, see compactRaftLogSize.
+func (pr *replica) RaftLogSize() uint64 {
+	return atomic.LoadUint64(&pr.stats.raftLogSizeHint)
+}
+
+// compactRaftLogSize subtracts the size of the entries in [firstIndex, index]
+// from the raft log size hint, it must be called before the entries are
+// removed from logdb. The entries committed before the replica is started are
+// not in the hint, so the hint never goes below 0.
+func (pr *replica) compactRaftLogSize(firstIndex, index uint64) error {
+	compacted := uint64(0)
+	if index >= firstIndex {
+		entries, _, err := pr.logdb.IterateEntries(nil, 0, pr.shardID,
+			pr.replicaID, firstIndex, index+1, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			compacted += uint64(len(entry.Data))
+		}
+	}
+	size := atomic.LoadUint64(&pr.stats.raftLogSizeHint)
+	if size > compacted {
+		size -= compacted
+	} else {
+		size = 0
+	}
+	atomic.StoreUint64(&pr.stats.raftLogSizeHint, size)
+	metric.SetRaftLogSize(pr.shardID, size)
+	return nil
+}
+
+// Initialized returns true once the replica has successfully handled its
+// initial snapshot, which may happen long after the replica is added to the
+// store. The replica should not serve requests before that.
+func (pr *replica) Initialized() bool {
+	return atomic.LoadUint32(&pr.initializedState) == 1
+}
+
+// SizeDiffHint returns the approximate size of the shard, it is accumulated
+// from the diffs of the bytes stored by the applied writes since the last split
+// check, or estimated by the data storage if it implements
+// storage.ShardSizeEstimator. The split check is triggered once it reaches the
+// ShardSplitCheckBytes.
+func (pr *replica) SizeDiffHint() uint64 {
+	return pr.stats.getApproximateSize()
+}
+
+// Replicas returns a copy of the replicas of the shard, including their roles.
+func (pr *replica) Replicas() []Replica {
+	replicas := pr.getShard().Replicas
+	return append(make([]Replica, 0, len(replicas)), replicas...)
+}
+
+// notifyWorker notifies the worker pool to handle the events of the replica.
+// Notifications are coalesced, only the first one is sent until the worker
+// starts to handle the events, see resetNotifyPending. Callers must make the
+// new event visible, e.g. put it into the queue, before calling notifyWorker,
+// so the event is either seen by the pending round or notified again.
 func (pr *replica) notifyWorker() {
 	pr.waitStarted()
+	if !atomic.CompareAndSwapUint32(&pr.notifyPending, 0, 1) {
+		return
+	}
+	atomic.AddUint64(&pr.notifyCount, 1)
 	pr.store.workerPool.notify(pr.shardID)
 }
 
+// resetNotifyPending is called by the worker before handling the events, the
+// events added after that will notify the worker again.
+func (pr *replica) resetNotifyPending() {
+	atomic.StoreUint32(&pr.notifyPending, 0)
+}
+
 func (pr *replica) doCampaign() error {
 	return pr.rn.Campaign()
 }
@@ -516,32 +893,7 @@ func (pr *replica) execReadRequest(req rpcpb.Request) {
 					log.RaftRequestField("request", &req))
 			}
 
-			ctx := acquireReadCtx()
-			defer releaseReadCtx(ctx)
-
-			// FIXME: pr.getShard() has a lock, it's a hot path.
-			ctx.reset(pr.getShard(), storage.Request{
-				CmdType: req.CustomType,
-				Key:     req.Key,
-				Cmd:     req.Cmd,
-			})
-
-			v, err := pr.sm.dataStorage.Read(ctx)
-			if err != nil {
-				// FIXME: some read failures should be tolerated.
-				pr.logger.Fatal("fail to exec read batch",
-					zap.Error(err))
-			}
-
-			pr.addAction(action{
-				actionType: updateReadMetrics,
-				readMetrics: readMetrics{
-					readBytes: ctx.readBytes,
-					readKeys:  1,
-				},
-			})
-
-			requestDone(req, pr.store.shardsProxy.OnResponse, v)
+			requestDone(req, pr.store.shardsProxy.OnResponse, pr.doReadRequest(req))
 		}
 	})
 	if err == stop.ErrUnavailable {
@@ -554,6 +906,35 @@ func (pr *replica) execReadRequest(req rpcpb.Request) {
 	}
 }
 
+// doReadRequest executes the read request on the local data storage.
+func (pr *replica) doReadRequest(req rpcpb.Request) []byte {
+	ctx := acquireReadCtx()
+	defer releaseReadCtx(ctx)
+
+	// FIXME: pr.getShard() has a lock, it's a hot path.
+	ctx.reset(pr.getShard(), storage.Request{
+		CmdType: req.CustomType,
+		Key:     req.Key,
+		Cmd:     req.Cmd,
+	})
+
+	v, err := pr.sm.dataStorage.Read(ctx)
+	if err != nil {
+		// FIXME: some read failures should be tolerated.
+		pr.logger.Fatal("fail to exec read batch",
+			zap.Error(err))
+	}
+
+	pr.addAction(action{
+		actionType: updateReadMetrics,
+		readMetrics: readMetrics{
+			readBytes: ctx.readBytes,
+			readKeys:  1,
+		},
+	})
+	return v
+}
+
 func (pr *replica) pendingReadCount() int {
 	return pr.rn.PendingReadCount()
 }
@@ -569,7 +950,7 @@ func (pr *replica) resetIncomingProposals() {
 }
 
 func (pr *replica) collectDownReplicas() []metapb.ReplicaStats {
-	now := time.Now()
+	now := pr.clock.Now()
 	shard := pr.getShard()
 	var downReplicas []metapb.ReplicaStats
 	for _, p := range shard.Replicas {
@@ -591,17 +972,126 @@ func (pr *replica) collectDownReplicas() []metapb.ReplicaStats {
 	return downReplicas
 }
 
-// collectPendingReplicas returns a list of replicas that are potentially waiting for
-// snapshots from the leader.
+// collectPendingReplicas returns a list of replicas that are added but not yet
+// caught up, they are waiting for snapshots from the leader, or lagging behind
+// the leader more than Raft.MaxPendingReplicaLag.
 func (pr *replica) collectPendingReplicas() []Replica {
-	// TODO: impl
-	return []Replica{}
+	pendingReplicas := []Replica{}
+	if !pr.isLeader() {
+		return pendingReplicas
+	}
+	status := pr.rn.Status()
+	lastIndex := status.Progress[pr.replicaID].Match
+	maxLag := pr.cfg.Raft.MaxPendingReplicaLag
+	for _, r := range pr.getShard().Replicas {
+		if r.ID == pr.replicaID {
+			continue
+		}
+		p, ok := status.Progress[r.ID]
+		if !ok {
+			continue
+		}
+		if p.State == trackerPkg.StateSnapshot ||
+			(maxLag > 0 && lastIndex > p.Match+maxLag) {
+			pendingReplicas = append(pendingReplicas, r)
+		}
+	}
+	return pendingReplicas
 }
 
 func (pr *replica) nextProposalIndex() uint64 {
 	return pr.rn.NextProposalIndex()
 }
 
+// updateSuppressElection suppresses the ticks and campaigns of the replica if
+// it is not initialized, quarantined or the store is read only.
+func (pr *replica) updateSuppressElection() {
+	v := uint32(0)
+	if !pr.initialized || pr.cfg.Raft.ReadOnly ||
+		(pr.sm != nil && pr.sm.isQuarantined()) {
+		v = 1
+	}
+	atomic.StoreUint32(&pr.suppressElection, v)
+}
+
+// checkQuarantined makes sure the quarantined replica, which rejects all
+// requests, never campaigns and does not stay the leader of the shard. The
+// leadership is transferred to the most up to date voter, the other voters
+// elect a new leader once the election timeout is reached if there is none,
+// since the ticks of the quarantined replica are suppressed.
+func (pr *replica) checkQuarantined() {
+	if pr.sm == nil || !pr.sm.isQuarantined() {
+		return
+	}
+	if !pr.isElectionSuppressed() {
+		pr.updateSuppressElection()
+	}
+	if !pr.isLeader() || pr.rn.BasicStatus().LeadTransferee != 0 {
+		return
+	}
+	if target, ok := pr.getQuarantineTransferee(); ok {
+		pr.logger.Warn("transfer leader of the quarantined replica",
+			log.ReplicaField("to", target))
+		pr.doTransferLeader(target)
+	}
+}
+
+// getQuarantineTransferee returns the voter with the largest match index other
+// than the current replica.
+func (pr *replica) getQuarantineTransferee() (Replica, bool) {
+	progress := pr.rn.Status().Progress
+	var target Replica
+	match := uint64(0)
+	found := false
+	for _, r := range pr.getShard().Replicas {
+		if r.ID == pr.replicaID || r.Role != metapb.ReplicaRole_Voter {
+			continue
+		}
+		if p, ok := progress[r.ID]; ok && (!found || p.Match > match) {
+			target, match, found = r, p.Match, true
+		}
+	}
+	return target, found
+}
+
+func (pr *replica) isElectionSuppressed() bool {
+	return atomic.LoadUint32(&pr.suppressElection) == 1
+}
+
+// getReplicaProgress returns the replication progress of all replicas of the
+// shard, nil if the replica is not the leader. It must be called in the event
+// worker.
+func (pr *replica) getReplicaProgress() map[uint64]ReplicaProgress {
+	if !pr.isLeader() {
+		return nil
+	}
+	progress := pr.rn.Status().Progress
+	values := make(map[uint64]ReplicaProgress, len(progress))
+	for id, p := range progress {
+		values[id] = ReplicaProgress{
+			Match: p.Match,
+			Next:  p.Next,
+			State: p.State.String(),
+		}
+	}
+	return values
+}
+
+// LastError returns the most recent non-fatal error encountered during the
+// event handling and the time when it was recorded, nil if there is no error.
+func (pr *replica) LastError() (error, time.Time) {
+	pr.lastErrorMu.Lock()
+	defer pr.lastErrorMu.Unlock()
+	return pr.lastErrorMu.err, pr.lastErrorMu.at
+}
+
+func (pr *replica) setLastError(err error) {
+	pr.lastErrorMu.Lock()
+	defer pr.lastErrorMu.Unlock()
+	pr.lastErrorMu.err = err
+	pr.lastErrorMu.at = pr.clock.Now()
+}
+
 func (pr *replica) getTickTotalCount() uint64 {
 	return atomic.LoadUint64(&pr.tickTotalCount)
 }
@@ -610,6 +1100,35 @@ func (pr *replica) getTickHandledCount() uint64 {
 	return atomic.LoadUint64(&pr.tickHandledCount)
 }
 
+func (pr *replica) setLastProgress(now time.Time) {
+	atomic.StoreInt64(&pr.lastProgress, now.UnixNano())
+}
+
+func (pr *replica) getLastProgress() time.Time {
+	if v := atomic.LoadInt64(&pr.lastProgress); v > 0 {
+		return time.Unix(0, v)
+	}
+	return time.Time{}
+}
+
+func (pr *replica) setLastActive(now time.Time) {
+	atomic.StoreInt64(&pr.lastActive, now.UnixNano())
+}
+
+// LastActive returns the last time the event loop of the replica handled any
+// event, it is zero if no event handled yet. Unlike the last progress, it
+// does not advance while the replica is idle.
+func (pr *replica) LastActive() time.Time {
+	if v := atomic.LoadInt64(&pr.lastActive); v > 0 {
+		return time.Unix(0, v)
+	}
+	return time.Time{}
+}
+
+func (pr *replica) getNotifyCount() uint64 {
+	return atomic.LoadUint64(&pr.notifyCount)
+}
+
 func getRaftConfig(id, appliedIndex uint64, lr *LogReader, cfg *config.Config, logger *zap.Logger) *raft.Config {
 	return &raft.Config{
 		ID:                        id,
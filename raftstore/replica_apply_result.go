@@ -15,9 +15,9 @@ package raftstore
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"go.etcd.io/etcd/raft/v3/raftpb"
@@ -86,6 +86,7 @@ func (pr *replica) handleApplyResult(result applyResult) {
 
 func (pr *replica) updateAppliedIndex(result applyResult) {
 	pr.appliedIndex = result.index
+	pr.stats.sampleApplyRate(pr.shardID, result.index, pr.clock.Now())
 	pr.maybeSetLeaseReadReady()
 	pr.maybeExecRead()
 }
@@ -97,11 +98,12 @@ func (pr *replica) updateMetricsHints(result applyResult) {
 	pr.stats.writtenKeys += result.metrics.writtenKeys
 	if result.hasSplitResult() {
 		pr.stats.deleteKeysHint = result.metrics.deleteKeysHint
-		pr.stats.approximateSize = result.metrics.approximateDiffHint
+		pr.stats.setApproximateSize(result.metrics.approximateDiffHint)
 	} else {
 		pr.stats.deleteKeysHint += result.metrics.deleteKeysHint
-		pr.stats.approximateSize += result.metrics.approximateDiffHint
+		pr.stats.addApproximateSize(result.metrics.approximateDiffHint)
 	}
+	metric.SetShardSizeDiff(pr.shardID, pr.stats.getApproximateSize())
 }
 
 func (pr *replica) handleAdminResult(result applyResult) {
@@ -157,12 +159,24 @@ func (pr *replica) applyConfChange(cp configChangeResult) {
 	pr.rn.ApplyConfChange(cp.confChange)
 
 	needPing := false
-	now := time.Now()
+	demoting := false
+	now := pr.clock.Now()
 	for _, change := range cp.changes {
+		if isLeaveJointConfigChangeRequest(change) {
+			// roles may be changed after leaving the joint state
+			if r := findReplica(cp.shard, pr.storeID); r != nil {
+				pr.replica = *r
+			}
+			continue
+		}
 		changeType := change.ChangeType
 		replica := change.Replica
 		replicaID := replica.ID
 
+		if isDemotingVoterRequest(change) {
+			demoting = true
+		}
+
 		switch changeType {
 		case metapb.ConfigChangeType_AddNode, metapb.ConfigChangeType_AddLearnerNode:
 			if replica.StoreID == pr.storeID {
@@ -178,6 +192,13 @@ func (pr *replica) applyConfChange(cp configChangeResult) {
 			pr.store.replicaRecords.Delete(replicaID)
 		}
 	}
+	pr.store.configChangeNotifier.addEvent(configChangeEvent{
+		shardID: pr.shardID,
+		result: ConfigChangeResult{
+			Shard:   cp.shard,
+			Changes: cp.changes,
+		},
+	})
 
 	if pr.isLeader() {
 		// Notify prophet immediately.
@@ -205,6 +226,12 @@ func (pr *replica) applyConfChange(cp configChangeResult) {
 			// Speed up snapshot instead of waiting another heartbeat.
 			pr.rn.Ping()
 		}
+
+		if demoting && pr.isLeader() {
+			// the demoting voter becomes learner after leaving the joint state
+			pr.logger.Info("propose leave joint after demoting voter")
+			pr.addAdminRequest(rpcpb.CmdConfigChange, &rpcpb.ConfigChangeRequest{})
+		}
 	}
 
 	if pr.store.aware != nil {
@@ -231,7 +258,7 @@ func (pr *replica) applySplit(result splitResult) {
 
 	// we consider the split to be roughly even, so we calculate the current estimated size of the shard
 	// based on the number of new shards.
-	estimatedSize := pr.stats.approximateSize / uint64(len(result.newShards))
+	estimatedSize := pr.stats.getApproximateSize() / uint64(len(result.newShards))
 	estimatedKeys := pr.stats.approximateKeys / uint64(len(result.newShards))
 
 	isLeader := pr.isLeader()
@@ -240,7 +267,7 @@ func (pr *replica) applySplit(result splitResult) {
 		withReason(reason).
 		withStartReplica(false, func(r *replica) {
 			r.stats.approximateKeys = estimatedKeys
-			r.stats.approximateSize = estimatedSize
+			r.stats.setApproximateSize(estimatedSize)
 			r.sm.updateLease(result.newLeases[0])
 			result.newLeases = result.newLeases[1:]
 		}, func(r *replica) {
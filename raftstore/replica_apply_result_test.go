@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"testing"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -22,6 +23,8 @@ import (
 	keysutil "github.com/matrixorigin/matrixcube/util/keys"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
 )
 
 func TestApplySplit(t *testing.T) {
@@ -181,3 +184,67 @@ func TestUpdateMetricsHintsCanBeIgnored(t *testing.T) {
 	assert.Equal(t, uint64(2), pr.stats.approximateSize)
 
 }
+
+func TestConfigChangeObserver(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.configChangeNotifier.start()
+	defer s.configChangeNotifier.close()
+
+	type event struct {
+		shardID uint64
+		result  ConfigChangeResult
+	}
+	c := make(chan event, 2)
+	s.OnConfigChange(func(shardID uint64, result ConfigChangeResult) {
+		c <- event{shardID: shardID, result: result}
+	})
+
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 100}}}, Replica{ID: 100}, s)
+	rn, err := raft.NewRawNode(&raft.Config{
+		ID:              100,
+		ElectionTick:    10,
+		HeartbeatTick:   1,
+		Storage:         raft.NewMemoryStorage(),
+		MaxInflightMsgs: 100,
+	})
+	assert.NoError(t, err)
+	pr.rn = rn
+	pr.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 100})
+	add := rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+		Replica:    Replica{ID: 200, StoreID: 2, Role: metapb.ReplicaRole_Learner},
+	}
+	remove := rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_RemoveNode,
+		Replica:    Replica{ID: 200, StoreID: 2, Role: metapb.ReplicaRole_Learner},
+	}
+	added := Shard{ID: 1, Replicas: []Replica{{ID: 100}, add.Replica}}
+	removed := Shard{ID: 1, Replicas: []Replica{{ID: 100}}}
+	pr.applyConfChange(configChangeResult{
+		index:      1,
+		confChange: raftpb.ConfChangeV2{Changes: []raftpb.ConfChangeSingle{{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 200}}},
+		changes:    []rpcpb.ConfigChangeRequest{add},
+		shard:      added,
+	})
+	pr.applyConfChange(configChangeResult{
+		index:      2,
+		confChange: raftpb.ConfChangeV2{Changes: []raftpb.ConfChangeSingle{{Type: raftpb.ConfChangeRemoveNode, NodeID: 200}}},
+		changes:    []rpcpb.ConfigChangeRequest{remove},
+		shard:      removed,
+	})
+
+	for _, expect := range []event{
+		{shardID: 1, result: ConfigChangeResult{Shard: added, Changes: []rpcpb.ConfigChangeRequest{add}}},
+		{shardID: 1, result: ConfigChangeResult{Shard: removed, Changes: []rpcpb.ConfigChangeRequest{remove}}},
+	} {
+		select {
+		case e := <-c:
+			assert.Equal(t, expect, e)
+		case <-time.After(time.Second * 10):
+			assert.FailNow(t, "observer not invoked")
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"time"
+
+	"github.com/matrixorigin/matrixcube/config"
+)
+
+const (
+	minReadyBatchSize = 16
+)
+
+// adaptiveBatchSize controls how many items are drained from the replica queues
+// in a single round of the event loop. The size is halved when the processing
+// time of a round exceeds the target latency and doubled when it is well below
+// the target, so that the worker stays responsive under heavy load. A zero
+// target latency disables the adaptation and the max batch size is always used.
+type adaptiveBatchSize struct {
+	targetLatency time.Duration
+	maxSize       int64
+	size          int64
+}
+
+func newAdaptiveBatchSize(targetLatency time.Duration, maxSize int) adaptiveBatchSize {
+	return adaptiveBatchSize{
+		targetLatency: targetLatency,
+		maxSize:       int64(maxSize),
+		size:          int64(maxSize),
+	}
+}
+
+// max returns the max batch size, readyBatchSize is used if it is not
+// specified.
+func (b *adaptiveBatchSize) max() int64 {
+	if b.maxSize <= 0 {
+		return readyBatchSize
+	}
+	return b.maxSize
+}
+
+// min returns the min batch size, it never exceeds the max batch size.
+func (b *adaptiveBatchSize) min() int64 {
+	if max := b.max(); max < minReadyBatchSize {
+		return max
+	}
+	return minReadyBatchSize
+}
+
+func (b *adaptiveBatchSize) get() int64 {
+	if b.targetLatency == 0 || b.size == 0 {
+		return b.max()
+	}
+	return b.size
+}
+
+func (b *adaptiveBatchSize) observe(cost time.Duration) {
+	if b.targetLatency == 0 {
+		return
+	}
+
+	size := b.get()
+	if cost > b.targetLatency {
+		size = size / 2
+		if min := b.min(); size < min {
+			size = min
+		}
+	} else if cost < b.targetLatency/2 {
+		size = size * 2
+		if max := b.max(); size > max {
+			size = max
+		}
+	}
+	b.size = size
+}
+
+// eventQueueBudget is the max number of items drained from each replica queue
+// in a single round of the event loop on top of the adaptive batch size. A zero
+// budget means the queue is only limited by the batch size.
+type eventQueueBudget struct {
+	messages       int64
+	ticks          int64
+	feedbacks      int64
+	snapshotStatus int64
+	requests       int64
+	actions        int64
+	// actionsDuration is the max time spent on handling actions in a round
+	actionsDuration time.Duration
+}
+
+func newEventQueueBudget(cfg config.EventQueueBudgetConfig) eventQueueBudget {
+	return eventQueueBudget{
+		messages:        int64(cfg.Messages),
+		ticks:           int64(cfg.Ticks),
+		feedbacks:       int64(cfg.Feedbacks),
+		snapshotStatus:  int64(cfg.SnapshotStatus),
+		requests:        int64(cfg.Requests),
+		actions:         int64(cfg.Actions),
+		actionsDuration: cfg.ActionsDuration.Duration,
+	}
+}
+
+// drainLimit returns the max number of items to be drained from a queue with
+// the specified budget in the current round of the event loop.
+func (pr *replica) drainLimit(budget int64) int64 {
+	size := pr.batchSize.get()
+	if budget > 0 && budget < size {
+		return budget
+	}
+	return size
+}
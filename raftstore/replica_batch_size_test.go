@@ -0,0 +1,88 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestAdaptiveBatchSizeDisabled(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var b adaptiveBatchSize
+	assert.Equal(t, int64(readyBatchSize), b.get())
+	b.observe(time.Hour)
+	assert.Equal(t, int64(readyBatchSize), b.get())
+
+	b = newAdaptiveBatchSize(0, readyBatchSize)
+	b.observe(time.Hour)
+	assert.Equal(t, int64(readyBatchSize), b.get())
+}
+
+func TestAdaptiveBatchSize(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	b := newAdaptiveBatchSize(time.Millisecond*10, readyBatchSize)
+	assert.Equal(t, int64(readyBatchSize), b.get())
+
+	// heavy load, shrinks until the min batch size
+	b.observe(time.Millisecond * 20)
+	assert.Equal(t, int64(readyBatchSize/2), b.get())
+	for i := 0; i < 100; i++ {
+		b.observe(time.Millisecond * 20)
+	}
+	assert.Equal(t, int64(minReadyBatchSize), b.get())
+
+	// close to the target latency, keeps the current batch size
+	b.observe(time.Millisecond * 8)
+	assert.Equal(t, int64(minReadyBatchSize), b.get())
+
+	// light load, grows until readyBatchSize
+	b.observe(time.Millisecond)
+	assert.Equal(t, int64(minReadyBatchSize*2), b.get())
+	for i := 0; i < 100; i++ {
+		b.observe(time.Millisecond)
+	}
+	assert.Equal(t, int64(readyBatchSize), b.get())
+}
+
+func TestAdaptiveBatchSizeWithTinyMaxSize(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	b := newAdaptiveBatchSize(0, 2)
+	assert.Equal(t, int64(2), b.get())
+
+	// the min batch size never exceeds the max batch size
+	b = newAdaptiveBatchSize(time.Millisecond*10, 4)
+	assert.Equal(t, int64(4), b.get())
+	b.observe(time.Millisecond * 20)
+	assert.Equal(t, int64(4), b.get())
+	b.observe(time.Millisecond)
+	assert.Equal(t, int64(4), b.get())
+
+	b = newAdaptiveBatchSize(time.Millisecond*10, 64)
+	for i := 0; i < 10; i++ {
+		b.observe(time.Millisecond * 20)
+	}
+	assert.Equal(t, int64(minReadyBatchSize), b.get())
+	for i := 0; i < 10; i++ {
+		b.observe(time.Millisecond)
+	}
+	assert.Equal(t, int64(64), b.get())
+}
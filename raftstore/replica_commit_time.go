@@ -0,0 +1,65 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"time"
+)
+
+var (
+	// maxCommitTimes max number of the committed but unapplied ranges of logs
+	// whose commit time is tracked, the oldest ones are dropped once exceeded.
+	maxCommitTimes = 1024
+)
+
+// commitTime is the time the logs in [firstIndex, lastIndex] are seen
+// committed.
+type commitTime struct {
+	firstIndex uint64
+	lastIndex  uint64
+	at         time.Time
+}
+
+// commitTimes tracks the commit time of the committed logs until they are
+// applied, it is only accessed in the event worker of the replica. The logs
+// committed before the replica is started, or whose commit time is dropped,
+// have no commit time.
+type commitTimes struct {
+	times []commitTime
+}
+
+// add records the committed logs in [firstIndex, lastIndex] are seen at the
+// time.
+func (c *commitTimes) add(firstIndex, lastIndex uint64, at time.Time) {
+	if len(c.times) >= maxCommitTimes {
+		c.times = c.times[1:]
+	}
+	c.times = append(c.times, commitTime{
+		firstIndex: firstIndex,
+		lastIndex:  lastIndex,
+		at:         at,
+	})
+}
+
+// get returns the commit time of the log with the index, the commit times of
+// all previous logs are dropped as the logs are applied in order.
+func (c *commitTimes) get(index uint64) (time.Time, bool) {
+	for len(c.times) > 0 && c.times[0].lastIndex < index {
+		c.times = c.times[1:]
+	}
+	if len(c.times) == 0 || c.times[0].firstIndex > index {
+		return time.Time{}, false
+	}
+	return c.times[0].at, true
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestCommitTimes(t *testing.T) {
+	now := time.Now()
+	c := commitTimes{}
+	c.add(3, 5, now)
+	c.add(6, 6, now.Add(time.Second))
+
+	// committed before the tracking is started
+	_, ok := c.get(2)
+	assert.False(t, ok)
+	for i := uint64(3); i <= 5; i++ {
+		at, ok := c.get(i)
+		assert.True(t, ok)
+		assert.Equal(t, now, at)
+	}
+	at, ok := c.get(6)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(time.Second), at)
+	_, ok = c.get(7)
+	assert.False(t, ok)
+	assert.Empty(t, c.times)
+
+	// skipped logs, e.g. covered by a snapshot
+	c.add(8, 10, now)
+	c.add(11, 12, now.Add(time.Second))
+	at, ok = c.get(12)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(time.Second), at)
+}
+
+func TestCommitTimesDropsOldest(t *testing.T) {
+	old := maxCommitTimes
+	maxCommitTimes = 2
+	defer func() {
+		maxCommitTimes = old
+	}()
+
+	c := commitTimes{}
+	c.add(1, 1, time.Now())
+	c.add(2, 2, time.Now())
+	c.add(3, 3, time.Now())
+	assert.Equal(t, 2, len(c.times))
+	_, ok := c.get(1)
+	assert.False(t, ok)
+	_, ok = c.get(2)
+	assert.True(t, ok)
+}
+
+func getCommitToApplyLatencySamples(t *testing.T, group uint64) (uint64, time.Duration) {
+	m := getMetric(t, "matrixcube_raftstore_commit_to_apply_latency_seconds",
+		map[string]string{"group": fmt.Sprintf("%d", group)})
+	if m == nil {
+		return 0, 0
+	}
+	h := m.GetHistogram()
+	return h.GetSampleCount(), time.Duration(h.GetSampleSum() * float64(time.Second))
+}
+
+func TestSaveRaftStateRecordsCommitTimes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 2}, s)
+	clock := newMockClock(time.Now())
+	pr.clock = clock
+	var entries []raftpb.Entry
+	for i := uint64(1); i <= 3; i++ {
+		entries = append(entries, raftpb.Entry{Index: i, Term: 1, Data: []byte("test")})
+	}
+	wc := s.logdb.NewWorkerContext()
+	defer wc.Close()
+	require.NoError(t, pr.saveRaftState(raft.Ready{Entries: entries}, wc))
+	assert.Empty(t, pr.sm.commitTimes.times)
+
+	wc.Reset()
+	require.NoError(t, pr.saveRaftState(raft.Ready{HardState: raftpb.HardState{Term: 1, Commit: 2}}, wc))
+	committed := clock.Now()
+	clock.Advance(time.Second)
+	wc.Reset()
+	require.NoError(t, pr.saveRaftState(raft.Ready{HardState: raftpb.HardState{Term: 1, Commit: 3}}, wc))
+	assert.Equal(t, []commitTime{
+		{firstIndex: 1, lastIndex: 2, at: committed},
+		{firstIndex: 3, lastIndex: 3, at: committed.Add(time.Second)},
+	}, pr.sm.commitTimes.times)
+}
+
+func TestStateMachineObservesCommitToApplyLatency(t *testing.T) {
+	h := &recordingResultHandler{}
+	f := func(sm *stateMachine) {
+		group := sm.getShard().Group
+		count, sum := getCommitToApplyLatencySamples(t, group)
+		// committed before the tracking is started, e.g. before a restart
+		sm.applyCommittedEntries([]raftpb.Entry{{Index: 1, Term: 1}})
+		newCount, _ := getCommitToApplyLatencySamples(t, group)
+		assert.Equal(t, count, newCount)
+
+		var entries []raftpb.Entry
+		for i := uint64(2); i <= 3; i++ {
+			entry, _ := newKVSetWriteEntry(i, 1)
+			entries = append(entries, entry)
+		}
+		sm.commitTimes.add(2, 3, time.Now())
+
+		// the apply loop is delayed
+		delay := 20 * time.Millisecond
+		time.Sleep(delay)
+		sm.applyCommittedEntries(entries[:1])
+		newCount, newSum := getCommitToApplyLatencySamples(t, group)
+		assert.Equal(t, count+1, newCount)
+		assert.True(t, newSum-sum >= delay)
+
+		time.Sleep(delay)
+		sm.applyCommittedEntries(entries[1:])
+		count, sum = newCount, newSum
+		newCount, newSum = getCommitToApplyLatencySamples(t, group)
+		assert.Equal(t, count+1, newCount)
+		assert.True(t, newSum-sum >= 2*delay)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
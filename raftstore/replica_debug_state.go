@@ -0,0 +1,84 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync/atomic"
+)
+
+// ReplicaQueueDepths is the number of items enqueued but not yet handled by
+// the event worker of a shard replica.
+type ReplicaQueueDepths struct {
+	Requests        int64
+	Actions         int64
+	PriorityActions int64
+	Messages        int64
+	Feedbacks       int64
+	Ticks           int64
+}
+
+// ReplicaDebugState is a dump of the internal state of a shard replica for
+// debugging.
+type ReplicaDebugState struct {
+	ShardID   uint64
+	ReplicaID uint64
+	Group     uint64
+	Epoch     Epoch
+	// LeaderID is the replica ID of the leader known by the replica, 0 if
+	// unknown.
+	LeaderID       uint64
+	AppliedIndex   uint64
+	CommittedIndex uint64
+	FirstIndex     uint64
+	LastIndex      uint64
+	// PendingProposals is the number of proposals waiting for their results.
+	PendingProposals int
+	Queues           ReplicaQueueDepths
+	Initialized      bool
+	LeaseReadReady   bool
+	Quarantined      bool
+}
+
+// DebugState returns a dump of the internal state of the replica. It never
+// waits for the event worker, so it can be used to inspect a stuck replica,
+// each field is read under its own lock or atomically and the fields may be
+// updated by the event worker in between.
+func (pr *replica) DebugState() ReplicaDebugState {
+	shard := pr.getShard()
+	applied, _ := pr.sm.getAppliedIndexTerm()
+	last, _ := pr.lr.LastIndex()
+	return ReplicaDebugState{
+		ShardID:          pr.shardID,
+		ReplicaID:        pr.replicaID,
+		Group:            shard.Group,
+		Epoch:            shard.Epoch,
+		LeaderID:         pr.getLeaderReplicaID(),
+		AppliedIndex:     applied,
+		CommittedIndex:   atomic.LoadUint64(&pr.lastCommittedIndex),
+		FirstIndex:       pr.sm.getFirstIndex(),
+		LastIndex:        last,
+		PendingProposals: pr.pendingProposals.len(),
+		Queues: ReplicaQueueDepths{
+			Requests:        pr.requests.Len(),
+			Actions:         pr.actions.Len(),
+			PriorityActions: pr.priorityActions.Len(),
+			Messages:        pr.messages.Len(),
+			Feedbacks:       pr.feedbacks.Len(),
+			Ticks:           pr.ticks.Len(),
+		},
+		Initialized:    pr.Initialized(),
+		LeaseReadReady: pr.leaseReadReady(),
+		Quarantined:    pr.sm.isQuarantined(),
+	}
+}
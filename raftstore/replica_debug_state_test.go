@@ -0,0 +1,96 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestReplicaDebugState(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, Group: 2, Epoch: Epoch{Generation: 3, ConfigVer: 4},
+		Replicas: []Replica{{ID: 100}}}, Replica{ID: 100}, s)
+	pr.setLeaderReplicaID(100)
+	pr.sm.updateAppliedIndexTerm(5, 1)
+	pr.sm.setFirstIndex(3)
+
+	state := pr.DebugState()
+	assert.Equal(t, uint64(1), state.ShardID)
+	assert.Equal(t, uint64(100), state.ReplicaID)
+	assert.Equal(t, uint64(2), state.Group)
+	assert.Equal(t, Epoch{Generation: 3, ConfigVer: 4}, state.Epoch)
+	assert.Equal(t, uint64(100), state.LeaderID)
+	assert.Equal(t, uint64(5), state.AppliedIndex)
+	assert.Equal(t, uint64(3), state.FirstIndex)
+	assert.Equal(t, ReplicaQueueDepths{}, state.Queues)
+	assert.Equal(t, 0, state.PendingProposals)
+	assert.False(t, state.Initialized)
+	assert.False(t, state.Quarantined)
+
+	// the event worker is not running, all the items are left in the queues
+	for i := 0; i < 3; i++ {
+		require.NoError(t, pr.addRequest(newReqCtx(rpcpb.Request{ID: []byte{byte(i)}}, nil)))
+	}
+	require.NoError(t, pr.addAction(action{actionType: checkCompactLogAction}))
+	require.NoError(t, pr.addAction(action{actionType: campaignAction}))
+	pr.addMessage(metapb.RaftMessage{Message: raftpb.Message{Type: raftpb.MsgApp}})
+	pr.addMessage(metapb.RaftMessage{Message: raftpb.Message{Type: raftpb.MsgHeartbeat}})
+	pr.addFeedback(1)
+	assert.True(t, pr.addRaftTick())
+	pr.pendingProposals.append(newTestBatch("1", "", 0, rpcpb.Write, 0, nil))
+	pr.pendingProposals.append(newTestBatch("2", "", 0, rpcpb.Write, 0, nil))
+
+	state = pr.DebugState()
+	assert.Equal(t, ReplicaQueueDepths{
+		Requests:        3,
+		Actions:         1,
+		PriorityActions: 1,
+		Messages:        2,
+		Feedbacks:       1,
+		Ticks:           1,
+	}, state.Queues)
+	assert.Equal(t, 2, state.PendingProposals)
+
+	_, ok := pr.pendingProposals.pop()
+	assert.True(t, ok)
+	assert.Equal(t, 1, pr.DebugState().PendingProposals)
+}
+
+func TestStoreReplicasDebugState(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	assert.Empty(t, s.ReplicasDebugState())
+	for _, id := range []uint64{3, 1, 2} {
+		r := Replica{ID: id + 100}
+		s.addReplica(newTestReplica(Shard{ID: id, Replicas: []Replica{r}}, r, s))
+	}
+	var ids []uint64
+	for _, state := range s.ReplicasDebugState() {
+		ids = append(ids, state.ShardID)
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, ids)
+}
@@ -22,6 +22,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/storage"
 )
@@ -147,6 +148,9 @@ func (s *store) vacuum(t vacuumTask) error {
 	if err := s.logdb.RemoveReplicaData(t.shard.ID); err != nil {
 		return err
 	}
+	if err := s.removeReplicaSnapshots(t); err != nil {
+		return err
+	}
 	err := s.DataStorageByGroup(t.shard.Group).RemoveShard(t.shard, t.removeData)
 	s.logger.Info("delete shard data returned",
 		s.storeField(),
@@ -161,6 +165,31 @@ func (s *store) vacuum(t vacuumTask) error {
 	return err
 }
 
+// removeReplicaSnapshots removes all snapshots of the destroyed replica.
+func (s *store) removeReplicaSnapshots(t vacuumTask) error {
+	replicaID := uint64(0)
+	if t.replica != nil {
+		replicaID = t.replica.replicaID
+	} else if r := findReplica(t.shard, s.Meta().ID); r != nil {
+		replicaID = r.ID
+	}
+	if replicaID == 0 {
+		return nil
+	}
+	ss := newSnapshotter(t.shard.ID, replicaID, s.logger.Named("snapshotter"),
+		s.GetReplicaSnapshotDir, s.logdb, s.cfg.FS)
+	reclaimed, err := ss.removeAllSnapshots()
+	if err != nil {
+		s.logger.Error("failed to remove snapshots",
+			s.storeField(),
+			log.ShardIDField(t.shard.ID),
+			zap.Error(err))
+		return err
+	}
+	metric.AddSnapshotReclaimedBytes(reclaimed)
+	return nil
+}
+
 func (pr *replica) destroy(shardRemoved bool, reason string) error {
 	pr.logger.Info("begin to destroy",
 		zap.Bool("shard-removed", shardRemoved),
@@ -30,7 +30,7 @@ var (
 )
 
 // actionHandleFunc used to testing
-type actionHandleFunc func(action)
+type actionHandleFunc func(action) error
 
 // destroyingStorage is the storage used to store metadata for shard destruction information.
 // The default implementation is to store it on the `Prophet` via the `Prophet`'s client.
@@ -116,11 +116,12 @@ func TestDestroyTaskWithStartCheckLogCommittedStep(t *testing.T) {
 
 	dms := newTestDestroyMetadataStorage(false)
 	c := make(chan []uint64)
-	f := newTestDestroyReplicaTaskFactory(false).setDestroyingStorage(dms).setActionHandler(func(a action) {
+	f := newTestDestroyReplicaTaskFactory(false).setDestroyingStorage(dms).setActionHandler(func(a action) error {
 		if a.actionType == checkLogCommittedAction {
 			assert.NotNil(t, a.actionCallback)
 			c <- []uint64{1, 2, 3}
 		}
+		return nil
 	}).setCheckInterval(time.Millisecond * 10)
 	go f.new(pr, 100, false, "TestDestroyTaskWithStartCheckLogCommittedStep").run(ctx)
 	select {
@@ -142,11 +143,12 @@ func TestDestroyTaskWithCompleteCheckLogCommittedStep(t *testing.T) {
 	defer cancel()
 
 	dms := newTestDestroyMetadataStorage(true)
-	f := newTestDestroyReplicaTaskFactory(false).setDestroyingStorage(dms).setActionHandler(func(a action) {
+	f := newTestDestroyReplicaTaskFactory(false).setDestroyingStorage(dms).setActionHandler(func(a action) error {
 		if a.actionType == checkLogCommittedAction {
 			assert.NotNil(t, a.actionCallback)
 			go a.actionCallback([]uint64{1, 2, 3})
 		}
+		return nil
 	}).setCheckInterval(time.Millisecond * 10)
 	go f.new(pr, 100, false, "TestDestroyTaskWithCompleteCheckLogCommittedStep").run(ctx)
 
@@ -176,11 +178,12 @@ func TestDestroyTaskWithStartCheckLogAppliedStep(t *testing.T) {
 	_, err := dms.CreateDestroying(pr.shardID, 100, false, []uint64{1, 2, 3})
 	assert.NoError(t, err)
 
-	f := newTestDestroyReplicaTaskFactory(false).setDestroyingStorage(dms).setActionHandler(func(a action) {
+	f := newTestDestroyReplicaTaskFactory(false).setDestroyingStorage(dms).setActionHandler(func(a action) error {
 		if a.actionType == checkLogAppliedAction {
 			assert.NotNil(t, a.actionCallback)
 			c <- struct{}{}
 		}
+		return nil
 	}).setCheckInterval(time.Millisecond * 10)
 	go f.new(pr, 100, false, "TestDestroyTaskWithStartCheckLogAppliedStep").run(ctx)
 
@@ -208,10 +211,11 @@ func TestDestroyTaskWithStartCompleteCheckLogAppliedStep(t *testing.T) {
 	_, err := dms.CreateDestroying(pr.shardID, 100, false, []uint64{1, 2, 3})
 	assert.NoError(t, err)
 
-	f := newTestDestroyReplicaTaskFactory(false).setDestroyingStorage(dms).setActionHandler(func(a action) {
+	f := newTestDestroyReplicaTaskFactory(false).setDestroyingStorage(dms).setActionHandler(func(a action) error {
 		if a.actionType == checkLogAppliedAction {
 			go a.actionCallback(nil)
 		}
+		return nil
 	}).setCheckInterval(time.Millisecond * 10)
 	go func() {
 		f.new(pr, 100, false, "TestDestroyTaskWithStartCompleteCheckLogAppliedStep").run(ctx)
@@ -15,19 +15,50 @@ package raftstore
 
 import (
 	"testing"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/snapshot"
 	"github.com/matrixorigin/matrixcube/storage"
 	keysutil "github.com/matrixorigin/matrixcube/util/keys"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/matrixorigin/matrixcube/util/stop"
 	"github.com/matrixorigin/matrixcube/util/task"
+	"github.com/matrixorigin/matrixcube/vfs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func newTestDestroyableReplica(s *store, shard Shard, r Replica) *replica {
+	pr := &replica{
+		shardID:           shard.ID,
+		replicaID:         r.ID,
+		replica:           r,
+		startedC:          make(chan struct{}),
+		closedC:           make(chan struct{}),
+		destroyedC:        make(chan struct{}),
+		unloadedC:         make(chan struct{}),
+		store:             s,
+		logger:            s.logger,
+		clock:             realClock{},
+		ticks:             task.New(32),
+		messages:          task.New(32),
+		requests:          task.New(32),
+		actions:           task.New(32),
+		priorityActions:   task.New(32),
+		feedbacks:         task.New(32),
+		pendingProposals:  newPendingProposals(),
+		incomingProposals: newProposalBatch(s.logger, 10, shard.ID, r),
+		pendingReads:      &readIndexQueue{shardID: shard.ID, logger: s.logger},
+		readStopper:       stop.NewStopper("test"),
+	}
+	pr.sm = newStateMachine(pr.logger, s.DataStorageByGroup(0), s.logdb, shard, pr.replica, nil, nil, nil)
+	close(pr.startedC)
+	return pr
+}
+
 func TestDestroyReplica(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	r := Replica{ID: 1}
@@ -55,31 +86,22 @@ func TestDestroyReplica(t *testing.T) {
 
 	assert.Equal(t, 2, scan())
 
-	pr := &replica{
-		shardID:           1,
-		replica:           r,
-		startedC:          make(chan struct{}),
-		closedC:           make(chan struct{}),
-		destroyedC:        make(chan struct{}),
-		unloadedC:         make(chan struct{}),
-		store:             s,
-		logger:            s.logger,
-		ticks:             task.New(32),
-		messages:          task.New(32),
-		requests:          task.New(32),
-		actions:           task.New(32),
-		feedbacks:         task.New(32),
-		pendingProposals:  newPendingProposals(),
-		incomingProposals: newProposalBatch(s.logger, 10, 1, r),
-		pendingReads:      &readIndexQueue{shardID: 1, logger: s.logger},
-		readStopper:       stop.NewStopper("TestDestroyReplica"),
-	}
-	pr.sm = newStateMachine(pr.logger, s.DataStorageByGroup(0), s.logdb, shard, pr.replica, nil, nil, nil)
+	pr := newTestDestroyableReplica(s, shard, r)
 	s.vacuumCleaner.start()
 	defer s.vacuumCleaner.close()
-	close(pr.startedC)
 	s.addReplica(pr)
 	assert.NotNil(t, s.getReplica(1, false))
+	fs := s.cfg.FS
+	snapshotDir := s.GetReplicaSnapshotDir(1, 1)
+	for i := uint64(1); i <= 3; i++ {
+		dir := fs.PathJoin(snapshotDir, snapshot.GetSnapshotDirName(i*100, i))
+		require.NoError(t, fs.MkdirAll(dir, 0755))
+		f, err := fs.Create(fs.PathJoin(dir, "data"))
+		require.NoError(t, err)
+		_, err = f.Write([]byte("hello"))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
 	s.destroyReplica(pr.shardID, true, true, "testing")
 	for {
 		if pr.closed() {
@@ -100,6 +122,52 @@ func TestDestroyReplica(t *testing.T) {
 	smd, err := pr.sm.dataStorage.GetInitialStates()
 	require.NoError(t, err)
 	require.Empty(t, smd)
+	_, err = fs.Stat(snapshotDir)
+	assert.True(t, vfs.IsNotExist(err))
+}
+
+func TestTombstoneGC(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r := Replica{ID: 1}
+	s, cancel := newTestStore(t)
+	defer cancel()
+	c := newMockClock(time.Now())
+	s.clock = c
+	kv := s.DataStorageByGroup(0).(storage.KVStorageWrapper).GetKVStorage()
+	assert.NoError(t, kv.Set(keysutil.EncodeDataKey([]byte("a1"), nil), []byte("hello-a1"), false))
+	shard := Shard{ID: 1, Start: []byte("a"), End: []byte("b"), Replicas: []Replica{r}}
+
+	pr := newTestDestroyableReplica(s, shard, r)
+	live := newTestDestroyableReplica(s, Shard{ID: 2, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2})
+	s.vacuumCleaner.start()
+	defer s.vacuumCleaner.close()
+	s.addReplica(pr)
+	s.addReplica(live)
+	pr.sm.setRemoved()
+
+	// within the retention
+	s.handleTombstoneGCTask()
+	c.Advance(s.cfg.Replication.TombstoneRetention.Duration / 2)
+	s.handleTombstoneGCTask()
+	assert.False(t, pr.closed())
+
+	c.Advance(s.cfg.Replication.TombstoneRetention.Duration / 2)
+	s.handleTombstoneGCTask()
+	for !pr.closed() {
+		time.Sleep(time.Millisecond)
+	}
+	wc := s.logdb.NewWorkerContext()
+	defer wc.Close()
+	_, err := pr.handleEvent(wc)
+	assert.NoError(t, err)
+	pr.waitDestroyed()
+	assert.Nil(t, s.getReplica(1, false))
+	assert.NotNil(t, s.getReplica(2, false))
+	assert.False(t, live.closed())
+	assert.Empty(t, s.tombstoneSince)
+	v, err := kv.Get(keysutil.EncodeDataKey([]byte("a1"), nil))
+	require.NoError(t, err)
+	assert.Empty(t, v)
 }
 
 func TestReplicaDestroyedState(t *testing.T) {
@@ -14,16 +14,20 @@
 package raftstore
 
 import (
+	"fmt"
+	"reflect"
 	"sync/atomic"
 	"time"
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/components/prophet"
 	"github.com/matrixorigin/matrixcube/logdb"
 	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/util/task"
 	"github.com/matrixorigin/matrixcube/util/uuid"
 	"go.etcd.io/etcd/raft/v3"
 	"go.etcd.io/etcd/raft/v3/raftpb"
@@ -32,7 +36,10 @@ import (
 )
 
 const (
+	// readyBatchSize is the max batch size used when it is not configured
 	readyBatchSize = 1024
+	// maxCatchUpRaftTicks is the max number of missed raft ticks fired at once
+	maxCatchUpRaftTicks = 5
 )
 
 type action struct {
@@ -77,11 +84,72 @@ const (
 	logCompactionAction
 	snapshotCompactionAction
 	checkPendingReadsAction
+	getProgressAction
+	createSnapshotAction
 )
 
+const (
+	minActionRetryBackoff = 10 * time.Millisecond
+	maxActionRetryBackoff = time.Second
+
+	minInitRetryBackoff = 10 * time.Millisecond
+	maxInitRetryBackoff = time.Second
+
+	minHeartbeatRetryBackoff = 100 * time.Millisecond
+	maxHeartbeatRetries      = 3
+)
+
+var actionTypeNames = map[actionType]string{
+	campaignAction:           "campaign",
+	checkSplitAction:         "check-split",
+	checkCompactLogAction:    "check-compact-log",
+	splitAction:              "split",
+	heartbeatAction:          "heartbeat",
+	updateReadMetrics:        "update-read-metrics",
+	checkLogCommittedAction:  "check-log-committed",
+	checkLogAppliedAction:    "check-log-applied",
+	logCompactionAction:      "log-compaction",
+	snapshotCompactionAction: "snapshot-compaction",
+	checkPendingReadsAction:  "check-pending-reads",
+	getProgressAction:        "get-progress",
+	createSnapshotAction:     "create-snapshot",
+}
+
+func (t actionType) String() string {
+	if name, ok := actionTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown-%d", int(t))
+}
+
+// critical returns true if the operation never happens once the action is
+// dropped. Other actions are either triggered periodically or not required for
+// correctness.
+func (t actionType) critical() bool {
+	switch t {
+	case splitAction, logCompactionAction, snapshotCompactionAction:
+		return true
+	}
+	return false
+}
+
+// addAdminRequest proposes the internal admin request, e.g. log compaction or
+// split. A request rejected because the replica is quarantined or the request
+// is too large is dropped, it is requested again by its periodic trigger.
 func (pr *replica) addAdminRequest(adminType rpcpb.InternalCmd, request protoc.PB) {
+	if err := pr.tryAddAdminRequest(adminType, request); err != nil {
+		if err == ErrReplicaStopped {
+			panic(err)
+		}
+		pr.logger.Warn("admin request dropped",
+			zap.String("type", adminType.String()),
+			zap.Error(err))
+	}
+}
+
+func (pr *replica) tryAddAdminRequest(adminType rpcpb.InternalCmd, request protoc.PB) error {
 	shard := pr.getShard()
-	if err := pr.addRequest(newReqCtx(rpcpb.Request{
+	return pr.addRequest(newReqCtx(rpcpb.Request{
 		ID:         uuid.NewV4().Bytes(),
 		Group:      shard.Group,
 		ToShard:    shard.ID,
@@ -89,52 +157,223 @@ func (pr *replica) addAdminRequest(adminType rpcpb.InternalCmd, request protoc.P
 		CustomType: uint64(adminType),
 		Epoch:      shard.Epoch,
 		Cmd:        protoc.MustMarshal(request),
-	}, nil)); err != nil {
-		panic(err)
-	}
+	}, nil))
 }
 
+// addRequest adds the request to the requests queue, ErrReplicaStopped,
+// ErrReplicaQuarantined, ErrRequestQueueFull, ErrRateLimited or
+// ErrRequestTooLarge is returned if the request is rejected. Admin requests are
+// never rejected by the queue size or the rate limit.
 func (pr *replica) addRequest(req reqCtx) error {
-	pr.limiter.Wait(int64(req.req.Size()))
-	if err := pr.requests.Put(req); err != nil {
+	if err := pr.admitRequest(req, 0); err != nil {
 		return err
 	}
+
+	req = pr.tracer.trace(req)
+	if err := pr.requests.Put(req); err != nil {
+		pr.tracer.abort(req.req.ID, ErrReplicaStopped)
+		return ErrReplicaStopped
+	}
+	pr.updateQueueMetric(metric.RaftRequestQueue, pr.requests.Len())
+	pr.notifyWorker()
+	return nil
+}
+
+// addRequests adds the requests to the requests queue in one call, the returned
+// errors are in the same order as the requests, see addRequest.
+func (pr *replica) addRequests(reqs []reqCtx) []error {
+	errs := make([]error, len(reqs))
+	items := make([]interface{}, 0, len(reqs))
+	for i, req := range reqs {
+		if err := pr.admitRequest(req, int64(len(items))); err != nil {
+			errs[i] = err
+			continue
+		}
+		items = append(items, pr.tracer.trace(req))
+	}
+	if len(items) == 0 {
+		return errs
+	}
+
+	if err := pr.requests.Put(items...); err != nil {
+		for i := range reqs {
+			if errs[i] == nil {
+				pr.tracer.abort(reqs[i].req.ID, ErrReplicaStopped)
+				errs[i] = ErrReplicaStopped
+			}
+		}
+		return errs
+	}
+	pr.updateQueueMetric(metric.RaftRequestQueue, pr.requests.Len())
 	pr.notifyWorker()
+	return errs
+}
+
+// admitRequest returns the error if the request can not be added to the
+// requests queue, pending is the number of requests to be added together.
+func (pr *replica) admitRequest(req reqCtx, pending int64) error {
+	if pr.sm.isQuarantined() {
+		return ErrReplicaQuarantined
+	}
+	size := int64(req.req.Size())
+	if max := int64(pr.cfg.Raft.MaxEntryBytes); max > 0 && size > max {
+		return ErrRequestTooLarge
+	}
+
+	admin := req.req.Type == rpcpb.Admin
+	if max := pr.cfg.Raft.MaxRequestQueueSize; !admin && max > 0 &&
+		pr.requests.Len()+pending >= int64(max) {
+		return ErrRequestQueueFull
+	}
+	if !admin && pr.cfg.Raft.RejectRateLimitedRequests {
+		if !pr.limiter.WaitMaxDuration(size, 0) {
+			return ErrRateLimited
+		}
+	} else {
+		pr.limiter.Wait(size)
+	}
 	return nil
 }
 
+// highPriority returns true if the action is added to the high-priority lane,
+// which is drained before the normal one in each round of the event loop, so
+// elections and heartbeats are not delayed by the maintenance actions.
+func (t actionType) highPriority() bool {
+	switch t {
+	case campaignAction, heartbeatAction:
+		return true
+	}
+	return false
+}
+
 // addAction adds the specified action to the actions queue so it will be
-// scheduled to execute in the raft worker thread.
-func (pr *replica) addAction(act action) {
-	if err := pr.actions.Put(act); err != nil {
-		return
+// scheduled to execute in the raft worker thread. ErrReplicaStopped or
+// ErrActionQueueFull is returned if the action is dropped. Critical actions are
+// never dropped because of the queue size, they are retried with backoff until
+// the queue has room or the replica is stopped. High-priority actions are never
+// limited by the queue size.
+func (pr *replica) addAction(act action) error {
+	if act.actionType.highPriority() {
+		return pr.putAction(pr.priorityActions, act)
+	}
+	if pr.actionQueueFull() {
+		if act.actionType.critical() {
+			pr.retryAction(act, minActionRetryBackoff)
+			return nil
+		}
+		pr.dropAction(act)
+		return ErrActionQueueFull
+	}
+	return pr.putAction(pr.actions, act)
+}
+
+func (pr *replica) putAction(q *task.Queue, act action) error {
+	if err := q.Put(act); err != nil {
+		pr.dropAction(act)
+		return ErrReplicaStopped
 	}
+	pr.updateQueueMetric(metric.RaftActionQueue, pr.actionQueueLen())
 	pr.notifyWorker()
+	return nil
+}
+
+func (pr *replica) actionQueueLen() int64 {
+	return pr.priorityActions.Len() + pr.actions.Len()
+}
+
+func (pr *replica) actionQueueFull() bool {
+	max := pr.cfg.Raft.MaxActionQueueSize
+	return max > 0 && pr.actions.Len() >= int64(max)
+}
+
+// retryAction adds the action to the actions queue after the backoff, the
+// backoff is doubled for each retry until maxActionRetryBackoff.
+func (pr *replica) retryAction(act action, backoff time.Duration) {
+	atomic.AddUint64(&pr.actionRetryCount, 1)
+	w := util.DefaultTimeoutWheel()
+	if _, err := w.Schedule(backoff, func(interface{}) {
+		if pr.actions.Disposed() {
+			pr.dropAction(act)
+			return
+		}
+		if pr.actionQueueFull() {
+			backoff *= 2
+			if backoff > maxActionRetryBackoff {
+				backoff = maxActionRetryBackoff
+			}
+			pr.retryAction(act, backoff)
+			return
+		}
+		pr.putAction(pr.actions, act)
+	}, nil); err != nil {
+		panic(err)
+	}
+}
+
+func (pr *replica) dropAction(act action) {
+	atomic.AddUint64(&pr.actionDroppedCount, 1)
+	metric.AddDroppedAction(act.actionType.String(), 1)
+	if act.actionType.critical() {
+		pr.logger.Warn("critical action dropped",
+			zap.String("action", act.actionType.String()))
+	}
 }
 
 func (pr *replica) addMessage(msg metapb.RaftMessage) {
+	if pr.shouldDropMessage(msg.Message.Type) {
+		atomic.AddUint64(&pr.messageDroppedCount, 1)
+		metric.AddDroppedRaftMessage(msg.Message.Type.String(), 1)
+		return
+	}
 	if err := pr.messages.Put(msg); err != nil {
 		pr.logger.Info("raft step stopped")
 		return
 	}
+	pr.updateQueueMetric(metric.RaftStepQueue, pr.messages.Len())
 	pr.notifyWorker()
 }
 
+// shouldDropMessage returns true if the received raft message should be dropped
+// because of the messages queue size. Heartbeats are dropped first, votes and
+// snapshots are never dropped. Raft recovers from the dropped messages by
+// itself.
+func (pr *replica) shouldDropMessage(t raftpb.MessageType) bool {
+	max := int64(pr.cfg.Raft.MaxMessageQueueSize)
+	if max <= 0 {
+		return false
+	}
+	switch t {
+	case raftpb.MsgVote, raftpb.MsgVoteResp, raftpb.MsgPreVote,
+		raftpb.MsgPreVoteResp, raftpb.MsgSnap:
+		return false
+	case raftpb.MsgHeartbeat, raftpb.MsgHeartbeatResp:
+		return pr.messages.Len() >= max
+	}
+	return pr.messages.Len() >= max*2
+}
+
 func (pr *replica) addFeedback(feedback interface{}) {
 	if err := pr.feedbacks.Put(feedback); err != nil {
 		pr.logger.Info("raft feedback stopped")
 	}
+	pr.updateQueueMetric(metric.RaftReportQueue, pr.feedbacks.Len())
 	pr.notifyWorker()
 }
 
 func (pr *replica) addSnapshotStatus(ss snapshotStatus) {
-	if err := pr.snapshotStatus.Put(ss); err != nil {
-		pr.logger.Info("snapshot status stopped")
+	if dropped, ok := pr.snapshotStatus.put(ss); ok {
+		pr.logger.Warn("snapshot status queue is full, drop the oldest status",
+			log.ReplicaIDField(dropped.to),
+			zap.Bool("rejected", dropped.rejected))
 	}
 	pr.notifyWorker()
 }
 
 func (pr *replica) addRaftTick() bool {
+	if pr.isElectionSuppressed() {
+		// keep the tick timer running until the replica is closed
+		return !pr.ticks.Disposed()
+	}
 	if err := pr.ticks.Put(struct{}{}); err != nil {
 		return false
 	}
@@ -144,15 +383,48 @@ func (pr *replica) addRaftTick() bool {
 }
 
 func (pr *replica) onRaftTick(arg interface{}) {
-	if pr.addRaftTick() {
-		metric.SetRaftTickQueueMetric(pr.ticks.Len())
-		w := util.DefaultTimeoutWheel()
-		if _, err := w.Schedule(pr.cfg.Raft.TickInterval.Duration, pr.onRaftTick, nil); err != nil {
-			panic(err)
+	n, next := pr.ticksToFire(pr.clock.Now())
+	for i := 0; i < n; i++ {
+		if !pr.addRaftTick() {
+			pr.logger.Info("raft tick stopped")
+			return
 		}
-		return
 	}
-	pr.logger.Info("raft tick stopped")
+	pr.updateQueueMetric(metric.RaftTickQueue, pr.ticks.Len())
+	w := util.DefaultTimeoutWheel()
+	if _, err := w.Schedule(next, pr.onRaftTick, nil); err != nil {
+		panic(err)
+	}
+}
+
+// ticksToFire returns the number of raft ticks to fire at now and the delay of
+// the next tick. The timeout wheel may fire the tick late on a busy machine,
+// the missed ticks are fired to keep the logical clock of raft aligned with
+// the wall-clock, at most maxCatchUpRaftTicks at a time, the rest are dropped.
+func (pr *replica) ticksToFire(now time.Time) (int, time.Duration) {
+	interval := pr.cfg.Raft.TickInterval.Duration
+	if pr.tickedUntil.IsZero() {
+		pr.tickedUntil = now
+		return 1, interval
+	}
+
+	drift := now.Sub(pr.tickedUntil.Add(interval))
+	if drift < 0 {
+		drift = 0
+	}
+	metric.ObserveRaftTickDrift(drift)
+
+	n := int(now.Sub(pr.tickedUntil) / interval)
+	if n > maxCatchUpRaftTicks {
+		pr.logger.Warn("raft tick is too late, drop the missed ticks",
+			zap.Duration("drift", drift),
+			zap.Int("dropped", n-maxCatchUpRaftTicks))
+		n = maxCatchUpRaftTicks
+		pr.tickedUntil = now
+	} else {
+		pr.tickedUntil = pr.tickedUntil.Add(time.Duration(n) * interval)
+	}
+	return n, pr.tickedUntil.Add(interval).Sub(now)
 }
 
 func (pr *replica) addCheckPendingReads() bool {
@@ -178,7 +450,10 @@ func (pr *replica) onCheckPendingReads(arg interface{}) {
 }
 
 func (pr *replica) shutdown() {
-	pr.metrics.flush()
+	pr.metricsAggregator.add(&pr.metrics)
+	pr.clearLaggingReplicas()
+	pr.clearCompactionLags()
+	pr.priorityActions.Dispose()
 	pr.actions.Dispose()
 	pr.ticks.Dispose()
 	pr.messages.Dispose()
@@ -193,11 +468,50 @@ func (pr *replica) shutdown() {
 	pr.logger.Info("replica shutdown completed")
 }
 
+// shutdownGraceful shuts down the replica after applying the entries already
+// committed, so that their proposals get the real responses instead of the
+// store not match error. New requests are rejected immediately. Whatever
+// remains at the deadline is responded as shutdown does.
+func (pr *replica) shutdownGraceful(deadline time.Time) {
+	pr.rejectRequests()
+
+	wc := pr.logdb.NewWorkerContext()
+	defer wc.Close()
+	for pr.appliedIndex < pr.rn.BasicStatus().Commit &&
+		pr.rn.HasReady() && pr.clock.Now().Before(deadline) {
+		wc.Reset()
+		if err := pr.handleRaftReady(wc); err != nil {
+			pr.logger.Error("fail to apply committed entries before shutdown",
+				zap.Error(err))
+			break
+		}
+	}
+	pr.logger.Info("committed entries drained before shutdown",
+		log.IndexField(pr.appliedIndex),
+		zap.Uint64("committed", pr.rn.BasicStatus().Commit))
+	pr.shutdown()
+}
+
 func (pr *replica) handleEvent(wc *logdb.WorkerContext) (hasEvent bool, err error) {
+	// must be reset before checking any event to avoid missing notifications
+	pr.resetNotifyPending()
+	defer func() {
+		if err == nil {
+			now := pr.clock.Now()
+			pr.setLastProgress(now)
+			if hasEvent {
+				pr.setLastActive(now)
+			}
+		}
+	}()
 	select {
 	case <-pr.closedC:
 		if !pr.unloaded() {
-			pr.shutdown()
+			if timeout := pr.cfg.Raft.ShutdownDrainTimeout.Duration; timeout > 0 {
+				pr.shutdownGraceful(pr.clock.Now().Add(timeout))
+			} else {
+				pr.shutdown()
+			}
 			pr.confirmUnloaded()
 		}
 		pr.logger.Debug("skip handling events on stopped replica")
@@ -212,6 +526,13 @@ func (pr *replica) handleEvent(wc *logdb.WorkerContext) (hasEvent bool, err erro
 	if hasEvent {
 		return hasEvent, nil
 	}
+	if err := pr.handleFreeze(); err != nil {
+		return hasEvent, err
+	}
+	start := time.Now()
+	defer func() {
+		pr.batchSize.observe(time.Since(start))
+	}()
 	if pr.handleMessage(pr.items) {
 		hasEvent = true
 	}
@@ -245,12 +566,16 @@ func (pr *replica) handleEvent(wc *logdb.WorkerContext) (hasEvent bool, err erro
 // apply the already received snapshot
 // for safety, we have to apply the snapshot once it is received and acked. it
 // would corrupt the raft state if we just ignore such snapshots.
-func (pr *replica) handleInitializedState() (bool, error) {
+func (pr *replica) handleInitializedState() (hasEvent bool, err error) {
 	if pr.initialized {
 		return false, nil
 	}
 	defer func() {
 		pr.initialized = true
+		pr.updateSuppressElection()
+		if err == nil {
+			atomic.StoreUint32(&pr.initializedState, 1)
+		}
 	}()
 	pr.logger.Debug("checking initial snapshot")
 	ss, err := pr.logdb.GetSnapshot(pr.shardID)
@@ -262,10 +587,17 @@ func (pr *replica) handleInitializedState() (bool, error) {
 		return false, err
 	}
 	if raft.IsEmptySnap(ss) {
-		// should never be empty here
-		panic("unexpected empty snapshot")
+		// the snapshot record is corrupted, start without the initial snapshot,
+		// a new snapshot is sent by the leader if the raft log is not enough.
+		pr.logger.Error("empty initial snapshot record, ignored",
+			log.SnapshotField(ss))
+		return false, nil
+	}
+	index, err := pr.getPersistentLogIndexWithRetry()
+	if err == ErrReplicaStopped {
+		// the replica is shutdown in the next round
+		return false, nil
 	}
-	index, err := pr.getPersistentLogIndex()
 	if err != nil {
 		return false, err
 	}
@@ -293,16 +625,99 @@ func (pr *replica) handleInitializedState() (bool, error) {
 	return true, nil
 }
 
+// getPersistentLogIndexWithRetry retries getPersistentLogIndex with backoff,
+// so a transient failure does not fail the initialization. It gives up once
+// Raft.MaxInitRetryDuration is exceeded, and returns ErrReplicaStopped if the
+// replica is closed while waiting.
+func (pr *replica) getPersistentLogIndexWithRetry() (uint64, error) {
+	deadline := pr.clock.Now().Add(pr.cfg.Raft.MaxInitRetryDuration.Duration)
+	backoff := minInitRetryBackoff
+	for {
+		index, err := pr.getPersistentLogIndex()
+		if err == nil {
+			return index, nil
+		}
+		if pr.clock.Now().Add(backoff).After(deadline) {
+			return 0, err
+		}
+
+		pr.logger.Warn("fail to get persistent log index, retry later",
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		timer := time.NewTimer(backoff)
+		select {
+		case <-pr.closedC:
+			timer.Stop()
+			return 0, ErrReplicaStopped
+		case <-timer.C:
+		}
+		backoff *= 2
+		if backoff > maxInitRetryBackoff {
+			backoff = maxInitRetryBackoff
+		}
+	}
+}
+
 func (pr *replica) handleAction(items []interface{}) (bool, error) {
-	if size := pr.actions.Len(); size == 0 {
-		return false, nil
+	start := pr.clock.Now()
+	hasPriority, err := pr.handleActionQueue(pr.priorityActions, items, start)
+	if err != nil {
+		return false, err
 	}
-	n, err := pr.actions.Get(readyBatchSize, items)
+	hasNormal, err := pr.handleActionQueue(pr.actions, items, start)
 	if err != nil {
+		return false, err
+	}
+	if !hasPriority && !hasNormal {
 		return false, nil
 	}
 
-	for i := int64(0); i < n; i++ {
+	size := pr.actionQueueLen()
+	pr.updateQueueMetric(metric.RaftActionQueue, size)
+	if size > 0 {
+		pr.notifyWorker()
+	}
+	return true, nil
+}
+
+// handleActionQueue handles the actions in the queue. When the time budget of
+// the actions is set, the actions are drained one by one and at least one of
+// them is handled before the budget is checked, the actions left stay in the
+// queue in order and the worker is notified again by handleAction.
+func (pr *replica) handleActionQueue(q *task.Queue,
+	items []interface{}, start time.Time) (bool, error) {
+	if size := q.Len(); size == 0 {
+		return false, nil
+	}
+	limit := pr.drainLimit(pr.queueBudget.actions)
+	batch := limit
+	if pr.queueBudget.actionsDuration > 0 {
+		batch = 1
+	}
+	handled := int64(0)
+	for handled < limit {
+		n, err := q.Get(batch, items)
+		if err != nil || n == 0 {
+			break
+		}
+		if err := pr.doHandleActions(items[:n]); err != nil {
+			return false, err
+		}
+		handled += n
+		if n < batch || pr.actionsBudgetExceeded(start) {
+			break
+		}
+	}
+	return handled > 0, nil
+}
+
+func (pr *replica) actionsBudgetExceeded(start time.Time) bool {
+	budget := pr.queueBudget.actionsDuration
+	return budget > 0 && pr.clock.Now().Sub(start) >= budget
+}
+
+func (pr *replica) doHandleActions(items []interface{}) error {
+	for i := range items {
 		act := items[i].(action)
 		switch act.actionType {
 		case checkSplitAction:
@@ -310,6 +725,10 @@ func (pr *replica) handleAction(items []interface{}) (bool, error) {
 		case splitAction:
 			pr.doSplit(act)
 		case campaignAction:
+			if pr.isElectionSuppressed() {
+				pr.logger.Info("campaign skipped, election suppressed")
+				continue
+			}
 			if err := pr.doCampaign(); err != nil {
 				pr.logger.Fatal("failed to do campaign",
 					zap.Error(err))
@@ -323,30 +742,30 @@ func (pr *replica) handleAction(items []interface{}) (bool, error) {
 		case checkLogAppliedAction:
 			pr.doCheckLogApplied(act)
 		case checkCompactLogAction:
+			pr.saveCommittedIndexes()
 			pr.doCheckLogCompact(pr.rn.Status().Progress, pr.rn.LastIndex())
 		case logCompactionAction:
 			if err := pr.doLogCompaction(act.targetIndex); err != nil {
-				return false, err
+				return err
 			}
 		case snapshotCompactionAction:
 			if err := pr.snapshotCompaction(act.snapshotCompaction.snapshot,
 				act.snapshotCompaction.persistentLogIndex); err != nil {
-				return false, err
+				return err
 			}
 		case checkPendingReadsAction:
 			pr.pendingReads.removeLost()
+		case getProgressAction:
+			act.actionCallback(pr.getReplicaProgress())
+		case createSnapshotAction:
+			pr.doCreateSnapshot(act)
 		}
 	}
-
-	if pr.actions.Len() > 0 {
-		pr.notifyWorker()
-	}
-	return true, nil
+	return nil
 }
 
 func (pr *replica) doUpdateReadMetrics(act action) {
-	pr.stats.readBytes += act.readMetrics.readBytes
-	pr.stats.readKeys += act.readMetrics.readKeys
+	pr.stats.addReadStats(act.readMetrics.readBytes, act.readMetrics.readKeys)
 }
 
 func (pr *replica) handleMessage(items []interface{}) bool {
@@ -354,53 +773,104 @@ func (pr *replica) handleMessage(items []interface{}) bool {
 		return false
 	}
 
-	n, err := pr.messages.Get(readyBatchSize, items)
+	n, err := pr.messages.Get(pr.drainLimit(pr.queueBudget.messages), items)
 	if err != nil {
 		return false
 	}
 	for i := int64(0); i < n; i++ {
-		raftMsg := items[i].(metapb.RaftMessage)
-		msg := raftMsg.Message
-		pr.updateReplicasCommittedIndex(raftMsg)
-
-		if pr.isLeader() && msg.From != 0 {
-			pr.replicaHeartbeatsMap.Store(msg.From, time.Now())
-		}
-
-		if err := pr.rn.Step(msg); err != nil {
-			pr.logger.Error("fail to step raft",
-				zap.Error(err))
-		}
+		pr.stepRaftMessage(items[i].(metapb.RaftMessage))
 	}
 
 	size := pr.messages.Len()
-	metric.SetRaftStepQueueMetric(size)
+	pr.updateQueueMetric(metric.RaftStepQueue, size)
 	if size > 0 {
 		pr.notifyWorker()
 	}
 	return true
 }
 
+func (pr *replica) stepRaftMessage(raftMsg metapb.RaftMessage) {
+	msg := raftMsg.Message
+	pr.updateReplicasCommittedIndex(raftMsg)
+	pr.updateCompactionLag(raftMsg)
+
+	if pr.isLeader() && msg.From != 0 {
+		pr.replicaHeartbeatsMap.Store(msg.From, pr.clock.Now())
+	}
+
+	// a replica never campaigns while its election is suppressed, including
+	// the campaigns requested by the leadership transfers
+	if msg.Type == raftpb.MsgTimeoutNow && pr.isElectionSuppressed() {
+		pr.logger.Info("leadership transfer rejected, election suppressed")
+		return
+	}
+	if err := pr.rn.Step(msg); err != nil {
+		pr.logger.Error("fail to step raft",
+			zap.String("type", msg.Type.String()),
+			zap.Error(err))
+		pr.setLastError(err)
+		metric.AddRaftStepError(msg.Type.String())
+	}
+}
+
 func (pr *replica) updateReplicasCommittedIndex(msg metapb.RaftMessage) {
-	pr.committedIndexes[msg.From.ID] = msg.CommitIndex
+	if pr.committedIndexes[msg.From.ID] != msg.CommitIndex {
+		pr.committedIndexes[msg.From.ID] = msg.CommitIndex
+		pr.committedIndexesChanged = true
+	}
+}
+
+// updateCompactionLag compares the first index of the follower attached to the
+// message against the first index of the leader.
+func (pr *replica) updateCompactionLag(msg metapb.RaftMessage) {
+	if !pr.cfg.Raft.ReportCompactionLag ||
+		msg.FirstIndex == 0 || !pr.isLeader() {
+		return
+	}
+
+	firstIndex, err := pr.lr.FirstIndex()
+	if err != nil {
+		return
+	}
+	lag := uint64(0)
+	if firstIndex > msg.FirstIndex {
+		lag = firstIndex - msg.FirstIndex
+	}
+	pr.compactionLags[msg.From.ID] = lag
+	metric.SetShardCompactionLag(pr.shardID, msg.From.ID, lag)
+}
+
+func (pr *replica) clearCompactionLags() {
+	for id := range pr.compactionLags {
+		delete(pr.compactionLags, id)
+		metric.DeleteShardCompactionLag(pr.shardID, id)
+	}
 }
 
 func (pr *replica) handleTick(items []interface{}) bool {
 	if size := pr.ticks.Len(); size == 0 {
-		pr.metrics.flush()
-		metric.SetRaftTickQueueMetric(size)
+		pr.metricsAggregator.add(&pr.metrics)
+		pr.updateQueueMetric(metric.RaftTickQueue, size)
 		return false
 	}
 
-	n, err := pr.ticks.Get(readyBatchSize, items)
+	n, err := pr.ticks.Get(pr.drainLimit(pr.queueBudget.ticks), items)
 	if err != nil {
 		return false
 	}
+	if pr.isElectionSuppressed() {
+		return true
+	}
 	for i := int64(0); i < n; i++ {
 		pr.rn.Tick()
 		atomic.AddUint64(&pr.tickHandledCount, 1)
 	}
+	pr.tryLeaveJointState()
+	pr.checkUnavailable()
 
+	if pr.ticks.Len() > 0 {
+		pr.notifyWorker()
+	}
 	return true
 }
 
@@ -409,7 +879,7 @@ func (pr *replica) handleFeedback(items []interface{}) bool {
 		return false
 	}
 
-	n, err := pr.feedbacks.Get(readyBatchSize, items)
+	n, err := pr.feedbacks.Get(pr.drainLimit(pr.queueBudget.feedbacks), items)
 	if err != nil {
 		return false
 	}
@@ -420,7 +890,7 @@ func (pr *replica) handleFeedback(items []interface{}) bool {
 	}
 
 	size := pr.feedbacks.Len()
-	metric.SetRaftReportQueueMetric(size)
+	pr.updateQueueMetric(metric.RaftReportQueue, size)
 	if size > 0 {
 		pr.notifyWorker()
 	}
@@ -429,16 +899,19 @@ func (pr *replica) handleFeedback(items []interface{}) bool {
 }
 
 func (pr *replica) handleSnapshotStatus(items []interface{}) bool {
-	if size := pr.snapshotStatus.Len(); size == 0 {
+	if size := pr.snapshotStatus.len(); size == 0 {
 		return false
 	}
 
-	n, err := pr.snapshotStatus.Get(readyBatchSize, items)
-	if err != nil {
-		return false
-	}
+	n := pr.snapshotStatus.get(pr.drainLimit(pr.queueBudget.snapshotStatus), items)
 	for i := int64(0); i < n; i++ {
 		if ss, ok := items[i].(snapshotStatus); ok {
+			pr.snapshotTransferCompleted(ss.to)
+			if !pr.isShardMember(ss.to) {
+				pr.logger.Debug("skip snapshot status for non-member replica",
+					log.ReplicaIDField(ss.to))
+				continue
+			}
 			rss := raft.SnapshotFinish
 			if ss.rejected {
 				rss = raft.SnapshotFailure
@@ -447,8 +920,8 @@ func (pr *replica) handleSnapshotStatus(items []interface{}) bool {
 		}
 	}
 
-	size := pr.snapshotStatus.Len()
-	metric.SetRaftReportQueueMetric(size)
+	size := pr.snapshotStatus.len()
+	pr.updateQueueMetric(metric.RaftReportQueue, size)
 	if size > 0 {
 		pr.notifyWorker()
 	}
@@ -456,6 +929,17 @@ func (pr *replica) handleSnapshotStatus(items []interface{}) bool {
 	return true
 }
 
+// isShardMember returns true if the replica is in the current config of
+// the shard. Snapshot status of removed replicas is meaningless to raft.
+func (pr *replica) isShardMember(replicaID uint64) bool {
+	for _, r := range pr.getShard().Replicas {
+		if r.ID == replicaID {
+			return true
+		}
+	}
+	return false
+}
+
 func (pr *replica) prophetHeartbeat() {
 	if !pr.isLeader() {
 		return
@@ -467,22 +951,139 @@ func (pr *replica) prophetHeartbeat() {
 		StoreID:         pr.storeID,
 		DownReplicas:    pr.collectDownReplicas(),
 		PendingReplicas: pr.collectPendingReplicas(),
-		Stats:           pr.stats.heartbeatState(),
-		GroupKey:        pr.groupController.getShardGroupKey(shard),
+		Stats:           pr.stats.heartbeatState(pr.shardID, pr.clock.Now()),
+		GroupKey:        pr.getShardGroupKey(shard),
 		Lease:           pr.getLease(),
 	}
-	pr.logger.Debug("start send shard heartbeat")
-	if err := pr.prophetClient.ShardHeartbeat(shard, req); err != nil {
-		pr.logger.Error("fail to send heartbeat to prophet",
+	pressure, err := pr.store.pressure.get(pr.clock.Now())
+	if err != nil {
+		pr.logger.Error("fail to sample store pressure",
 			zap.Error(err))
 	}
+	req.StoreDiskUsage = pressure.diskUsage
+	req.StoreCPULoad = pressure.cpuLoad
+	req.ReadQPS, req.WriteQPS = pr.stats.getQPS()
+	if pr.shouldSendKeepalive(shard, req) {
+		pr.sendKeepalive(shard, req)
+		return
+	}
+	if pr.store.heartbeatBatcher.enabled() {
+		pr.store.heartbeatBatcher.add(shard, req, pr.shardHeartbeatSent)
+		return
+	}
+	pr.logger.Debug("start send shard heartbeat")
+	if err := pr.prophetClient.ShardHeartbeat(shard, req, pr.shardHeartbeatSent); err != nil {
+		pr.shardHeartbeatSent(err)
+	}
 	pr.logger.Debug("end send shard heartbeat")
 }
 
+// shardHeartbeatSent handles the send result of the shard heartbeat, it is
+// invoked by the prophet client or the heartbeat batcher once the result is
+// known, not necessarily in the event worker. A heartbeat failed with a
+// transient error is resent with backoff, up to maxHeartbeatRetries times
+// before waiting for the next scheduled heartbeat.
+func (pr *replica) shardHeartbeatSent(err error) {
+	if err == nil {
+		atomic.StoreUint64(&pr.heartbeatFailures, 0)
+		pr.store.heartbeatSucceeded()
+		return
+	}
+
+	pr.logger.Error("fail to send heartbeat to prophet",
+		zap.Error(err))
+	pr.setLastError(err)
+	pr.store.heartbeatFailed()
+	atomic.StoreUint32(&pr.heartbeatOutdated, 1)
+	failures := atomic.AddUint64(&pr.heartbeatFailures, 1)
+	if isPermanentHeartbeatError(err) || failures > maxHeartbeatRetries {
+		atomic.StoreUint64(&pr.heartbeatFailures, 0)
+		return
+	}
+	backoff := minHeartbeatRetryBackoff << (failures - 1)
+	if _, err := util.DefaultTimeoutWheel().Schedule(backoff, func(interface{}) {
+		pr.addAction(action{actionType: heartbeatAction})
+	}, nil); err != nil {
+		panic(err)
+	}
+}
+
+// shouldSendKeepalive returns true if the epoch, the membership, the leader and
+// the stats of the shard are all unchanged since the last heartbeat, prophet
+// has nothing to update in this case and a keepalive is sent instead. No more
+// than Replication.MaxShardKeepalives keepalives are sent in a row, and never
+// after a failed heartbeat or keepalive, so that prophet can recover from
+// missed updates.
+func (pr *replica) shouldSendKeepalive(shard Shard, req rpcpb.ShardHeartbeatReq) bool {
+	max := pr.cfg.Replication.MaxShardKeepalives
+	if max <= 0 {
+		return false
+	}
+
+	current := shardHeartbeat{shard: shard, req: req}
+	// the stats interval always changes, the QPS is derived from the stats, and
+	// the store pressure is not the state of the shard
+	current.req.Stats.Interval = nil
+	current.req.StoreDiskUsage = 0
+	current.req.StoreCPULoad = 0
+	current.req.ReadQPS = 0
+	current.req.WriteQPS = 0
+	last := pr.lastHeartbeat
+	pr.lastHeartbeat = current
+	outdated := atomic.SwapUint32(&pr.heartbeatOutdated, 0) == 1
+	if outdated || pr.keepalives >= max ||
+		!reflect.DeepEqual(last.shard, current.shard) ||
+		!reflect.DeepEqual(last.req, current.req) {
+		pr.keepalives = 0
+		return false
+	}
+	pr.keepalives++
+	return true
+}
+
+// sendKeepalive sends the keepalive of the unchanged shard. It fails like a
+// heartbeat, prophet rejects it if its cached shard is outdated, so a full
+// heartbeat is sent next.
+func (pr *replica) sendKeepalive(shard Shard, req rpcpb.ShardHeartbeatReq) {
+	keepalive := rpcpb.ShardKeepaliveReq{
+		ShardID: shard.ID,
+		Epoch:   shard.Epoch,
+		Term:    req.Term,
+		Leader:  *req.Leader,
+	}
+	if err := pr.prophetClient.ShardKeepalive(keepalive, pr.shardHeartbeatSent); err != nil {
+		pr.shardHeartbeatSent(err)
+	}
+}
+
+// isPermanentHeartbeatError returns true if resending the heartbeat can not
+// succeed.
+func isPermanentHeartbeatError(err error) bool {
+	return err == prophet.ErrClosed
+}
+
+// getShardGroupKey returns the group key of the shard sent with the heartbeat.
+// The shard may be mis-bucketed by the scheduler with an invalid group key, so
+// the key encoded with the shard group only is used as the fallback.
+func (pr *replica) getShardGroupKey(shard Shard) string {
+	key := pr.groupController.getShardGroupKey(shard)
+	if isValidGroupKey(key, shard.Group) {
+		return key
+	}
+
+	pr.logger.Warn("invalid shard group key, use the fallback key",
+		log.HexField("group-key", []byte(key)))
+	metric.IncInvalidGroupKeyCount()
+	return getFallbackGroupKey(shard)
+}
+
 func (pr *replica) doCheckLogCompact(progresses map[uint64]trackerPkg.Progress, lastIndex uint64) {
 	if !pr.isLeader() {
+		pr.clearLaggingReplicas()
+		pr.shardMetrics.SetRaftLogLag(0)
 		return
 	}
+	pr.updateLaggingReplicas(progresses, lastIndex)
 
 	var minReplicatedIndex uint64
 	for _, p := range progresses {
@@ -501,6 +1102,7 @@ func (pr *replica) doCheckLogCompact(progresses map[uint64]trackerPkg.Progress,
 				zap.Uint64("last", lastIndex))
 		}
 		metric.ObserveRaftLogLag(lastIndex - minReplicatedIndex)
+		pr.shardMetrics.SetRaftLogLag(lastIndex - minReplicatedIndex)
 	}
 
 	compactIndex := minReplicatedIndex
@@ -523,7 +1125,7 @@ func (pr *replica) doCheckLogCompact(progresses map[uint64]trackerPkg.Progress,
 		appliedIndex-firstIndex >= pr.feature.ForceCompactCount {
 		compactIndex = appliedIndex
 	} else if compactIndex == 0 &&
-		pr.stats.raftLogSizeHint >= pr.feature.ForceCompactBytes {
+		atomic.LoadUint64(&pr.stats.raftLogSizeHint) >= pr.feature.ForceCompactBytes {
 		compactIndex = appliedIndex
 	}
 
@@ -537,14 +1139,26 @@ func (pr *replica) doCheckLogCompact(progresses map[uint64]trackerPkg.Progress,
 		return
 	}
 
+	lagging := 0
 	if compactIndex > minReplicatedIndex {
 		pr.logger.Debug("some replica lag is too large, maybe sent a snapshot later",
 			zap.Uint64("lag", compactIndex-minReplicatedIndex))
+		for _, p := range progresses {
+			if p.Match < compactIndex {
+				lagging++
+			}
+		}
 	}
 	compactIndex--
 	if compactIndex < firstIndex {
 		return
 	}
+	if !pr.store.allowCompactionSnapshots(lagging) {
+		pr.logger.Info("requesting log compaction skipped, too many snapshots induced by log compaction",
+			log.IndexField(compactIndex),
+			zap.Int("lagging-replicas", lagging))
+		return
+	}
 	pr.logger.Info("requesting log compaction",
 		log.IndexField(compactIndex))
 	pr.addAdminRequest(rpcpb.CmdCompactLog, &rpcpb.CompactLogRequest{
@@ -553,7 +1167,7 @@ func (pr *replica) doCheckLogCompact(progresses map[uint64]trackerPkg.Progress,
 }
 
 func (pr *replica) doLogCompaction(index uint64) error {
-	if index == 0 {
+	if index == 0 || pr.deferLogCompaction(index) {
 		return nil
 	}
 	pr.logger.Info("log compaction action handled",
@@ -599,6 +1213,10 @@ func (pr *replica) doLogCompaction(index uint64) error {
 	}
 	pr.logger.Info("dummy snapshot saved",
 		log.IndexField(index))
+	firstIndex, _ := pr.lr.FirstIndex()
+	if err := pr.compactRaftLogSize(firstIndex, index); err != nil {
+		return err
+	}
 	// update LogReader's range info to make the compacted entries invisible to
 	// raft.
 	if err := pr.lr.Compact(index); err != nil {
@@ -612,6 +1230,10 @@ func (pr *replica) doLogCompaction(index uint64) error {
 	}
 	pr.logger.Info("compaction completed",
 		log.IndexField(index))
+	if index >= firstIndex {
+		pr.store.compactionStats.addLogCompaction(pr.getShard().Group,
+			index-firstIndex+1)
+	}
 
 	return nil
 }
@@ -626,6 +1248,12 @@ func (pr *replica) notifyShutdownToPendings() {
 	// resp all pending requests in batch and queue
 	pr.pendingReads.close()
 
+	pr.rejectRequests()
+}
+
+// rejectRequests stops accepting new requests, the requests already in the
+// queue are responded with the store not match error.
+func (pr *replica) rejectRequests() {
 	requests := pr.requests.Dispose()
 	for _, r := range requests {
 		req := r.(reqCtx)
@@ -634,3 +1262,62 @@ func (pr *replica) notifyShutdownToPendings() {
 		}
 	}
 }
+
+// updateLaggingReplicas marks the replicas whose raft log lag exceeds
+// RaftLog.LaggingHighWaterMark as lagging. A lagging replica is cleared only
+// after its lag drops below RaftLog.LaggingLowWaterMark, so that a replica
+// hovering around the high-water mark does not flap.
+func (pr *replica) updateLaggingReplicas(progresses map[uint64]trackerPkg.Progress, lastIndex uint64) {
+	high := pr.store.cfg.Raft.RaftLog.LaggingHighWaterMark
+	if high == 0 {
+		return
+	}
+	low := pr.store.cfg.Raft.RaftLog.LaggingLowWaterMark
+
+	for id, p := range progresses {
+		// the match index is unknown right after an election or a new replica
+		// is added
+		if id == pr.replicaID || p.Match == 0 {
+			continue
+		}
+		var lag uint64
+		if lastIndex > p.Match {
+			lag = lastIndex - p.Match
+		}
+		_, lagging := pr.laggingReplicas[id]
+		if !lagging && lag > high {
+			pr.laggingReplicas[id] = struct{}{}
+			metric.SetShardLagging(pr.shardID, id)
+			pr.logger.Warn("replica is lagging",
+				log.ReplicaIDField(id),
+				zap.Uint64("lag", lag),
+				zap.Uint64("high-water-mark", high))
+		} else if lagging && lag < low {
+			pr.clearLaggingReplica(id)
+			pr.logger.Info("replica is no longer lagging",
+				log.ReplicaIDField(id),
+				zap.Uint64("lag", lag),
+				zap.Uint64("low-water-mark", low))
+		}
+	}
+	// removed replicas
+	for id := range pr.laggingReplicas {
+		if _, ok := progresses[id]; !ok {
+			pr.clearLaggingReplica(id)
+		}
+	}
+}
+
+// clearLaggingReplicas clears all lagging marks, the replica lost its
+// leadership and the new leader tracks the lagging replicas, or the replica is
+// shut down.
+func (pr *replica) clearLaggingReplicas() {
+	for id := range pr.laggingReplicas {
+		pr.clearLaggingReplica(id)
+	}
+}
+
+func (pr *replica) clearLaggingReplica(id uint64) {
+	delete(pr.laggingReplicas, id)
+	metric.DeleteShardLagging(pr.shardID, id)
+}
@@ -14,17 +14,31 @@
 package raftstore
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	cpebble "github.com/cockroachdb/pebble"
 	"github.com/fagongzi/util/protoc"
+	"github.com/golang/mock/gomock"
+	"github.com/juju/ratelimit"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
 	trackerPkg "go.etcd.io/etcd/raft/v3/tracker"
 
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/components/prophet"
+	"github.com/matrixorigin/matrixcube/components/prophet/mock/mockclient"
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/logdb"
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
@@ -72,6 +86,7 @@ func getCloseableReplica() (*replica, func()) {
 	}
 	return &replica{
 		logger:            l,
+		clock:             realClock{},
 		replica:           r,
 		shardID:           shardID,
 		rn:                rn,
@@ -83,8 +98,9 @@ func getCloseableReplica() (*replica, func()) {
 		messages:          task.New(32),
 		requests:          task.New(32),
 		actions:           task.New(32),
+		priorityActions:   task.New(32),
 		feedbacks:         task.New(32),
-		snapshotStatus:    task.New(32),
+		snapshotStatus:    newSnapshotStatusQueue(0),
 		items:             make([]interface{}, 1024),
 		startedC:          make(chan struct{}),
 		closedC:           make(chan struct{}),
@@ -111,6 +127,9 @@ func TestApplyInitialSnapshot(t *testing.T) {
 		}
 		assert.Equal(t, uint64(100), ss.Metadata.Index)
 		assert.True(t, created)
+		var si metapb.SnapshotInfo
+		protoc.MustUnmarshal(&si, ss.Data)
+		assert.True(t, si.TotalSize > 0)
 
 		rd := raft.Ready{Snapshot: ss}
 		assert.NoError(t, r.logdb.SaveRaftState(1, 1, rd, r.logdb.NewWorkerContext()))
@@ -158,6 +177,73 @@ func TestApplyInitialSnapshot(t *testing.T) {
 	runReplicaSnapshotTest(t, fn, fs)
 }
 
+type flakyPersistentLogIndexDataStorage struct {
+	storage.DataStorage
+	failures int
+}
+
+func (s *flakyPersistentLogIndexDataStorage) GetPersistentLogIndex(shardID uint64) (uint64, error) {
+	if s.failures > 0 {
+		s.failures--
+		return 0, errors.New("transient failure")
+	}
+	return s.DataStorage.GetPersistentLogIndex(shardID)
+}
+
+func TestApplyInitialSnapshotRetriesPersistentLogIndex(t *testing.T) {
+	fn := func(t *testing.T, r *replica, fs vfs.FS) {
+		ss, created, err := r.createSnapshot()
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		rd := raft.Ready{Snapshot: ss}
+		assert.NoError(t, r.logdb.SaveRaftState(1, 1, rd, r.logdb.NewWorkerContext()))
+		dsMem := mem.NewStorage()
+		base := kv.NewBaseStorage(dsMem, fs)
+		ds := kv.NewKVDataStorage(base, nil)
+		defer ds.Close()
+		_, err = ds.GetInitialStates()
+		assert.NoError(t, err)
+		replicaRec := Replica{ID: 1, StoreID: 100}
+		shard := Shard{ID: 1, Replicas: []Replica{replicaRec}}
+		flaky := &flakyPersistentLogIndexDataStorage{DataStorage: ds, failures: 3}
+		r.sm = newStateMachine(r.logger, flaky, r.logdb, shard, replicaRec, nil, nil, nil)
+		r.cfg.Raft.MaxInitRetryDuration.Duration = time.Second * 10
+
+		hasEvent, err := r.handleEvent(r.logdb.NewWorkerContext())
+		assert.NoError(t, err)
+		assert.True(t, hasEvent)
+		assert.True(t, r.initialized)
+		assert.Equal(t, 0, flaky.failures)
+		assert.Equal(t, ss.Metadata.Index, r.sm.metadataMu.index)
+	}
+	fs := vfs.GetTestFS()
+	runReplicaSnapshotTest(t, fn, fs)
+}
+
+func TestGetPersistentLogIndexWithRetry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	flaky := &flakyPersistentLogIndexDataStorage{failures: 100}
+	r.sm.dataStorage = flaky
+
+	// gives up once the max retry duration exceeded
+	r.cfg.Raft.MaxInitRetryDuration.Duration = time.Millisecond * 50
+	_, err := r.getPersistentLogIndexWithRetry()
+	assert.Error(t, err)
+	assert.NotEqual(t, ErrReplicaStopped, err)
+	assert.True(t, flaky.failures < 100)
+
+	// interrupted by closing the replica
+	r.cfg.Raft.MaxInitRetryDuration.Duration = time.Minute
+	r.close()
+	_, err = r.getPersistentLogIndexWithRetry()
+	assert.Equal(t, ErrReplicaStopped, err)
+}
+
 func TestInitialSnapshotRecordIsNeverRemoved(t *testing.T) {
 	fn := func(t *testing.T, r *replica, fs vfs.FS) {
 		ss, created, err := r.createSnapshot()
@@ -297,3 +383,1271 @@ func TestDoCheckCompactLog(t *testing.T) {
 	protoc.MustUnmarshal(req, v.(reqCtx).req.Cmd)
 	assert.Equal(t, uint64(100), req.CompactIndex)
 }
+
+type testRateLimitClock struct {
+	now time.Time
+}
+
+func (c *testRateLimitClock) Now() time.Time        { return c.now }
+func (c *testRateLimitClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestDoCheckCompactLogLimitsInducedSnapshots(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	pr.leaderID = 1
+
+	// two snapshots per second
+	clock := &testRateLimitClock{now: time.Now()}
+	s.compactionSnapshotLimiter = ratelimit.NewBucketWithRateAndClock(2, 2, clock)
+
+	pr.feature.ForceCompactCount = 1
+	pr.store.cfg.Raft.RaftLog.CompactThreshold = 10
+	pr.sm.setFirstIndex(100)
+	pr.appliedIndex = 200
+	progresses := map[uint64]trackerPkg.Progress{
+		1: {Match: 200},
+		2: {Match: 101},
+		3: {Match: 102},
+	}
+
+	// compaction past the 2 lagging replicas
+	pr.doCheckLogCompact(progresses, 200)
+	assert.Equal(t, int64(1), pr.requests.Len())
+
+	// aggressive compactions are skipped until the rate limit allows
+	for i := 0; i < 10; i++ {
+		pr.doCheckLogCompact(progresses, 200)
+	}
+	assert.Equal(t, int64(1), pr.requests.Len())
+	clock.Sleep(time.Millisecond * 500)
+	pr.doCheckLogCompact(progresses, 200)
+	assert.Equal(t, int64(1), pr.requests.Len())
+
+	clock.Sleep(time.Millisecond * 500)
+	pr.doCheckLogCompact(progresses, 200)
+	assert.Equal(t, int64(2), pr.requests.Len())
+
+	// compactions not inducing snapshots are not limited
+	progresses[2] = trackerPkg.Progress{Match: 200}
+	progresses[3] = trackerPkg.Progress{Match: 200}
+	pr.doCheckLogCompact(progresses, 200)
+	assert.Equal(t, int64(3), pr.requests.Len())
+}
+
+func TestDoCheckCompactLogMarksLaggingReplicas(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 103}, Replica{ID: 1}, s)
+	pr.leaderID = 1
+	pr.store.cfg.Raft.RaftLog.CompactThreshold = 1000
+	pr.store.cfg.Raft.RaftLog.LaggingHighWaterMark = 100
+	pr.store.cfg.Raft.RaftLog.LaggingLowWaterMark = 50
+
+	check := func(match2, match3 uint64) {
+		pr.doCheckLogCompact(map[uint64]trackerPkg.Progress{
+			1: {Match: 1000},
+			2: {Match: match2},
+			3: {Match: match3},
+		}, 1000)
+	}
+	// the replicas with the lagging gauge
+	lagging := func() []uint64 {
+		var replicas []uint64
+		for _, id := range []uint64{2, 3} {
+			if v, ok := getMetricValue(t, "matrixcube_raftstore_shard_lagging",
+				map[string]string{"shard": "103", "replica": fmt.Sprintf("%d", id)}); ok {
+				assert.Equal(t, float64(1), v)
+				replicas = append(replicas, id)
+			}
+		}
+		return replicas
+	}
+
+	// at the high-water mark, not lagging yet
+	check(900, 1000)
+	assert.Empty(t, lagging())
+	check(899, 1000)
+	assert.Equal(t, []uint64{2}, lagging())
+	// between the marks, still lagging
+	check(920, 1000)
+	check(899, 1000)
+	check(950, 1000)
+	assert.Equal(t, []uint64{2}, lagging())
+	// below the low-water mark
+	check(951, 1000)
+	assert.Empty(t, lagging())
+	// between the marks, not lagging again
+	check(920, 1000)
+	check(951, 1000)
+	assert.Empty(t, lagging())
+
+	// unknown match index is skipped
+	check(0, 800)
+	assert.Equal(t, []uint64{3}, lagging())
+	assert.Equal(t, map[uint64]struct{}{3: {}}, pr.laggingReplicas)
+
+	// the removed replica is cleared
+	pr.doCheckLogCompact(map[uint64]trackerPkg.Progress{
+		1: {Match: 1000},
+		2: {Match: 1000},
+	}, 1000)
+	assert.Empty(t, lagging())
+
+	// the lagging replicas are cleared once the leadership is lost
+	check(1000, 800)
+	assert.Equal(t, []uint64{3}, lagging())
+	pr.leaderID = 2
+	pr.doCheckLogCompact(nil, 0)
+	assert.Empty(t, lagging())
+	assert.Empty(t, pr.laggingReplicas)
+}
+
+func TestHandleSnapshotStatusSkipsNonMemberReplica(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.sm.metadataMu.shard = Shard{ID: 1, Replicas: []Replica{{ID: 1}, {ID: 2}}}
+	assert.True(t, r.isShardMember(1))
+	assert.True(t, r.isShardMember(2))
+	assert.False(t, r.isShardMember(3))
+
+	r.snapshotStatus.put(snapshotStatus{to: 3})
+	r.snapshotStatus.put(snapshotStatus{to: 2, rejected: true})
+	assert.True(t, r.handleSnapshotStatus(r.items))
+	assert.Equal(t, int64(0), r.snapshotStatus.len())
+}
+
+func TestAddRequestRejected(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	newReq := func(size int) reqCtx {
+		return newReqCtx(rpcpb.Request{ID: []byte{0x1}, Type: rpcpb.Write, Cmd: make([]byte, size)}, nil)
+	}
+	checkResp := func(err error, retryable bool) {
+		var resp rpcpb.ResponseBatch
+		s.respRejectedRequest(1, err, newReq(1).req, func(rb rpcpb.ResponseBatch) { resp = rb })
+		assert.Equal(t, retryable, errorpb.Retryable(resp.Header.Error))
+	}
+
+	pr.cfg.Raft.MaxEntryBytes = 100
+	assert.Equal(t, ErrRequestTooLarge, pr.addRequest(newReq(200)))
+	checkResp(ErrRequestTooLarge, false)
+	// the too large admin requests are dropped
+	assert.NotPanics(t, func() {
+		pr.addAdminRequest(rpcpb.CmdBatchSplit, &rpcpb.BatchSplitRequest{
+			Requests: []rpcpb.SplitRequest{{Start: make([]byte, 200)}},
+		})
+	})
+	assert.Equal(t, int64(0), pr.requests.Len())
+
+	pr.cfg.Raft.MaxRequestQueueSize = 1
+	assert.NoError(t, pr.addRequest(newReq(1)))
+	assert.Equal(t, ErrRequestQueueFull, pr.addRequest(newReq(1)))
+	checkResp(ErrRequestQueueFull, true)
+	// admin requests are not limited by the queue size
+	assert.NoError(t, pr.addRequest(newReqCtx(rpcpb.Request{ID: []byte{0x2}, Type: rpcpb.Admin}, nil)))
+	pr.cfg.Raft.MaxRequestQueueSize = 0
+
+	pr.cfg.Raft.RejectRateLimitedRequests = true
+	limiter := pr.limiter
+	pr.limiter = ratelimit.NewBucketWithRate(1, 1)
+	assert.Equal(t, ErrRateLimited, pr.addRequest(newReq(10)))
+	checkResp(ErrRateLimited, true)
+	pr.cfg.Raft.RejectRateLimitedRequests = false
+	pr.limiter = limiter
+
+	pr.requests.Dispose()
+	assert.Equal(t, ErrReplicaStopped, pr.addRequest(newReq(0)))
+}
+
+func TestSuppressElection(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.replicaID = 1
+	r.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+
+	// not initialized
+	r.updateSuppressElection()
+	assert.True(t, r.isElectionSuppressed())
+	assert.True(t, r.addRaftTick())
+	assert.Equal(t, int64(0), r.ticks.Len())
+	assert.Equal(t, uint64(0), r.getTickTotalCount())
+	assert.NoError(t, r.ticks.Put(struct{}{}))
+	assert.True(t, r.handleTick(r.items))
+	assert.Equal(t, int64(0), r.ticks.Len())
+	assert.Equal(t, uint64(0), r.getTickHandledCount())
+	assert.NoError(t, r.actions.Put(action{actionType: campaignAction}))
+	_, err := r.handleAction(r.items)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.StateFollower, r.rn.Status().RaftState)
+
+	// read only
+	r.initialized = true
+	r.cfg.Raft.ReadOnly = true
+	r.updateSuppressElection()
+	assert.True(t, r.isElectionSuppressed())
+
+	// resumed
+	r.cfg.Raft.ReadOnly = false
+	r.updateSuppressElection()
+	assert.False(t, r.isElectionSuppressed())
+	assert.NoError(t, r.ticks.Put(struct{}{}))
+	assert.True(t, r.handleTick(r.items))
+	assert.Equal(t, uint64(1), r.getTickHandledCount())
+	assert.NoError(t, r.actions.Put(action{actionType: campaignAction}))
+	_, err = r.handleAction(r.items)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.StateLeader, r.rn.Status().RaftState)
+}
+
+func TestReplicaLastError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	err, at := r.LastError()
+	assert.NoError(t, err)
+	assert.True(t, at.IsZero())
+
+	// local messages can not be stepped into the raft node
+	before := time.Now()
+	r.committedIndexes = make(map[uint64]uint64)
+	assert.NoError(t, r.messages.Put(metapb.RaftMessage{
+		Message: raftpb.Message{Type: raftpb.MsgHup, From: 2},
+	}))
+	assert.True(t, r.handleMessage(r.items))
+	err, at = r.LastError()
+	assert.Equal(t, raft.ErrStepLocalMsg, err)
+	assert.False(t, at.Before(before))
+}
+
+// setTestStore sets a store with a not started worker pool to the replica
+// returned by getCloseableReplica, so the replica can notify the worker.
+func setTestStore(r *replica) {
+	r.store = &store{
+		meta:       metapb.Store{ID: 1},
+		logger:     r.logger,
+		workerPool: newWorkerPool(r.logger, r.logdb, nil, 1),
+	}
+	r.store.addReplica(r)
+	r.setStarted()
+}
+
+func TestReplicaEventQueueBudget(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.replica = Replica{ID: 1, StoreID: 1}
+	r.replicaID = r.replica.ID
+	r.incomingProposals = newProposalBatch(r.logger, 0, r.shardID, r.replica)
+	r.committedIndexes = make(map[uint64]uint64)
+	r.initialized = true
+	r.queueBudget = newEventQueueBudget(config.EventQueueBudgetConfig{Messages: 8})
+	setTestStore(r)
+
+	// flood the messages queue
+	messages := 200
+	for i := 0; i < messages; i++ {
+		assert.NoError(t, r.messages.Put(metapb.RaftMessage{
+			Message: raftpb.Message{Type: raftpb.MsgHup, From: 2},
+		}))
+	}
+	requests := 10
+	responded := 0
+	for i := 0; i < requests; i++ {
+		req := rpcpb.Request{ID: []byte{byte(i)}, Type: rpcpb.Write, Key: []byte{byte(i)}}
+		assert.NoError(t, r.requests.Put(newReqCtx(req, func(resp rpcpb.ResponseBatch) {
+			assert.Equal(t, 1, len(resp.Responses))
+			assert.NotNil(t, resp.Responses[0].Error.NotLeader)
+			responded++
+		})))
+	}
+
+	// all requests are handled in the first round, while the messages are
+	// drained in bounded slices
+	wc := r.logdb.NewWorkerContext()
+	defer wc.Close()
+	hasEvent, err := r.handleEvent(wc)
+	assert.NoError(t, err)
+	assert.True(t, hasEvent)
+	assert.Equal(t, requests, responded)
+	assert.Equal(t, int64(0), r.requests.Len())
+	assert.Equal(t, int64(messages-8), r.messages.Len())
+
+	for i := 1; i < messages/8; i++ {
+		_, err := r.handleEvent(wc)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int64(0), r.messages.Len())
+}
+
+func TestVoteSteppedAfterInitialSnapshotApplied(t *testing.T) {
+	fn := func(t *testing.T, r *replica, fs vfs.FS) {
+		ss, created, err := r.createSnapshot()
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		rd := raft.Ready{Snapshot: ss}
+		assert.NoError(t, r.logdb.SaveRaftState(1, 1, rd, r.logdb.NewWorkerContext()))
+		dsMem := mem.NewStorage()
+		base := kv.NewBaseStorage(dsMem, fs)
+		ds := kv.NewKVDataStorage(base, nil)
+		defer ds.Close()
+		_, err = ds.GetInitialStates()
+		assert.NoError(t, err)
+		replicaRec := Replica{ID: 1, StoreID: 100}
+		shard := Shard{ID: 1, Replicas: []Replica{replicaRec}}
+		r.sm = newStateMachine(r.logger, ds, r.logdb, shard, replicaRec, nil, nil, nil)
+		// the raft node is started on the initial snapshot as initLogState does
+		require.NoError(t, r.lr.ApplySnapshot(ss))
+		c := &raft.Config{
+			ID:              1,
+			ElectionTick:    10,
+			HeartbeatTick:   1,
+			Storage:         r.lr,
+			MaxInflightMsgs: 100,
+			Applied:         ss.Metadata.Index,
+		}
+		r.rn, err = raft.NewRawNode(c)
+		require.NoError(t, err)
+		r.committedIndexes = make(map[uint64]uint64)
+		r.ticks = task.New(32)
+		r.requests = task.New(32)
+		r.feedbacks = task.New(32)
+		r.snapshotStatus = newSnapshotStatusQueue(0)
+		r.pendingProposals = newPendingProposals()
+		r.incomingProposals = newProposalBatch(r.logger, 0, r.shardID, r.replica)
+		r.pendingReads = &readIndexQueue{shardID: r.shardID, logger: r.logger}
+		r.closedC = make(chan struct{})
+		r.messages = task.New(32)
+		r.items = make([]interface{}, 1024)
+
+		// the vote received before the initial snapshot is applied stays queued
+		vote := metapb.RaftMessage{
+			Message: raftpb.Message{Type: raftpb.MsgVote, From: 2, To: 1,
+				Term: ss.Metadata.Term + 5, Index: ss.Metadata.Index, LogTerm: ss.Metadata.Term},
+		}
+		assert.NoError(t, r.messages.Put(vote))
+		term := r.rn.BasicStatus().Term
+		hasEvent, err := r.handleEvent(r.logdb.NewWorkerContext())
+		assert.NoError(t, err)
+		assert.True(t, hasEvent)
+		assert.True(t, r.initialized)
+		assert.Equal(t, ss.Metadata.Index, r.sm.metadataMu.index)
+		assert.Equal(t, int64(1), r.messages.Len())
+		assert.Equal(t, term, r.rn.BasicStatus().Term)
+
+		// it is stepped in the next round after the snapshot is applied
+		hasEvent, err = r.handleEvent(r.logdb.NewWorkerContext())
+		assert.NoError(t, err)
+		assert.True(t, hasEvent)
+		assert.Equal(t, int64(0), r.messages.Len())
+		assert.Equal(t, vote.Message.Term, r.rn.BasicStatus().Term)
+	}
+	fs := vfs.GetTestFS()
+	runReplicaSnapshotTest(t, fn, fs)
+}
+
+func TestReplicaTinyReadyBatchSize(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.committedIndexes = make(map[uint64]uint64)
+	r.initialized = true
+	r.batchSize = newAdaptiveBatchSize(0, 2)
+	r.items = make([]interface{}, 2)
+	setTestStore(r)
+
+	count := 5
+	for i := 0; i < count; i++ {
+		assert.NoError(t, r.ticks.Put(struct{}{}))
+		assert.NoError(t, r.feedbacks.Put(uint64(2)))
+		assert.NoError(t, r.actions.Put(action{actionType: checkPendingReadsAction}))
+		assert.NoError(t, r.messages.Put(metapb.RaftMessage{
+			Message: raftpb.Message{Type: raftpb.MsgHup, From: 2},
+		}))
+	}
+
+	drain := func(q *task.Queue, handle func() bool) {
+		iterations := 0
+		for q.Len() > 0 {
+			expected := q.Len() - 2
+			if expected < 0 {
+				expected = 0
+			}
+			assert.True(t, handle())
+			assert.Equal(t, expected, q.Len())
+			iterations++
+		}
+		assert.Equal(t, 3, iterations)
+	}
+	drain(r.ticks, func() bool { return r.handleTick(r.items) })
+	drain(r.feedbacks, func() bool { return r.handleFeedback(r.items) })
+	drain(r.messages, func() bool { return r.handleMessage(r.items) })
+	drain(r.actions, func() bool {
+		ok, err := r.handleAction(r.items)
+		assert.NoError(t, err)
+		return ok
+	})
+	assert.Equal(t, uint64(count), atomic.LoadUint64(&r.tickHandledCount))
+}
+
+func TestReplicaCompactionLag(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.committedIndexes = make(map[uint64]uint64)
+	r.compactionLags = make(map[uint64]uint64)
+	r.lr = NewLogReader(r.logger, r.shardID, 1, r.logdb)
+	assert.NoError(t, r.lr.ApplySnapshot(raftpb.Snapshot{
+		Metadata: raftpb.SnapshotMetadata{Index: 100, Term: 1},
+	}))
+	heartbeatResp := func(from uint64, firstIndex uint64) metapb.RaftMessage {
+		return metapb.RaftMessage{
+			From:       Replica{ID: from},
+			Message:    raftpb.Message{Type: raftpb.MsgHeartbeatResp, From: from},
+			FirstIndex: firstIndex,
+		}
+	}
+
+	// disabled
+	r.stepRaftMessage(heartbeatResp(2, 10))
+	assert.Empty(t, r.compactionLags)
+
+	// not leader
+	r.cfg.Raft.ReportCompactionLag = true
+	r.replicaID = 1
+	r.stepRaftMessage(heartbeatResp(2, 10))
+	assert.Empty(t, r.compactionLags)
+
+	r.leaderID = 1
+	r.stepRaftMessage(heartbeatResp(2, 10))
+	r.stepRaftMessage(heartbeatResp(3, 101))
+	r.stepRaftMessage(heartbeatResp(4, 200))
+	r.stepRaftMessage(heartbeatResp(5, 0))
+	assert.Equal(t, map[uint64]uint64{2: 91, 3: 0, 4: 0}, r.compactionLags)
+
+	// the lagging follower catches up
+	r.stepRaftMessage(heartbeatResp(2, 101))
+	assert.Equal(t, uint64(0), r.compactionLags[2])
+}
+
+func TestReplicaNotifyWorkerCoalesced(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.committedIndexes = make(map[uint64]uint64)
+	r.initialized = true
+	setTestStore(r)
+
+	for i := 0; i < 100; i++ {
+		r.addFeedback(uint64(2))
+	}
+	assert.Equal(t, uint64(1), r.getNotifyCount())
+
+	// the worker notifies itself when the batch is not fully drained
+	r.batchSize = newAdaptiveBatchSize(0, 64)
+	hasEvent, err := r.handleEvent(r.logdb.NewWorkerContext())
+	assert.NoError(t, err)
+	assert.True(t, hasEvent)
+	assert.Equal(t, uint64(2), r.getNotifyCount())
+
+	r.addFeedback(uint64(2))
+	assert.Equal(t, uint64(2), r.getNotifyCount())
+	hasEvent, err = r.handleEvent(r.logdb.NewWorkerContext())
+	assert.NoError(t, err)
+	assert.True(t, hasEvent)
+	assert.Equal(t, int64(0), r.feedbacks.Len())
+	assert.Equal(t, uint64(2), r.getNotifyCount())
+
+	// notified again after the worker started to handle the events
+	r.addFeedback(uint64(2))
+	assert.Equal(t, uint64(3), r.getNotifyCount())
+}
+
+func TestReplicaNotifyWorkerNeverMissesEvents(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.committedIndexes = make(map[uint64]uint64)
+	r.initialized = true
+	r.store = &store{meta: metapb.Store{ID: 1}, logger: r.logger}
+	r.store.workerPool = newWorkerPool(r.logger, r.logdb,
+		&storeReplicaLoader{store: r.store}, 4)
+	r.store.addReplica(r)
+	r.setStarted()
+	r.store.workerPool.start()
+	defer r.store.workerPool.close()
+
+	producers := 8
+	count := 1000
+	var wg sync.WaitGroup
+	for i := 0; i < producers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				r.addFeedback(uint64(2))
+				r.addAction(action{actionType: checkPendingReadsAction})
+			}
+		}()
+	}
+	wg.Wait()
+
+	timeout := time.After(testWaitTimeout)
+	for r.feedbacks.Len() > 0 || r.actions.Len() > 0 {
+		select {
+		case <-timeout:
+			assert.FailNow(t, "events left unprocessed")
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+	assert.True(t, r.getNotifyCount() <= uint64(2*producers*count))
+}
+
+func BenchmarkReplicaNotifyWorker(b *testing.B) {
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.committedIndexes = make(map[uint64]uint64)
+	r.initialized = true
+	setTestStore(r)
+	wc := r.logdb.NewWorkerContext()
+	defer wc.Close()
+
+	burst := 1000
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < burst; j++ {
+			r.addFeedback(uint64(2))
+		}
+		for r.feedbacks.Len() > 0 {
+			if _, err := r.handleEvent(wc); err != nil {
+				b.Fatalf("failed to handle event %v", err)
+			}
+		}
+	}
+	b.ReportMetric(float64(burst), "items/op")
+	b.ReportMetric(float64(r.getNotifyCount())/float64(b.N), "notifications/op")
+}
+
+func TestReplicaActionQueueFull(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.initialized = true
+	r.cfg.Raft.MaxActionQueueSize = 4
+	setTestStore(r)
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, r.addAction(action{actionType: checkPendingReadsAction}))
+	}
+	assert.Equal(t, ErrActionQueueFull, r.addAction(action{actionType: checkCompactLogAction}))
+	assert.Equal(t, ErrActionQueueFull, r.addAction(action{actionType: checkSplitAction}))
+	assert.Equal(t, uint64(2), atomic.LoadUint64(&r.actionDroppedCount))
+	// high-priority actions are not limited by the queue size
+	assert.NoError(t, r.addAction(action{actionType: heartbeatAction}))
+	assert.Equal(t, int64(1), r.priorityActions.Len())
+
+	// critical actions are retried until the queue has room
+	assert.NoError(t, r.addAction(action{actionType: splitAction}))
+	assert.Eventually(t, func() bool {
+		return atomic.LoadUint64(&r.actionRetryCount) > 1
+	}, time.Second*10, time.Millisecond*10)
+	assert.Equal(t, int64(4), r.actions.Len())
+
+	n, err := r.actions.Get(4, r.items)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+	assert.Eventually(t, func() bool {
+		return r.actions.Len() == 1
+	}, time.Second*10, time.Millisecond*10)
+	n, err = r.actions.Get(1, r.items)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, splitAction, r.items[0].(action).actionType)
+	assert.Equal(t, uint64(2), atomic.LoadUint64(&r.actionDroppedCount))
+}
+
+func TestReplicaShutdownGraceful(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	r := Replica{ID: 1, StoreID: s.Meta().ID}
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{r}}, r, s)
+	pr.prophetClient = client
+	s.addReplica(pr)
+	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+	pr.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+	require.NoError(t, pr.rn.Campaign())
+	wc := pr.logdb.NewWorkerContext()
+	defer wc.Close()
+	require.NoError(t, pr.handleRaftReady(wc))
+	require.True(t, pr.isLeader())
+
+	// the writes are committed but not applied
+	var responses []rpcpb.ResponseBatch
+	for i := 0; i < 3; i++ {
+		req := createTestWriteReq(fmt.Sprintf("id-%d", i), fmt.Sprintf("key-%d", i), "value")
+		req.ToShard = 1
+		require.NoError(t, pr.addRequest(newReqCtx(req, func(resp rpcpb.ResponseBatch) {
+			responses = append(responses, resp)
+		})))
+	}
+	assert.True(t, pr.handleRequest(pr.items))
+	require.True(t, pr.appliedIndex < pr.rn.BasicStatus().Commit)
+	assert.Empty(t, responses)
+
+	pr.shutdownGraceful(time.Now().Add(time.Second * 10))
+	assert.Equal(t, pr.rn.BasicStatus().Commit, pr.appliedIndex)
+	// the writes are proposed in a single batch
+	require.Equal(t, 1, len(responses))
+	assert.Equal(t, errorpb.Error{}, responses[0].Header.Error)
+	require.Equal(t, 3, len(responses[0].Responses))
+	for _, resp := range responses[0].Responses {
+		assert.Equal(t, errorpb.Error{}, resp.Error)
+	}
+
+	// new requests are rejected
+	req := createTestWriteReq("id-3", "key-3", "value")
+	assert.Equal(t, ErrReplicaStopped, pr.addRequest(newReqCtx(req, nil)))
+}
+
+func TestReplicaHealth(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.committedIndexes = make(map[uint64]uint64)
+	r.initialized = true
+	setTestStore(r)
+
+	_, _, _, ok := r.store.ReplicaHealth(r.shardID + 1)
+	assert.False(t, ok)
+
+	// ticks keep climbing while the event loop makes no progress
+	for i := 0; i < 10; i++ {
+		assert.True(t, r.addRaftTick())
+	}
+	lastProgress, handled, total, ok := r.store.ReplicaHealth(r.shardID)
+	assert.True(t, ok)
+	assert.True(t, lastProgress.IsZero())
+	assert.Equal(t, uint64(0), handled)
+	assert.Equal(t, uint64(10), total)
+
+	start := time.Now()
+	wc := r.logdb.NewWorkerContext()
+	defer wc.Close()
+	_, err := r.handleEvent(wc)
+	assert.NoError(t, err)
+	lastProgress, handled, total, ok = r.store.ReplicaHealth(r.shardID)
+	assert.True(t, ok)
+	assert.False(t, lastProgress.Before(start))
+	assert.Equal(t, uint64(10), handled)
+	assert.Equal(t, uint64(10), total)
+}
+
+func TestReplicaLastActive(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.committedIndexes = make(map[uint64]uint64)
+	r.initialized = true
+	setTestStore(r)
+	assert.True(t, r.LastActive().IsZero())
+
+	wc := r.logdb.NewWorkerContext()
+	defer wc.Close()
+	start := time.Now()
+	assert.True(t, r.addRaftTick())
+	hasEvent, err := r.handleEvent(wc)
+	assert.NoError(t, err)
+	assert.True(t, hasEvent)
+	active := r.LastActive()
+	assert.False(t, active.Before(start))
+
+	// idle rounds do not advance it
+	for r.rn.HasReady() {
+		_, err := r.handleEvent(wc)
+		assert.NoError(t, err)
+	}
+	active = r.LastActive()
+	time.Sleep(time.Millisecond)
+	hasEvent, err = r.handleEvent(wc)
+	assert.NoError(t, err)
+	assert.False(t, hasEvent)
+	assert.Equal(t, active, r.LastActive())
+	assert.True(t, r.getLastProgress().After(active))
+
+	time.Sleep(time.Millisecond)
+	assert.True(t, r.addRaftTick())
+	hasEvent, err = r.handleEvent(wc)
+	assert.NoError(t, err)
+	assert.True(t, hasEvent)
+	assert.True(t, r.LastActive().After(active))
+}
+
+func newTestHeartbeatReplica(s *store, client *mockclient.MockClient) *replica {
+	r := Replica{ID: 1, StoreID: s.Meta().ID}
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{r}}, r, s)
+	pr.prophetClient = client
+	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+	pr.setLeaderReplicaID(pr.replicaID)
+	return pr
+}
+
+func TestShardHeartbeatRetry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	errFailed := errors.New("transient failure")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	// the failures are reported asynchronously after the heartbeat is sent
+	respond := func(err error) func(Shard, rpcpb.ShardHeartbeatReq, func(error)) error {
+		return func(shard Shard, req rpcpb.ShardHeartbeatReq, cb func(error)) error {
+			cb(err)
+			return nil
+		}
+	}
+	gomock.InOrder(
+		client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(respond(errFailed)).Times(2),
+		client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(respond(nil)).Times(1),
+	)
+	pr := newTestHeartbeatReplica(s, client)
+
+	pr.prophetHeartbeat()
+	assert.Equal(t, uint64(1), atomic.LoadUint64(&s.heartbeatFailures))
+	items := make([]interface{}, readyBatchSize)
+	require.Eventually(t, func() bool {
+		_, err := pr.handleAction(items)
+		require.NoError(t, err)
+		return atomic.LoadUint64(&pr.heartbeatFailures) == 0
+	}, testWaitTimeout, time.Millisecond*10)
+	assert.Equal(t, uint64(0), atomic.LoadUint64(&s.heartbeatFailures))
+	err, _ := pr.LastError()
+	assert.Equal(t, errFailed, err)
+}
+
+func TestShardHeartbeatRetryIsBounded(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	// permanent errors are not retried
+	client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).Return(prophet.ErrClosed).Times(1)
+	pr := newTestHeartbeatReplica(s, client)
+	pr.prophetHeartbeat()
+	assert.Equal(t, uint64(0), atomic.LoadUint64(&pr.heartbeatFailures))
+
+	// transient errors are retried maxHeartbeatRetries times
+	client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(shard Shard, req rpcpb.ShardHeartbeatReq, cb func(error)) error {
+			cb(prophet.ErrTimeout)
+			return nil
+		}).Times(maxHeartbeatRetries + 1)
+	pr.prophetHeartbeat()
+	items := make([]interface{}, readyBatchSize)
+	require.Eventually(t, func() bool {
+		_, err := pr.handleAction(items)
+		require.NoError(t, err)
+		return atomic.LoadUint64(&pr.heartbeatFailures) == 0
+	}, testWaitTimeout, time.Millisecond*10)
+	assert.Equal(t, uint64(maxHeartbeatRetries+2), atomic.LoadUint64(&s.heartbeatFailures))
+	// no more retry
+	time.Sleep(minHeartbeatRetryBackoff * 2)
+	_, err := pr.handleAction(items)
+	require.NoError(t, err)
+}
+
+func TestShardKeepaliveOfUnchangedShard(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	sent := 0
+	var keepalives []rpcpb.ShardKeepaliveReq
+	var keepaliveErr error
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(shard Shard, req rpcpb.ShardHeartbeatReq, cb func(error)) error {
+			sent++
+			cb(nil)
+			return nil
+		}).AnyTimes()
+	client.EXPECT().ShardKeepalive(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(req rpcpb.ShardKeepaliveReq, cb func(error)) error {
+			keepalives = append(keepalives, req)
+			cb(keepaliveErr)
+			return nil
+		}).AnyTimes()
+	pr := newTestHeartbeatReplica(s, client)
+	pr.cfg.Replication.MaxShardKeepalives = 2
+
+	// the shard is idle, keepalives are sent between the full heartbeats sent
+	// every 3 intervals
+	for i := 0; i < 7; i++ {
+		pr.prophetHeartbeat()
+	}
+	assert.Equal(t, 3, sent)
+	assert.Equal(t, 4, len(keepalives))
+	shard := pr.getShard()
+	for _, keepalive := range keepalives {
+		assert.Equal(t, rpcpb.ShardKeepaliveReq{
+			ShardID: shard.ID,
+			Epoch:   shard.Epoch,
+			Term:    pr.rn.BasicStatus().Term,
+			Leader:  pr.replica,
+		}, keepalive)
+	}
+	pr.prophetHeartbeat()
+	assert.Equal(t, 3, sent)
+	assert.Equal(t, 5, len(keepalives))
+
+	// stats changed
+	pr.stats.writtenKeys++
+	pr.prophetHeartbeat()
+	assert.Equal(t, 4, sent)
+	pr.prophetHeartbeat()
+	assert.Equal(t, 4, sent)
+	assert.Equal(t, 6, len(keepalives))
+
+	// the heartbeat after a failed one is always sent
+	pr.shardHeartbeatSent(prophet.ErrClosed)
+	pr.prophetHeartbeat()
+	assert.Equal(t, 5, sent)
+
+	// prophet rejects the keepalive, a full heartbeat is sent next
+	keepaliveErr = errors.New("shard changed since the last heartbeat")
+	pr.prophetHeartbeat()
+	assert.Equal(t, 7, len(keepalives))
+	pr.prophetHeartbeat()
+	assert.Equal(t, 6, sent)
+	assert.Equal(t, 7, len(keepalives))
+
+	pr.cfg.Replication.MaxShardKeepalives = 0
+	pr.prophetHeartbeat()
+	assert.Equal(t, 7, sent)
+	assert.Equal(t, 7, len(keepalives))
+}
+
+func TestShardHeartbeatWithCustomGroupKey(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	tenantKey := util.EncodeGroupKey(0, []metapb.ScheduleGroupRule{{ID: 1, GroupByLabel: "tenant"}},
+		[]metapb.Label{{Key: "tenant", Value: "t1"}})
+	s.cfg.Customize.CustomGroupKeyFunc = func(shard Shard) string {
+		for _, l := range shard.Labels {
+			if l.Key == "tenant" {
+				return tenantKey
+			}
+		}
+		return ""
+	}
+
+	var keys []string
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(shard Shard, req rpcpb.ShardHeartbeatReq, cb func(error)) error {
+			keys = append(keys, req.GroupKey)
+			cb(nil)
+			return nil
+		}).Times(2)
+	pr := newTestHeartbeatReplica(s, client)
+	// the default key is used if the func returns an empty key
+	pr.prophetHeartbeat()
+	pr.sm.updateShard(Shard{ID: 1, Replicas: pr.getShard().Replicas,
+		Labels: []metapb.Label{{Key: "tenant", Value: "t1"}}})
+	pr.prophetHeartbeat()
+	assert.Equal(t, []string{util.EncodeGroupKey(0, nil, nil), tenantKey}, keys)
+}
+
+type testStorePressureSampler struct {
+	pressure storePressure
+	samples  int
+}
+
+func (s *testStorePressureSampler) sample() (storePressure, error) {
+	s.samples++
+	return s.pressure, nil
+}
+
+func TestShardHeartbeatWithStorePressure(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	sampler := &testStorePressureSampler{pressure: storePressure{diskUsage: 85.5, cpuLoad: 42}}
+	s.pressure = newStorePressureCache(sampler, time.Second)
+
+	var reqs []rpcpb.ShardHeartbeatReq
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(shard Shard, req rpcpb.ShardHeartbeatReq, cb func(error)) error {
+			reqs = append(reqs, req)
+			cb(nil)
+			return nil
+		}).Times(3)
+	clock := newMockClock(time.Now())
+	pr := newTestHeartbeatReplica(s, client)
+	pr.clock = clock
+	pr2 := newTestHeartbeatReplica(s, client)
+	pr2.clock = clock
+
+	// the store pressure is sampled once for the heartbeats of all replicas in
+	// the interval
+	pr.prophetHeartbeat()
+	pr2.prophetHeartbeat()
+	assert.Equal(t, 1, sampler.samples)
+
+	clock.Advance(time.Second)
+	sampler.pressure = storePressure{diskUsage: 90, cpuLoad: 10}
+	pr.prophetHeartbeat()
+	assert.Equal(t, 2, sampler.samples)
+
+	require.Equal(t, 3, len(reqs))
+	for _, req := range reqs[:2] {
+		assert.Equal(t, 85.5, req.StoreDiskUsage)
+		assert.Equal(t, float64(42), req.StoreCPULoad)
+	}
+	assert.Equal(t, float64(90), reqs[2].StoreDiskUsage)
+	assert.Equal(t, float64(10), reqs[2].StoreCPULoad)
+}
+
+func TestShardHeartbeatWithQPS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	var reqs []rpcpb.ShardHeartbeatReq
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(shard Shard, req rpcpb.ShardHeartbeatReq, cb func(error)) error {
+			reqs = append(reqs, req)
+			cb(nil)
+			return nil
+		}).Times(2)
+	clock := newMockClock(time.Now())
+	pr := newTestHeartbeatReplica(s, client)
+	pr.clock = clock
+
+	pr.prophetHeartbeat()
+	// 200 reads and 100 writes in 2 seconds
+	for i := 0; i < 200; i++ {
+		pr.stats.addReadStats(10, 1)
+	}
+	pr.stats.writtenKeys += 100
+	clock.Advance(2 * time.Second)
+	pr.prophetHeartbeat()
+
+	require.Equal(t, 2, len(reqs))
+	assert.Equal(t, float64(0), reqs[0].ReadQPS)
+	assert.Equal(t, float64(0), reqs[0].WriteQPS)
+	assert.Equal(t, float64(100), reqs[1].ReadQPS)
+	assert.Equal(t, float64(50), reqs[1].WriteQPS)
+}
+
+func TestReplicaTicksToFire(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	interval := time.Millisecond * 100
+	r.cfg.Raft.TickInterval.Duration = interval
+	start := time.Now()
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	total := 0
+	fire := func(now time.Time, expectedTicks int, expectedNext time.Duration) {
+		n, next := r.ticksToFire(now)
+		assert.Equal(t, expectedTicks, n)
+		assert.Equal(t, expectedNext, next)
+		total += n
+	}
+
+	fire(at(0), 1, interval)
+	fire(at(interval), 1, interval)
+	// late by 150ms, the missed tick is fired and the next one is brought forward
+	fire(at(interval*3+interval/2), 2, interval/2)
+	fire(at(interval*4), 1, interval)
+	// fired early, nothing to catch up
+	fire(at(interval*4+interval/2), 0, interval/2)
+	fire(at(interval*5), 1, interval)
+	assert.Equal(t, 6, total)
+
+	// the catch-up is bounded, the rest of the delay is dropped
+	fire(at(interval*50+interval/2), maxCatchUpRaftTicks, interval)
+	fire(at(interval*51+interval/2), 1, interval)
+	assert.Equal(t, 6+maxCatchUpRaftTicks+1, total)
+}
+
+func TestQuarantinedReplicaGivesUpLeadership(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	newQuarantineTestReplica := func() (*replica, func()) {
+		r, closer := getCloseableReplica()
+		r.replicaID = 1
+		r.replica = Replica{ID: 1}
+		r.initialized = true
+		r.sm.updateShard(Shard{ID: 1, Replicas: []Replica{
+			{ID: 1, Role: metapb.ReplicaRole_Voter},
+			{ID: 2, Role: metapb.ReplicaRole_Voter},
+			{ID: 3, Role: metapb.ReplicaRole_Learner},
+		}})
+		r.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+		r.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 2})
+		r.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 3})
+		r.updateSuppressElection()
+		return r, closer
+	}
+
+	t.Run("leader", func(t *testing.T) {
+		r, closer := newQuarantineTestReplica()
+		defer r.close()
+		defer closer()
+
+		// elected with the votes of replica 2
+		require.NoError(t, r.rn.Campaign())
+		term := r.rn.BasicStatus().Term
+		require.NoError(t, r.rn.Step(raftpb.Message{From: 2, To: 1, Type: raftpb.MsgPreVoteResp, Term: term + 1}))
+		require.NoError(t, r.rn.Step(raftpb.Message{From: 2, To: 1, Type: raftpb.MsgVoteResp, Term: term + 1}))
+		require.Equal(t, raft.StateLeader, r.rn.Status().RaftState)
+		r.setLeaderReplicaID(1)
+
+		r.checkQuarantined()
+		assert.False(t, r.isElectionSuppressed())
+		assert.Equal(t, uint64(0), r.rn.BasicStatus().LeadTransferee)
+
+		// the leadership is transferred to the voter
+		r.sm.setQuarantined()
+		r.checkQuarantined()
+		assert.True(t, r.isElectionSuppressed())
+		assert.Equal(t, uint64(2), r.rn.BasicStatus().LeadTransferee)
+	})
+
+	t.Run("follower", func(t *testing.T) {
+		r, closer := newQuarantineTestReplica()
+		defer r.close()
+		defer closer()
+
+		r.sm.setQuarantined()
+		r.checkQuarantined()
+		assert.True(t, r.isElectionSuppressed())
+		// neither campaigns nor accepts the leadership
+		assert.NoError(t, r.actions.Put(action{actionType: campaignAction}))
+		_, err := r.handleAction(r.items)
+		assert.NoError(t, err)
+		assert.Equal(t, raft.StateFollower, r.rn.Status().RaftState)
+		r.stepRaftMessage(metapb.RaftMessage{Message: raftpb.Message{
+			From: 2, To: 1, Type: raftpb.MsgTimeoutNow, Term: r.rn.BasicStatus().Term}})
+		assert.Equal(t, raft.StateFollower, r.rn.Status().RaftState)
+	})
+}
+
+func TestReplicaHighPriorityActions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.replicaID = 1
+	r.initialized = true
+	r.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+	r.updateSuppressElection()
+	setTestStore(r)
+	r.queueBudget.actions = 8
+
+	// flood the normal lane
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, r.addAction(action{actionType: checkPendingReadsAction}))
+	}
+	assert.NoError(t, r.addAction(action{actionType: campaignAction}))
+	assert.Equal(t, int64(100), r.actions.Len())
+	assert.Equal(t, int64(1), r.priorityActions.Len())
+
+	// the campaign action is handled in the first round
+	hasEvent, err := r.handleAction(r.items)
+	assert.NoError(t, err)
+	assert.True(t, hasEvent)
+	assert.Equal(t, raft.StateLeader, r.rn.Status().RaftState)
+	assert.Equal(t, int64(0), r.priorityActions.Len())
+	assert.Equal(t, int64(92), r.actions.Len())
+}
+
+func TestReplicaActionsTimeBudget(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	setTestStore(r)
+	clock := newMockClock(time.Now())
+	r.clock = clock
+	r.queueBudget.actionsDuration = time.Millisecond * 10
+	handled := 0
+	slow := action{
+		actionType: getProgressAction,
+		actionCallback: func(interface{}) {
+			handled++
+			clock.Advance(time.Millisecond * 6)
+		},
+	}
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, r.addAction(slow))
+	}
+
+	// yields once the budget is exceeded
+	r.resetNotifyPending()
+	hasEvent, err := r.handleAction(r.items)
+	assert.NoError(t, err)
+	assert.True(t, hasEvent)
+	assert.Equal(t, 2, handled)
+	assert.Equal(t, int64(3), r.actionQueueLen())
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&r.notifyPending))
+
+	// no limit
+	r.queueBudget.actionsDuration = 0
+	hasEvent, err = r.handleAction(r.items)
+	assert.NoError(t, err)
+	assert.True(t, hasEvent)
+	assert.Equal(t, 5, handled)
+	assert.Equal(t, int64(0), r.actionQueueLen())
+}
+
+func TestReplicaMessageQueueBounded(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.cfg.Raft.MaxMessageQueueSize = 10
+	setTestStore(r)
+
+	msg := func(t raftpb.MessageType) metapb.RaftMessage {
+		return metapb.RaftMessage{Message: raftpb.Message{Type: t}}
+	}
+	for i := 0; i < 100; i++ {
+		r.addMessage(msg(raftpb.MsgHeartbeat))
+	}
+	assert.Equal(t, int64(10), r.messages.Len())
+	for i := 0; i < 100; i++ {
+		r.addMessage(msg(raftpb.MsgApp))
+	}
+	assert.Equal(t, int64(20), r.messages.Len())
+	assert.Equal(t, uint64(180), atomic.LoadUint64(&r.messageDroppedCount))
+
+	// votes and snapshots are never dropped
+	r.addMessage(msg(raftpb.MsgVote))
+	r.addMessage(msg(raftpb.MsgPreVote))
+	r.addMessage(msg(raftpb.MsgSnap))
+	assert.Equal(t, int64(23), r.messages.Len())
+	assert.Equal(t, uint64(180), atomic.LoadUint64(&r.messageDroppedCount))
+
+	n, err := r.messages.Get(100, r.items)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(23), n)
+	var types []raftpb.MessageType
+	for _, v := range r.items[20:n] {
+		types = append(types, v.(metapb.RaftMessage).Message.Type)
+	}
+	assert.Equal(t, []raftpb.MessageType{raftpb.MsgVote, raftpb.MsgPreVote, raftpb.MsgSnap}, types)
+}
+
+func TestReplicaRaftStepErrorCounted(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	getStepErrors := func(msgType raftpb.MessageType) float64 {
+		v, _ := getMetricValue(t, "matrixcube_raftstore_raft_step_error_total",
+			map[string]string{"type": msgType.String()})
+		return v
+	}
+	hupErrors := getStepErrors(raftpb.MsgHup)
+	appRespErrors := getStepErrors(raftpb.MsgAppResp)
+
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+	setTestStore(r)
+
+	// local messages can not be stepped
+	r.stepRaftMessage(metapb.RaftMessage{Message: raftpb.Message{Type: raftpb.MsgHup, From: 2, To: 1}})
+	err, _ := r.LastError()
+	assert.Equal(t, raft.ErrStepLocalMsg, err)
+	assert.Equal(t, hupErrors+1, getStepErrors(raftpb.MsgHup))
+	assert.Equal(t, appRespErrors, getStepErrors(raftpb.MsgAppResp))
+
+	// responses from the unknown replicas
+	r.stepRaftMessage(metapb.RaftMessage{Message: raftpb.Message{Type: raftpb.MsgAppResp, From: 100, To: 1}})
+	err, _ = r.LastError()
+	assert.Equal(t, raft.ErrStepPeerNotFound, err)
+	assert.Equal(t, hupErrors+1, getStepErrors(raftpb.MsgHup))
+	assert.Equal(t, appRespErrors+1, getStepErrors(raftpb.MsgAppResp))
+}
+
+func TestClearCompactionLags(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 104}, Replica{ID: 1}, s)
+	pr.compactionLags[2] = 10
+	metric.SetShardCompactionLag(104, 2, 10)
+
+	pr.clearCompactionLags()
+	assert.Empty(t, pr.compactionLags)
+	_, ok := getMetricValue(t, "matrixcube_raftstore_shard_compaction_lag_entries",
+		map[string]string{"shard": "104", "replica": "2"})
+	assert.False(t, ok)
+}
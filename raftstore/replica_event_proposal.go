@@ -14,14 +14,18 @@
 package raftstore
 
 import (
+	"time"
+
 	"github.com/cockroachdb/errors"
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"go.etcd.io/etcd/raft/v3/confchange"
 	"go.etcd.io/etcd/raft/v3/raftpb"
 	trackerPkg "go.etcd.io/etcd/raft/v3/tracker"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -32,6 +36,9 @@ var (
 	ErrPendingConfigChange        = errors.New("pending config change")
 	ErrDuplicatedRequest          = errors.New("duplicated config change request")
 	ErrLearnerOnlyChange          = errors.New("learner only change")
+	ErrPromoteLaggingLearner      = errors.New("promoting lagging learner")
+	ErrBreakQuorum                = errors.New("config change breaks the quorum")
+	ErrNoVoterLeft                = errors.New("no voter left")
 )
 
 type tracker = trackerPkg.ProgressTracker
@@ -66,8 +73,12 @@ const (
 
 // FIXME: fix the len == 0 and len() > 0 check below
 func (pr *replica) handleRequest(items []interface{}) bool {
+	// requests are kept in the queue until the replica is unfrozen
+	if pr.frozen {
+		return false
+	}
 	if size := pr.requests.Len(); size > 0 {
-		n, err := pr.requests.Get(readyBatchSize, items)
+		n, err := pr.requests.Get(pr.drainLimit(pr.queueBudget.requests), items)
 		if err != nil {
 			return false
 		}
@@ -76,6 +87,9 @@ func (pr *replica) handleRequest(items []interface{}) bool {
 			if ce := pr.logger.Check(zap.DebugLevel, "push to proposal batch"); ce != nil {
 				ce.Write(log.HexField("id", req.req.ID))
 			}
+			if req.ctx != nil {
+				trace.SpanFromContext(req.ctx).AddEvent("dequeued")
+			}
 			pr.incomingProposals.push(pr.group, req)
 		}
 	} else {
@@ -91,8 +105,7 @@ func (pr *replica) handleRequest(items []interface{}) bool {
 	}
 
 	size := pr.requests.Len()
-	// FIXME: why the metric is set here
-	metric.SetRaftRequestQueueMetric(size)
+	pr.queueMetrics.update(metric.RaftRequestQueue, size)
 	if size > 0 {
 		pr.notifyWorker()
 	}
@@ -122,6 +135,7 @@ func (pr *replica) propose(c batch) {
 	}
 
 	if madeProposal {
+		pr.tracer.proposed(c.requestBatch.Requests)
 		pr.updatePendingProposal(c, isConfChange)
 	}
 }
@@ -183,6 +197,14 @@ func (pr *replica) proposeNormal(c batch) bool {
 		return false
 	}
 
+	if c.tp == write && pr.isApplyLagging() {
+		pr.logger.Debug("reject write proposal, apply lag too large",
+			zap.Uint64("committed-index", pr.lastCommittedIndex),
+			zap.Uint64("applied-index", pr.appliedIndex))
+		c.respServerIsBusy()
+		return false
+	}
+
 	data := protoc.MustMarshal(&c.requestBatch)
 	size := len(data)
 	metric.ObserveProposalBytes(int64(size))
@@ -211,6 +233,15 @@ func (pr *replica) proposeNormal(c batch) bool {
 	return true
 }
 
+// isApplyLagging returns true if the committed but not yet applied logs exceed
+// the configured limit, it is used to provide backpressure when the data storage
+// can not keep up with the write workload.
+func (pr *replica) isApplyLagging() bool {
+	max := pr.cfg.Raft.MaxApplyLag
+	return max > 0 && pr.lastCommittedIndex > pr.appliedIndex &&
+		pr.lastCommittedIndex-pr.appliedIndex > max
+}
+
 func (pr *replica) proposeConfChange(c batch) bool {
 	if !pr.isLeader() {
 		pr.respNotLeader(c)
@@ -226,14 +257,29 @@ func (pr *replica) proposeConfChange(c batch) bool {
 		return false
 	}
 
+	pr.checkRepeatedConfChange(c.requestBatch.GetConfigChangeRequest())
 	if err := pr.proposeConfChangeInternal(c); err != nil {
 		pr.logger.Error("fail to proposal conf change",
 			zap.Error(err))
+		pr.setLastError(err)
 		return false
 	}
 	return true
 }
 
+// checkRepeatedConfChange reports the config change if it has been proposed
+// Raft.MaxRepeatedConfigChanges times recently, the scheduler is likely stuck
+// on a config change that keeps failing.
+func (pr *replica) checkRepeatedConfChange(req rpcpb.ConfigChangeRequest) {
+	if n, ok := pr.confChangeRepeats.add(req); ok {
+		pr.repeatedConfChanges++
+		metric.IncRepeatedConfigChangeCount()
+		pr.logger.Warn("same config change proposed repeatedly",
+			log.ConfigChangeField("request", &req),
+			zap.Int("times", n))
+	}
+}
+
 func (pr *replica) proposeConfChangeInternal(c batch) error {
 	req := c.requestBatch.GetConfigChangeRequest()
 	cc := pr.toConfChangeI(req, protoc.MustMarshal(&c.requestBatch))
@@ -264,6 +310,21 @@ func (pr *replica) proposeConfChangeInternal(c batch) error {
 }
 
 func (pr *replica) toConfChangeI(req rpcpb.ConfigChangeRequest, data []byte) raftpb.ConfChangeI {
+	if isLeaveJointConfigChangeRequest(req) {
+		// a ConfChangeV2 without any change leaves the joint state
+		return &raftpb.ConfChangeV2{Context: data}
+	}
+	if isDemotingVoterRequest(req) {
+		// enter the joint state explicitly, the leader will propose to leave the
+		// joint state once the change is applied
+		return &raftpb.ConfChangeV2{
+			Transition: raftpb.ConfChangeTransitionJointExplicit,
+			Changes: []raftpb.ConfChangeSingle{
+				{Type: raftpb.ConfChangeAddLearnerNode, NodeID: req.Replica.ID},
+			},
+			Context: data,
+		}
+	}
 	return &raftpb.ConfChange{
 		Type:    raftpb.ConfChangeType(req.ChangeType),
 		NodeID:  req.Replica.ID,
@@ -350,9 +411,21 @@ func isValidConfigChangeRequest(ccr rpcpb.ConfigChangeRequest) bool {
 		ccr.Replica.Role == metapb.ReplicaRole_Learner {
 		return true
 	}
+	// demote voter
+	if isDemotingVoterRequest(ccr) {
+		return true
+	}
 	return false
 }
 
+// isDemotingVoterRequest returns true if the config change request demotes a
+// voter to learner through the joint state, the replica stays as DemotingVoter
+// until the shard leaves the joint state.
+func isDemotingVoterRequest(ccr rpcpb.ConfigChangeRequest) bool {
+	return ccr.ChangeType == metapb.ConfigChangeType_AddLearnerNode &&
+		ccr.Replica.Role == metapb.ReplicaRole_DemotingVoter
+}
+
 func isRemovingOrDemotingLeader(kind confChangeKind,
 	ccr rpcpb.ConfigChangeRequest, leaderReplicaID uint64) bool {
 	// targeting the leader
@@ -395,7 +468,9 @@ func (pr *replica) checkConfChange(changes []rpcpb.ConfigChangeRequest,
 
 	dup := make(map[uint64]struct{})
 	learnerOnly := true
-	voters := pr.rn.NewChanger().Tracker.Config.Voters.IDs()
+	force := false
+	changer := pr.rn.NewChanger()
+	voters := changer.Tracker.Config.Voters.IDs()
 	for _, cp := range changes {
 		if removingVoterDirectlyInJointConsensusCC(kind, cp) {
 			// TODO: error log the cp value here
@@ -417,19 +492,132 @@ func (pr *replica) checkConfChange(changes []rpcpb.ConfigChangeRequest,
 
 		if cp.ChangeType == metapb.ConfigChangeType_AddNode {
 			learnerOnly = false
+			if pr.isLearnerLagging(changer, cp.Replica.ID) {
+				return ErrPromoteLaggingLearner
+			}
 		}
 		if _, ok := voters[cp.Replica.ID]; ok {
 			learnerOnly = false
 		}
+		if cp.Force {
+			force = true
+		}
 	}
 	// such config change request will confuse raftstore
 	if kind != simpleKind && learnerOnly {
 		return ErrLearnerOnlyChange
 	}
+	if !force && pr.isBreakingQuorum(changer, changes) {
+		return ErrBreakQuorum
+	}
 
 	return nil
 }
 
+// isLearnerLagging returns true if the replica is a learner and its match index
+// is too far behind the last index of the leader. Promoting such learner may
+// make the shard lose the quorum until the learner catches up.
+func (pr *replica) isLearnerLagging(changer confchange.Changer, replicaID uint64) bool {
+	maxLag := pr.cfg.Raft.MaxPromoteLearnerLag
+	if maxLag == 0 {
+		return false
+	}
+	p, ok := changer.Tracker.Progress[replicaID]
+	if !ok || !p.IsLearner {
+		return false
+	}
+	return changer.LastIndex > p.Match+maxLag
+}
+
+// isBreakingQuorum returns true if the remaining healthy voters can not form a
+// quorum after removing or demoting voters. The committed config changes are
+// always applied, so the check is only performed before proposing. The new
+// leader does not know which followers are active until they respond, so the
+// check is skipped in the first election timeout after becoming the leader.
+func (pr *replica) isBreakingQuorum(changer confchange.Changer,
+	changes []rpcpb.ConfigChangeRequest) bool {
+	voters := changer.Tracker.Config.Voters.IDs()
+	removing := false
+	for _, cp := range changes {
+		if _, ok := voters[cp.Replica.ID]; !ok {
+			if cp.ChangeType == metapb.ConfigChangeType_AddNode {
+				voters[cp.Replica.ID] = struct{}{}
+			}
+			continue
+		}
+		if cp.ChangeType == metapb.ConfigChangeType_RemoveNode ||
+			cp.ChangeType == metapb.ConfigChangeType_AddLearnerNode {
+			delete(voters, cp.Replica.ID)
+			removing = true
+		}
+	}
+	if !removing {
+		return false
+	}
+	if pr.clock.Now().Sub(pr.leaderSince) < pr.cfg.Raft.GetElectionTimeoutDuration() {
+		return false
+	}
+
+	healthy := 0
+	for id := range voters {
+		if id == pr.replicaID {
+			healthy++
+			continue
+		}
+		if p, ok := changer.Tracker.Progress[id]; ok && p.RecentActive {
+			healthy++
+		}
+	}
+	return healthy < len(voters)/2+1
+}
+
+// isLeaveJointConfigChangeRequest returns true if the config change request is
+// used to leave the joint state. Such request has no target replica.
+func isLeaveJointConfigChangeRequest(req rpcpb.ConfigChangeRequest) bool {
+	return req.Replica.ID == 0
+}
+
+// isInJointState returns true if the shard is in the joint state, it shares
+// the definition with the apply of the leave joint config change, so that the
+// proposed leave joint config change is never rejected.
+func (pr *replica) isInJointState() bool {
+	return isJointShard(pr.getShard())
+}
+
+// tryLeaveJointState proposes a leave joint config change if the shard has been
+// in the joint state longer than the JointStateTimeout. The explicit leave joint
+// config change is proposed by the leader applied the config change entering
+// the joint state, e.g. Store.DemoteVoter, the shard will stay in the joint
+// state forever if that leader crashed before that.
+func (pr *replica) tryLeaveJointState() {
+	timeout := pr.cfg.Raft.JointStateTimeout.Duration
+	if timeout == 0 || !pr.isLeader() || !pr.isInJointState() {
+		pr.jointStateSince = time.Time{}
+		return
+	}
+
+	now := pr.clock.Now()
+	if pr.jointStateSince.IsZero() {
+		pr.jointStateSince = now
+		return
+	}
+	if now.Sub(pr.jointStateSince) < timeout {
+		return
+	}
+	// config changes can not be proposed before the pending one is applied,
+	// e.g. right after the leader is elected
+	if pr.rn.PendingConfIndex() > pr.appliedIndex {
+		return
+	}
+
+	pr.logger.Warn("shard stays in joint state too long, propose leave joint",
+		zap.Duration("timeout", timeout),
+		zap.Time("since", pr.jointStateSince))
+	pr.addAdminRequest(rpcpb.CmdConfigChange, &rpcpb.ConfigChangeRequest{})
+	// retry after another timeout if the shard is still in the joint state
+	pr.jointStateSince = now
+}
+
 func (pr *replica) checkJointState(cci raftpb.ConfChangeI) (*tracker, error) {
 	changer := pr.rn.NewChanger()
 	var cfg trackerPkg.Config
@@ -438,7 +626,7 @@ func (pr *replica) checkJointState(cci raftpb.ConfChangeI) (*tracker, error) {
 	cc := cci.AsV2()
 	if cc.LeaveJoint() {
 		cfg, _, changes, err = changer.LeaveJoint()
-	} else if autoLeave, _ := cc.EnterJoint(); autoLeave {
+	} else if autoLeave, ok := cc.EnterJoint(); ok {
 		cfg, _, changes, err = changer.EnterJoint(autoLeave, cc.Changes...)
 	} else {
 		cfg, _, changes, err = changer.Simple(cc.Changes...)
@@ -16,7 +16,9 @@ package raftstore
 import (
 	"math"
 	"testing"
+	"time"
 
+	"github.com/fagongzi/util/protoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/raft/v3"
@@ -25,6 +27,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/logdb"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
@@ -381,3 +384,247 @@ func TestInvalidConfigChangeRequestIsRejected(t *testing.T) {
 		assert.Equal(t, tt.err, result, "idx: %d", idx)
 	}
 }
+
+func TestPromoteLaggingLearnerIsRejected(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.replicaID = 1
+	r.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+	r.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 2})
+	require.NoError(t, r.rn.Campaign())
+	for i := 0; i < 10; i++ {
+		require.NoError(t, r.rn.Propose([]byte{0x1}))
+	}
+
+	req := rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_AddNode,
+		Replica: metapb.Replica{
+			ID:   2,
+			Role: metapb.ReplicaRole_Voter,
+		},
+	}
+	cci := r.toConfChangeI(req, nil)
+	assert.NoError(t, r.checkConfChange([]rpcpb.ConfigChangeRequest{req}, cci))
+
+	r.cfg.Raft.MaxPromoteLearnerLag = 5
+	assert.Equal(t, ErrPromoteLaggingLearner,
+		r.checkConfChange([]rpcpb.ConfigChangeRequest{req}, cci))
+
+	r.cfg.Raft.MaxPromoteLearnerLag = 100
+	assert.NoError(t, r.checkConfChange([]rpcpb.ConfigChangeRequest{req}, cci))
+}
+
+func TestProposeNormalRejectedWhenApplyLagging(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.cfg.Raft.MaxApplyLag = 10
+	// the data storage is slow, committed logs are not applied in time
+	r.lastCommittedIndex = 100
+	r.appliedIndex = 50
+	assert.True(t, r.isApplyLagging())
+
+	var resp rpcpb.ResponseBatch
+	c := newBatch(r.logger, rpcpb.RequestBatch{
+		Header:   rpcpb.RequestBatchHeader{ID: []byte{0x1}},
+		Requests: []rpcpb.Request{{ID: []byte{0x2}}},
+	}, func(rb rpcpb.ResponseBatch) { resp = rb }, write, 0)
+	assert.False(t, r.proposeNormal(c))
+	require.Equal(t, 1, len(resp.Responses))
+	assert.NotNil(t, resp.Responses[0].Error.ServerIsBusy)
+	assert.True(t, errorpb.Retryable(resp.Responses[0].Error))
+
+	r.appliedIndex = 90
+	assert.False(t, r.isApplyLagging())
+	r.cfg.Raft.MaxApplyLag = 0
+	r.appliedIndex = 0
+	assert.False(t, r.isApplyLagging())
+}
+
+func TestTryLeaveJointState(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+	pr.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+	pr.setLeaderReplicaID(1)
+	pr.cfg.Raft.JointStateTimeout.Duration = time.Millisecond * 10
+
+	pr.tryLeaveJointState()
+	assert.True(t, pr.jointStateSince.IsZero())
+
+	// enter the joint state, and the explicit leave joint is never proposed
+	shard := Shard{ID: 1, Replicas: []Replica{
+		{ID: 1, StoreID: 1, Role: metapb.ReplicaRole_Voter},
+		{ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Voter},
+	}}
+	pr.sm.metadataMu.shard = shard
+	assert.False(t, pr.isInJointState())
+	pr.rn.ApplyConfChange(raftpb.ConfChangeV2{
+		Transition: raftpb.ConfChangeTransitionJointExplicit,
+		Changes: []raftpb.ConfChangeSingle{
+			{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 2},
+		},
+	})
+	require.NoError(t, applyConfigChangeToShard(&shard, rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+		Replica:    Replica{ID: 2, StoreID: 2, Role: metapb.ReplicaRole_DemotingVoter},
+	}))
+	pr.sm.metadataMu.shard = shard
+	assert.True(t, pr.isInJointState())
+	pr.tryLeaveJointState()
+	assert.False(t, pr.jointStateSince.IsZero())
+	assert.Equal(t, int64(0), pr.requests.Len())
+
+	time.Sleep(time.Millisecond * 20)
+	pr.tryLeaveJointState()
+	require.Equal(t, int64(1), pr.requests.Len())
+	v, err := pr.requests.Peek()
+	require.NoError(t, err)
+	req := v.(reqCtx).req
+	assert.Equal(t, rpcpb.Admin, req.Type)
+	assert.Equal(t, uint64(rpcpb.CmdConfigChange), req.CustomType)
+	ccr := rpcpb.ConfigChangeRequest{}
+	protoc.MustUnmarshal(&ccr, req.Cmd)
+	assert.True(t, isLeaveJointConfigChangeRequest(ccr))
+
+	cci := pr.toConfChangeI(ccr, nil)
+	assert.True(t, cci.AsV2().LeaveJoint())
+	assert.NoError(t, pr.checkConfChange([]rpcpb.ConfigChangeRequest{ccr}, cci))
+
+	// the timer is reset after the shard left the joint state
+	pr.rn.ApplyConfChange(cci)
+	require.NoError(t, applyConfigChangeToShard(&shard, ccr))
+	pr.sm.metadataMu.shard = shard
+	assert.False(t, pr.isInJointState())
+	pr.tryLeaveJointState()
+	assert.True(t, pr.jointStateSince.IsZero())
+}
+
+func TestRemoveVoterBreakingQuorumIsRejected(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.replicaID = 1
+	for id := uint64(1); id <= 3; id++ {
+		r.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: id})
+	}
+	// become leader with the vote from replica 2, all followers are not active
+	require.NoError(t, r.rn.Campaign())
+	term := r.rn.Status().Term
+	require.NoError(t, r.rn.Step(raftpb.Message{Type: raftpb.MsgPreVoteResp, From: 2, To: 1, Term: term + 1}))
+	require.NoError(t, r.rn.Step(raftpb.Message{Type: raftpb.MsgVoteResp, From: 2, To: 1, Term: term + 1}))
+	require.Equal(t, raft.StateLeader, r.rn.Status().RaftState)
+
+	req := rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_RemoveNode,
+		Replica: metapb.Replica{
+			ID:   3,
+			Role: metapb.ReplicaRole_Voter,
+		},
+	}
+	cci := r.toConfChangeI(req, nil)
+	assert.Equal(t, ErrBreakQuorum, r.checkConfChange([]rpcpb.ConfigChangeRequest{req}, cci))
+
+	// the followers are not known to be inactive in the first election timeout
+	// after becoming the leader
+	clock := newMockClock(time.Now())
+	r.clock = clock
+	r.cfg.Raft.ElectionTimeoutTicks = 10
+	r.cfg.Raft.TickInterval.Duration = time.Millisecond * 100
+	r.leaderSince = clock.Now()
+	assert.NoError(t, r.checkConfChange([]rpcpb.ConfigChangeRequest{req}, cci))
+	clock.Advance(r.cfg.Raft.GetElectionTimeoutDuration())
+	assert.Equal(t, ErrBreakQuorum, r.checkConfChange([]rpcpb.ConfigChangeRequest{req}, cci))
+
+	// forced removal
+	req.Force = true
+	assert.NoError(t, r.checkConfChange([]rpcpb.ConfigChangeRequest{req}, cci))
+
+	// replica 2 becomes active, remaining voters still have the quorum
+	req.Force = false
+	require.NoError(t, r.rn.Step(raftpb.Message{Type: raftpb.MsgHeartbeatResp, From: 2, To: 1, Term: term + 1}))
+	assert.NoError(t, r.checkConfChange([]rpcpb.ConfigChangeRequest{req}, cci))
+}
+
+func TestRepeatedConfigChangeIsDetected(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r, closer := getCloseableReplica()
+	defer r.close()
+	defer closer()
+
+	r.replicaID = 1
+	r.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+	require.NoError(t, r.rn.Campaign())
+	r.setLeaderReplicaID(1)
+	r.confChangeRepeats = newConfigChangeRepeats(3)
+
+	propose := func(req rpcpb.ConfigChangeRequest) error {
+		c := newTestBatch("", "", uint64(rpcpb.CmdConfigChange), rpcpb.Admin, 0,
+			func(rpcpb.ResponseBatch) {})
+		c.requestBatch.Requests[0].Cmd = protoc.MustMarshal(&req)
+		if r.proposeConfChange(c) {
+			return nil
+		}
+		err, _ := r.LastError()
+		return err
+	}
+
+	// removing the only voter keeps failing
+	removeLeader := rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_RemoveNode,
+		Replica:    metapb.Replica{ID: 1, StoreID: 1},
+	}
+	other := rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_RemoveNode,
+		Replica:    metapb.Replica{ID: 1, StoreID: 2},
+	}
+	assert.Error(t, propose(removeLeader))
+	assert.Error(t, propose(other))
+	assert.Error(t, propose(removeLeader))
+	assert.Equal(t, uint64(0), r.repeatedConfChanges)
+	assert.Error(t, propose(removeLeader))
+	assert.Equal(t, uint64(1), r.repeatedConfChanges)
+	assert.Error(t, propose(removeLeader))
+	assert.Equal(t, uint64(2), r.repeatedConfChanges)
+	assert.Error(t, propose(other))
+	assert.Equal(t, uint64(2), r.repeatedConfChanges)
+}
+
+func TestConfigChangeRepeatsWindow(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	assert.Nil(t, newConfigChangeRepeats(0))
+	n, ok := (*configChangeRepeats)(nil).add(rpcpb.ConfigChangeRequest{})
+	assert.Equal(t, 0, n)
+	assert.False(t, ok)
+
+	r := newConfigChangeRepeats(2)
+	req := rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+		Replica:    metapb.Replica{ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Learner},
+	}
+	n, ok = r.add(req)
+	assert.Equal(t, 1, n)
+	assert.False(t, ok)
+	// pushed out of the window by other config changes
+	for i := 0; i < minConfigChangeRepeatsWindow; i++ {
+		r.add(rpcpb.ConfigChangeRequest{Replica: metapb.Replica{ID: uint64(i + 100)}})
+	}
+	n, ok = r.add(req)
+	assert.Equal(t, 1, n)
+	assert.False(t, ok)
+	n, ok = r.add(req)
+	assert.Equal(t, 2, n)
+	assert.True(t, ok)
+}
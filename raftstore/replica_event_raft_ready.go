@@ -14,6 +14,7 @@
 package raftstore
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -72,11 +73,20 @@ func (pr *replica) processReady(rd raft.Ready, wc *logdb.WorkerContext) error {
 func (pr *replica) handleRaftState(rd raft.Ready) {
 	// etcd raft won't repeatedly return the same non-empty soft state
 	if rd.SoftState != nil {
+		if pr.getLeaderReplicaID() != rd.SoftState.Lead {
+			pr.store.leaderChangeNotifier.addEvent(leaderChangeEvent{
+				shardID:  pr.shardID,
+				leaderID: rd.SoftState.Lead,
+				term:     pr.rn.BasicStatus().Term,
+			})
+		}
 		pr.setLeaderReplicaID(rd.SoftState.Lead)
 		shard := pr.getShard()
 		// If we become leader, send heartbeat to pd
 		if rd.SoftState.RaftState == raft.StateLeader {
 			pr.logger.Info("********become leader now********")
+			pr.leaderSince = pr.clock.Now()
+			pr.checkQuarantined()
 			pr.prophetHeartbeat()
 			pr.resetIncomingProposals()
 			if pr.aware != nil {
@@ -87,12 +97,13 @@ func (pr *replica) handleRaftState(rd raft.Ready) {
 			for _, r := range shard.Replicas {
 				if r.ID != pr.replicaID {
 					if _, has := pr.replicaHeartbeatsMap.Load(r.ID); !has {
-						pr.replicaHeartbeatsMap.Store(r.ID, time.Now())
+						pr.replicaHeartbeatsMap.Store(r.ID, pr.clock.Now())
 					}
 				}
 			}
 		} else {
 			pr.logger.Info("********become follower now********")
+			pr.leaderSince = time.Time{}
 			if pr.aware != nil {
 				pr.aware.BecomeFollower(shard)
 			}
@@ -158,8 +169,15 @@ func (pr *replica) saveRaftState(rd raft.Ready, wc *logdb.WorkerContext) error {
 	}
 
 	if !raft.IsEmptyHardState(rd.HardState) {
-		pr.lastCommittedIndex = rd.HardState.Commit
+		// the logs are committed once the advanced commit index is seen, they
+		// may be applied much later, e.g. when the apply is slow
+		if rd.HardState.Commit > pr.lastCommittedIndex {
+			pr.sm.commitTimes.add(pr.lastCommittedIndex+1, rd.HardState.Commit,
+				pr.clock.Now())
+		}
+		atomic.StoreUint64(&pr.lastCommittedIndex, rd.HardState.Commit)
 		pr.committedIndexes[pr.replicaID] = pr.lastCommittedIndex
+		pr.committedIndexesChanged = true
 	}
 	return nil
 }
@@ -189,14 +207,33 @@ func (pr *replica) entriesToApply(entries []raftpb.Entry) []raftpb.Entry {
 
 func (pr *replica) applyCommittedEntries(rd raft.Ready) error {
 	if !raft.IsEmptySnap(rd.Snapshot) {
+		// the snapshot has to be applied, the freeze is over
+		if pr.frozen {
+			if err := pr.loseFreeze("snapshot received"); err != nil {
+				return err
+			}
+		}
 		if err := pr.applySnapshot(rd.Snapshot); err != nil {
 			return err
 		}
 		pr.pushedIndex = rd.Snapshot.Metadata.Index
 		pr.logger.Info("snapshot applied into the replica")
 	}
-	for _, entry := range rd.CommittedEntries {
-		pr.stats.raftLogSizeHint += uint64(len(entry.Data))
+	if len(rd.CommittedEntries) > 0 {
+		size := uint64(0)
+		for _, entry := range rd.CommittedEntries {
+			size += uint64(len(entry.Data))
+		}
+		metric.SetRaftLogSize(pr.shardID, atomic.AddUint64(&pr.stats.raftLogSizeHint, size))
+	}
+	if len(rd.CommittedEntries) > 0 && pr.frozen {
+		if !hasConfChange(rd.CommittedEntries) {
+			pr.frozenEntries = append(pr.frozenEntries, rd.CommittedEntries...)
+			return nil
+		}
+		if err := pr.loseFreeze("config change committed"); err != nil {
+			return err
+		}
 	}
 	if len(rd.CommittedEntries) > 0 {
 		var startTime int64
@@ -207,6 +244,7 @@ func (pr *replica) applyCommittedEntries(rd raft.Ready) error {
 		if err := pr.doApplyCommittedEntries(rd.CommittedEntries); err != nil {
 			return err
 		}
+		pr.checkQuarantined()
 		if ce := pr.logger.Check(zap.DebugLevel,
 			"apply committed entries completed"); ce != nil {
 			cost := time.Now().UnixMilli() - startTime
@@ -289,6 +327,11 @@ func (pr *replica) sendRaftMessage(msg raftpb.Message) error {
 		// FIXME: remove this hack
 		SendTime: uint64(time.Now().UnixMilli()),
 	}
+	if pr.cfg.Raft.ReportCompactionLag {
+		if firstIndex, err := pr.lr.FirstIndex(); err == nil {
+			m.FirstIndex = firstIndex
+		}
+	}
 
 	// There could be two cases:
 	// 1. Target replica already exists but has not established communication with
@@ -309,6 +352,7 @@ func (pr *replica) sendRaftMessage(msg raftpb.Message) error {
 
 	if msg.Type == raftpb.MsgSnap {
 		pr.logger.Info("sending a snapshot message")
+		pr.addInflightSnapshot(msg.To, msg.Snapshot.Metadata.Index)
 		pr.transport.SendSnapshot(m)
 	} else {
 		pr.transport.Send(m)
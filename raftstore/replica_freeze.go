@@ -0,0 +1,155 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+
+	"go.etcd.io/etcd/raft/v3/raftpb"
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+)
+
+// Freeze pauses proposing new requests and applying committed entries on the
+// replica, so that an external backup can snapshot the data storage at a
+// consistent applied index. It returns once the event worker stopped at that
+// applied index, which is returned. The replica keeps replicating the raft
+// log while frozen, and resumes once Unfreeze is called. The freeze is lost
+// when the configured Raft.MaxFreezeDuration is exceeded, a snapshot has to be
+// applied or a config change is committed, Unfreeze reports it.
+func (pr *replica) Freeze() (uint64, error) {
+	max := pr.cfg.Raft.MaxFreezeDuration.Duration
+	ack := make(chan uint64, 1)
+	pr.freezeMu.Lock()
+	pr.freezeMu.requested = true
+	pr.freezeMu.lost = false
+	pr.freezeMu.until = pr.clock.Now().Add(max)
+	pr.freezeMu.acks = append(pr.freezeMu.acks, ack)
+	pr.freezeMu.Unlock()
+	pr.notifyWorker()
+
+	// the same clock as handleFreeze, so that the freeze is expired there once
+	// the caller timed out
+	timer := pr.clock.NewTimer(max)
+	defer timer.Stop()
+	select {
+	case index := <-ack:
+		return index, nil
+	case <-pr.closedC:
+		return 0, ErrReplicaStopped
+	case <-timer.C():
+		return 0, ErrFreezeTimeout
+	}
+}
+
+// Unfreeze resumes the replica frozen by Freeze, the entries committed while
+// frozen are applied first. ErrFreezeLost is returned if the replica applied
+// any entry before Unfreeze is called, the data storage may have changed after
+// the applied index returned by Freeze.
+func (pr *replica) Unfreeze() error {
+	lost := pr.cancelFreeze()
+	pr.notifyWorker()
+	if lost {
+		return ErrFreezeLost
+	}
+	return nil
+}
+
+func (pr *replica) cancelFreeze() bool {
+	pr.freezeMu.Lock()
+	defer pr.freezeMu.Unlock()
+	lost := pr.freezeMu.lost
+	pr.freezeMu.requested = false
+	pr.freezeMu.lost = false
+	return lost
+}
+
+// loseFreeze leaves the frozen state before Unfreeze is called, the loss is
+// reported by Unfreeze.
+func (pr *replica) loseFreeze(reason string) error {
+	pr.freezeMu.Lock()
+	if pr.freezeMu.requested {
+		pr.freezeMu.requested = false
+		pr.freezeMu.lost = true
+	}
+	pr.freezeMu.Unlock()
+	pr.logger.Warn("freeze lost, unfreeze the replica",
+		log.ReasonField(reason))
+	return pr.unfreeze()
+}
+
+// handleFreeze makes the event worker enter or leave the frozen state as
+// requested by Freeze and Unfreeze.
+func (pr *replica) handleFreeze() error {
+	pr.freezeMu.Lock()
+	requested := pr.freezeMu.requested
+	expired := requested && !pr.clock.Now().Before(pr.freezeMu.until)
+	acks := pr.freezeMu.acks
+	pr.freezeMu.acks = nil
+	pr.freezeMu.Unlock()
+
+	if expired {
+		if pr.frozen {
+			return pr.loseFreeze(fmt.Sprintf("exceeded the max freeze duration %s",
+				pr.cfg.Raft.MaxFreezeDuration.Duration))
+		}
+		// Freeze has already timed out
+		pr.cancelFreeze()
+		return nil
+	}
+	if requested {
+		if !pr.frozen {
+			pr.frozen = true
+			pr.logger.Info("replica frozen",
+				log.IndexField(pr.appliedIndex))
+		}
+		for _, ack := range acks {
+			ack <- pr.appliedIndex
+		}
+		return nil
+	}
+	if pr.frozen {
+		return pr.unfreeze()
+	}
+	return nil
+}
+
+// unfreeze leaves the frozen state and applies the entries committed while
+// frozen.
+func (pr *replica) unfreeze() error {
+	entries := pr.frozenEntries
+	pr.frozen = false
+	pr.frozenEntries = nil
+	pr.logger.Info("replica unfrozen",
+		log.IndexField(pr.appliedIndex),
+		zap.Int("pending-entries", len(entries)))
+	if len(entries) > 0 {
+		return pr.doApplyCommittedEntries(entries)
+	}
+	return nil
+}
+
+// hasConfChange returns true if any of the entries is a config change, raft
+// expects them to be applied once Advance is called, so they can not be kept
+// while frozen.
+func hasConfChange(entries []raftpb.Entry) bool {
+	for _, entry := range entries {
+		if entry.Type == raftpb.EntryConfChange ||
+			entry.Type == raftpb.EntryConfChangeV2 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/components/prophet/mock/mockclient"
+	"github.com/matrixorigin/matrixcube/logdb"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func newTestFreezeReplica(t *testing.T, s *store) *replica {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	r := Replica{ID: 1, StoreID: s.Meta().ID}
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{r}}, r, s)
+	pr.prophetClient = client
+	s.addReplica(pr)
+	require.NoError(t, pr.sm.dataStorage.SaveShardMetadata([]metapb.ShardMetadata{
+		{ShardID: 1, Metadata: metapb.ShardLocalState{Shard: pr.getShard()}},
+	}))
+	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+	pr.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+	require.NoError(t, pr.rn.Campaign())
+	return pr
+}
+
+// freezeReplica calls Freeze and drives the event worker until it is frozen
+func freezeReplica(t *testing.T, pr *replica) uint64 {
+	type result struct {
+		index uint64
+		err   error
+	}
+	resultC := make(chan result, 1)
+	go func() {
+		index, err := pr.Freeze()
+		resultC <- result{index, err}
+	}()
+	for {
+		require.NoError(t, pr.handleFreeze())
+		select {
+		case r := <-resultC:
+			require.NoError(t, r.err)
+			return r.index
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func applyAll(t *testing.T, pr *replica, wc *logdb.WorkerContext) {
+	for pr.rn.HasReady() {
+		wc.Reset()
+		require.NoError(t, pr.handleRaftReady(wc))
+	}
+}
+
+func TestReplicaFreeze(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestFreezeReplica(t, s)
+	pr.cfg.Raft.MaxFreezeDuration.Duration = time.Second * 10
+	wc := pr.logdb.NewWorkerContext()
+	defer wc.Close()
+	applyAll(t, pr, wc)
+	require.True(t, pr.isLeader())
+
+	var responses []rpcpb.ResponseBatch
+	addWrite := func(i int) {
+		req := createTestWriteReq(fmt.Sprintf("id-%d", i), fmt.Sprintf("key-%d", i), "value")
+		req.ToShard = 1
+		require.NoError(t, pr.addRequest(newReqCtx(req, func(resp rpcpb.ResponseBatch) {
+			responses = append(responses, resp)
+		})))
+	}
+	addWrite(0)
+	assert.True(t, pr.handleRequest(pr.items))
+	applyAll(t, pr, wc)
+	require.Equal(t, 1, len(responses))
+
+	// proposed before the freeze, committed while frozen
+	addWrite(1)
+	assert.True(t, pr.handleRequest(pr.items))
+	frozenIndex := freezeReplica(t, pr)
+	assert.Equal(t, pr.appliedIndex, frozenIndex)
+	applyAll(t, pr, wc)
+	assert.True(t, pr.rn.BasicStatus().Commit > frozenIndex)
+	assert.Equal(t, frozenIndex, pr.appliedIndex)
+
+	// new requests are not proposed while frozen
+	addWrite(2)
+	assert.False(t, pr.handleRequest(pr.items))
+	assert.Equal(t, int64(1), pr.requests.Len())
+	assert.False(t, pr.rn.HasReady())
+	assert.Equal(t, frozenIndex, pr.appliedIndex)
+	assert.Equal(t, 1, len(responses))
+
+	// the snapshot is taken at the frozen applied index
+	require.NoError(t, pr.snapshotter.prepareReplicaSnapshotDir())
+	ss, created, err := pr.createSnapshot()
+	require.NoError(t, err)
+	require.True(t, created)
+	assert.Equal(t, frozenIndex, ss.Metadata.Index)
+
+	assert.NoError(t, pr.Unfreeze())
+	require.NoError(t, pr.handleFreeze())
+	assert.False(t, pr.frozen)
+	assert.True(t, pr.appliedIndex > frozenIndex)
+	assert.True(t, pr.handleRequest(pr.items))
+	applyAll(t, pr, wc)
+	assert.Equal(t, pr.rn.BasicStatus().Commit, pr.appliedIndex)
+	assert.Equal(t, 3, len(responses))
+}
+
+func TestReplicaFreezeExpired(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestFreezeReplica(t, s)
+	clock := newMockClock(time.Now())
+	pr.clock = clock
+	pr.cfg.Raft.MaxFreezeDuration.Duration = time.Second * 10
+	wc := pr.logdb.NewWorkerContext()
+	defer wc.Close()
+	applyAll(t, pr, wc)
+
+	freezeReplica(t, pr)
+	require.True(t, pr.frozen)
+	clock.Advance(pr.cfg.Raft.MaxFreezeDuration.Duration)
+	require.NoError(t, pr.handleFreeze())
+	assert.False(t, pr.frozen)
+	assert.Equal(t, ErrFreezeLost, pr.Unfreeze())
+	// the next freeze is not affected
+	freezeReplica(t, pr)
+	assert.NoError(t, pr.Unfreeze())
+}
+
+func TestReplicaFreezeTimeout(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestFreezeReplica(t, s)
+	clock := newMockClock(time.Now())
+	pr.clock = clock
+	pr.cfg.Raft.MaxFreezeDuration.Duration = time.Second * 10
+
+	// the event worker does not handle the freeze in time
+	errC := make(chan error, 1)
+	go func() {
+		_, err := pr.Freeze()
+		errC <- err
+	}()
+	var err error
+	for done := false; !done; {
+		select {
+		case err = <-errC:
+			done = true
+		case <-time.After(time.Millisecond):
+			// the caller only times out once the clock reaches the deadline
+			clock.Advance(pr.cfg.Raft.MaxFreezeDuration.Duration / 2)
+		}
+	}
+	assert.Equal(t, ErrFreezeTimeout, err)
+	// the freeze is also expired in the event worker
+	require.NoError(t, pr.handleFreeze())
+	assert.False(t, pr.frozen)
+	assert.NoError(t, pr.Unfreeze())
+}
+
+func TestReplicaFreezeLostOnConfigChange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestFreezeReplica(t, s)
+	pr.cfg.Raft.MaxFreezeDuration.Duration = time.Second * 10
+	pr.transport = &replicaTestTransport{}
+	wc := pr.logdb.NewWorkerContext()
+	defer wc.Close()
+	applyAll(t, pr, wc)
+	require.True(t, pr.isLeader())
+
+	// proposed before the freeze, committed while frozen
+	c := newTestBatch("", "", uint64(rpcpb.CmdConfigChange), rpcpb.Admin, 0,
+		func(rpcpb.ResponseBatch) {})
+	c.requestBatch.Header.ShardID = 1
+	c.requestBatch.Requests[0].Cmd = protoc.MustMarshal(&rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+		Replica:    metapb.Replica{ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Learner},
+	})
+	require.True(t, pr.proposeConfChange(c))
+	frozenIndex := freezeReplica(t, pr)
+	applyAll(t, pr, wc)
+	// raft expects the config change to be applied once it is advanced
+	assert.False(t, pr.frozen)
+	assert.True(t, pr.appliedIndex > frozenIndex)
+	assert.Equal(t, 2, len(pr.getShard().Replicas))
+	assert.Equal(t, ErrFreezeLost, pr.Unfreeze())
+}
@@ -14,6 +14,8 @@
 package raftstore
 
 import (
+	"time"
+
 	"github.com/cockroachdb/errors"
 	"github.com/fagongzi/util/protoc"
 	"go.etcd.io/etcd/raft/v3"
@@ -21,6 +23,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/storage"
 )
@@ -41,6 +44,26 @@ func (pr *replica) handleRaftCreateSnapshotRequest() error {
 	return nil
 }
 
+// createSnapshotResult the result of the createSnapshotAction
+type createSnapshotResult struct {
+	snapshot raftpb.Snapshot
+	err      error
+}
+
+// doCreateSnapshot creates a snapshot at the current applied index as requested
+// by the createSnapshotAction, only the leader replica creates it.
+func (pr *replica) doCreateSnapshot(act action) {
+	if !pr.isLeader() {
+		act.actionCallback(createSnapshotResult{err: errNotLeader})
+		return
+	}
+	ss, created, err := pr.createSnapshot()
+	if err == nil && !created {
+		err = storage.ErrAborted
+	}
+	act.actionCallback(createSnapshotResult{snapshot: ss, err: err})
+}
+
 func (pr *replica) createSnapshot() (raftpb.Snapshot, bool, error) {
 	index, term := pr.sm.getAppliedIndexTerm()
 	if index == 0 {
@@ -55,6 +78,9 @@ func (pr *replica) createSnapshot() (raftpb.Snapshot, bool, error) {
 		log.ReplicaIDsField("voters", cs.Voters),
 		log.ReplicaIDsField("learners", cs.Learners))
 
+	pr.store.snapshotStarted()
+	defer pr.store.snapshotCompleted()
+	start := pr.clock.Now()
 	ss, ssenv, err := pr.snapshotter.save(pr.sm.dataStorage, cs, index, term)
 	if err != nil {
 		if errors.Is(err, storage.ErrAborted) {
@@ -91,9 +117,34 @@ func (pr *replica) createSnapshot() (raftpb.Snapshot, bool, error) {
 		return raftpb.Snapshot{}, false, err
 	}
 	logger.Info("snapshot created")
+	pr.notifySnapshotCreated(ss, ssenv.GetFinalDir(), pr.clock.Now().Sub(start))
 	return ss, true, nil
 }
 
+// notifySnapshotCreated invokes the CustomSnapshotCreatedHandler with the
+// created snapshot.
+func (pr *replica) notifySnapshotCreated(ss raftpb.Snapshot,
+	dir string, duration time.Duration) {
+	handler := pr.cfg.Customize.CustomSnapshotCreatedHandler
+	if handler == nil {
+		return
+	}
+	size, err := pr.snapshotter.getDirSize(dir)
+	if err != nil {
+		pr.logger.Warn("failed to get the snapshot size",
+			log.SnapshotField(ss),
+			zap.Error(err))
+	}
+	handler(config.SnapshotCreatedEvent{
+		ShardID:   pr.shardID,
+		ReplicaID: pr.replicaID,
+		Index:     ss.Metadata.Index,
+		Term:      ss.Metadata.Term,
+		Size:      size,
+		Duration:  duration,
+	})
+}
+
 func (pr *replica) applySnapshot(ss raftpb.Snapshot) error {
 	logger := pr.logger.With(log.SnapshotField(ss))
 	// double check whether we are trying to recover from a dummy snapshot
@@ -104,6 +155,8 @@ func (pr *replica) applySnapshot(ss raftpb.Snapshot) error {
 			logger.Fatal("trying to recover from a dummy snapshot")
 		}
 	}
+	pr.store.snapshotStarted()
+	defer pr.store.snapshotCompleted()
 	md, err := pr.snapshotter.recover(pr.sm.dataStorage, ss)
 	if err != nil {
 		logger.Error("failed to recover from the snapshot",
@@ -127,6 +180,10 @@ func (pr *replica) applySnapshot(ss raftpb.Snapshot) error {
 	// r.replica is more like a local cached copy of the replica record.
 	pr.replica = *findReplica(pr.getShard(), pr.storeID)
 	pr.sm.updateAppliedIndexTerm(ss.Metadata.Index, ss.Metadata.Term)
+	// the requests applied before the snapshot are not in the local raft logs
+	if err := pr.initRequestDedup(); err != nil {
+		return err
+	}
 	// persistentLogIndex is not guaranteed to be the same as ss.Metadata.Index
 	// as the log entry at ss.Metadata.Index, including a few nearby entries
 	// are entries not visible to the state machine, e.g. NOOP entries or admin
@@ -160,21 +217,45 @@ func (pr *replica) snapshotCompaction(ss raftpb.Snapshot,
 	if err != nil {
 		return err
 	}
+	reclaimed := uint64(0)
 	for _, cs := range snapshots {
 		if cs.Metadata.Index < ss.Metadata.Index {
+			reclaimed += pr.getCompactedSnapshotSize(cs)
 			if err := pr.removeSnapshot(cs, true); err != nil {
 				return err
 			}
 		}
 	}
 	if persistentLogIndex == ss.Metadata.Index {
+		reclaimed += pr.getCompactedSnapshotSize(ss)
 		if err := pr.removeSnapshot(ss, false); err != nil {
 			return err
 		}
 	}
+	pr.store.compactionStats.addReclaimedBytes(pr.getShard().Group, reclaimed)
 	return nil
 }
 
+// getCompactedSnapshotSize returns the size of the snapshot dir to be removed
+// by the snapshot compaction, 0 if the compaction stats are disabled.
+func (pr *replica) getCompactedSnapshotSize(ss raftpb.Snapshot) uint64 {
+	if !pr.store.compactionStats.enabled() {
+		return 0
+	}
+	env := pr.snapshotter.getRecoverSnapshotEnv(ss)
+	if !env.FinalDirExists() {
+		return 0
+	}
+	size, err := pr.snapshotter.getDirSize(env.GetFinalDir())
+	if err != nil {
+		pr.logger.Warn("failed to get the snapshot size",
+			log.SnapshotField(ss),
+			zap.Error(err))
+		return 0
+	}
+	return size
+}
+
 func (pr *replica) removeSnapshot(ss raftpb.Snapshot, removeFromLogDB bool) error {
 	logger := pr.logger.With(log.SnapshotField(ss))
 	if removeFromLogDB {
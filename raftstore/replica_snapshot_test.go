@@ -14,13 +14,16 @@
 package raftstore
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/logdb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/snapshot"
+	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/storage/kv"
 	"github.com/matrixorigin/matrixcube/storage/kv/mem"
 	"github.com/matrixorigin/matrixcube/util/fileutil"
@@ -73,15 +76,18 @@ func runReplicaSnapshotTest(t *testing.T,
 		store: &store{
 			workerPool: newWorkerPool(logger, ldb, nil, 96),
 		},
-		actions:     task.New(32),
-		storeID:     100,
-		logger:      logger,
-		logdb:       ldb,
-		sm:          sm,
-		snapshotter: snapshotter,
-		shardID:     1,
-		replica:     replicaRec,
-		lr:          lr,
+		actions:         task.New(32),
+		priorityActions: task.New(32),
+		storeID:         100,
+		logger:          logger,
+		clock:           realClock{},
+		logdb:           ldb,
+		sm:              sm,
+		snapshotter:     snapshotter,
+		shardID:         1,
+		replica:         replicaRec,
+		replicaID:       replicaRec.ID,
+		lr:              lr,
 	}
 	r.setStarted()
 	fn(t, r, fs)
@@ -116,6 +122,38 @@ func TestReplicaSnapshotCanBeCreated(t *testing.T) {
 	runReplicaSnapshotTest(t, fn, fs)
 }
 
+func TestReplicaSnapshotCreatedEvent(t *testing.T) {
+	fn := func(t *testing.T, r *replica, fs vfs.FS) {
+		var events []config.SnapshotCreatedEvent
+		r.cfg.Customize.CustomSnapshotCreatedHandler = func(event config.SnapshotCreatedEvent) {
+			events = append(events, event)
+		}
+		ss, created, err := r.createSnapshot()
+		require.NoError(t, err)
+		assert.True(t, created)
+		require.Equal(t, 1, len(events))
+
+		var si metapb.SnapshotInfo
+		protoc.MustUnmarshal(&si, ss.Data)
+		env := snapshot.NewSSEnv(r.snapshotter.rootDirFunc,
+			1, 1, ss.Metadata.Index, si.Extra, snapshot.CreatingMode, r.snapshotter.fs)
+		env.FinalizeIndex(ss.Metadata.Index)
+		size, err := r.snapshotter.getDirSize(env.GetFinalDir())
+		require.NoError(t, err)
+		assert.True(t, size > 0)
+
+		e := events[0]
+		assert.Equal(t, uint64(1), e.ShardID)
+		assert.Equal(t, uint64(1), e.ReplicaID)
+		assert.Equal(t, uint64(100), e.Index)
+		assert.Equal(t, uint64(1), e.Term)
+		assert.Equal(t, size, e.Size)
+		assert.True(t, e.Duration > 0)
+	}
+	fs := vfs.GetTestFS()
+	runReplicaSnapshotTest(t, fn, fs)
+}
+
 // other related tests
 // TestApplyInitialSnapshot
 // TestApplyReceivedSnapshot
@@ -256,6 +294,51 @@ func TestCreatingOutOfDateSnapshotWillCausePanic(t *testing.T) {
 	runReplicaSnapshotTest(t, fn, fs)
 }
 
+type blockedSnapshotDataStorage struct {
+	storage.DataStorage
+	startedC chan struct{}
+	releaseC chan struct{}
+}
+
+func (s *blockedSnapshotDataStorage) CreateSnapshot(shardID uint64, path string) error {
+	s.startedC <- struct{}{}
+	<-s.releaseC
+	return storage.ErrAborted
+}
+
+func TestInFlightSnapshots(t *testing.T) {
+	fn := func(t *testing.T, r *replica, fs vfs.FS) {
+		n := 3
+		ds := &blockedSnapshotDataStorage{
+			DataStorage: r.sm.dataStorage,
+			startedC:    make(chan struct{}, n),
+			releaseC:    make(chan struct{}),
+		}
+		r.sm.dataStorage = ds
+		assert.Equal(t, 0, r.store.InFlightSnapshots())
+
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, created, err := r.createSnapshot()
+				assert.NoError(t, err)
+				assert.False(t, created)
+			}()
+		}
+		for i := 0; i < n; i++ {
+			<-ds.startedC
+		}
+		assert.Equal(t, n, r.store.InFlightSnapshots())
+		close(ds.releaseC)
+		wg.Wait()
+		assert.Equal(t, 0, r.store.InFlightSnapshots())
+	}
+	fs := vfs.GetTestFS()
+	runReplicaSnapshotTest(t, fn, fs)
+}
+
 func TestSnapshotCompactionWithMatchedPersistentLogIndex(t *testing.T) {
 	testSnapshotCompaction(t, 200, true)
 }
@@ -310,7 +393,9 @@ func testSnapshotCompaction(t *testing.T, index uint64, matched bool) {
 		assert.True(t, env1.FinalDirExists())
 		assert.True(t, env2.FinalDirExists())
 
+		r.store.compactionStats = newGroupCompactionStats(true)
 		assert.NoError(t, r.snapshotCompaction(ss2, index))
+		assert.True(t, r.store.GroupCompactionStats()[r.getShard().Group].ReclaimedBytes > 0)
 
 		// when matched, both snapshot images are suppose to be removed
 		// otherwise, the latest image should be kept
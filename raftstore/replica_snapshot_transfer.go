@@ -0,0 +1,101 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+)
+
+// inflightSnapshot is a snapshot being sent to a follower replica.
+type inflightSnapshot struct {
+	index  uint64
+	sentAt time.Time
+}
+
+// addInflightSnapshot records the snapshot sent to the replica, the log
+// compaction past its index is deferred until the transfer completes, so the
+// follower can catch up with the raft log right after applying the snapshot.
+func (pr *replica) addInflightSnapshot(to, index uint64) {
+	if pr.cfg.Raft.RaftLog.SnapshotTransferTimeout.Duration == 0 {
+		return
+	}
+	if pr.inflightSnapshots == nil {
+		pr.inflightSnapshots = make(map[uint64]inflightSnapshot)
+	}
+	pr.inflightSnapshots[to] = inflightSnapshot{index: index, sentAt: pr.clock.Now()}
+}
+
+// snapshotTransferCompleted is invoked once the status of the snapshot sent to
+// the replica is reported, the deferred log compaction is resumed if it is no
+// longer blocked by other transfers.
+func (pr *replica) snapshotTransferCompleted(to uint64) {
+	if _, ok := pr.inflightSnapshots[to]; !ok {
+		return
+	}
+	delete(pr.inflightSnapshots, to)
+	if index := pr.deferredCompactIndex; index > 0 && !pr.isLogCompactionBlocked(index) {
+		pr.logger.Info("resume deferred log compaction",
+			log.IndexField(index))
+		pr.deferredCompactIndex = 0
+		pr.addAction(action{
+			actionType:  logCompactionAction,
+			targetIndex: index,
+		})
+	}
+}
+
+// isLogCompactionBlocked returns true if compacting the raft log up to the
+// index removes the entries required by the followers receiving snapshots.
+// The transfers not completed within Raft.RaftLog.SnapshotTransferTimeout are
+// considered lost.
+func (pr *replica) isLogCompactionBlocked(index uint64) bool {
+	timeout := pr.cfg.Raft.RaftLog.SnapshotTransferTimeout.Duration
+	now := pr.clock.Now()
+	blocked := false
+	for to, ss := range pr.inflightSnapshots {
+		if now.Sub(ss.sentAt) >= timeout {
+			pr.logger.Warn("snapshot transfer timeout",
+				log.ReplicaIDField(to),
+				log.IndexField(ss.index))
+			delete(pr.inflightSnapshots, to)
+			continue
+		}
+		if index > ss.index {
+			blocked = true
+		}
+	}
+	return blocked
+}
+
+// deferLogCompaction returns true if the log compaction up to the index has
+// to be deferred, see isLogCompactionBlocked.
+func (pr *replica) deferLogCompaction(index uint64) bool {
+	if !pr.isLogCompactionBlocked(index) {
+		if index >= pr.deferredCompactIndex {
+			pr.deferredCompactIndex = 0
+		}
+		return false
+	}
+	if index > pr.deferredCompactIndex {
+		pr.deferredCompactIndex = index
+	}
+	pr.logger.Info("log compaction deferred by snapshot transfers",
+		log.IndexField(index),
+		zap.Int("transfers", len(pr.inflightSnapshots)))
+	return true
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestLogCompactionDeferredBySnapshotTransfer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	c := newMockClock(time.Now())
+	s.clock = c
+	r := Replica{ID: 1, StoreID: s.Meta().ID}
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{r, {ID: 2, StoreID: 2}}}, r, s)
+	pr.cfg.Raft.RaftLog.SnapshotTransferTimeout.Duration = time.Minute
+	var entries []raftpb.Entry
+	for i := uint64(1); i <= 10; i++ {
+		entries = append(entries, raftpb.Entry{Index: i, Term: 1})
+	}
+	wc := pr.logdb.NewWorkerContext()
+	defer wc.Close()
+	require.NoError(t, pr.logdb.SaveRaftState(pr.shardID, pr.replicaID,
+		raft.Ready{Entries: entries}, wc))
+	require.NoError(t, pr.lr.Append(entries))
+	firstIndex := func() uint64 {
+		v, _ := pr.lr.FirstIndex()
+		return v
+	}
+
+	pr.addInflightSnapshot(2, 3)
+	// the entries following the snapshot are required by the follower
+	require.NoError(t, pr.doLogCompaction(5))
+	assert.Equal(t, uint64(1), firstIndex())
+	require.NoError(t, pr.doLogCompaction(3))
+	assert.Equal(t, uint64(4), firstIndex())
+	assert.Equal(t, uint64(5), pr.deferredCompactIndex)
+
+	// resumed once the transfer completes
+	pr.snapshotTransferCompleted(2)
+	assert.Equal(t, uint64(0), pr.deferredCompactIndex)
+	require.Equal(t, int64(1), pr.actions.Len())
+	n, err := pr.actions.Get(1, pr.items)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	act := pr.items[0].(action)
+	assert.Equal(t, logCompactionAction, act.actionType)
+	require.NoError(t, pr.doLogCompaction(act.targetIndex))
+	assert.Equal(t, uint64(6), firstIndex())
+
+	// the transfer is considered lost after the timeout
+	pr.addInflightSnapshot(2, 6)
+	require.NoError(t, pr.doLogCompaction(8))
+	assert.Equal(t, uint64(6), firstIndex())
+	c.Advance(time.Minute)
+	require.NoError(t, pr.doLogCompaction(8))
+	assert.Equal(t, uint64(9), firstIndex())
+	assert.Empty(t, pr.inflightSnapshots)
+	assert.Equal(t, uint64(0), pr.deferredCompactIndex)
+
+	// never deferred if disabled
+	pr.cfg.Raft.RaftLog.SnapshotTransferTimeout.Duration = 0
+	pr.addInflightSnapshot(2, 9)
+	require.NoError(t, pr.doLogCompaction(10))
+	assert.Equal(t, uint64(11), firstIndex())
+}
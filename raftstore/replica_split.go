@@ -14,27 +14,26 @@
 package raftstore
 
 import (
+	"fmt"
+
 	trackerPkg "go.etcd.io/etcd/raft/v3/tracker"
 	"go.uber.org/zap"
 
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
 )
 
 func (pr *replica) tryCheckSplit(act action) bool {
-	if !pr.isLeader() {
-		return false
-	}
-
 	if !pr.needDoCheckSplit() {
 		return false
 	}
 
-	// If a replica is applying snapshot, skip split, avoid sent snapshot again in future.
-	if ok, id := pr.hasReplicaInSnapshotState(); ok {
+	if ok, reason := pr.canSplit(); !ok {
 		pr.logger.Debug("check split skipped",
-			log.ReplicaIDField(id),
-			log.ReasonField("applying snapshot"))
+			log.ReasonField(reason))
 		return false
 	}
 
@@ -50,9 +49,36 @@ func (pr *replica) tryCheckSplit(act action) bool {
 	return true
 }
 
-func (pr *replica) hasReplicaInSnapshotState() (bool, uint64) {
-	for id, p := range pr.rn.Status().Progress {
-		// If a peer is apply snapshot, skip split, avoid sent snapshot again in future.
+// canSplit returns whether the shard can be split by the current replica, and
+// the reason if it can not.
+func (pr *replica) canSplit() (bool, string) {
+	if pr.feature.DisableShardSplit {
+		return false, "split disabled"
+	}
+	if !pr.isLeader() {
+		return false, "not leader"
+	}
+	if pr.sm.isRemoved() {
+		return false, "replica removed"
+	}
+	if state := pr.getShard().State; state == metapb.ShardState_Destroying ||
+		state == metapb.ShardState_Destroyed {
+		return false, "shard is destroying"
+	}
+
+	status := pr.rn.Status()
+	if len(status.Config.Voters[1]) > 0 {
+		return false, "in joint config"
+	}
+	// If a replica is applying snapshot, skip split, avoid sent snapshot again in future.
+	if ok, id := hasReplicaInSnapshotState(status.Progress); ok {
+		return false, fmt.Sprintf("replica %d applying snapshot", id)
+	}
+	return true, ""
+}
+
+func hasReplicaInSnapshotState(progress map[uint64]trackerPkg.Progress) (bool, uint64) {
+	for id, p := range progress {
 		if p.State == trackerPkg.StateSnapshot {
 			return true, id
 		}
@@ -61,7 +87,23 @@ func (pr *replica) hasReplicaInSnapshotState() (bool, uint64) {
 }
 
 func (pr *replica) needDoCheckSplit() bool {
-	return pr.stats.approximateSize >= pr.feature.ShardSplitCheckBytes
+	pr.maybeEstimateSize()
+	return pr.SizeDiffHint() >= pr.feature.ShardSplitCheckBytes
+}
+
+// maybeEstimateSize replaces the size accumulated from the diffs of the
+// applied writes with the size estimated by the data storage if supported.
+func (pr *replica) maybeEstimateSize() {
+	estimator, ok := pr.sm.dataStorage.(storage.ShardSizeEstimator)
+	if !ok {
+		return
+	}
+	size, ok := estimator.EstimateShardSize(pr.getShard())
+	if !ok {
+		return
+	}
+	pr.stats.setApproximateSize(size)
+	metric.SetShardSizeDiff(pr.shardID, size)
 }
 
 func (pr *replica) doSplit(act action) {
@@ -79,7 +121,7 @@ func (pr *replica) doSplit(act action) {
 	}
 
 	if act.splitCheckData.size > 0 {
-		pr.stats.approximateSize = act.splitCheckData.size
+		pr.stats.setApproximateSize(act.splitCheckData.size)
 	}
 	if act.splitCheckData.keys > 0 {
 		pr.stats.approximateKeys = act.splitCheckData.keys
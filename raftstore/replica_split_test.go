@@ -18,10 +18,13 @@ import (
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/stretchr/testify/assert"
 	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
 )
 
 func TestTryCheckSplit(t *testing.T) {
@@ -50,6 +53,55 @@ func TestTryCheckSplit(t *testing.T) {
 	}}))
 }
 
+func TestCanSplit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+	pr.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+
+	pr.feature.DisableShardSplit = true
+	ok, reason := pr.canSplit()
+	assert.False(t, ok)
+	assert.Equal(t, "split disabled", reason)
+	pr.feature.DisableShardSplit = false
+
+	pr.leaderID = 2
+	ok, reason = pr.canSplit()
+	assert.False(t, ok)
+	assert.Equal(t, "not leader", reason)
+	pr.leaderID = 1
+
+	pr.sm.setShardState(metapb.ShardState_Destroying)
+	ok, reason = pr.canSplit()
+	assert.False(t, ok)
+	assert.Equal(t, "shard is destroying", reason)
+	pr.sm.setShardState(metapb.ShardState_Running)
+
+	ok, reason = pr.canSplit()
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+
+	pr.rn.ApplyConfChange(raftpb.ConfChangeV2{
+		Transition: raftpb.ConfChangeTransitionJointExplicit,
+		Changes: []raftpb.ConfChangeSingle{
+			{Type: raftpb.ConfChangeAddNode, NodeID: 2},
+			{Type: raftpb.ConfChangeAddNode, NodeID: 3},
+		},
+	})
+	ok, reason = pr.canSplit()
+	assert.False(t, ok)
+	assert.Equal(t, "in joint config", reason)
+
+	pr.sm.setRemoved()
+	ok, reason = pr.canSplit()
+	assert.False(t, ok)
+	assert.Equal(t, "replica removed", reason)
+}
+
 func TestDoSplit(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -115,3 +167,70 @@ func TestDoSplit(t *testing.T) {
 	assert.Equal(t, pr.getShard().End, req.Requests[1].End)
 	assert.Equal(t, act.splitCheckData.splitIDs[1].NewID, req.Requests[1].NewShardID)
 }
+
+type testShardSizeEstimator struct {
+	storage.DataStorage
+	size uint64
+	ok   bool
+}
+
+func (s *testShardSizeEstimator) EstimateShardSize(shard metapb.Shard) (uint64, bool) {
+	return s.size, s.ok
+}
+
+func TestSizeDiffHint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 2}, s)
+	_, err := pr.sm.dataStorage.GetInitialStates()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), pr.SizeDiffHint())
+
+	write := func(index uint64, value []byte) uint64 {
+		ctx := newApplyContext()
+		ctx.index = index
+		ctx.req = newTestRequestBatch(2, func(r *rpcpb.Request, i int) {
+			r.CustomType = uint64(rpcpb.CmdKVSet)
+			r.Cmd = protoc.MustMarshal(&rpcpb.KVSetRequest{Key: r.Key, Value: value})
+		})
+		pr.sm.applyCtx.metrics = applyMetrics{}
+		pr.sm.execWriteRequest(ctx)
+		diff := pr.sm.applyCtx.metrics.approximateDiffHint
+		assert.True(t, diff > 0)
+		pr.updateMetricsHints(applyResult{metrics: pr.sm.applyCtx.metrics})
+		return diff
+	}
+
+	diff1 := write(1, []byte("value"))
+	assert.Equal(t, diff1, pr.SizeDiffHint())
+	diff2 := write(2, make([]byte, 1024))
+	assert.Equal(t, diff1+diff2, pr.SizeDiffHint())
+}
+
+func TestSizeDiffHintSupersededByEstimatedSize(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 2}, s)
+	pr.stats.approximateSize = 100
+	pr.feature.ShardSplitCheckBytes = 200
+	estimator := &testShardSizeEstimator{DataStorage: pr.sm.dataStorage, size: 300}
+	pr.sm.dataStorage = estimator
+
+	// estimation not available, use the diff based size
+	assert.False(t, pr.needDoCheckSplit())
+	assert.Equal(t, uint64(100), pr.SizeDiffHint())
+
+	estimator.ok = true
+	assert.True(t, pr.needDoCheckSplit())
+	assert.Equal(t, uint64(300), pr.SizeDiffHint())
+
+	estimator.size = 50
+	assert.False(t, pr.needDoCheckSplit())
+	assert.Equal(t, uint64(50), pr.SizeDiffHint())
+}
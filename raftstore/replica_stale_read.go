@@ -0,0 +1,53 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/stop"
+)
+
+// staleRead serves the read request from the local data storage once the
+// replica is removed from the shard. The replica stops applying entries after
+// applying its removal, so its data is valid but stops at that applied index
+// until the replica is destroyed.
+func (pr *replica) staleRead(req rpcpb.Request, cb func(rpcpb.ResponseBatch)) error {
+	if !pr.cfg.Raft.ServeStaleReadsAfterRemoved ||
+		req.Type != rpcpb.Read ||
+		!pr.sm.isRemoved() {
+		return ErrStaleReadNotAllowed
+	}
+
+	err := pr.readStopper.RunTask(context.Background(), func(ctx context.Context) {
+		select {
+		case <-ctx.Done():
+			requestDoneWithReplicaRemoved(req, cb, pr.shardID)
+		default:
+			if ce := pr.logger.Check(zap.DebugLevel, "begin to exec stale read request"); ce != nil {
+				ce.Write(log.RequestIDField(req.ID),
+					log.RaftRequestField("request", &req))
+			}
+			requestDone(req, cb, pr.doReadRequest(req))
+		}
+	})
+	if err == stop.ErrUnavailable {
+		return ErrReplicaStopped
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestStaleReadFromRemovedReplica(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestFreezeReplica(t, s)
+	wc := pr.logdb.NewWorkerContext()
+	defer wc.Close()
+	applyAll(t, pr, wc)
+	require.True(t, pr.isLeader())
+
+	var responses []rpcpb.ResponseBatch
+	cb := func(resp rpcpb.ResponseBatch) {
+		responses = append(responses, resp)
+	}
+	req := createTestWriteReq("w1", "key", "value")
+	req.ToShard = 1
+	require.NoError(t, pr.addRequest(newReqCtx(req, cb)))
+	assert.True(t, pr.handleRequest(pr.items))
+	applyAll(t, pr, wc)
+	require.Equal(t, 1, len(responses))
+
+	respC := make(chan rpcpb.ResponseBatch, 1)
+	staleCB := func(resp rpcpb.ResponseBatch) {
+		respC <- resp
+	}
+	read := createTestReadReq("r1", "key")
+	read.ToShard = 1
+
+	// disabled
+	assert.Equal(t, ErrStaleReadNotAllowed, s.OnStaleReadWithCB(read, staleCB))
+
+	// not removed
+	pr.cfg.Raft.ServeStaleReadsAfterRemoved = true
+	assert.Equal(t, ErrStaleReadNotAllowed, s.OnStaleReadWithCB(read, staleCB))
+
+	pr.sm.setRemoved()
+	require.NoError(t, s.OnStaleReadWithCB(read, staleCB))
+	select {
+	case resp := <-respC:
+		require.Equal(t, 1, len(resp.Responses))
+		assert.True(t, resp.Header.IsEmpty())
+		var v rpcpb.KVGetResponse
+		protoc.MustUnmarshal(&v, resp.Responses[0].Value)
+		assert.Equal(t, []byte("value"), v.Value)
+		assert.Equal(t, read.ID, resp.Responses[0].ID)
+	case <-time.After(testWaitTimeout):
+		assert.FailNow(t, "timeout")
+	}
+
+	// writes are refused
+	write := createTestWriteReq("w2", "key", "value2")
+	write.ToShard = 1
+	assert.Equal(t, ErrStaleReadNotAllowed, s.OnStaleReadWithCB(write, staleCB))
+	assert.Empty(t, respC)
+
+	// unknown shard
+	read.ToShard = 2
+	assert.Equal(t, ErrStaleReadNotAllowed, s.OnStaleReadWithCB(read, staleCB))
+}
@@ -34,6 +34,7 @@ import (
 type applyContext struct {
 	index       uint64
 	term        uint64
+	entryBytes  uint64
 	req         rpcpb.RequestBatch
 	v2cc        raftpb.ConfChangeV2
 	adminResult *adminResult
@@ -49,6 +50,7 @@ func newApplyContext() *applyContext {
 func (ctx *applyContext) initialize(entry raftpb.Entry) {
 	ctx.index = entry.Index
 	ctx.term = entry.Term
+	ctx.entryBytes = uint64(len(entry.Data))
 	ctx.req = rpcpb.RequestBatch{}
 	ctx.adminResult = nil
 	ctx.metrics = applyMetrics{}
@@ -94,15 +96,51 @@ type stateMachine struct {
 	replicaCreatorFactory    replicaCreatorFactory
 	resultHandler            replicaResultHandler
 	aware                    aware.ShardStateAware
+	dedup                    *requestDedup
+	configChangeHistory      *configChangeHistory
+	// isDecommissioningStore returns true if the store is being decommissioned,
+	// errStoreDestroyed if the store is physically destroyed
+	isDecommissioningStore func(storeID uint64) (bool, error)
+	// allowPartialWrite allows the data storage to apply only a part of the
+	// write requests, see storage.ErrPartialWrite
+	allowPartialWrite bool
+	// enforceKeyRange rejects the write requests whose keys are not in the
+	// range of the shard
+	enforceKeyRange bool
+	// maxReplicas rejects the config changes adding more replicas than it, 0
+	// means no limit.
+	maxReplicas int
+	// maxApplyFailures how many times applying the same committed log can fail
+	// before the replica is quarantined, 0 means never quarantined.
+	maxApplyFailures int
+	// writeThrough forwards the applied writes to the secondary storage, nil if
+	// disabled
+	writeThrough *writeThroughForwarder
+	// writeBatch accumulates the write requests of the consecutive committed
+	// logs being applied
+	writeBatch applyWriteBatch
+	// preApplyHook is invoked with the write requests of each committed log
+	// before they are written to the data storage, see Customize.PreApplyHook
+	preApplyHook func(shardID uint64, requests []rpcpb.Request) error
+	// applyBurstEntries min number of committed logs applied at once to pause
+	// the compactions of the data storage, see storage.CompactionCoordinator
+	applyBurstEntries int
+	// tracer traces the requests proposed by the replica, nil if disabled
+	tracer *requestTracer
+	// shardMetrics the metrics labeled by the shard ID, nil if disabled
+	shardMetrics *metric.ShardMetrics
+	// commitTimes the commit time of the committed logs not yet applied
+	commitTimes commitTimes
 
 	metadataMu struct {
 		sync.Mutex
-		lease   *EpochLease
-		shard   Shard
-		removed bool
-		splited bool
-		index   uint64
-		term    uint64
+		lease       *EpochLease
+		shard       Shard
+		removed     bool
+		splited     bool
+		quarantined bool
+		index       uint64
+		term        uint64
 		// TODO: maybe should move to replica struct
 		firstIndex uint64
 	}
@@ -166,19 +204,34 @@ func (d *stateMachine) getLease() *EpochLease {
 	return d.metadataMu.lease
 }
 
+// getConfState returns the raft config of the shard. In the joint state, the
+// voters are the incoming config and the outgoing config is in VotersOutgoing,
+// the demoting voters are outgoing voters becoming learners once the shard
+// leaves the joint state.
 func (d *stateMachine) getConfState() raftpb.ConfState {
 	d.metadataMu.Lock()
 	defer d.metadataMu.Unlock()
 	cs := raftpb.ConfState{}
+	var outgoing []uint64
 	for _, r := range d.metadataMu.shard.Replicas {
-		if r.Role == metapb.ReplicaRole_Voter {
+		switch r.Role {
+		case metapb.ReplicaRole_Voter:
 			cs.Voters = append(cs.Voters, r.ID)
-		} else if r.Role == metapb.ReplicaRole_Learner {
+			outgoing = append(outgoing, r.ID)
+		case metapb.ReplicaRole_Learner:
 			cs.Learners = append(cs.Learners, r.ID)
-		} else {
+		case metapb.ReplicaRole_IncomingVoter:
+			cs.Voters = append(cs.Voters, r.ID)
+		case metapb.ReplicaRole_DemotingVoter:
+			outgoing = append(outgoing, r.ID)
+			cs.LearnersNext = append(cs.LearnersNext, r.ID)
+		default:
 			panic("unknown replica role")
 		}
 	}
+	if isJointShard(d.metadataMu.shard) {
+		cs.VotersOutgoing = outgoing
+	}
 	return cs
 }
 
@@ -189,13 +242,31 @@ func (d *stateMachine) applyCommittedEntries(entries []raftpb.Entry) {
 
 	d.logger.Debug("apply committed logs",
 		zap.Int("count", len(entries)))
+	if coordinator, ok := d.getCompactionCoordinator(len(entries)); ok {
+		coordinator.PauseCompaction()
+		defer coordinator.ResumeCompaction()
+	}
 	start := time.Now()
-	// FIXME: the initial idea is to batch multiple entries into the same
-	// executeContext so they can be applied into the stateMachine together.
-	// in the loop below, we are still applying entries one by one.
+	// the write requests of the consecutive entries are batched into the same
+	// writeContext when enabled, the batch is flushed before applying any other
+	// entry and at the end.
 	for _, entry := range entries {
+		// the quarantined replica never applies the committed logs, otherwise
+		// its state diverges from the other replicas
+		if d.isQuarantined() {
+			return
+		}
 		d.applyCtx.initialize(entry)
 		d.checkEntryIndexTerm(entry)
+		d.observeCommitToApplyLatency(entry.Index)
+		if d.canBatchWrite(entry) {
+			d.batchWrite(entry)
+			continue
+		}
+		d.flushWriteBatch()
+		if d.isQuarantined() {
+			return
+		}
 		// notify all clients that current shard has been removed or splitted
 		if !d.canApply(entry) {
 			if ce := d.logger.Check(zap.DebugLevel, "apply committed log skipped"); ce != nil {
@@ -219,6 +290,9 @@ func (d *stateMachine) applyCommittedEntries(entries []raftpb.Entry) {
 		}
 
 		ignoreMetrics := d.applyRequestBatch(d.applyCtx)
+		if d.isQuarantined() {
+			return
+		}
 		result := applyResult{
 			shardID:       d.shardID,
 			adminResult:   d.applyCtx.adminResult,
@@ -239,11 +313,24 @@ func (d *stateMachine) applyCommittedEntries(entries []raftpb.Entry) {
 		d.updateAppliedIndexTerm(entry.Index, entry.Term)
 		d.resultHandler.handleApplyResult(result)
 	}
+	d.flushWriteBatch()
 	metric.ObserveRaftLogApplyDuration(start)
 }
 
+// observeCommitToApplyLatency observes how long the committed log waits before
+// it is applied, the logs without the commit time are ignored.
+func (d *stateMachine) observeCommitToApplyLatency(index uint64) {
+	if at, ok := d.commitTimes.get(index); ok {
+		metric.ObserveCommitToApplyLatency(d.getShard().Group, time.Since(at))
+	}
+}
+
 func (d *stateMachine) checkEntryIndexTerm(entry raftpb.Entry) {
 	index, term := d.getAppliedIndexTerm()
+	// the batched entries are not applied yet
+	if lastIndex, lastTerm, ok := d.writeBatch.last(); ok {
+		index, term = lastIndex, lastTerm
+	}
 	if index+1 != entry.Index {
 		d.logger.Fatal("unexpected committed entry index",
 			zap.Uint64("applied", index),
@@ -349,6 +436,58 @@ func (d *stateMachine) isRemoved() bool {
 	return d.metadataMu.removed
 }
 
+func (d *stateMachine) setQuarantined() {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	d.metadataMu.quarantined = true
+}
+
+func (d *stateMachine) isQuarantined() bool {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	return d.metadataMu.quarantined
+}
+
+// handleApplyFailure handles the failure of applying the committed log at the
+// specified index. The failures of the same index are counted in logdb across
+// restarts, the failure is fatal until the count reaches maxApplyFailures, so
+// the log is applied again after the restart, then the replica is quarantined.
+func (d *stateMachine) handleApplyFailure(index uint64, msg string, err error) {
+	if d.maxApplyFailures <= 0 {
+		d.logger.Fatal(msg,
+			log.IndexField(index),
+			zap.Error(err))
+	}
+	last, failures, lerr := d.logdb.GetApplyFailures(d.shardID)
+	if lerr != nil {
+		d.logger.Fatal(msg,
+			log.IndexField(index),
+			zap.Error(err),
+			zap.NamedError("logdb-error", lerr))
+	}
+	if last != index {
+		failures = 0
+	}
+	failures++
+	if lerr := d.logdb.SaveApplyFailures(d.shardID, index, failures); lerr != nil {
+		d.logger.Fatal(msg,
+			log.IndexField(index),
+			zap.Error(err),
+			zap.NamedError("logdb-error", lerr))
+	}
+	if failures < uint64(d.maxApplyFailures) {
+		d.logger.Fatal(msg,
+			log.IndexField(index),
+			zap.Uint64("failures", failures),
+			zap.Error(err))
+	}
+	d.setQuarantined()
+	d.logger.Error("replica quarantined",
+		log.IndexField(index),
+		zap.Uint64("failures", failures),
+		zap.Error(err))
+}
+
 func (d *stateMachine) setSplited() {
 	d.metadataMu.Lock()
 	defer d.metadataMu.Unlock()
@@ -404,6 +543,16 @@ func (d *stateMachine) setFirstIndex(index uint64) {
 	d.metadataMu.firstIndex = index
 }
 
+// getCompactionCoordinator returns the CompactionCoordinator of the data
+// storage if applying the number of committed logs is a burst of apply work.
+func (d *stateMachine) getCompactionCoordinator(entries int) (storage.CompactionCoordinator, bool) {
+	if d.applyBurstEntries <= 0 || entries < d.applyBurstEntries {
+		return nil, false
+	}
+	coordinator, ok := d.dataStorage.(storage.CompactionCoordinator)
+	return coordinator, ok
+}
+
 func (d *stateMachine) getAppliedIndexTerm() (uint64, uint64) {
 	d.metadataMu.Lock()
 	defer d.metadataMu.Unlock()
@@ -18,10 +18,13 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
@@ -33,9 +36,28 @@ var (
 	ErrNotLearnerReplica = errors.New("not learner")
 	ErrReplicaNotFound   = errors.New("replica not found")
 	ErrReplicaDuplicated = errors.New("replica duplicated")
+	// ErrRemoveMissingReplica is also an ErrReplicaNotFound
+	ErrRemoveMissingReplica = errors.Wrap(ErrReplicaNotFound, "remove missing replica")
+	ErrRemoveVoterInJoint   = errors.New("remove voter in joint state")
+	ErrJointStatePending    = errors.New("joint state pending")
+	ErrNotInJointState      = errors.New("not in joint state")
+	ErrEmptySplitRange      = errors.New("empty split range")
+	ErrTooManyReplicas      = errors.New("too many replicas")
+)
+
+const (
+	applyLatencyWrite = "write"
+	applyLatencyAdmin = "admin"
 )
 
 func (d *stateMachine) execAdminRequest(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	start := time.Now()
+	defer func() {
+		latency := time.Since(start)
+		metric.ObserveApplyLatency(d.getShard().Group, applyLatencyAdmin, latency)
+		d.shardMetrics.ObserveApplyLatency(latency)
+	}()
+
 	switch ctx.req.GetAdminCmdType() {
 	case rpcpb.CmdConfigChange:
 		return d.doExecConfigChange(ctx)
@@ -100,6 +122,9 @@ func (d *stateMachine) adjustCompactionIndex(index uint64) (uint64, error) {
 
 func (d *stateMachine) doExecConfigChange(ctx *applyContext) (rpcpb.ResponseBatch, error) {
 	req := ctx.req.GetConfigChangeRequest()
+	if isLeaveJointConfigChangeRequest(req) {
+		return d.doExecLeaveJoint(ctx, req)
+	}
 	replica := req.Replica
 	current := d.getShard()
 
@@ -110,85 +135,243 @@ func (d *stateMachine) doExecConfigChange(ctx *applyContext) (rpcpb.ResponseBatc
 
 	shard := Shard{}
 	protoc.MustUnmarshal(&shard, protoc.MustMarshal(&current))
-	shard.Epoch.ConfigVer++
-	p := findReplica(shard, replica.StoreID)
+	if err := applyConfigChangeToShard(&shard, req); err != nil {
+		return rpcpb.ResponseBatch{}, err
+	}
+	if d.maxReplicas > 0 && len(shard.Replicas) > len(current.Replicas) &&
+		len(shard.Replicas) > d.maxReplicas {
+		d.logger.Warn("reject config change adding too many replicas",
+			log.ConfigChangeField("request", &req),
+			zap.Int("max-replicas", d.maxReplicas))
+		return rpcpb.ResponseBatch{}, errors.Wrapf(ErrTooManyReplicas,
+			"shardID %d, max replicas %d", shard.ID, d.maxReplicas)
+	}
+	p := findReplica(current, replica.StoreID)
+	switch req.ChangeType {
+	case metapb.ConfigChangeType_AddNode:
+		if p != nil {
+			d.logger.Info("learner promoted to voter",
+				log.ReplicaField("replica", *findReplica(shard, replica.StoreID)),
+				log.StoreIDField(replica.StoreID))
+		}
+	case metapb.ConfigChangeType_RemoveNode:
+		if d.isDecommissioningStore != nil {
+			// the removal is applied anyway, the store state only classifies it
+			decommissioning, err := d.isDecommissioningStore(replica.StoreID)
+			if err != nil {
+				d.logger.Warn("replica on destroyed store removed, not counted as decommission",
+					log.ReplicaField("replica", replica),
+					log.StoreIDField(replica.StoreID),
+					zap.Error(err))
+			} else if decommissioning {
+				ctx.metrics.admin.decommissionReplicaRemoved++
+				d.logger.Warn("replica on decommissioning store removed",
+					log.ReplicaField("replica", replica),
+					log.StoreIDField(replica.StoreID),
+					zap.Bool("decommissioning", true))
+			}
+		}
+
+		lease := d.getLease()
+		if lease.GetReplicaID() == p.ID {
+			d.updateLease(nil)
+		}
+
+		if d.replica.ID == replica.ID {
+			// Remove ourself, will destroy all shard data later.
+			d.setRemoved()
+			d.logger.Info("replica remoted itself",
+				log.ReplicaField("replica", *p),
+				log.StoreIDField(replica.StoreID))
+		}
+	case metapb.ConfigChangeType_AddLearnerNode:
+		if p != nil {
+			d.logger.Info("voter demoting",
+				log.ReplicaField("replica", *findReplica(shard, replica.StoreID)),
+				log.StoreIDField(replica.StoreID))
+		}
+	}
+	state := metapb.ReplicaState_Normal
+	if d.isRemoved() {
+		state = metapb.ReplicaState_ReplicaTombstone
+	}
+	d.updateShard(shard)
+	if err := d.saveShardMetedata(ctx.index, shard, state, d.getLease()); err != nil {
+		d.handleApplyFailure(ctx.index, "failed to save metadata", err)
+		return rpcpb.ResponseBatch{}, err
+	}
+
+	d.logger.Info("apply change replica completed",
+		log.ShardField("metadata", shard),
+		zap.String("state", state.String()))
+
+	resp := newAdminResponseBatch(rpcpb.CmdConfigChange, &rpcpb.ConfigChangeResponse{
+		Shard: shard,
+	})
+	ctx.adminResult = &adminResult{
+		adminType: rpcpb.CmdConfigChange,
+		configChangeResult: configChangeResult{
+			index:   ctx.index,
+			changes: []rpcpb.ConfigChangeRequest{req},
+			shard:   shard,
+		},
+	}
+	d.recordConfigChange(ctx.adminResult.configChangeResult)
+	return resp, nil
+}
+
+// ValidateConfChange checks whether the config changes can be applied to the
+// shard one by one, the first error is returned. It is a dry run of the apply
+// of config changes, the current shard is never modified.
+func ValidateConfChange(current Shard, changes []rpcpb.ConfigChangeRequest) error {
+	shard := Shard{}
+	protoc.MustUnmarshal(&shard, protoc.MustMarshal(&current))
+	for _, req := range changes {
+		if err := applyConfigChangeToShard(&shard, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyConfigChangeToShard validates the config change and applies it to the
+// shard, the shard is left unchanged if an error is returned. It has no other
+// side effect, so it is shared by the apply and the dry run of config changes.
+func applyConfigChangeToShard(shard *Shard, req rpcpb.ConfigChangeRequest) error {
+	if isLeaveJointConfigChangeRequest(req) {
+		// leaving a non-joint config is a protocol-ordering issue, e.g. a
+		// duplicated leave joint request, reject the entry rather than crashing
+		// the store.
+		if !isJointShard(*shard) {
+			return errors.Wrapf(ErrNotInJointState, "shardID %d", shard.ID)
+		}
+		for idx := range shard.Replicas {
+			switch shard.Replicas[idx].Role {
+			case metapb.ReplicaRole_IncomingVoter:
+				shard.Replicas[idx].Role = metapb.ReplicaRole_Voter
+			case metapb.ReplicaRole_DemotingVoter:
+				shard.Replicas[idx].Role = metapb.ReplicaRole_Learner
+			}
+		}
+		shard.Epoch.ConfigVer++
+		return nil
+	}
+
+	replica := req.Replica
+	p := findReplica(*shard, replica.StoreID)
+	if err := checkJointStateReplicas(*shard, req, p); err != nil {
+		return err
+	}
 	switch req.ChangeType {
 	case metapb.ConfigChangeType_AddNode:
-		exists := false
 		if p != nil {
-			exists = true
 			if p.ID == replica.ID {
 				if p.Role != metapb.ReplicaRole_Learner {
-					err := errors.Wrapf(ErrReplicaDuplicated,
+					return errors.Wrapf(ErrReplicaDuplicated,
 						"shardID %d, replicaID %d, role %v", shard.ID, p.ID, p.Role)
-					return rpcpb.ResponseBatch{}, err
 				}
 			} else {
-				err := errors.Wrapf(ErrReplicaDuplicated,
+				return errors.Wrapf(ErrReplicaDuplicated,
 					"shardID %d, replicaID %d found on store %d", shard.ID, p.ID, replica.StoreID)
-				return rpcpb.ResponseBatch{}, err
 			}
 			p.Role = metapb.ReplicaRole_Voter
-			d.logger.Info("learner promoted to voter",
-				log.ReplicaField("replica", *p),
-				log.StoreIDField(replica.StoreID))
-		}
-		if !exists {
+		} else {
 			replica.Role = metapb.ReplicaRole_Voter
 			shard.Replicas = append(shard.Replicas, replica)
 		}
 	case metapb.ConfigChangeType_RemoveNode:
-		if p != nil {
-			if p.ID != replica.ID {
-				err := errors.Wrapf(ErrReplicaNotFound,
-					"shardID %d, replicaID %d found on store %d", shard.ID, p.ID, replica.StoreID)
-				return rpcpb.ResponseBatch{}, err
-			} else {
-				removeReplica(&shard, replica.StoreID)
-			}
-
-			lease := d.getLease()
-			if lease.GetReplicaID() == p.ID {
-				d.updateLease(nil)
-			}
-
-			if d.replica.ID == replica.ID {
-				// Remove ourself, will destroy all shard data later.
-				d.setRemoved()
-				d.logger.Info("replica remoted itself",
-					log.ReplicaField("replica", *p),
-					log.StoreIDField(replica.StoreID))
-			}
-		} else {
-			err := errors.Wrapf(ErrReplicaNotFound,
+		if p == nil {
+			return errors.Wrapf(ErrRemoveMissingReplica,
 				"shardID %d, replicaID %d found on store %d",
 				shard.ID,
 				replica.ID, replica.StoreID)
-			return rpcpb.ResponseBatch{}, err
 		}
+		if p.ID != replica.ID {
+			return errors.Wrapf(ErrRemoveMissingReplica,
+				"shardID %d, replicaID %d found on store %d", shard.ID, p.ID, replica.StoreID)
+		}
+		removeReplica(shard, replica.StoreID)
 	case metapb.ConfigChangeType_AddLearnerNode:
+		if p != nil && p.ID == replica.ID && p.Role == metapb.ReplicaRole_Voter &&
+			isDemotingVoterRequest(req) {
+			p.Role = metapb.ReplicaRole_DemotingVoter
+			break
+		}
 		if p != nil {
-			err := errors.Wrapf(ErrReplicaDuplicated,
+			return errors.Wrapf(ErrReplicaDuplicated,
 				"shardID %d, replicaID %d role %v already exist on store %d",
 				shard.ID, p.ID, p.Role, replica.StoreID)
-			return rpcpb.ResponseBatch{}, err
 		}
 		replica.Role = metapb.ReplicaRole_Learner
 		shard.Replicas = append(shard.Replicas, replica)
 	}
-	state := metapb.ReplicaState_Normal
-	if d.isRemoved() {
-		state = metapb.ReplicaState_ReplicaTombstone
+	shard.Epoch.ConfigVer++
+	return nil
+}
+
+// checkJointStateReplicas rejects the config change if the shard is in the joint
+// state, only the leave joint config change can be applied in such state.
+func checkJointStateReplicas(shard Shard, req rpcpb.ConfigChangeRequest, target *Replica) error {
+	if target != nil && target.ID == req.Replica.ID &&
+		req.ChangeType == metapb.ConfigChangeType_RemoveNode &&
+		isJointStateRole(target.Role) {
+		return errors.Wrapf(ErrRemoveVoterInJoint,
+			"shardID %d, replicaID %d role %v", shard.ID, target.ID, target.Role)
+	}
+	for _, r := range shard.Replicas {
+		if isJointStateRole(r.Role) {
+			return errors.Wrapf(ErrJointStatePending,
+				"shardID %d, replicaID %d role %v", shard.ID, r.ID, r.Role)
+		}
+	}
+	return nil
+}
+
+func isJointStateRole(role metapb.ReplicaRole) bool {
+	return role == metapb.ReplicaRole_IncomingVoter ||
+		role == metapb.ReplicaRole_DemotingVoter
+}
+
+// isJointShard returns true if the shard is in the joint state, i.e. any of its
+// replicas has a joint state role. The shard enters the joint state only by the
+// config changes marking the replicas with such roles, so it is consistent with
+// the raft config once the config change is applied.
+func isJointShard(shard Shard) bool {
+	for _, r := range shard.Replicas {
+		if isJointStateRole(r.Role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *stateMachine) recordConfigChange(result configChangeResult) {
+	d.configChangeHistory.add(ConfigChangeRecord{
+		Index:   result.index,
+		Time:    time.Now(),
+		Changes: result.changes,
+	})
+}
+
+func (d *stateMachine) doExecLeaveJoint(ctx *applyContext, req rpcpb.ConfigChangeRequest) (rpcpb.ResponseBatch, error) {
+	current := d.getShard()
+	d.logger.Info("begin to apply leave joint",
+		log.IndexField(ctx.index),
+		log.ShardField("current", current))
+
+	shard := Shard{}
+	protoc.MustUnmarshal(&shard, protoc.MustMarshal(&current))
+	if err := applyConfigChangeToShard(&shard, req); err != nil {
+		return rpcpb.ResponseBatch{}, err
 	}
 	d.updateShard(shard)
-	if err := d.saveShardMetedata(ctx.index, shard, state, d.getLease()); err != nil {
-		d.logger.Fatal("failed to save metadata",
-			zap.Error(err))
+	if err := d.saveShardMetedata(ctx.index, shard, metapb.ReplicaState_Normal, d.getLease()); err != nil {
+		d.handleApplyFailure(ctx.index, "failed to save metadata", err)
+		return rpcpb.ResponseBatch{}, err
 	}
 
-	d.logger.Info("apply change replica completed",
-		log.ShardField("metadata", shard),
-		zap.String("state", state.String()))
+	d.logger.Info("apply leave joint completed",
+		log.ShardField("metadata", shard))
 
 	resp := newAdminResponseBatch(rpcpb.CmdConfigChange, &rpcpb.ConfigChangeResponse{
 		Shard: shard,
@@ -201,6 +384,7 @@ func (d *stateMachine) doExecConfigChange(ctx *applyContext) (rpcpb.ResponseBatc
 			shard:   shard,
 		},
 	}
+	d.recordConfigChange(ctx.adminResult.configChangeResult)
 	return resp, nil
 }
 
@@ -247,6 +431,16 @@ func (d *stateMachine) doExecSplit(ctx *applyContext) (rpcpb.ResponseBatch, erro
 				log.HexField("split-start", req.Start),
 				log.HexField("expect-start", expectStart))
 		}
+		// a split key equal to the start key of the range leaves an empty shard,
+		// reject the whole split and keep the current shard unchanged.
+		if len(req.End) > 0 && bytes.Compare(req.Start, req.End) >= 0 {
+			d.logger.Error("split rejected",
+				log.ReasonField("empty split range"),
+				log.HexField("split-start", req.Start),
+				log.HexField("split-end", req.End))
+			return rpcpb.ResponseBatch{}, errors.Wrapf(ErrEmptySplitRange,
+				"shardID %d, new shardID %d", current.ID, req.NewShardID)
+		}
 		expectStart = req.End
 
 		newShard := Shard{}
@@ -450,8 +644,117 @@ func (d *stateMachine) doUpdateMetadata(ctx *applyContext) (rpcpb.ResponseBatch,
 }
 
 func (d *stateMachine) execWriteRequest(ctx *applyContext) rpcpb.ResponseBatch {
+	d.tracer.committed(ctx.req.Requests)
 	d.writeCtx.initialize(d.getShard(), ctx.index)
-	requests := ctx.req.Requests
+	w := d.newPendingWrite(ctx)
+	err := d.preApply(w)
+	w = d.addWriteRequest(ctx, w)
+	if err != nil {
+		d.abortWrite(ctx.index, "pre-apply hook failed", err)
+	} else {
+		d.writeToDataStorage(ctx.index)
+	}
+	d.tracer.applied(w.requests)
+	resp := d.getWriteResponse(w, &ctx.metrics)
+	d.updateWriteMetrics(&d.applyCtx.metrics)
+	if ratio, ok := writeAmplification(d.writeCtx, ctx.entryBytes); ok {
+		metric.ObserveWriteAmplification(d.getShard().Group, ratio)
+	}
+	return resp
+}
+
+// pendingWrite is the write requests of a committed log added into the
+// writeContext, the results are available once the writeContext is written to
+// the data storage.
+type pendingWrite struct {
+	index    uint64
+	requests []rpcpb.Request
+	// responses of the requests which have been applied before
+	duplicated map[int][]byte
+	// errors of the requests whose keys are not in the shard
+	rejected map[int]errorpb.Error
+	// offset of the first request of the log in the batch of the writeContext
+	offset int
+}
+
+// newPendingWrite returns the pending write of the committed log, with the
+// requests whose keys are not in the shard and the requests applied before
+// recorded. The writeContext is not changed.
+func (d *stateMachine) newPendingWrite(ctx *applyContext) pendingWrite {
+	shard := d.getShard()
+	w := pendingWrite{
+		index:    ctx.index,
+		requests: ctx.req.Requests,
+	}
+	// the requests of the log applied before the later ones in the same log
+	var applied map[string]struct{}
+	requests := w.requests
+	for idx := range requests {
+		if requests[idx].IsTransaction() {
+			continue
+		}
+		if d.enforceKeyRange {
+			if err := checkKeyInShard(requests[idx].Key, shard); err != nil {
+				if w.rejected == nil {
+					w.rejected = make(map[int]errorpb.Error)
+				}
+				w.rejected[idx] = *err
+				d.logger.Warn("write rejected",
+					log.HexField("id", requests[idx].ID),
+					log.HexField("key", requests[idx].Key),
+					log.ReasonField("key not in shard"),
+					log.IndexField(ctx.index))
+				continue
+			}
+		}
+		v, ok := d.dedup.get(requests[idx].ID)
+		if !ok && d.dedup != nil && len(requests[idx].ID) > 0 {
+			_, ok = applied[string(requests[idx].ID)]
+		}
+		if ok {
+			if w.duplicated == nil {
+				w.duplicated = make(map[int][]byte)
+			}
+			w.duplicated[idx] = v
+			d.logger.Debug("skip duplicated write",
+				log.HexField("id", requests[idx].ID),
+				log.IndexField(ctx.index))
+			continue
+		}
+		if applied == nil {
+			applied = make(map[string]struct{})
+		}
+		applied[string(requests[idx].ID)] = struct{}{}
+	}
+	return w
+}
+
+// appliedRequests returns the requests of the pending write which are applied,
+// i.e. neither rejected nor applied before.
+func (w pendingWrite) appliedRequests() []rpcpb.Request {
+	if len(w.duplicated) == 0 && len(w.rejected) == 0 {
+		return w.requests
+	}
+	requests := make([]rpcpb.Request, 0, len(w.requests))
+	for idx := range w.requests {
+		if _, ok := w.duplicated[idx]; ok {
+			continue
+		}
+		if _, ok := w.rejected[idx]; ok {
+			continue
+		}
+		requests = append(requests, w.requests[idx])
+	}
+	return requests
+}
+
+// addWriteRequest adds the applied write requests of the pending write returned
+// by newPendingWrite into the initialized writeContext, the writeContext can
+// hold the requests of multiple consecutive logs.
+func (d *stateMachine) addWriteRequest(ctx *applyContext, w pendingWrite) pendingWrite {
+	d.writeCtx.batch.Index = ctx.index
+	w.offset = len(d.writeCtx.batch.Requests)
+	requests := w.requests
 	for idx := range requests {
 		if ce := d.logger.Check(zap.DebugLevel, "begin to execute write"); ce != nil {
 			ce.Write(log.HexField("id", requests[idx].ID),
@@ -460,6 +763,15 @@ func (d *stateMachine) execWriteRequest(ctx *applyContext) rpcpb.ResponseBatch {
 				log.IndexField(ctx.index))
 		}
 		if !requests[idx].IsTransaction() {
+			if _, ok := w.rejected[idx]; ok {
+				continue
+			}
+			if _, ok := w.duplicated[idx]; ok {
+				continue
+			}
+			// record it before the write, so duplicated requests in the later
+			// logs of the same batch are also skipped.
+			d.dedup.add(requests[idx].ID, nil)
 			d.writeCtx.batch.Requests = append(d.writeCtx.batch.Requests, storage.Request{
 				CmdType: requests[idx].CustomType,
 				Key:     requests[idx].Key,
@@ -470,34 +782,126 @@ func (d *stateMachine) execWriteRequest(ctx *applyContext) rpcpb.ResponseBatch {
 
 		d.execTransactionWrite(requests[idx], d.writeCtx)
 	}
+	return w
+}
 
-	if err := d.dataStorage.Write(d.writeCtx); err != nil {
-		d.logger.Fatal("failed to exec write cmd",
-			zap.Error(err))
+// writeToDataStorage writes the requests in the writeContext to the data
+// storage, index is the first committed log in the writeContext.
+func (d *stateMachine) writeToDataStorage(index uint64) {
+	// failed requests are reported by the data storage through the write
+	// context, errors returned here are unrecoverable storage failures unless
+	// partial write is allowed.
+	// the changes of the dedup state are persisted with the applied index, so it
+	// can be restored after restarts and snapshots.
+	d.writeCtx.batch.AppliedRequests, d.writeCtx.batch.RemovedRequests = d.dedup.takeChanges()
+	start := time.Now()
+	err := d.dataStorage.Write(d.writeCtx)
+	latency := time.Since(start)
+	metric.ObserveApplyLatency(d.writeCtx.shard.Group, applyLatencyWrite, latency)
+	d.shardMetrics.ObserveApplyLatency(latency)
+	if err != nil {
+		if d.allowPartialWrite && errors.Is(err, storage.ErrPartialWrite) {
+			failed := d.writeCtx.failUnapplied(err)
+			d.logger.Error("write cmd partially applied",
+				log.IndexField(index),
+				zap.Int("failed", failed),
+				zap.Error(err))
+		} else {
+			d.abortWrite(index, "failed to exec write cmd", err)
+		}
 	}
+}
+
+// preApply invokes the PreApplyHook with the applied requests of the committed
+// log before they are added into the writeContext. The hook is not invoked if
+// none of the requests is applied.
+func (d *stateMachine) preApply(w pendingWrite) error {
+	if d.preApplyHook == nil {
+		return nil
+	}
+	requests := w.appliedRequests()
+	if len(requests) == 0 {
+		return nil
+	}
+	return d.preApplyHook(d.shardID, requests)
+}
+
+// abortWrite fails all the requests in the writeContext once the replica is
+// quarantined by the failure, see handleApplyFailure.
+func (d *stateMachine) abortWrite(index uint64, msg string, err error) {
+	d.handleApplyFailure(index, msg, err)
+	// quarantined, none of the requests is considered applied
+	d.writeCtx.responses = d.writeCtx.responses[:0]
+	d.writeCtx.errors = d.writeCtx.errors[:0]
+	d.writeCtx.failUnapplied(ErrReplicaQuarantined)
+}
 
+// getWriteResponse returns the responses of the pending write after the
+// writeContext is written to the data storage, one for each request.
+func (d *stateMachine) getWriteResponse(w pendingWrite,
+	metrics *applyMetrics) rpcpb.ResponseBatch {
+	var writeThrough []storage.Request
+	requests := w.requests
 	resp := rpcpb.ResponseBatch{}
-	customResponseIdx := 0
+	customResponseIdx := w.offset
 	for idx := range requests {
 		if ce := d.logger.Check(zap.DebugLevel, "write completed"); ce != nil {
 			ce.Write(log.HexField("id", requests[idx].ID),
 				log.ShardIDField(d.shardID),
 				log.ReplicaIDField(d.replica.ID),
-				log.IndexField(ctx.index))
+				log.IndexField(w.index))
 		}
-		ctx.metrics.writtenKeys++
 		r := rpcpb.Response{}
+		if v, ok := w.duplicated[idx]; ok {
+			r.Value = v
+			resp.Responses = append(resp.Responses, r)
+			continue
+		}
+		if err, ok := w.rejected[idx]; ok {
+			r.Error = err
+			resp.Responses = append(resp.Responses, r)
+			continue
+		}
 		if !requests[idx].IsTransaction() {
+			err := d.writeCtx.errors[customResponseIdx]
 			r.Value = d.writeCtx.responses[customResponseIdx]
 			customResponseIdx++
+			if err != nil {
+				d.logger.Debug("failed to exec write request",
+					log.HexField("id", requests[idx].ID),
+					log.IndexField(w.index),
+					zap.Error(err))
+				d.dedup.remove(requests[idx].ID)
+				r.Error = errorpb.Error{Message: err.Error()}
+				resp.Responses = append(resp.Responses, r)
+				continue
+			}
+			d.dedup.add(requests[idx].ID, r.Value)
+			if d.writeThrough != nil {
+				writeThrough = append(writeThrough, storage.Request{
+					CmdType: requests[idx].CustomType,
+					Key:     requests[idx].Key,
+					Cmd:     requests[idx].Cmd,
+				})
+			}
 		}
+		metrics.writtenKeys++
 		resp.Responses = append(resp.Responses, r)
 	}
 
-	d.updateWriteMetrics()
+	d.writeThrough.addEvent(d.shardID, w.index, writeThrough)
 	return resp
 }
 
+// writeAmplification returns the ratio of the bytes written to the data storage
+// to the bytes of the proposed raft log, false if the ratio is meaningless.
+func writeAmplification(ctx *writeContext, entryBytes uint64) (float64, bool) {
+	if entryBytes == 0 || !ctx.hasRequest() {
+		return 0, false
+	}
+	return float64(ctx.writtenBytes) / float64(entryBytes), true
+}
+
 func (d *stateMachine) execTransactionWrite(req rpcpb.Request, ctx storage.WriteContext) {
 	if d.transactionalDataStorage == nil {
 		d.logger.Fatal("can not handle transaction request.",
@@ -536,17 +940,17 @@ func (d *stateMachine) execTransactionWrite(req rpcpb.Request, ctx storage.Write
 	}
 }
 
-func (d *stateMachine) updateWriteMetrics() {
-	d.applyCtx.metrics.writtenBytes += d.writeCtx.writtenBytes
+func (d *stateMachine) updateWriteMetrics(metrics *applyMetrics) {
+	metrics.writtenBytes += d.writeCtx.writtenBytes
 	if d.writeCtx.diffBytes < 0 {
 		v := uint64(math.Abs(float64(d.writeCtx.diffBytes)))
-		if v >= d.applyCtx.metrics.approximateDiffHint {
-			d.applyCtx.metrics.approximateDiffHint = 0
+		if v >= metrics.approximateDiffHint {
+			metrics.approximateDiffHint = 0
 		} else {
-			d.applyCtx.metrics.approximateDiffHint -= v
+			metrics.approximateDiffHint -= v
 		}
 	} else {
-		d.applyCtx.metrics.approximateDiffHint += uint64(d.writeCtx.diffBytes)
+		metrics.approximateDiffHint += uint64(d.writeCtx.diffBytes)
 	}
 }
 
@@ -14,9 +14,13 @@
 package raftstore
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/pb/hlcpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -31,6 +35,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/raft/v3"
 	"go.etcd.io/etcd/raft/v3/raftpb"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestStateMachineAddNode(t *testing.T) {
@@ -182,7 +188,336 @@ func TestStateMachineRemoveNode(t *testing.T) {
 	})
 }
 
-// TODO: add tests to cover failed config change
+func TestStateMachineLeaveJoint(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		shard := Shard{
+			ID: 1,
+			Replicas: []metapb.Replica{
+				{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_Voter},
+				{ID: 101, StoreID: 201, Role: metapb.ReplicaRole_IncomingVoter},
+				{ID: 102, StoreID: 202, Role: metapb.ReplicaRole_DemotingVoter},
+			},
+		}
+		sm.updateShard(shard)
+
+		batch := newTestAdminRequestBatch(string([]byte{0x1, 0x2, 0x3}), 0,
+			rpcpb.CmdConfigChange,
+			protoc.MustMarshal(&rpcpb.ConfigChangeRequest{}))
+		batch.Header.ShardID = 1
+		cc := raftpb.ConfChangeV2{
+			Context: protoc.MustMarshal(&batch),
+		}
+		entry := raftpb.Entry{
+			Index: 1,
+			Term:  1,
+			Type:  raftpb.EntryConfChangeV2,
+			Data:  protoc.MustMarshal(&cc),
+		}
+		sm.applyCommittedEntries([]raftpb.Entry{entry})
+		shard = sm.getShard()
+		require.Equal(t, 3, len(shard.Replicas))
+		assert.Equal(t, uint64(1), shard.Epoch.ConfigVer)
+		assert.Equal(t, metapb.ReplicaRole_Voter, shard.Replicas[0].Role)
+		assert.Equal(t, metapb.ReplicaRole_Voter, shard.Replicas[1].Role)
+		assert.Equal(t, metapb.ReplicaRole_Learner, shard.Replicas[2].Role)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineFailedConfigChange(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		normal := []metapb.Replica{
+			{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_Voter},
+			{ID: 101, StoreID: 201, Role: metapb.ReplicaRole_Learner},
+		}
+		joint := []metapb.Replica{
+			{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_Voter},
+			{ID: 101, StoreID: 201, Role: metapb.ReplicaRole_DemotingVoter},
+		}
+		tests := []struct {
+			replicas []metapb.Replica
+			req      rpcpb.ConfigChangeRequest
+			err      error
+		}{
+			{
+				normal,
+				rpcpb.ConfigChangeRequest{
+					ChangeType: metapb.ConfigChangeType_AddNode,
+					Replica:    metapb.Replica{ID: 100, StoreID: 200},
+				},
+				ErrReplicaDuplicated,
+			},
+			{
+				normal,
+				rpcpb.ConfigChangeRequest{
+					ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+					Replica:    metapb.Replica{ID: 102, StoreID: 201},
+				},
+				ErrReplicaDuplicated,
+			},
+			{
+				normal,
+				rpcpb.ConfigChangeRequest{
+					ChangeType: metapb.ConfigChangeType_RemoveNode,
+					Replica:    metapb.Replica{ID: 102, StoreID: 202},
+				},
+				ErrRemoveMissingReplica,
+			},
+			{
+				normal,
+				rpcpb.ConfigChangeRequest{
+					ChangeType: metapb.ConfigChangeType_RemoveNode,
+					Replica:    metapb.Replica{ID: 102, StoreID: 201},
+				},
+				ErrRemoveMissingReplica,
+			},
+			{
+				joint,
+				rpcpb.ConfigChangeRequest{
+					ChangeType: metapb.ConfigChangeType_RemoveNode,
+					Replica:    metapb.Replica{ID: 101, StoreID: 201},
+				},
+				ErrRemoveVoterInJoint,
+			},
+			{
+				joint,
+				rpcpb.ConfigChangeRequest{
+					ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+					Replica:    metapb.Replica{ID: 102, StoreID: 202, Role: metapb.ReplicaRole_Learner},
+				},
+				ErrJointStatePending,
+			},
+			{
+				normal,
+				rpcpb.ConfigChangeRequest{},
+				ErrNotInJointState,
+			},
+		}
+
+		for i, tt := range tests {
+			shard := Shard{ID: 1, Replicas: tt.replicas}
+			sm.updateShard(shard)
+			ctx := newApplyContext()
+			ctx.index = uint64(i + 1)
+			ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdConfigChange, protoc.MustMarshal(&tt.req))
+			_, err := sm.doExecConfigChange(ctx)
+			assert.True(t, errors.Is(err, tt.err), "index %d, err %v", i, err)
+			assert.Nil(t, ctx.adminResult, "index %d", i)
+			assert.Equal(t, shard, sm.getShard(), "index %d", i)
+		}
+		assert.True(t, errors.Is(ErrRemoveMissingReplica, ErrReplicaNotFound))
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineConfigChangeWithMaxReplicas(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		sm.maxReplicas = 3
+		sm.updateShard(Shard{ID: 1, Replicas: []metapb.Replica{{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_Voter}}})
+		index := uint64(0)
+		exec := func(changeType metapb.ConfigChangeType, replica metapb.Replica) error {
+			index++
+			req := rpcpb.ConfigChangeRequest{ChangeType: changeType, Replica: replica}
+			ctx := newApplyContext()
+			ctx.index = index
+			ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdConfigChange, protoc.MustMarshal(&req))
+			_, err := sm.doExecConfigChange(ctx)
+			return err
+		}
+
+		require.NoError(t, exec(metapb.ConfigChangeType_AddNode, metapb.Replica{ID: 101, StoreID: 201}))
+		require.NoError(t, exec(metapb.ConfigChangeType_AddLearnerNode, metapb.Replica{ID: 102, StoreID: 202}))
+		assert.Equal(t, 3, len(sm.getShard().Replicas))
+
+		// over the limit
+		shard := sm.getShard()
+		err := exec(metapb.ConfigChangeType_AddNode, metapb.Replica{ID: 103, StoreID: 203})
+		assert.True(t, errors.Is(err, ErrTooManyReplicas))
+		err = exec(metapb.ConfigChangeType_AddLearnerNode, metapb.Replica{ID: 103, StoreID: 203})
+		assert.True(t, errors.Is(err, ErrTooManyReplicas))
+		assert.Equal(t, shard, sm.getShard())
+
+		// promoting the learner does not add replicas
+		require.NoError(t, exec(metapb.ConfigChangeType_AddNode, metapb.Replica{ID: 102, StoreID: 202}))
+		require.NoError(t, exec(metapb.ConfigChangeType_RemoveNode, metapb.Replica{ID: 101, StoreID: 201}))
+		require.NoError(t, exec(metapb.ConfigChangeType_AddNode, metapb.Replica{ID: 103, StoreID: 203}))
+		assert.Equal(t, 3, len(sm.getShard().Replicas))
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestValidateConfChange(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		normal := []metapb.Replica{
+			{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_Voter},
+			{ID: 101, StoreID: 201, Role: metapb.ReplicaRole_Learner},
+		}
+		joint := []metapb.Replica{
+			{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_Voter},
+			{ID: 101, StoreID: 201, Role: metapb.ReplicaRole_DemotingVoter},
+		}
+		addVoter := rpcpb.ConfigChangeRequest{
+			ChangeType: metapb.ConfigChangeType_AddNode,
+			Replica:    metapb.Replica{ID: 102, StoreID: 202},
+		}
+		promote := rpcpb.ConfigChangeRequest{
+			ChangeType: metapb.ConfigChangeType_AddNode,
+			Replica:    metapb.Replica{ID: 101, StoreID: 201},
+		}
+		addLearner := rpcpb.ConfigChangeRequest{
+			ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+			Replica:    metapb.Replica{ID: 102, StoreID: 202},
+		}
+		remove := rpcpb.ConfigChangeRequest{
+			ChangeType: metapb.ConfigChangeType_RemoveNode,
+			Replica:    metapb.Replica{ID: 101, StoreID: 201},
+		}
+		demote := rpcpb.ConfigChangeRequest{
+			ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+			Replica:    metapb.Replica{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_DemotingVoter},
+		}
+		leaveJoint := rpcpb.ConfigChangeRequest{}
+		tests := []struct {
+			replicas []metapb.Replica
+			changes  []rpcpb.ConfigChangeRequest
+			err      error
+		}{
+			{normal, []rpcpb.ConfigChangeRequest{addVoter}, nil},
+			{normal, []rpcpb.ConfigChangeRequest{promote}, nil},
+			{normal, []rpcpb.ConfigChangeRequest{addLearner, remove}, nil},
+			{normal, []rpcpb.ConfigChangeRequest{demote, leaveJoint}, nil},
+			{joint, []rpcpb.ConfigChangeRequest{leaveJoint, remove}, nil},
+			{normal, []rpcpb.ConfigChangeRequest{promote, promote}, ErrReplicaDuplicated},
+			{normal, []rpcpb.ConfigChangeRequest{addVoter, addLearner}, ErrReplicaDuplicated},
+			{normal, []rpcpb.ConfigChangeRequest{remove, remove}, ErrRemoveMissingReplica},
+			{normal, []rpcpb.ConfigChangeRequest{leaveJoint}, ErrNotInJointState},
+			{joint, []rpcpb.ConfigChangeRequest{remove}, ErrRemoveVoterInJoint},
+			{joint, []rpcpb.ConfigChangeRequest{addLearner}, ErrJointStatePending},
+			{normal, []rpcpb.ConfigChangeRequest{demote, addVoter}, ErrJointStatePending},
+		}
+
+		for i, tt := range tests {
+			shard := Shard{ID: 1, Replicas: tt.replicas}
+			err := ValidateConfChange(shard, tt.changes)
+			assert.Equal(t, tt.err == nil, err == nil, "index %d, err %v", i, err)
+			assert.True(t, errors.Is(err, tt.err), "index %d, err %v", i, err)
+			assert.Equal(t, Shard{ID: 1, Replicas: tt.replicas}, shard, "index %d", i)
+
+			// the dry run matches the real apply
+			sm.updateShard(shard)
+			var applyErr error
+			for j, req := range tt.changes {
+				ctx := newApplyContext()
+				ctx.index = uint64(i*10 + j + 1)
+				ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdConfigChange, protoc.MustMarshal(&req))
+				if _, applyErr = sm.doExecConfigChange(ctx); applyErr != nil {
+					break
+				}
+			}
+			assert.True(t, errors.Is(applyErr, tt.err), "index %d, err %v", i, applyErr)
+		}
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineRemoveReplicaOnDecommissioningStore(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		core, logs := observer.New(zap.WarnLevel)
+		sm.logger = zap.New(core)
+		sm.isDecommissioningStore = func(storeID uint64) (bool, error) {
+			if storeID == 203 {
+				return false, errStoreDestroyed
+			}
+			return storeID == 201, nil
+		}
+		sm.updateShard(Shard{
+			ID: 1,
+			Replicas: []metapb.Replica{
+				{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_Voter},
+				{ID: 101, StoreID: 201, Role: metapb.ReplicaRole_Voter},
+				{ID: 102, StoreID: 202, Role: metapb.ReplicaRole_Voter},
+				{ID: 103, StoreID: 203, Role: metapb.ReplicaRole_Voter},
+			},
+		})
+
+		ctx := newApplyContext()
+		for i, r := range []metapb.Replica{{ID: 102, StoreID: 202}, {ID: 103, StoreID: 203}, {ID: 101, StoreID: 201}} {
+			ctx.index = uint64(i + 1)
+			ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdConfigChange,
+				protoc.MustMarshal(&rpcpb.ConfigChangeRequest{
+					ChangeType: metapb.ConfigChangeType_RemoveNode,
+					Replica:    r,
+				}))
+			_, err := sm.doExecConfigChange(ctx)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, len(sm.getShard().Replicas))
+		assert.Equal(t, uint64(1), ctx.metrics.admin.decommissionReplicaRemoved)
+		entries := logs.FilterMessage("replica on decommissioning store removed").All()
+		require.Equal(t, 1, len(entries))
+		assert.Equal(t, true, entries[0].ContextMap()["decommissioning"])
+		// the replica on the destroyed store is removed but not counted
+		entries = logs.FilterMessage("replica on destroyed store removed, not counted as decommission").All()
+		require.Equal(t, 1, len(entries))
+		assert.Equal(t, errStoreDestroyed.Error(), entries[0].ContextMap()["error"])
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineConfigChangeHistory(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		sm.configChangeHistory = newConfigChangeHistory(3)
+		sm.updateShard(Shard{ID: 1})
+		reqs := []rpcpb.ConfigChangeRequest{
+			{
+				ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+				Replica:    metapb.Replica{ID: 100, StoreID: 200},
+			},
+			{
+				ChangeType: metapb.ConfigChangeType_AddNode,
+				Replica:    metapb.Replica{ID: 100, StoreID: 200},
+			},
+			{
+				ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+				Replica:    metapb.Replica{ID: 101, StoreID: 201},
+			},
+			// failed config change is not recorded
+			{
+				ChangeType: metapb.ConfigChangeType_RemoveNode,
+				Replica:    metapb.Replica{ID: 102, StoreID: 202},
+			},
+			{
+				ChangeType: metapb.ConfigChangeType_RemoveNode,
+				Replica:    metapb.Replica{ID: 101, StoreID: 201},
+			},
+		}
+		for i, req := range reqs {
+			ctx := newApplyContext()
+			ctx.index = uint64(i + 1)
+			ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdConfigChange, protoc.MustMarshal(&req))
+			_, err := sm.doExecConfigChange(ctx)
+			assert.Equal(t, i == 3, err != nil, "index %d", i)
+		}
+
+		// the oldest record is evicted
+		records := sm.configChangeHistory.get()
+		require.Equal(t, 3, len(records))
+		for i, idx := range []int{1, 2, 4} {
+			assert.Equal(t, uint64(idx+1), records[i].Index)
+			assert.False(t, records[i].Time.IsZero())
+			require.Equal(t, 1, len(records[i].Changes))
+			assert.Equal(t, reqs[idx].ChangeType, records[i].Changes[0].ChangeType)
+			assert.Equal(t, reqs[idx].Replica.ID, records[i].Changes[0].Replica.ID)
+		}
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
 
 func TestDoExecSplit(t *testing.T) {
 	defer leaktest.AfterTest(t)()
@@ -229,6 +564,19 @@ func TestDoExecSplit(t *testing.T) {
 	go checkPanicFn()
 	assert.True(t, <-ch)
 
+	// check split key at the start key
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdBatchSplit, protoc.MustMarshal(&rpcpb.BatchSplitRequest{
+		Requests: []rpcpb.SplitRequest{
+			{Start: []byte{1}, End: []byte{1}, NewShardID: 2, NewReplicas: []Replica{{ID: 200, StoreID: storeID}}},
+			{Start: []byte{1}, End: []byte{10}, NewShardID: 3, NewReplicas: []Replica{{ID: 300, StoreID: storeID}}},
+		},
+	}))
+	current := pr.getShard()
+	_, err := pr.sm.execAdminRequest(ctx)
+	assert.True(t, errors.Is(err, ErrEmptySplitRange))
+	assert.Nil(t, ctx.adminResult)
+	assert.Equal(t, current, pr.getShard())
+
 	// s1 -> s2+s3
 	ctx.index = 100
 	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdBatchSplit, protoc.MustMarshal(&rpcpb.BatchSplitRequest{
@@ -324,6 +672,12 @@ type testDataStorage struct {
 	persistentLogIndex uint64
 	feature            storage.Feature
 	counts             map[int]int
+	writes             int
+	// partialWrites applies at most partialWrites requests in each Write when it
+	// is not 0, storage.ErrPartialWrite is returned if more requests are given.
+	partialWrites int
+	// writeErr is returned by all writes if it is not nil
+	writeErr error
 }
 
 func (t *testDataStorage) Close() error                                     { panic("not implemented") }
@@ -332,7 +686,18 @@ func (t *testDataStorage) NewWriteBatch() storage.Resetable                 { pa
 func (t *testDataStorage) CreateSnapshot(shardID uint64, path string) error { panic("not implemented") }
 func (t *testDataStorage) ApplySnapshot(shardID uint64, path string) error  { panic("not implemented") }
 func (t *testDataStorage) Write(ctx storage.WriteContext) error {
-	for range ctx.Batch().Requests {
+	if t.writeErr != nil {
+		return t.writeErr
+	}
+	for idx, req := range ctx.Batch().Requests {
+		if t.partialWrites > 0 && idx >= t.partialWrites {
+			return fmt.Errorf("%d requests applied: %w", idx, storage.ErrPartialWrite)
+		}
+		if string(req.Cmd) == "invalid" {
+			ctx.AppendError(errors.New("invalid request"))
+			continue
+		}
+		t.writes++
 		ctx.AppendResponse([]byte("OK"))
 	}
 	return nil
@@ -565,6 +930,373 @@ func TestExecWriteRequest(t *testing.T) {
 	}
 }
 
+func TestExecWriteRequestWithFailedRequest(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	ds := &testDataStorage{}
+	_, err := ds.GetInitialStates()
+	assert.NoError(t, err)
+	pr.sm.dataStorage = ds
+
+	ctx := newApplyContext()
+	ctx.req = newTestRequestBatch(3, func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdReserved) + 1
+		if i == 1 {
+			r.Cmd = []byte("invalid")
+		}
+	})
+	resp := pr.sm.execWriteRequest(ctx)
+	assert.Equal(t, 2, ds.writes)
+	require.Equal(t, 3, len(resp.Responses))
+	assert.Equal(t, []byte("OK"), resp.Responses[0].Value)
+	assert.Equal(t, "", resp.Responses[0].Error.Message)
+	assert.Nil(t, resp.Responses[1].Value)
+	assert.Equal(t, "invalid request", resp.Responses[1].Error.Message)
+	assert.Equal(t, []byte("OK"), resp.Responses[2].Value)
+	assert.Equal(t, "", resp.Responses[2].Error.Message)
+	assert.Equal(t, uint64(2), ctx.metrics.writtenKeys)
+}
+
+func TestExecWriteRequestWithPartialWrite(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.cfg.Raft.AllowPartialWrite = true
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	ds := &testDataStorage{partialWrites: 3}
+	_, err := ds.GetInitialStates()
+	assert.NoError(t, err)
+	pr.sm.dataStorage = ds
+
+	ctx := newApplyContext()
+	ctx.req = newTestRequestBatch(5, func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdReserved) + 1
+		if i == 1 {
+			r.Cmd = []byte("invalid")
+		}
+	})
+	resp := pr.sm.execWriteRequest(ctx)
+	assert.Equal(t, 2, ds.writes)
+	require.Equal(t, 5, len(resp.Responses))
+	assert.Equal(t, []byte("OK"), resp.Responses[0].Value)
+	assert.Equal(t, "", resp.Responses[0].Error.Message)
+	assert.Equal(t, "invalid request", resp.Responses[1].Error.Message)
+	assert.Equal(t, []byte("OK"), resp.Responses[2].Value)
+	assert.Equal(t, "", resp.Responses[2].Error.Message)
+	for _, r := range resp.Responses[3:] {
+		assert.Nil(t, r.Value)
+		assert.Equal(t, "3 requests applied: partial write", r.Error.Message)
+	}
+	assert.Equal(t, uint64(2), ctx.metrics.writtenKeys)
+}
+
+// slowDataStorage delays the writes and the persistent log index queries
+type slowDataStorage struct {
+	storage.DataStorage
+	delay time.Duration
+}
+
+func (s *slowDataStorage) Write(ctx storage.WriteContext) error {
+	time.Sleep(s.delay)
+	return s.DataStorage.Write(ctx)
+}
+
+func (s *slowDataStorage) GetPersistentLogIndex(shardID uint64) (uint64, error) {
+	time.Sleep(s.delay)
+	return s.DataStorage.GetPersistentLogIndex(shardID)
+}
+
+func getApplyLatencySamples(t *testing.T, group uint64, cmdType string) (uint64, time.Duration) {
+	m := getMetric(t, "matrixcube_raftstore_apply_latency_seconds",
+		map[string]string{"group": fmt.Sprintf("%d", group), "type": cmdType})
+	if m == nil {
+		return 0, 0
+	}
+	h := m.GetHistogram()
+	return h.GetSampleCount(), time.Duration(h.GetSampleSum() * float64(time.Second))
+}
+
+func TestApplyLatencyObserved(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1, Group: 1002, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	ds := &testDataStorage{persistentLogIndex: 100}
+	_, err := ds.GetInitialStates()
+	require.NoError(t, err)
+	delay := 50 * time.Millisecond
+	pr.sm.dataStorage = &slowDataStorage{DataStorage: ds, delay: delay}
+
+	ctx := newApplyContext()
+	ctx.req = newTestRequestBatch(2, func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdReserved) + 1
+	})
+	pr.sm.execWriteRequest(ctx)
+	count, sum := getApplyLatencySamples(t, 1002, applyLatencyWrite)
+	assert.Equal(t, uint64(1), count)
+	assert.True(t, sum >= delay)
+
+	ctx = newApplyContext()
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdCompactLog,
+		protoc.MustMarshal(&rpcpb.CompactLogRequest{CompactIndex: 10}))
+	_, err = pr.sm.execAdminRequest(ctx)
+	require.NoError(t, err)
+	count, sum = getApplyLatencySamples(t, 1002, applyLatencyAdmin)
+	assert.Equal(t, uint64(1), count)
+	assert.True(t, sum >= delay)
+}
+
+func TestReplicaQuarantinedAfterRepeatedApplyFailures(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.cfg.Raft.MaxApplyFailures = 2
+	newReplicaWithStorage := func(id uint64, ds *testDataStorage) *replica {
+		r := Replica{ID: id + 100, StoreID: s.Meta().ID}
+		pr := newTestReplica(Shard{ID: id, Replicas: []Replica{r}}, r, s)
+		_, err := ds.GetInitialStates()
+		require.NoError(t, err)
+		pr.sm.dataStorage = ds
+		pr.sm.logger = log.GetPanicZapLogger()
+		s.addReplica(pr)
+		return pr
+	}
+	newWriteContext := func() *applyContext {
+		ctx := newApplyContext()
+		ctx.index = 10
+		ctx.req = newTestRequestBatch(2, func(r *rpcpb.Request, i int) {
+			r.CustomType = uint64(rpcpb.CmdReserved) + 1
+		})
+		return ctx
+	}
+	pr1 := newReplicaWithStorage(1, &testDataStorage{writeErr: errors.New("poison")})
+	pr2 := newReplicaWithStorage(2, &testDataStorage{})
+
+	// the first failure crashes the store, the log is applied again after the
+	// restart
+	assert.Panics(t, func() { pr1.sm.execWriteRequest(newWriteContext()) })
+	assert.False(t, pr1.sm.isQuarantined())
+	assert.Empty(t, s.QuarantinedShards())
+
+	// quarantined after failing again on the same index
+	resp := pr1.sm.execWriteRequest(newWriteContext())
+	assert.True(t, pr1.sm.isQuarantined())
+	require.Equal(t, 2, len(resp.Responses))
+	for _, r := range resp.Responses {
+		assert.Equal(t, ErrReplicaQuarantined.Error(), r.Error.Message)
+	}
+	assert.Equal(t, []uint64{1}, s.QuarantinedShards())
+	assert.Equal(t, ErrReplicaQuarantined, pr1.addRequest(newReqCtx(rpcpb.Request{}, nil)))
+	// the internal admin requests are dropped
+	assert.NotPanics(t, func() {
+		pr1.addAdminRequest(rpcpb.CmdCompactLog, &rpcpb.CompactLogRequest{CompactIndex: 1})
+	})
+	assert.Equal(t, int64(0), pr1.requests.Len())
+	// no more logs applied
+	index, _ := pr1.sm.getAppliedIndexTerm()
+	pr1.sm.applyCommittedEntries([]raftpb.Entry{{Index: index + 1, Term: 1}})
+	applied, _ := pr1.sm.getAppliedIndexTerm()
+	assert.Equal(t, index, applied)
+
+	// other shards are healthy
+	resp = pr2.sm.execWriteRequest(newWriteContext())
+	require.Equal(t, 2, len(resp.Responses))
+	for _, r := range resp.Responses {
+		assert.Equal(t, []byte("OK"), r.Value)
+	}
+	assert.False(t, pr2.sm.isQuarantined())
+	assert.NoError(t, pr2.addRequest(newReqCtx(rpcpb.Request{}, nil)))
+}
+
+func TestPreApplyHookFailureAbortsApply(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	ds := &testDataStorage{}
+	_, err := ds.GetInitialStates()
+	require.NoError(t, err)
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 2}, s)
+	pr.sm.dataStorage = ds
+	pr.sm.logger = log.GetPanicZapLogger()
+	pr.sm.preApplyHook = func(shardID uint64, requests []rpcpb.Request) error {
+		return errors.New("external log unavailable")
+	}
+
+	ctx := newApplyContext()
+	ctx.index = 10
+	ctx.req = newTestRequestBatch(2, func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdReserved) + 1
+	})
+	assert.Panics(t, func() { pr.sm.execWriteRequest(ctx) })
+	assert.Equal(t, 0, ds.writes)
+}
+
+func TestExecWriteRequestWithKeyNotInShard(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.cfg.Raft.EnforceKeyRange = true
+	shard := Shard{ID: 1, Start: []byte("b"), End: []byte("d"), Replicas: []Replica{{ID: 2}}}
+	pr := newTestReplica(shard, Replica{ID: 2}, s)
+	ds := &testDataStorage{}
+	_, err := ds.GetInitialStates()
+	assert.NoError(t, err)
+	pr.sm.dataStorage = ds
+
+	keys := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}
+	ctx := newApplyContext()
+	ctx.req = newTestRequestBatch(len(keys), func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdReserved) + 1
+		r.Key = keys[i]
+	})
+	resp := pr.sm.execWriteRequest(ctx)
+	assert.Equal(t, 2, ds.writes)
+	require.Equal(t, len(keys), len(resp.Responses))
+	for _, i := range []int{0, 3} {
+		assert.Nil(t, resp.Responses[i].Value)
+		require.NotNil(t, resp.Responses[i].Error.KeyNotInShard)
+		assert.Equal(t, keys[i], resp.Responses[i].Error.KeyNotInShard.Key)
+		assert.Equal(t, uint64(1), resp.Responses[i].Error.KeyNotInShard.ShardID)
+	}
+	for _, i := range []int{1, 2} {
+		assert.Equal(t, []byte("OK"), resp.Responses[i].Value)
+		assert.Equal(t, "", resp.Responses[i].Error.Message)
+	}
+	assert.Equal(t, uint64(2), ctx.metrics.writtenKeys)
+	// the rejected requests can be retried after being routed to the right shard
+	_, ok := pr.sm.dedup.get(ctx.req.Requests[0].ID)
+	assert.False(t, ok)
+}
+
+func TestWriteAmplification(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+
+	value := make([]byte, 1024)
+	rb := newTestRequestBatch(10, func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdKVSet)
+		r.Cmd = protoc.MustMarshal(&rpcpb.KVSetRequest{Key: r.Key, Value: value})
+	})
+	rb.Header.ShardID = 1
+	ctx := newApplyContext()
+	ctx.initialize(raftpb.Entry{Index: 1, Term: 1, Data: protoc.MustMarshal(&rb)})
+	pr.sm.execWriteRequest(ctx)
+	ratio, ok := writeAmplification(pr.sm.writeCtx, ctx.entryBytes)
+	assert.True(t, ok)
+	// the written key-values are slightly smaller than the proposed entry which
+	// also includes the encoded request headers
+	assert.True(t, ratio > 0.8 && ratio < 1, "ratio %f", ratio)
+
+	_, ok = writeAmplification(pr.sm.writeCtx, 0)
+	assert.False(t, ok)
+	pr.sm.writeCtx.initialize(pr.getShard(), 2)
+	_, ok = writeAmplification(pr.sm.writeCtx, ctx.entryBytes)
+	assert.False(t, ok)
+}
+
+func TestExecWriteRequestWithDedup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.cfg.Raft.WriteDedupWindow = 10
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	ds := &testDataStorage{}
+	_, err := ds.GetInitialStates()
+	assert.NoError(t, err)
+	pr.sm.dataStorage = ds
+
+	ctx := newApplyContext()
+	ctx.req = newTestRequestBatch(2, func(r *rpcpb.Request, i int) { r.CustomType = uint64(rpcpb.CmdReserved) + 1 })
+	resp := pr.sm.execWriteRequest(ctx)
+	assert.Equal(t, 2, ds.writes)
+	assert.Equal(t, 2, len(resp.Responses))
+
+	// retry the requests after an ambiguous timeout, with a new request
+	ctx.req = newTestRequestBatch(3, func(r *rpcpb.Request, i int) { r.CustomType = uint64(rpcpb.CmdReserved) + 1 })
+	resp = pr.sm.execWriteRequest(ctx)
+	assert.Equal(t, 3, ds.writes)
+	require.Equal(t, 3, len(resp.Responses))
+	for _, r := range resp.Responses {
+		assert.Equal(t, []byte("OK"), r.Value)
+	}
+	assert.Equal(t, 3, pr.sm.dedup.len())
+}
+
+func TestInitRequestDedup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.cfg.Raft.WriteDedupWindow = 2
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	// the applied requests are not persisted by the testDataStorage
+	pr.sm.dataStorage = &testDataStorage{}
+
+	var entries []raftpb.Entry
+	for i := uint64(1); i <= 4; i++ {
+		rb := newTestRequestBatch(1, func(r *rpcpb.Request, _ int) { r.ID = buf.Int2Bytes(int(i)) })
+		rb.Header.ShardID = 1
+		entries = append(entries, raftpb.Entry{Index: i, Term: 1, Data: protoc.MustMarshal(&rb)})
+	}
+	assert.NoError(t, pr.logdb.SaveRaftState(pr.shardID, pr.replicaID, raft.Ready{
+		Entries:   entries,
+		HardState: raftpb.HardState{Commit: 4, Term: 1},
+	}, pr.logdb.NewWorkerContext()))
+	pr.lr.SetRange(1, 4)
+	// entry 4 is not applied, it will be replayed by raft
+	pr.appliedIndex = 3
+
+	assert.NoError(t, pr.initRequestDedup())
+	assert.Equal(t, 2, pr.sm.dedup.len())
+	for i, ok := range []bool{false, true, true, false} {
+		_, found := pr.sm.dedup.get(buf.Int2Bytes(i + 1))
+		assert.Equal(t, ok, found, "index %d", i+1)
+	}
+}
+
+func TestInitRequestDedupFromDataStorage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.cfg.Raft.WriteDedupWindow = 2
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	_, err := pr.sm.dataStorage.GetInitialStates()
+	require.NoError(t, err)
+
+	for i := uint64(1); i <= 3; i++ {
+		rb := newTestRequestBatch(1, func(r *rpcpb.Request, _ int) {
+			r.ID = buf.Int2Bytes(int(i))
+			r.CustomType = uint64(rpcpb.CmdKVSet)
+			r.Cmd = protoc.MustMarshal(&rpcpb.KVSetRequest{Key: r.Key, Value: r.Key})
+		})
+		rb.Header.ShardID = 1
+		ctx := newApplyContext()
+		ctx.initialize(raftpb.Entry{Index: i, Term: 1, Data: protoc.MustMarshal(&rb)})
+		pr.sm.execWriteRequest(ctx)
+	}
+	// only the changes are written by each batch
+	assert.Equal(t, [][]byte{buf.Int2Bytes(3)}, pr.sm.writeCtx.batch.AppliedRequests)
+	assert.Equal(t, [][]byte{buf.Int2Bytes(1)}, pr.sm.writeCtx.batch.RemovedRequests)
+
+	// the raft logs are not available, e.g. compacted or replaced by a snapshot
+	pr.sm.dedup = newRequestDedup(1)
+	assert.NoError(t, pr.initRequestDedup())
+	assert.Equal(t, 2, pr.sm.dedup.len())
+	for i, ok := range []bool{false, true, true} {
+		v, found := pr.sm.dedup.get(buf.Int2Bytes(i + 1))
+		assert.Equal(t, ok, found, "request %d", i+1)
+		// the responses are not restored
+		assert.Empty(t, v)
+	}
+	added, removed := pr.sm.dedup.takeChanges()
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
 func newTestRequestBatch(n int, builder func(*rpcpb.Request, int)) rpcpb.RequestBatch {
 	rb := rpcpb.RequestBatch{
 		Header: rpcpb.RequestBatchHeader{ID: uuid.NewV4().Bytes()}}
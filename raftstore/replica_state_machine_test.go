@@ -133,6 +133,32 @@ func TestStateMachineSetShardState(t *testing.T) {
 	runSimpleStateMachineTest(t, f, nil)
 }
 
+func TestStateMachineGetConfState(t *testing.T) {
+	f := func(sm *stateMachine) {
+		sm.updateShard(Shard{ID: 100, Replicas: []Replica{
+			{ID: 1, Role: metapb.ReplicaRole_Voter},
+			{ID: 2, Role: metapb.ReplicaRole_Voter},
+			{ID: 3, Role: metapb.ReplicaRole_Learner},
+		}})
+		assert.Equal(t, raftpb.ConfState{Voters: []uint64{1, 2}, Learners: []uint64{3}},
+			sm.getConfState())
+
+		// the joint config is restored from the shard metadata
+		sm.updateShard(Shard{ID: 100, Replicas: []Replica{
+			{ID: 1, Role: metapb.ReplicaRole_Voter},
+			{ID: 2, Role: metapb.ReplicaRole_DemotingVoter},
+			{ID: 3, Role: metapb.ReplicaRole_Learner},
+		}})
+		assert.Equal(t, raftpb.ConfState{
+			Voters:         []uint64{1},
+			VotersOutgoing: []uint64{1, 2},
+			Learners:       []uint64{3},
+			LearnersNext:   []uint64{2},
+		}, sm.getConfState())
+	}
+	runSimpleStateMachineTest(t, f, nil)
+}
+
 func TestStateMachineRemovedStateCanBeSet(t *testing.T) {
 	f := func(sm *stateMachine) {
 		assert.False(t, sm.isRemoved())
@@ -427,6 +453,41 @@ func TestStateMachineRejectsStaleEpochEntries(t *testing.T) {
 	runSimpleStateMachineTest(t, f, h)
 }
 
+func TestStateMachineRejectsConflictingConfigChange(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		shard := sm.getShard()
+		// leave joint while the shard is not in the joint state
+		batch := newTestAdminRequestBatch(string([]byte{0x1, 0x2, 0x3}), 0,
+			rpcpb.CmdConfigChange,
+			protoc.MustMarshal(&rpcpb.ConfigChangeRequest{}))
+		batch.Header.ShardID = shard.ID
+		batch.Requests[0].Epoch = shard.Epoch
+		cc := raftpb.ConfChangeV2{
+			Context: protoc.MustMarshal(&batch),
+		}
+		entry := raftpb.Entry{
+			Index: 1,
+			Term:  1,
+			Type:  raftpb.EntryConfChangeV2,
+			Data:  protoc.MustMarshal(&cc),
+		}
+		sm.applyCommittedEntries([]raftpb.Entry{entry})
+		index, term := sm.getAppliedIndexTerm()
+		assert.Equal(t, uint64(1), index)
+		assert.Equal(t, uint64(1), term)
+		assert.Equal(t, uint64(1), h.appliedIndex)
+
+		assert.Equal(t, uint64(1), h.notified)
+		assert.Equal(t, batch.Header.ID, h.id)
+		assert.Equal(t, true, h.isConfChange)
+		require.Equal(t, 0, len(h.resp.Responses))
+		assert.NotNil(t, h.resp.Header.Error.StaleEpoch)
+		assert.Equal(t, shard, sm.getShard())
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
 func TestStateMachineRejectsStaleLeaseEntries(t *testing.T) {
 	h := &testReplicaResultHandler{}
 	f := func(sm *stateMachine) {
@@ -0,0 +1,165 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+// batchedWrite is a committed write log added into the writeContext but not
+// yet written to the data storage.
+type batchedWrite struct {
+	pendingWrite
+	id         []byte
+	term       uint64
+	entryBytes uint64
+}
+
+// applyWriteBatch accumulates the write requests of the consecutive committed
+// logs, so they are written to the data storage together. The batch must be
+// flushed before applying any other log, the applied index is only updated
+// after the flush.
+type applyWriteBatch struct {
+	maxEntries int
+	maxBytes   uint64
+	writes     []batchedWrite
+	bytes      uint64
+}
+
+func (b *applyWriteBatch) enabled() bool {
+	return b.maxEntries > 1
+}
+
+func (b *applyWriteBatch) isEmpty() bool {
+	return len(b.writes) == 0
+}
+
+func (b *applyWriteBatch) isFull() bool {
+	return len(b.writes) >= b.maxEntries ||
+		(b.maxBytes > 0 && b.bytes >= b.maxBytes)
+}
+
+func (b *applyWriteBatch) add(w batchedWrite) {
+	b.writes = append(b.writes, w)
+	b.bytes += w.entryBytes
+}
+
+// last returns the index and term of the last log in the batch.
+func (b *applyWriteBatch) last() (uint64, uint64, bool) {
+	if b.isEmpty() {
+		return 0, 0, false
+	}
+	w := b.writes[len(b.writes)-1]
+	return w.index, w.term, true
+}
+
+func (b *applyWriteBatch) reset() {
+	b.writes = b.writes[:0]
+	b.bytes = 0
+}
+
+// canBatchWrite returns true if the committed log in the applyContext only
+// contains write requests which can be applied, it can be added into the
+// applyWriteBatch.
+func (d *stateMachine) canBatchWrite(entry raftpb.Entry) bool {
+	return d.writeBatch.enabled() &&
+		entry.Type == raftpb.EntryNormal &&
+		len(entry.Data) > 0 &&
+		!d.applyCtx.req.IsAdmin() &&
+		d.canApply(entry) &&
+		d.checkEpoch(d.applyCtx.req) &&
+		d.checkLease(d.applyCtx.req)
+}
+
+// batchWrite adds the write requests of the committed log in the applyContext
+// into the writeContext, the batch is flushed once it is full. The log is
+// passed to the PreApplyHook before it is batched. If the hook fails, the logs
+// already batched, which have been passed to the hook, are written first, so
+// that they are never passed again, then the apply of the failed log is
+// aborted.
+func (d *stateMachine) batchWrite(entry raftpb.Entry) {
+	if d.isRemoved() {
+		d.logger.Fatal("applying entries on removed replica")
+	}
+	w := d.newPendingWrite(d.applyCtx)
+	err := d.preApply(w)
+	if err != nil {
+		d.flushWriteBatch()
+		if d.isQuarantined() {
+			return
+		}
+	}
+	if d.writeBatch.isEmpty() {
+		d.writeCtx.initialize(d.getShard(), entry.Index)
+	}
+	d.tracer.committed(d.applyCtx.req.Requests)
+	d.writeBatch.add(batchedWrite{
+		pendingWrite: d.addWriteRequest(d.applyCtx, w),
+		id:           d.applyCtx.req.Header.ID,
+		term:         entry.Term,
+		entryBytes:   d.applyCtx.entryBytes,
+	})
+	if err != nil {
+		d.abortWrite(entry.Index, "pre-apply hook failed", err)
+		d.completeWriteBatch()
+		return
+	}
+	if d.writeBatch.isFull() {
+		d.flushWriteBatch()
+	}
+}
+
+// flushWriteBatch writes all batched write requests to the data storage in a
+// single write, then completes the batch.
+func (d *stateMachine) flushWriteBatch() {
+	if d.writeBatch.isEmpty() {
+		return
+	}
+	d.writeToDataStorage(d.writeBatch.writes[0].index)
+	d.completeWriteBatch()
+}
+
+// completeWriteBatch notifies the responses of the written or aborted batch
+// and updates the applied index log by log in the order of the logs.
+func (d *stateMachine) completeWriteBatch() {
+	writes := d.writeBatch.writes
+	quarantined := d.isQuarantined()
+	entryBytes := uint64(0)
+	for idx, w := range writes {
+		entryBytes += w.entryBytes
+		metrics := applyMetrics{}
+		resp := d.getWriteResponse(w.pendingWrite, &metrics)
+		// the bytes written to the data storage can not be split by logs
+		if idx == len(writes)-1 {
+			d.updateWriteMetrics(&metrics)
+		}
+		d.tracer.applied(w.requests)
+		d.resultHandler.notifyPendingProposal(w.id, resp, false)
+		if quarantined {
+			continue
+		}
+		d.updateAppliedIndexTerm(w.index, w.term)
+		d.resultHandler.handleApplyResult(applyResult{
+			shardID: d.shardID,
+			index:   w.index,
+			metrics: metrics,
+		})
+	}
+	if ratio, ok := writeAmplification(d.writeCtx, entryBytes); ok {
+		metric.ObserveWriteAmplification(d.getShard().Group, ratio)
+	}
+	d.writeBatch.reset()
+}
@@ -0,0 +1,398 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/executor"
+	"github.com/matrixorigin/matrixcube/storage/kv"
+	"github.com/matrixorigin/matrixcube/storage/kv/mem"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+// writeCountingDataStorage counts the writes of the data storage
+type writeCountingDataStorage struct {
+	storage.DataStorage
+	writes int
+}
+
+func (s *writeCountingDataStorage) Write(ctx storage.WriteContext) error {
+	s.writes++
+	return s.DataStorage.Write(ctx)
+}
+
+type notifiedProposal struct {
+	id   []byte
+	resp rpcpb.ResponseBatch
+}
+
+// recordingResultHandler records all applied indexes and notified proposals
+type recordingResultHandler struct {
+	applied  []uint64
+	notified []notifiedProposal
+}
+
+func (h *recordingResultHandler) handleApplyResult(a applyResult) {
+	h.applied = append(h.applied, a.index)
+}
+
+func (h *recordingResultHandler) notifyPendingProposal(id []byte,
+	resp rpcpb.ResponseBatch, isConfChange bool) {
+	h.notified = append(h.notified, notifiedProposal{id: id, resp: resp})
+}
+
+func newTestWriteEntry(index uint64, requests int,
+	builder func(*rpcpb.Request, int)) (raftpb.Entry, rpcpb.RequestBatch) {
+	batch := newTestRequestBatch(requests, func(r *rpcpb.Request, i int) {
+		r.ID = []byte(fmt.Sprintf("%d-%d", index, i))
+		r.Key = []byte(fmt.Sprintf("key-%d-%d", index, i))
+		builder(r, i)
+	})
+	batch.Header.ShardID = 1
+	return raftpb.Entry{
+		Index: index,
+		Term:  1,
+		Type:  raftpb.EntryNormal,
+		Data:  protoc.MustMarshal(&batch),
+	}, batch
+}
+
+func newKVSetWriteEntry(index uint64, requests int) (raftpb.Entry, rpcpb.RequestBatch) {
+	return newTestWriteEntry(index, requests, func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdKVSet)
+		r.Cmd = protoc.MustMarshal(&rpcpb.KVSetRequest{Key: r.Key, Value: r.Key})
+	})
+}
+
+func TestStateMachineApplyWriteBatch(t *testing.T) {
+	h := &recordingResultHandler{}
+	f := func(sm *stateMachine) {
+		ds := &writeCountingDataStorage{DataStorage: sm.dataStorage}
+		sm.dataStorage = ds
+		sm.writeBatch.maxEntries = 4
+
+		var entries []raftpb.Entry
+		var batches []rpcpb.RequestBatch
+		for i := uint64(1); i <= 7; i++ {
+			if i == 4 {
+				// the noop entry flushes the batch
+				entries = append(entries, raftpb.Entry{Index: i, Term: 1})
+				continue
+			}
+			entry, batch := newKVSetWriteEntry(i, 2)
+			entries = append(entries, entry)
+			batches = append(batches, batch)
+		}
+		sm.applyCommittedEntries(entries)
+
+		assert.Equal(t, 2, ds.writes)
+		index, term := sm.getAppliedIndexTerm()
+		assert.Equal(t, uint64(7), index)
+		assert.Equal(t, uint64(1), term)
+		assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7}, h.applied)
+		require.Equal(t, len(batches), len(h.notified))
+		for i, batch := range batches {
+			assert.Equal(t, batch.Header.ID, h.notified[i].id)
+			assert.Equal(t, len(batch.Requests), len(h.notified[i].resp.Responses))
+		}
+
+		readContext := newReadContext()
+		for _, batch := range batches {
+			for _, req := range batch.Requests {
+				readContext.reset(sm.getShard(), storage.Request{
+					Key:     req.Key,
+					CmdType: uint64(rpcpb.CmdKVGet),
+					Cmd:     protoc.MustMarshal(&rpcpb.KVGetRequest{Key: req.Key}),
+				})
+				data, err := sm.dataStorage.Read(readContext)
+				assert.NoError(t, err)
+				assert.Equal(t, protoc.MustMarshal(&rpcpb.KVGetResponse{Value: req.Key}), data)
+			}
+		}
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineApplyWriteBatchResponsesMatchRequests(t *testing.T) {
+	h := &recordingResultHandler{}
+	f := func(sm *stateMachine) {
+		ds := &testDataStorage{}
+		_, err := ds.GetInitialStates()
+		require.NoError(t, err)
+		sm.dataStorage = ds
+		sm.writeBatch.maxEntries = 16
+
+		var entries []raftpb.Entry
+		for i := uint64(1); i <= 3; i++ {
+			entry, _ := newTestWriteEntry(i, int(i)+1, func(r *rpcpb.Request, j int) {
+				r.CustomType = uint64(rpcpb.CmdReserved) + 1
+				r.Cmd = []byte(fmt.Sprintf("cmd-%d-%d", i, j))
+				if j == 1 {
+					r.Cmd = []byte("invalid")
+				}
+			})
+			entries = append(entries, entry)
+		}
+		sm.applyCommittedEntries(entries)
+
+		assert.Equal(t, 6, ds.writes)
+		assert.Equal(t, []uint64{1, 2, 3}, h.applied)
+		require.Equal(t, 3, len(h.notified))
+		for i, n := range h.notified {
+			require.Equal(t, i+2, len(n.resp.Responses))
+			for j, resp := range n.resp.Responses {
+				if j == 1 {
+					assert.Nil(t, resp.Value)
+					assert.Equal(t, "invalid request", resp.Error.Message)
+				} else {
+					assert.Equal(t, []byte("OK"), resp.Value)
+					assert.Empty(t, resp.Error.Message)
+				}
+			}
+		}
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+// compactionCoordinatingDataStorage records the writes and the pauses and
+// resumes of the compactions in order
+type compactionCoordinatingDataStorage struct {
+	storage.DataStorage
+	events []string
+}
+
+func (s *compactionCoordinatingDataStorage) Write(ctx storage.WriteContext) error {
+	s.events = append(s.events, "write")
+	return s.DataStorage.Write(ctx)
+}
+
+func (s *compactionCoordinatingDataStorage) PauseCompaction() {
+	s.events = append(s.events, "pause")
+}
+
+func (s *compactionCoordinatingDataStorage) ResumeCompaction() {
+	s.events = append(s.events, "resume")
+}
+
+func TestStateMachinePausesCompactionDuringApplyBurst(t *testing.T) {
+	h := &recordingResultHandler{}
+	f := func(sm *stateMachine) {
+		ds := &compactionCoordinatingDataStorage{DataStorage: sm.dataStorage}
+		sm.dataStorage = ds
+		sm.applyBurstEntries = 3
+
+		var entries []raftpb.Entry
+		for i := uint64(1); i <= 3; i++ {
+			entry, _ := newKVSetWriteEntry(i, 1)
+			entries = append(entries, entry)
+		}
+		sm.applyCommittedEntries(entries)
+		assert.Equal(t, []string{"pause", "write", "write", "write", "resume"}, ds.events)
+
+		// not a burst
+		ds.events = nil
+		entries = entries[:0]
+		for i := uint64(4); i <= 5; i++ {
+			entry, _ := newKVSetWriteEntry(i, 1)
+			entries = append(entries, entry)
+		}
+		sm.applyCommittedEntries(entries)
+		assert.Equal(t, []string{"write", "write"}, ds.events)
+		index, _ := sm.getAppliedIndexTerm()
+		assert.Equal(t, uint64(5), index)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachinePreApplyHook(t *testing.T) {
+	for _, maxEntries := range []int{0, 4} {
+		h := &recordingResultHandler{}
+		f := func(sm *stateMachine) {
+			ds := &compactionCoordinatingDataStorage{DataStorage: sm.dataStorage}
+			sm.dataStorage = ds
+			sm.writeBatch.maxEntries = maxEntries
+			var hooked [][]byte
+			sm.preApplyHook = func(shardID uint64, requests []rpcpb.Request) error {
+				assert.Equal(t, sm.shardID, shardID)
+				ds.events = append(ds.events, "hook")
+				for _, req := range requests {
+					hooked = append(hooked, req.ID)
+				}
+				return nil
+			}
+
+			var entries []raftpb.Entry
+			var expected [][]byte
+			for i := uint64(1); i <= 6; i++ {
+				if i == 4 {
+					entries = append(entries, raftpb.Entry{Index: i, Term: 1})
+					continue
+				}
+				entry, batch := newKVSetWriteEntry(i, 2)
+				entries = append(entries, entry)
+				for _, req := range batch.Requests {
+					expected = append(expected, req.ID)
+				}
+			}
+			sm.applyCommittedEntries(entries)
+
+			// every committed write is seen exactly once in the commit order, and
+			// before it is written to the data storage
+			assert.Equal(t, expected, hooked)
+			if maxEntries == 0 {
+				assert.Equal(t, []string{"hook", "write", "hook", "write", "hook", "write",
+					"hook", "write", "hook", "write"}, ds.events)
+			} else {
+				assert.Equal(t, []string{"hook", "hook", "hook", "write",
+					"hook", "hook", "write"}, ds.events)
+			}
+		}
+		runSimpleStateMachineTest(t, f, h)
+	}
+}
+
+func TestStateMachinePreApplyHookSkipsUnappliedRequests(t *testing.T) {
+	for _, maxEntries := range []int{0, 4} {
+		h := &recordingResultHandler{}
+		f := func(sm *stateMachine) {
+			sm.writeBatch.maxEntries = maxEntries
+			sm.dedup = newRequestDedup(16)
+			sm.enforceKeyRange = true
+			sm.updateShard(Shard{ID: sm.shardID, End: []byte("key-9")})
+			var hooked [][]string
+			sm.preApplyHook = func(shardID uint64, requests []rpcpb.Request) error {
+				var ids []string
+				for _, req := range requests {
+					ids = append(ids, string(req.ID))
+				}
+				hooked = append(hooked, ids)
+				return nil
+			}
+
+			e1, _ := newKVSetWriteEntry(1, 2)
+			e2, _ := newTestWriteEntry(2, 4, func(r *rpcpb.Request, i int) {
+				r.CustomType = uint64(rpcpb.CmdKVSet)
+				switch i {
+				case 0:
+					// applied by the previous log
+					r.ID = []byte("1-0")
+				case 2:
+					// applied by the previous request of the same log
+					r.ID = []byte("2-1")
+				case 3:
+					// not in the shard
+					r.Key = []byte("zzz")
+				}
+				r.Cmd = protoc.MustMarshal(&rpcpb.KVSetRequest{Key: r.Key, Value: r.Key})
+			})
+			// all applied before
+			e3, _ := newTestWriteEntry(3, 1, func(r *rpcpb.Request, i int) {
+				r.CustomType = uint64(rpcpb.CmdKVSet)
+				r.ID = []byte("1-1")
+				r.Cmd = protoc.MustMarshal(&rpcpb.KVSetRequest{Key: r.Key, Value: r.Key})
+			})
+			sm.applyCommittedEntries([]raftpb.Entry{e1, e2, e3})
+
+			assert.Equal(t, [][]string{{"1-0", "1-1"}, {"2-1"}}, hooked)
+			index, _ := sm.getAppliedIndexTerm()
+			assert.Equal(t, uint64(3), index)
+		}
+		runSimpleStateMachineTest(t, f, h)
+	}
+}
+
+func TestStateMachinePreApplyHookFailureInBatch(t *testing.T) {
+	h := &recordingResultHandler{}
+	f := func(sm *stateMachine) {
+		ds := &compactionCoordinatingDataStorage{DataStorage: sm.dataStorage}
+		sm.dataStorage = ds
+		sm.writeBatch.maxEntries = 4
+		var hooked []string
+		sm.preApplyHook = func(shardID uint64, requests []rpcpb.Request) error {
+			ds.events = append(ds.events, "hook")
+			hooked = append(hooked, string(requests[0].ID))
+			if string(requests[0].ID) == "3-0" {
+				return errors.New("external log unavailable")
+			}
+			return nil
+		}
+
+		var entries []raftpb.Entry
+		for i := uint64(1); i <= 3; i++ {
+			entry, _ := newKVSetWriteEntry(i, 2)
+			entries = append(entries, entry)
+		}
+		// the failure of the third log is fatal
+		assert.Panics(t, func() { sm.applyCommittedEntries(entries) })
+
+		// the logs batched before the failed one are written and applied, so
+		// they are not passed to the hook again after the restart
+		assert.Equal(t, []string{"1-0", "2-0", "3-0"}, hooked)
+		assert.Equal(t, []string{"hook", "hook", "hook", "write"}, ds.events)
+		assert.Equal(t, []uint64{1, 2}, h.applied)
+		index, _ := sm.getAppliedIndexTerm()
+		assert.Equal(t, uint64(2), index)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func benchmarkApplyWriteBatch(b *testing.B, maxEntries int) {
+	fs := vfs.NewMemFS()
+	st := mem.NewStorage()
+	defer st.Close()
+	base := kv.NewBaseStorage(st, fs)
+	ds := &writeCountingDataStorage{
+		DataStorage: kv.NewKVDataStorage(base, executor.NewKVExecutor(st)),
+	}
+	sm := newStateMachine(log.GetDefaultZapLogger(), ds, nil, Shard{ID: 100},
+		Replica{ID: 100}, &recordingResultHandler{}, nil, nil)
+	sm.writeBatch.maxEntries = maxEntries
+
+	count := 64
+	index := uint64(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		entries := make([]raftpb.Entry, 0, count)
+		for j := 0; j < count; j++ {
+			index++
+			entry, _ := newKVSetWriteEntry(index, 1)
+			entries = append(entries, entry)
+		}
+		sm.resultHandler = &recordingResultHandler{}
+		b.StartTimer()
+		sm.applyCommittedEntries(entries)
+	}
+	b.ReportMetric(float64(count), "entries/op")
+	b.ReportMetric(float64(ds.writes)/float64(b.N), "writes/op")
+}
+
+func BenchmarkApplyWriteEntries(b *testing.B) {
+	benchmarkApplyWriteBatch(b, 0)
+}
+
+func BenchmarkApplyWriteEntriesInBatch(b *testing.B) {
+	benchmarkApplyWriteBatch(b, 16)
+}
@@ -14,11 +14,38 @@
 package raftstore
 
 import (
+	"math"
+	"sync/atomic"
 	"time"
 
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 )
 
+// applyRateSampleInterval is the minimal interval between two samples of the
+// apply throughput.
+const applyRateSampleInterval = time.Second
+
+// ReadStats is the accumulated read metrics of a shard.
+type ReadStats struct {
+	// ReadBytes is the number of bytes read from the shard.
+	ReadBytes uint64
+	// ReadKeys is the number of keys read from the shard.
+	ReadKeys uint64
+}
+
+// ReplicaProgress is the replication progress of a shard replica tracked by
+// the shard leader.
+type ReplicaProgress struct {
+	// Match is the highest raft log index known to be replicated to the replica.
+	Match uint64
+	// Next is the raft log index of the next entry to be sent to the replica.
+	Next uint64
+	// State is the replication state of the replica, one of StateProbe,
+	// StateReplicate and StateSnapshot.
+	State string
+}
+
 type replicaStats struct {
 	prophetHeartbeatTime uint64
 	writtenKeys          uint64
@@ -29,26 +56,121 @@ type replicaStats struct {
 	deleteKeysHint       uint64
 	approximateSize      uint64
 	approximateKeys      uint64
+	// applyRate is the float64 bits of the applied entries per second
+	applyRate        uint64
+	lastSampledIndex uint64
+	lastSampledTime  time.Time
+	// readQPS and writeQPS are the float64 bits of the keys read and written per
+	// second during the last heartbeat interval
+	readQPS            uint64
+	writeQPS           uint64
+	lastQPSReadKeys    uint64
+	lastQPSWrittenKeys uint64
+	lastQPSSampledTime time.Time
 }
 
 func newReplicaStats() *replicaStats {
 	return &replicaStats{}
 }
 
-func (rs *replicaStats) heartbeatState() metapb.ShardStats {
-	now := uint64(time.Now().Unix())
+// approximateSize is updated in the event worker, it can be concurrently read
+// by the SizeDiffHint of the replica, so atomic ops are required.
+func (rs *replicaStats) getApproximateSize() uint64 {
+	return atomic.LoadUint64(&rs.approximateSize)
+}
+
+func (rs *replicaStats) setApproximateSize(size uint64) {
+	atomic.StoreUint64(&rs.approximateSize, size)
+}
+
+func (rs *replicaStats) addApproximateSize(diff uint64) uint64 {
+	return atomic.AddUint64(&rs.approximateSize, diff)
+}
+
+// addReadStats is called in the event worker, readBytes and readKeys can be
+// concurrently read by the query APIs of the store, so atomic ops are required.
+func (rs *replicaStats) addReadStats(readBytes, readKeys uint64) {
+	atomic.AddUint64(&rs.readBytes, readBytes)
+	atomic.AddUint64(&rs.readKeys, readKeys)
+}
+
+func (rs *replicaStats) getReadStats() ReadStats {
+	return ReadStats{
+		ReadBytes: atomic.LoadUint64(&rs.readBytes),
+		ReadKeys:  atomic.LoadUint64(&rs.readKeys),
+	}
+}
+
+// sampleApplyRate is called in the apply path to update the number of entries
+// applied per second, the rate is computed from the delta of the applied index
+// since the last sample.
+func (rs *replicaStats) sampleApplyRate(shardID uint64, index uint64, now time.Time) {
+	if rs.lastSampledTime.IsZero() || index < rs.lastSampledIndex {
+		rs.lastSampledIndex = index
+		rs.lastSampledTime = now
+		return
+	}
+	elapsed := now.Sub(rs.lastSampledTime)
+	if elapsed < applyRateSampleInterval {
+		return
+	}
+	rate := float64(index-rs.lastSampledIndex) / elapsed.Seconds()
+	atomic.StoreUint64(&rs.applyRate, math.Float64bits(rate))
+	rs.lastSampledIndex = index
+	rs.lastSampledTime = now
+	metric.SetShardApplyRate(shardID, rate)
+}
+
+func (rs *replicaStats) getApplyRate() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&rs.applyRate))
+}
+
+// sampleQPS is called when sending the shard heartbeat to compute the keys read
+// and written per second since the last heartbeat. A counter smaller than the
+// last sample is treated as reset, e.g. after the leader changed, so only the
+// keys counted since the reset are used.
+func (rs *replicaStats) sampleQPS(shardID uint64, readKeys, writtenKeys uint64, now time.Time) {
+	if !rs.lastQPSSampledTime.IsZero() {
+		if elapsed := now.Sub(rs.lastQPSSampledTime).Seconds(); elapsed > 0 {
+			readQPS := float64(counterDelta(readKeys, rs.lastQPSReadKeys)) / elapsed
+			writeQPS := float64(counterDelta(writtenKeys, rs.lastQPSWrittenKeys)) / elapsed
+			atomic.StoreUint64(&rs.readQPS, math.Float64bits(readQPS))
+			atomic.StoreUint64(&rs.writeQPS, math.Float64bits(writeQPS))
+			metric.SetShardQPS(shardID, readQPS, writeQPS)
+		}
+	}
+	rs.lastQPSReadKeys = readKeys
+	rs.lastQPSWrittenKeys = writtenKeys
+	rs.lastQPSSampledTime = now
+}
+
+func (rs *replicaStats) getQPS() (readQPS, writeQPS float64) {
+	return math.Float64frombits(atomic.LoadUint64(&rs.readQPS)),
+		math.Float64frombits(atomic.LoadUint64(&rs.writeQPS))
+}
+
+func counterDelta(current, last uint64) uint64 {
+	if current < last {
+		return current
+	}
+	return current - last
+}
+
+func (rs *replicaStats) heartbeatState(shardID uint64, now time.Time) metapb.ShardStats {
+	rds := rs.getReadStats()
+	rs.sampleQPS(shardID, rds.ReadKeys, rs.writtenKeys, now)
 	stats := metapb.ShardStats{
 		WrittenBytes:    rs.writtenBytes,
 		WrittenKeys:     rs.writtenKeys,
-		ReadBytes:       rs.readBytes,
-		ReadKeys:        rs.readKeys,
+		ReadBytes:       rds.ReadBytes,
+		ReadKeys:        rds.ReadKeys,
 		ApproximateKeys: rs.approximateKeys,
-		ApproximateSize: rs.approximateSize,
+		ApproximateSize: rs.getApproximateSize(),
 		Interval: &metapb.TimeInterval{
 			Start: rs.prophetHeartbeatTime,
-			End:   uint64(time.Now().Unix()),
+			End:   uint64(now.Unix()),
 		},
 	}
-	rs.prophetHeartbeatTime = now
+	rs.prophetHeartbeatTime = uint64(now.Unix())
 	return stats
 }
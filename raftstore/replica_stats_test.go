@@ -0,0 +1,81 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestSampleApplyRate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rs := newReplicaStats()
+	now := time.Now()
+	rs.sampleApplyRate(1, 100, now)
+	assert.Equal(t, float64(0), rs.getApplyRate())
+
+	// 50 entries per second, not sampled within the sample interval
+	for i := 1; i <= 10; i++ {
+		rs.sampleApplyRate(1, uint64(100+i*5), now.Add(time.Duration(i)*100*time.Millisecond))
+		if i < 10 {
+			assert.Equal(t, float64(0), rs.getApplyRate())
+		}
+	}
+	assert.Equal(t, float64(50), rs.getApplyRate())
+
+	// 200 entries per second
+	now = now.Add(time.Second)
+	rs.sampleApplyRate(1, 550, now.Add(2*time.Second))
+	assert.Equal(t, float64(200), rs.getApplyRate())
+}
+
+func TestSampleQPS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rs := newReplicaStats()
+	now := time.Now()
+	rs.sampleQPS(1, 0, 0, now)
+	readQPS, writeQPS := rs.getQPS()
+	assert.Equal(t, float64(0), readQPS)
+	assert.Equal(t, float64(0), writeQPS)
+
+	// 200 reads and 100 writes in 2 seconds
+	for i := 0; i < 200; i++ {
+		rs.addReadStats(10, 1)
+	}
+	rs.writtenKeys += 100
+	now = now.Add(2 * time.Second)
+	rs.sampleQPS(1, rs.getReadStats().ReadKeys, rs.writtenKeys, now)
+	readQPS, writeQPS = rs.getQPS()
+	assert.Equal(t, float64(100), readQPS)
+	assert.Equal(t, float64(50), writeQPS)
+
+	// counters are reset, 30 reads and 10 writes since the reset
+	now = now.Add(time.Second)
+	rs.sampleQPS(1, 30, 10, now)
+	readQPS, writeQPS = rs.getQPS()
+	assert.Equal(t, float64(30), readQPS)
+	assert.Equal(t, float64(10), writeQPS)
+
+	// heartbeat samples the counters
+	rs.addReadStats(10, 5)
+	rs.heartbeatState(1, time.Now())
+	assert.Equal(t, uint64(205), rs.lastQPSReadKeys)
+	assert.Equal(t, uint64(100), rs.lastQPSWrittenKeys)
+}
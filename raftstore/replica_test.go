@@ -15,13 +15,18 @@ package raftstore
 
 import (
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/fagongzi/util/protoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/raft/v3"
 	"go.etcd.io/etcd/raft/v3/raftpb"
 
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/keys"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/matrixorigin/matrixcube/util/stop"
@@ -89,9 +94,295 @@ func TestInitAppliedIndex(t *testing.T) {
 	assert.Equal(t, uint64(2), pr.appliedIndex)
 }
 
+func TestReplicaLeaseHolder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 1}, {ID: 2}}}, Replica{ID: 1}, s)
+
+	id, valid := pr.LeaseHolder()
+	assert.Equal(t, uint64(0), id)
+	assert.False(t, valid)
+
+	pr.sm.updateLease(&EpochLease{Epoch: 1, ReplicaID: 2})
+	id, valid = pr.LeaseHolder()
+	assert.Equal(t, uint64(2), id)
+	assert.True(t, valid)
+
+	// the current replica holds the lease, but not ready for lease based reads
+	pr.sm.updateLease(&EpochLease{Epoch: 2, ReplicaID: 1})
+	atomic.StoreUint32(&pr.leaseReadActived, 0)
+	id, valid = pr.LeaseHolder()
+	assert.Equal(t, uint64(1), id)
+	assert.False(t, valid)
+
+	pr.maybeSetLeaseReadReady()
+	id, valid = pr.LeaseHolder()
+	assert.Equal(t, uint64(1), id)
+	assert.True(t, valid)
+}
+
 func newTestReplica(shard Shard, peer Replica, s *store) *replica {
 	pr, _ := newReplica(s, shard, peer, "testing")
 	pr.readStopper = stop.NewStopper("test")
 	pr.setStarted()
 	return pr
 }
+
+func TestReplicas(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	replicas := []Replica{{ID: 1, StoreID: s.Meta().ID}, {ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Learner}}
+	pr := newTestReplica(Shard{ID: 1, Replicas: replicas}, replicas[0], s)
+
+	values := pr.Replicas()
+	assert.Equal(t, replicas, values)
+	values[1].Role = metapb.ReplicaRole_Voter
+	assert.Equal(t, replicas, pr.getShard().Replicas)
+	assert.Equal(t, replicas, pr.Replicas())
+}
+
+func TestCollectDownReplicas(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	replicas := []Replica{{ID: 1, StoreID: s.Meta().ID}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3}}
+	pr := newTestReplica(Shard{ID: 1, Replicas: replicas}, replicas[0], s)
+	pr.cfg.Replication.MaxPeerDownTime.Duration = time.Minute
+
+	now := time.Now()
+	pr.replicaHeartbeatsMap.Store(uint64(2), now)
+	pr.replicaHeartbeatsMap.Store(uint64(3), now)
+	assert.Empty(t, pr.collectDownReplicas())
+
+	// replica 3 is silent for longer than the threshold
+	pr.replicaHeartbeatsMap.Store(uint64(3), now.Add(-time.Minute-time.Second))
+	down := pr.collectDownReplicas()
+	require.Equal(t, 1, len(down))
+	assert.Equal(t, Replica{ID: 3, StoreID: 3}, down[0].Replica)
+	assert.True(t, down[0].DownSeconds >= 60)
+}
+
+func TestCollectDownReplicasWithMockClock(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	c := newMockClock(time.Now())
+	s.clock = c
+	replicas := []Replica{{ID: 1, StoreID: s.Meta().ID}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3}}
+	pr := newTestReplica(Shard{ID: 1, Replicas: replicas}, replicas[0], s)
+	pr.cfg.Replication.MaxPeerDownTime.Duration = time.Minute
+	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+	pr.setLeaderReplicaID(pr.replicaID)
+
+	heartbeat := func(from uint64) {
+		pr.stepRaftMessage(metapb.RaftMessage{
+			Message: raftpb.Message{Type: raftpb.MsgHeartbeatResp, From: from, To: 1},
+		})
+	}
+	heartbeat(2)
+	heartbeat(3)
+	c.Advance(time.Second * 30)
+	assert.Empty(t, pr.collectDownReplicas())
+
+	// replica 2 keeps sending heartbeats while replica 3 is silent
+	heartbeat(2)
+	c.Advance(time.Second * 45)
+	down := pr.collectDownReplicas()
+	require.Equal(t, 1, len(down))
+	assert.Equal(t, Replica{ID: 3, StoreID: 3}, down[0].Replica)
+	assert.Equal(t, uint64(75), down[0].DownSeconds)
+
+	heartbeat(3)
+	assert.Empty(t, pr.collectDownReplicas())
+}
+
+func TestCollectPendingReplicas(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	replicas := []Replica{{ID: 1, StoreID: s.Meta().ID}, {ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Learner}}
+	pr := newTestReplica(Shard{ID: 1, Replicas: replicas}, replicas[0], s)
+	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+	pr.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1})
+	pr.rn.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 2})
+	require.NoError(t, pr.rn.Campaign())
+	pr.setLeaderReplicaID(1)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, pr.rn.Propose([]byte("data")))
+	}
+	rd := pr.rn.Ready()
+	require.NoError(t, pr.lr.Append(rd.Entries))
+	pr.rn.Advance(rd)
+	require.Equal(t, uint64(6), pr.rn.Status().Progress[1].Match)
+
+	// the learner has not caught up, but not waiting for snapshot
+	pr.cfg.Raft.MaxPendingReplicaLag = 0
+	assert.Empty(t, pr.collectPendingReplicas())
+	pr.cfg.Raft.MaxPendingReplicaLag = 10
+	assert.Empty(t, pr.collectPendingReplicas())
+	pr.cfg.Raft.MaxPendingReplicaLag = 5
+	assert.Equal(t, []Replica{replicas[1]}, pr.collectPendingReplicas())
+}
+
+func TestCommittedIndexesRestoredAfterRestart(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	r1 := Replica{ID: 1, StoreID: s.Meta().ID}
+	shard := Shard{ID: 1, Replicas: []Replica{r1, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3}}}
+	pr := newTestReplica(shard, r1, s)
+	pr.committedIndexes[1] = 100
+	pr.updateReplicasCommittedIndex(metapb.RaftMessage{From: Replica{ID: 2}, CommitIndex: 90})
+	pr.updateReplicasCommittedIndex(metapb.RaftMessage{From: Replica{ID: 3}, CommitIndex: 80})
+	assert.True(t, pr.committedIndexesChanged)
+	pr.saveCommittedIndexes()
+	assert.False(t, pr.committedIndexesChanged)
+	// not saved until changed
+	pr.committedIndexes[3] = 85
+	pr.saveCommittedIndexes()
+
+	// restart with replica 3 removed
+	shard.Replicas = shard.Replicas[:2]
+	pr = newTestReplica(shard, r1, s)
+	assert.Empty(t, pr.committedIndexes)
+	require.NoError(t, pr.initCommittedIndexes())
+	assert.Equal(t, map[uint64]uint64{1: 100, 2: 90}, pr.committedIndexes)
+	assert.False(t, pr.committedIndexesChanged)
+}
+
+func TestInitLogStateRepairsLogReader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	newReplicaWithLogs := func(shardID uint64) *replica {
+		r := Replica{ID: shardID, StoreID: s.Meta().ID}
+		pr := newTestReplica(Shard{ID: shardID, Replicas: []Replica{r}}, r, s)
+		var entries []raftpb.Entry
+		for i := uint64(1); i <= 10; i++ {
+			entries = append(entries, raftpb.Entry{Index: i, Term: i})
+		}
+		wc := pr.logdb.NewWorkerContext()
+		defer wc.Close()
+		require.NoError(t, pr.logdb.SaveRaftState(pr.shardID, pr.replicaID,
+			raft.Ready{HardState: raftpb.HardState{Term: 10, Commit: 10}, Entries: entries}, wc))
+		return pr
+	}
+	checkRange := func(pr *replica, first, last uint64) {
+		v, _ := pr.lr.FirstIndex()
+		assert.Equal(t, first, v)
+		v, _ = pr.lr.LastIndex()
+		assert.Equal(t, last, v)
+	}
+
+	// the LogReader marker is ahead of logdb
+	pr := newReplicaWithLogs(1)
+	require.NoError(t, pr.lr.ApplySnapshot(raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 20, Term: 2}}))
+	_, err := pr.initLogState()
+	require.NoError(t, err)
+	checkRange(pr, 1, 10)
+	require.NoError(t, pr.lr.Compact(5))
+	term, err := pr.lr.Term(5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), term)
+
+	// the LogReader marker is in the middle of logdb
+	pr = newReplicaWithLogs(2)
+	require.NoError(t, pr.lr.ApplySnapshot(raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 5, Term: 5}}))
+	_, err = pr.initLogState()
+	require.NoError(t, err)
+	checkRange(pr, 1, 10)
+
+	// entries 1 to 3 are lost, entry 4 becomes the marker
+	pr = newReplicaWithLogs(3)
+	require.NoError(t, s.kvStorage.RangeDelete(keys.GetRaftLogKey(3, 0, nil),
+		keys.GetRaftLogKey(3, 4, nil), true))
+	_, err = pr.initLogState()
+	require.NoError(t, err)
+	checkRange(pr, 5, 10)
+	term, err = pr.lr.Term(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), term)
+
+	// nothing to repair
+	_, err = pr.initLogState()
+	require.NoError(t, err)
+	checkRange(pr, 5, 10)
+	require.NoError(t, pr.lr.Compact(6))
+}
+
+func TestRaftLogSize(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	r := Replica{ID: 1, StoreID: s.Meta().ID}
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{r}}, r, s)
+	var entries []raftpb.Entry
+	for i := uint64(1); i <= 10; i++ {
+		entries = append(entries, raftpb.Entry{Index: i, Term: 1, Data: make([]byte, 10)})
+	}
+	wc := pr.logdb.NewWorkerContext()
+	defer wc.Close()
+	require.NoError(t, pr.logdb.SaveRaftState(pr.shardID, pr.replicaID,
+		raft.Ready{Entries: entries}, wc))
+	require.NoError(t, pr.lr.Append(entries))
+
+	// the hint accumulated with the committed entries
+	atomic.StoreUint64(&pr.stats.raftLogSizeHint, 80)
+	assert.Equal(t, uint64(80), pr.RaftLogSize())
+
+	// reduced by the compacted entries
+	require.NoError(t, pr.doLogCompaction(5))
+	assert.Equal(t, uint64(30), pr.RaftLogSize())
+	// never below 0
+	require.NoError(t, pr.doLogCompaction(10))
+	assert.Equal(t, uint64(0), pr.RaftLogSize())
+}
+
+func TestReplicaInitialized(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	r := Replica{ID: 1, StoreID: s.Meta().ID}
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{r}}, r, s)
+	assert.False(t, pr.Initialized())
+	_, err := pr.initLogState()
+	require.NoError(t, err)
+	assert.False(t, pr.Initialized())
+
+	_, err = pr.handleInitializedState()
+	require.NoError(t, err)
+	assert.True(t, pr.Initialized())
+}
+
+func TestReplicaStartsWithEmptySnapshotRecord(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	r := Replica{ID: 1, StoreID: s.Meta().ID}
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{r}}, r, s)
+	require.NoError(t, s.kvStorage.Set(keys.GetSnapshotKey(pr.shardID, 5, nil),
+		protoc.MustMarshal(&raftpb.Snapshot{}), true))
+	ss, err := pr.logdb.GetSnapshot(pr.shardID)
+	require.NoError(t, err)
+	require.True(t, raft.IsEmptySnap(ss))
+
+	_, err = pr.initLogState()
+	require.NoError(t, err)
+	hasEvent, err := pr.handleInitializedState()
+	require.NoError(t, err)
+	assert.False(t, hasEvent)
+	assert.True(t, pr.initialized)
+	assert.Equal(t, uint64(0), pr.pushedIndex)
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+// shardUnavailability is the commit progress of the shard seen by the replica.
+type shardUnavailability struct {
+	// committedIndex is the committed index when the progress was last made
+	committedIndex uint64
+	// since when the replica is waiting for the commit progress, zero if not
+	// waiting
+	since       time.Time
+	unavailable bool
+}
+
+// checkUnavailable marks the shard as unavailable once the leader has pending
+// proposals but no raft log is committed for Raft.UnavailableTimeout, which is
+// usually caused by the lost quorum, the clients only see the timeouts. The
+// waiting continues after the leader steps down, e.g. because of CheckQuorum
+// after losing the majority, as long as the proposals are still pending or no
+// leader is known. The mark is cleared once the committed index advances
+// again, even if the replica is no longer the leader.
+func (pr *replica) checkUnavailable() {
+	timeout := pr.cfg.Raft.UnavailableTimeout.Duration
+	if timeout == 0 {
+		return
+	}
+
+	u := &pr.unavailability
+	now := pr.clock.Now()
+	if pr.lastCommittedIndex != u.committedIndex {
+		u.committedIndex = pr.lastCommittedIndex
+		u.since = time.Time{}
+		if u.unavailable {
+			u.unavailable = false
+			metric.SetShardUnavailable(pr.shardID, false)
+			pr.logger.Info("shard is available again",
+				zap.Uint64("committed-index", u.committedIndex))
+		}
+		return
+	}
+	if u.unavailable {
+		return
+	}
+	if u.since.IsZero() {
+		// only the leader with pending proposals starts waiting
+		if pr.isLeader() && pr.pendingProposals.len() > 0 {
+			u.since = now
+		}
+		return
+	}
+	if pr.pendingProposals.len() == 0 && pr.getLeaderReplicaID() != 0 {
+		u.since = time.Time{}
+		return
+	}
+	if now.Sub(u.since) < timeout {
+		return
+	}
+
+	u.unavailable = true
+	metric.SetShardUnavailable(pr.shardID, true)
+	pr.logger.Warn("shard is unavailable, no raft log committed with pending proposals",
+		zap.Duration("timeout", timeout),
+		zap.Time("since", u.since),
+		zap.Uint64("committed-index", u.committedIndex),
+		zap.Uint64("leader", pr.getLeaderReplicaID()),
+		zap.Int("pending-proposals", pr.pendingProposals.len()))
+	pr.store.unavailableNotifier.addEvent(pr.shardID)
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func getShardUnavailable(t *testing.T, shardID uint64) (float64, bool) {
+	return getMetricValue(t, "matrixcube_raftstore_shard_unavailable",
+		map[string]string{"shard": fmt.Sprintf("%d", shardID)})
+}
+
+func TestReplicaCheckUnavailable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	unavailableC := make(chan uint64, 1)
+	s.OnShardUnavailable(func(shardID uint64) {
+		unavailableC <- shardID
+	})
+	s.unavailableNotifier.start()
+	defer s.unavailableNotifier.close()
+
+	pr := newTestReplica(Shard{ID: 101}, Replica{ID: 1}, s)
+	clock := newMockClock(time.Now())
+	pr.clock = clock
+	pr.cfg.Raft.UnavailableTimeout.Duration = time.Second
+	pr.setLeaderReplicaID(1)
+	pr.lastCommittedIndex = 10
+
+	// no pending proposals
+	pr.checkUnavailable()
+	clock.Advance(time.Second * 2)
+	pr.checkUnavailable()
+	_, ok := getShardUnavailable(t, 101)
+	assert.False(t, ok)
+
+	// pending proposals without the commit progress
+	pr.pendingProposals.append(newTestBatch("1", "", 0, rpcpb.Write, 0, nil))
+	pr.checkUnavailable()
+	clock.Advance(time.Millisecond * 500)
+	pr.checkUnavailable()
+	_, ok = getShardUnavailable(t, 101)
+	assert.False(t, ok)
+	clock.Advance(time.Millisecond * 500)
+	pr.checkUnavailable()
+	pr.checkUnavailable()
+	v, _ := getShardUnavailable(t, 101)
+	assert.Equal(t, float64(1), v)
+	select {
+	case shardID := <-unavailableC:
+		assert.Equal(t, uint64(101), shardID)
+	case <-time.After(testWaitTimeout):
+		assert.FailNow(t, "wait unavailable notification timeout")
+	}
+
+	// cleared once the commit resumes, even if no longer the leader
+	pr.setLeaderReplicaID(2)
+	pr.checkUnavailable()
+	v, _ = getShardUnavailable(t, 101)
+	assert.Equal(t, float64(1), v)
+	pr.lastCommittedIndex = 11
+	pr.checkUnavailable()
+	v, _ = getShardUnavailable(t, 101)
+	assert.Equal(t, float64(0), v)
+
+	// not the leader
+	clock.Advance(time.Second * 2)
+	pr.checkUnavailable()
+	v, _ = getShardUnavailable(t, 101)
+	assert.Equal(t, float64(0), v)
+
+	// still waiting after stepping down without any known leader
+	pr.setLeaderReplicaID(1)
+	pr.checkUnavailable()
+	pr.setLeaderReplicaID(0)
+	clock.Advance(time.Second)
+	pr.checkUnavailable()
+	v, _ = getShardUnavailable(t, 101)
+	assert.Equal(t, float64(1), v)
+
+	// deleted once the replica is removed from the store
+	s.removeReplica(pr.getShard())
+	_, ok = getShardUnavailable(t, 101)
+	assert.False(t, ok)
+}
+
+func TestShardUnavailableWithLostQuorum(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	c := NewTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Raft.UnavailableTimeout.Duration = time.Millisecond * 300
+		}))
+	unavailableC := make(chan uint64, 3)
+	c.EveryStore(func(i int, s Store) {
+		s.OnShardUnavailable(func(shardID uint64) {
+			unavailableC <- shardID
+		})
+	})
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+	shard := c.GetShardByIndex(0, 0)
+	leader := c.GetShardLeaderNode(shard.ID)
+	kv := c.CreateTestKVClient(leader)
+	defer kv.Close()
+	require.NoError(t, kv.Set("k1", "v1", testWaitTimeout))
+	v, _ := getShardUnavailable(t, shard.ID)
+	assert.Equal(t, float64(0), v)
+
+	// the majority of the shard is down from the view of the leader
+	var others []int
+	c.EveryStore(func(i int, s Store) {
+		if i != leader {
+			others = append(others, i)
+		}
+	})
+	c.StartNetworkPartition([][]int{{leader}, others})
+	assert.Error(t, kv.Set("k2", "v2", time.Second))
+	select {
+	case shardID := <-unavailableC:
+		assert.Equal(t, shard.ID, shardID)
+	case <-time.After(testWaitTimeout):
+		assert.FailNow(t, "wait unavailable notification timeout")
+	}
+	v, _ = getShardUnavailable(t, shard.ID)
+	assert.Equal(t, float64(1), v)
+
+	// cleared once the old leader catches up with the commits of the majority
+	c.StopNetworkPartition()
+	assert.Eventually(t, func() bool {
+		v, _ := getShardUnavailable(t, shard.ID)
+		return v == 0
+	}, testWaitTimeout, time.Millisecond*100)
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"container/list"
+	"sort"
+)
+
+type dedupItem struct {
+	id    string
+	value []byte
+}
+
+// requestDedup records the IDs and responses of the most recently applied write
+// requests of a shard, it is used to avoid applying a retried write request
+// more than once. All replicas apply the same raft logs, so the recorded state
+// is the same on all replicas and it survives leader changes. requestDedup is
+// not thread safe, it is only accessed by the apply routine. A nil requestDedup
+// is valid and records nothing.
+type requestDedup struct {
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+	// changes is the requests added (true) or removed (false) since the last
+	// takeChanges call
+	changes map[string]bool
+}
+
+func newRequestDedup(capacity int) *requestDedup {
+	if capacity <= 0 {
+		return nil
+	}
+	return &requestDedup{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		changes:  make(map[string]bool),
+	}
+}
+
+// get returns the recorded response of the request, true if the request has
+// been applied.
+func (d *requestDedup) get(id []byte) ([]byte, bool) {
+	if d == nil || len(id) == 0 {
+		return nil, false
+	}
+	if e, ok := d.items[string(id)]; ok {
+		d.ll.MoveToFront(e)
+		return e.Value.(*dedupItem).value, true
+	}
+	return nil, false
+}
+
+// add records the response of the applied request, the least recently used
+// request is evicted if the capacity is exceeded.
+func (d *requestDedup) add(id []byte, value []byte) {
+	if d == nil || len(id) == 0 {
+		return
+	}
+	if e, ok := d.items[string(id)]; ok {
+		d.ll.MoveToFront(e)
+		e.Value.(*dedupItem).value = value
+		return
+	}
+	item := &dedupItem{id: string(id), value: value}
+	d.items[item.id] = d.ll.PushFront(item)
+	d.changes[item.id] = true
+	if d.ll.Len() > d.capacity {
+		e := d.ll.Back()
+		d.ll.Remove(e)
+		delete(d.items, e.Value.(*dedupItem).id)
+		d.changes[e.Value.(*dedupItem).id] = false
+	}
+}
+
+// remove removes the request, it is used when the request failed to apply.
+func (d *requestDedup) remove(id []byte) {
+	if d == nil || len(id) == 0 {
+		return
+	}
+	if e, ok := d.items[string(id)]; ok {
+		d.ll.Remove(e)
+		delete(d.items, string(id))
+		d.changes[string(id)] = false
+	}
+}
+
+func (d *requestDedup) len() int {
+	if d == nil {
+		return 0
+	}
+	return d.ll.Len()
+}
+
+// takeChanges returns the IDs of the requests added and removed since the last
+// call, so only the changes are persisted with each write. The added ones are
+// from the least recently used one to the most recently used one.
+func (d *requestDedup) takeChanges() ([][]byte, [][]byte) {
+	if d == nil || len(d.changes) == 0 {
+		return nil, nil
+	}
+	var added, removed [][]byte
+	for e := d.ll.Back(); e != nil; e = e.Prev() {
+		if id := e.Value.(*dedupItem).id; d.changes[id] {
+			added = append(added, []byte(id))
+		}
+	}
+	for id, ok := range d.changes {
+		if !ok {
+			removed = append(removed, []byte(id))
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return bytes.Compare(removed[i], removed[j]) < 0
+	})
+	d.changes = make(map[string]bool)
+	return added, removed
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestRequestDedupDisabled(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	d := newRequestDedup(0)
+	assert.Nil(t, d)
+	d.add([]byte("1"), []byte("v1"))
+	_, ok := d.get([]byte("1"))
+	assert.False(t, ok)
+	assert.Equal(t, 0, d.len())
+}
+
+func TestRequestDedup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	d := newRequestDedup(2)
+	d.add([]byte("1"), []byte("v1"))
+	d.add([]byte("2"), []byte("v2"))
+	v, ok := d.get([]byte("1"))
+	assert.True(t, ok)
+	assert.Equal(t, []byte("v1"), v)
+
+	// 2 is the least recently used one
+	d.add([]byte("3"), []byte("v3"))
+	assert.Equal(t, 2, d.len())
+	_, ok = d.get([]byte("2"))
+	assert.False(t, ok)
+	v, ok = d.get([]byte("3"))
+	assert.True(t, ok)
+	assert.Equal(t, []byte("v3"), v)
+
+	d.add([]byte("3"), []byte("v4"))
+	assert.Equal(t, 2, d.len())
+	v, ok = d.get([]byte("3"))
+	assert.True(t, ok)
+	assert.Equal(t, []byte("v4"), v)
+
+	d.remove([]byte("3"))
+	assert.Equal(t, 1, d.len())
+	_, ok = d.get([]byte("3"))
+	assert.False(t, ok)
+
+	// empty ID can not be deduplicated
+	d.add(nil, []byte("v"))
+	_, ok = d.get(nil)
+	assert.False(t, ok)
+}
+
+func TestRequestDedupChanges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	d := newRequestDedup(2)
+	added, removed := d.takeChanges()
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+
+	d.add([]byte("1"), nil)
+	d.add([]byte("2"), nil)
+	d.add([]byte("1"), []byte("v1"))
+	// from the least recently used one
+	added, removed = d.takeChanges()
+	assert.Equal(t, [][]byte{[]byte("2"), []byte("1")}, added)
+	assert.Empty(t, removed)
+
+	// 2 is evicted and 1 failed to apply
+	d.add([]byte("3"), nil)
+	d.remove([]byte("1"))
+	added, removed = d.takeChanges()
+	assert.Equal(t, [][]byte{[]byte("3")}, added)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("2")}, removed)
+
+	// no more changes
+	d.get([]byte("3"))
+	added, removed = d.takeChanges()
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+
+	var nilDedup *requestDedup
+	added, removed = nilDedup.takeChanges()
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
@@ -0,0 +1,175 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+const (
+	requestSpanName = "raftstore.request"
+	proposeSpanName = "raftstore.propose"
+	commitSpanName  = "raftstore.commit"
+	applySpanName   = "raftstore.apply"
+)
+
+// tracedRequest is a request being traced, span is the span of the whole
+// request, stage is the span of its current stage.
+type tracedRequest struct {
+	ctx   context.Context
+	span  trace.Span
+	stage trace.Span
+}
+
+// requestTracer traces the requests of a shard replica from they are added to
+// the replica until their responses are returned. The span of each request has
+// a child span for each stage, the proposal, the commit and the apply, the
+// stages are found by the request ID. A nil requestTracer traces nothing, see
+// Customize.Tracer.
+type requestTracer struct {
+	tracer  trace.Tracer
+	shardID uint64
+
+	mu struct {
+		sync.Mutex
+		requests map[string]*tracedRequest
+	}
+}
+
+func newRequestTracer(tracer trace.Tracer, shardID uint64) *requestTracer {
+	if tracer == nil {
+		return nil
+	}
+	t := &requestTracer{tracer: tracer, shardID: shardID}
+	t.mu.requests = make(map[string]*tracedRequest)
+	return t
+}
+
+// trace starts tracing the request added to the replica, the context of the
+// request span is attached to the returned reqCtx, and its callback ends the
+// spans of the requests once the responses are returned.
+func (t *requestTracer) trace(c reqCtx) reqCtx {
+	if t == nil {
+		return c
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.mu.requests[string(c.req.ID)]
+	if !ok {
+		ctx, span := t.tracer.Start(context.Background(), requestSpanName,
+			trace.WithAttributes(
+				attribute.String("request.id", hex.EncodeToString(c.req.ID)),
+				attribute.Int64("shard.id", int64(t.shardID))))
+		span.AddEvent("enqueued")
+		r = &tracedRequest{ctx: ctx, span: span}
+		_, r.stage = t.tracer.Start(ctx, proposeSpanName)
+		t.mu.requests[string(c.req.ID)] = r
+	}
+	c.ctx = r.ctx
+	cb := c.cb
+	c.cb = func(resp rpcpb.ResponseBatch) {
+		t.finish(resp)
+		if cb != nil {
+			cb(resp)
+		}
+	}
+	return c
+}
+
+// proposed ends the proposal stage of the requests proposed to raft.
+func (t *requestTracer) proposed(requests []rpcpb.Request) {
+	t.nextStage(requests, "proposed", commitSpanName)
+}
+
+// committed ends the commit stage of the requests of the committed log which is
+// being applied.
+func (t *requestTracer) committed(requests []rpcpb.Request) {
+	t.nextStage(requests, "committed", applySpanName)
+}
+
+// applied ends the apply stage of the requests written to the data storage.
+func (t *requestTracer) applied(requests []rpcpb.Request) {
+	t.nextStage(requests, "applied", "")
+}
+
+func (t *requestTracer) nextStage(requests []rpcpb.Request, event, next string) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for idx := range requests {
+		r, ok := t.mu.requests[string(requests[idx].ID)]
+		if !ok {
+			continue
+		}
+		if r.stage != nil {
+			r.stage.End()
+			r.stage = nil
+		}
+		r.span.AddEvent(event)
+		if next != "" {
+			_, r.stage = t.tracer.Start(r.ctx, next)
+		}
+	}
+}
+
+// finish ends the spans of the requests whose responses are returned.
+func (t *requestTracer) finish(resp rpcpb.ResponseBatch) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for idx := range resp.Responses {
+		msg := resp.Responses[idx].Error.Message
+		if msg == "" {
+			msg = resp.Header.Error.Message
+		}
+		t.endLocked(resp.Responses[idx].ID, msg)
+	}
+}
+
+// abort ends the spans of the request which is dropped without a response.
+func (t *requestTracer) abort(id []byte, err error) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.endLocked(id, err.Error())
+}
+
+func (t *requestTracer) endLocked(id []byte, errMsg string) {
+	r, ok := t.mu.requests[string(id)]
+	if !ok {
+		return
+	}
+	delete(t.mu.requests, string(id))
+	if r.stage != nil {
+		r.stage.End()
+	}
+	if errMsg != "" {
+		r.span.SetStatus(codes.Error, errMsg)
+	}
+	r.span.End()
+}
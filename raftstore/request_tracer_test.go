@@ -0,0 +1,139 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func newTestTracer() (trace.Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return provider.Tracer("raftstore-test"), exporter
+}
+
+func spanEventNames(span *sdktrace.SpanSnapshot) []string {
+	var names []string
+	for _, e := range span.MessageEvents {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+func TestRequestTracerDisabled(t *testing.T) {
+	tracer := newRequestTracer(nil, 1)
+	assert.Nil(t, tracer)
+
+	c := newReqCtx(rpcpb.Request{ID: []byte("1")}, nil)
+	c = tracer.trace(c)
+	assert.Nil(t, c.ctx)
+	assert.Nil(t, c.cb)
+	tracer.proposed([]rpcpb.Request{c.req})
+	tracer.committed([]rpcpb.Request{c.req})
+	tracer.applied([]rpcpb.Request{c.req})
+	tracer.abort(c.req.ID, ErrReplicaStopped)
+}
+
+func TestRequestTracerEndsSpansOnError(t *testing.T) {
+	tr, exporter := newTestTracer()
+	tracer := newRequestTracer(tr, 1)
+
+	var resp []rpcpb.ResponseBatch
+	c := tracer.trace(newReqCtx(rpcpb.Request{ID: []byte("1")},
+		func(r rpcpb.ResponseBatch) {
+			resp = append(resp, r)
+		}))
+	assert.NotNil(t, c.ctx)
+	// traced once
+	tracer.trace(newReqCtx(rpcpb.Request{ID: []byte("1")}, nil))
+	tracer.proposed([]rpcpb.Request{c.req})
+	stale := errorStaleCMDResp(nil)
+	stale.Responses = []rpcpb.Response{{ID: c.req.ID}}
+	c.cb(stale)
+	require.Equal(t, 1, len(resp))
+	assert.Empty(t, tracer.mu.requests)
+
+	spans := exporter.GetSpans()
+	require.Equal(t, 3, len(spans))
+	root := spans[2]
+	assert.Equal(t, requestSpanName, root.Name)
+	assert.Equal(t, codes.Error, root.StatusCode)
+	assert.Equal(t, errStaleCMD.Error(), root.StatusMessage)
+	assert.Equal(t, []string{"enqueued", "proposed"}, spanEventNames(root))
+
+	tracer.trace(newReqCtx(rpcpb.Request{ID: []byte("2")}, nil))
+	tracer.abort([]byte("2"), errors.New("dropped"))
+	assert.Empty(t, tracer.mu.requests)
+	assert.Equal(t, 5, len(exporter.GetSpans()))
+}
+
+func TestRequestTracing(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tracer, exporter := newTestTracer()
+	c := NewSingleTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Customize.Tracer = tracer
+		}))
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	require.NoError(t, kv.Set("k1", "v1", testWaitTimeout))
+
+	// the spans of the write request are in the same trace
+	var applied *sdktrace.SpanSnapshot
+	spans := exporter.GetSpans()
+	for _, span := range spans {
+		if span.Name == applySpanName {
+			applied = span
+		}
+	}
+	require.NotNil(t, applied)
+	traced := make(map[string]*sdktrace.SpanSnapshot)
+	for _, span := range spans {
+		if span.SpanContext.TraceID() == applied.SpanContext.TraceID() {
+			traced[span.Name] = span
+		}
+	}
+	require.Equal(t, 4, len(traced))
+	root := traced[requestSpanName]
+	require.NotNil(t, root)
+	assert.False(t, root.Parent.IsValid())
+	assert.Equal(t, codes.Unset, root.StatusCode)
+	assert.Equal(t, []string{"enqueued", "dequeued", "proposed", "committed", "applied"},
+		spanEventNames(root))
+	for _, name := range []string{proposeSpanName, commitSpanName, applySpanName} {
+		require.NotNil(t, traced[name], name)
+		assert.Equal(t, root.SpanContext.SpanID(), traced[name].Parent.SpanID(), name)
+	}
+	// the stages are in order
+	assert.False(t, traced[commitSpanName].StartTime.Before(traced[proposeSpanName].EndTime))
+	assert.False(t, traced[applySpanName].StartTime.Before(traced[commitSpanName].EndTime))
+	assert.False(t, root.EndTime.Before(traced[applySpanName].EndTime))
+}
@@ -80,6 +80,8 @@ type Router interface {
 	GetShardStats(id uint64) metapb.ShardStats
 	// GetStoreStats returns the runtime stats info of the store
 	GetStoreStats(id uint64) metapb.StoreStats
+	// GetStore returns the store metadata, false if the store not found
+	GetStore(id uint64) (metapb.Store, bool)
 }
 
 type op struct {
@@ -343,6 +345,14 @@ func (r *defaultRouter) GetStoreStats(id uint64) metapb.StoreStats {
 	return r.mu.storeStats[id]
 }
 
+func (r *defaultRouter) GetStore(id uint64) (metapb.Store, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	value, ok := r.mu.stores[id]
+	return value, ok
+}
+
 func (r *defaultRouter) UpdateLeader(shardID uint64, leaderReplciaID uint64) {
 	if leaderReplciaID == 0 {
 		return
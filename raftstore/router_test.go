@@ -237,6 +237,11 @@ func TestHandleStoreEvent(t *testing.T) {
 	r.handleEvent(e)
 
 	assert.Equal(t, store, r.mu.stores[store.ID])
+	v, ok := r.GetStore(store.ID)
+	assert.True(t, ok)
+	assert.Equal(t, store, v)
+	_, ok = r.GetStore(102)
+	assert.False(t, ok)
 }
 
 func TestSelectShard(t *testing.T) {
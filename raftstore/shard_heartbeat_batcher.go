@@ -0,0 +1,157 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lni/goutils/syncutil"
+
+	"github.com/matrixorigin/matrixcube/components/prophet"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+type shardHeartbeat struct {
+	shard Shard
+	req   rpcpb.ShardHeartbeatReq
+	// done is invoked with the send result of the heartbeat
+	done func(error)
+}
+
+// shardHeartbeatBatcher collects the shard heartbeats of the leader replicas on
+// the current store, and sends them to prophet in batches, so that a store
+// with thousands of shard leaders does not issue thousands of RPCs every
+// heartbeat interval. A nil shardHeartbeatBatcher is valid and means batching
+// is disabled.
+type shardHeartbeatBatcher struct {
+	batchSize int
+	interval  time.Duration
+	stopper   *syncutil.Stopper
+	notifyC   chan struct{}
+
+	mu struct {
+		sync.Mutex
+		client  prophet.Client
+		pending []shardHeartbeat
+		// index of the pending heartbeat by shard id
+		shards map[uint64]int
+	}
+}
+
+func newShardHeartbeatBatcher(batchSize int, interval time.Duration) *shardHeartbeatBatcher {
+	if batchSize <= 0 {
+		return nil
+	}
+	b := &shardHeartbeatBatcher{
+		batchSize: batchSize,
+		interval:  interval,
+		stopper:   syncutil.NewStopper(),
+		notifyC:   make(chan struct{}, 1),
+	}
+	b.mu.shards = make(map[uint64]int)
+	return b
+}
+
+func (b *shardHeartbeatBatcher) enabled() bool {
+	return b != nil
+}
+
+func (b *shardHeartbeatBatcher) start(client prophet.Client) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.mu.client = client
+	b.mu.Unlock()
+	b.stopper.RunWorker(func() {
+		ticker := time.NewTicker(b.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-b.stopper.ShouldStop():
+				return
+			case <-ticker.C:
+				b.flush()
+			case <-b.notifyC:
+				b.flush()
+			}
+		}
+	})
+}
+
+func (b *shardHeartbeatBatcher) close() {
+	if b == nil {
+		return
+	}
+	b.stopper.Stop()
+}
+
+// add adds the heartbeat of the shard to the next batch, it replaces the
+// pending heartbeat of the same shard which is out of date.
+func (b *shardHeartbeatBatcher) add(shard Shard, req rpcpb.ShardHeartbeatReq, done func(error)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	hb := shardHeartbeat{shard: shard, req: req, done: done}
+	if idx, ok := b.mu.shards[shard.ID]; ok {
+		b.mu.pending[idx] = hb
+		return
+	}
+	b.mu.shards[shard.ID] = len(b.mu.pending)
+	b.mu.pending = append(b.mu.pending, hb)
+	if len(b.mu.pending) >= b.batchSize {
+		select {
+		case b.notifyC <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *shardHeartbeatBatcher) getPending() ([]shardHeartbeat, prophet.Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pending := b.mu.pending
+	b.mu.pending = nil
+	if len(pending) > 0 {
+		b.mu.shards = make(map[uint64]int)
+	}
+	return pending, b.mu.client
+}
+
+// flush sends all the pending heartbeats, the send result of each heartbeat is
+// reported to its own done callback.
+func (b *shardHeartbeatBatcher) flush() {
+	pending, client := b.getPending()
+	for len(pending) > 0 {
+		n := b.batchSize
+		if n > len(pending) {
+			n = len(pending)
+		}
+		batch := pending[:n]
+		pending = pending[n:]
+
+		shards := make([]Shard, 0, n)
+		reqs := make([]rpcpb.ShardHeartbeatReq, 0, n)
+		for _, hb := range batch {
+			shards = append(shards, hb.shard)
+			reqs = append(reqs, hb.req)
+		}
+		errs := client.ShardHeartbeats(shards, reqs)
+		for i, hb := range batch {
+			if hb.done != nil && i < len(errs) {
+				hb.done(errs[i])
+			}
+		}
+	}
+}
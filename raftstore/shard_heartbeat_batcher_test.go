@@ -0,0 +1,127 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/components/prophet/mock/mockclient"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestShardHeartbeatBatcher(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	errFailed := errors.New("failed")
+	var mu sync.Mutex
+	rpcs := 0
+	terms := make(map[uint64]uint64)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().ShardHeartbeat(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	client.EXPECT().ShardHeartbeats(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(shards []Shard, reqs []rpcpb.ShardHeartbeatReq) []error {
+			mu.Lock()
+			defer mu.Unlock()
+			rpcs++
+			errs := make([]error, len(shards))
+			for i, shard := range shards {
+				terms[shard.ID] = reqs[i].Term
+				// partial failure
+				if shard.ID == 1 {
+					errs[i] = errFailed
+				}
+			}
+			return errs
+		}).AnyTimes()
+
+	s.heartbeatBatcher = newShardHeartbeatBatcher(16, time.Millisecond*10)
+	s.heartbeatBatcher.start(client)
+	defer s.heartbeatBatcher.close()
+
+	n := 200
+	var replicas []*replica
+	for i := 1; i <= n; i++ {
+		r := Replica{ID: uint64(i), StoreID: s.Meta().ID}
+		pr := newTestReplica(Shard{ID: uint64(i), Replicas: []Replica{r}}, r, s)
+		pr.prophetClient = client
+		pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+		pr.setLeaderReplicaID(pr.replicaID)
+		replicas = append(replicas, pr)
+	}
+	for _, pr := range replicas {
+		pr.prophetHeartbeat()
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(terms) == n
+	}, testWaitTimeout, time.Millisecond*10)
+	mu.Lock()
+	assert.True(t, rpcs <= n/4, "too many rpcs %d", rpcs)
+	for _, pr := range replicas {
+		assert.Equal(t, pr.rn.BasicStatus().Term, terms[pr.shardID])
+	}
+	mu.Unlock()
+
+	err, _ := replicas[0].LastError()
+	assert.Equal(t, errFailed, err)
+	for _, pr := range replicas[1:] {
+		err, _ := pr.LastError()
+		assert.NoError(t, err)
+	}
+}
+
+func TestShardHeartbeatBatcherReplacesOutOfDateHeartbeat(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var sent []rpcpb.ShardHeartbeatReq
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().ShardHeartbeats(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(shards []Shard, reqs []rpcpb.ShardHeartbeatReq) []error {
+			sent = append(sent, reqs...)
+			return make([]error, len(shards))
+		}).Times(1)
+
+	var disabled *shardHeartbeatBatcher
+	assert.False(t, disabled.enabled())
+	assert.Nil(t, newShardHeartbeatBatcher(0, time.Second))
+
+	b := newShardHeartbeatBatcher(16, time.Second)
+	b.mu.client = client
+	b.add(Shard{ID: 1}, rpcpb.ShardHeartbeatReq{Term: 1}, nil)
+	b.add(Shard{ID: 2}, rpcpb.ShardHeartbeatReq{Term: 1}, nil)
+	b.add(Shard{ID: 1}, rpcpb.ShardHeartbeatReq{Term: 2}, nil)
+	b.flush()
+	assert.Equal(t, []rpcpb.ShardHeartbeatReq{{Term: 2}, {Term: 1}}, sent)
+	// nothing pending
+	b.flush()
+}
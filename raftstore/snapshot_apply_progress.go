@@ -0,0 +1,83 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+var (
+	snapshotApplyProgressLogInterval = 10 * time.Second
+)
+
+// snapshotApplyProgress reports the progress of applying a snapshot by the
+// metric and the periodic logs, so a long restore of a large shard is not
+// mistaken for a hung store.
+type snapshotApplyProgress struct {
+	logger  *zap.Logger
+	shardID uint64
+	// total the bytes of the snapshot on disk
+	total   uint64
+	applied uint64
+	// started the time the snapshot apply is started
+	started    time.Time
+	lastLogged time.Time
+}
+
+func newSnapshotApplyProgress(shardID uint64, total uint64,
+	logger *zap.Logger) *snapshotApplyProgress {
+	now := time.Now()
+	p := &snapshotApplyProgress{
+		logger:     logger,
+		shardID:    shardID,
+		total:      total,
+		started:    now,
+		lastLogged: now,
+	}
+	metric.SetSnapshotApplyProgress(shardID, 0, total)
+	return p
+}
+
+// update is invoked by the data storage with the bytes of the snapshot applied
+// so far, the reported progress never goes backwards.
+func (p *snapshotApplyProgress) update(applied uint64) {
+	if applied <= p.applied {
+		return
+	}
+	p.applied = applied
+	// the total is estimated by the size of the snapshot directory
+	if p.applied > p.total {
+		p.total = p.applied
+	}
+	metric.SetSnapshotApplyProgress(p.shardID, p.applied, p.total)
+	if now := time.Now(); now.Sub(p.lastLogged) >= snapshotApplyProgressLogInterval {
+		p.lastLogged = now
+		p.logger.Info("applying snapshot",
+			zap.Uint64("applied-bytes", p.applied),
+			zap.Uint64("total-bytes", p.total),
+			zap.Duration("elapsed", now.Sub(p.started)))
+	}
+}
+
+// done marks the snapshot as completely applied.
+func (p *snapshotApplyProgress) done() {
+	p.update(p.total)
+	p.logger.Info("snapshot applied",
+		zap.Uint64("total-bytes", p.total),
+		zap.Duration("elapsed", time.Since(p.started)))
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/matrixorigin/matrixcube/logdb"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+type testProgressiveApplier struct {
+	chunks []uint64
+}
+
+func (t *testProgressiveApplier) ApplySnapshot(shardID uint64, path string) error {
+	panic("not implemented")
+}
+
+func (t *testProgressiveApplier) ApplySnapshotWithProgress(shardID uint64,
+	path string, progress storage.SnapshotApplyProgress) error {
+	for _, v := range t.chunks {
+		progress(v)
+	}
+	return nil
+}
+
+func (t *testProgressiveApplier) GetInitialStates() ([]metapb.ShardMetadata, error) {
+	panic("not implemented")
+}
+
+func TestSnapshotApplyProgress(t *testing.T) {
+	old := snapshotApplyProgressLogInterval
+	snapshotApplyProgressLogInterval = 0
+	defer func() {
+		snapshotApplyProgressLogInterval = old
+	}()
+
+	fs := vfs.GetTestFS()
+	fn := func(t *testing.T, ldb logdb.LogDB, s *snapshotter) {
+		core, logs := observer.New(zap.InfoLevel)
+		s.logger = zap.New(core)
+		env := s.getCreatingSnapshotEnv(0)
+		env.FinalizeIndex(100)
+		dir := env.GetFinalDir()
+		require.NoError(t, fs.MkdirAll(dir, 0755))
+		f, err := fs.Create(fs.PathJoin(dir, "db.data"))
+		require.NoError(t, err)
+		_, err = f.Write(make([]byte, 4000))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		check := func(recordedTotal, total uint64) {
+			logs.TakeAll()
+			// the data storage reports the progress in multiple chunks, stale
+			// reports are ignored
+			applier := &testProgressiveApplier{chunks: []uint64{1000, 2000, 1500, 3000}}
+			require.NoError(t, s.applySnapshot(applier, dir, recordedTotal))
+
+			var applied []uint64
+			for _, e := range logs.FilterMessage("applying snapshot").All() {
+				applied = append(applied, e.ContextMap()["applied-bytes"].(uint64))
+				assert.Equal(t, total, e.ContextMap()["total-bytes"])
+			}
+			assert.Equal(t, []uint64{1000, 2000, 3000, total}, applied)
+			assert.Equal(t, 1, logs.FilterMessage("snapshot applied").Len())
+		}
+		// the total size recorded in the SnapshotInfo
+		check(5000, 5000)
+		// the snapshot directory is measured if the total size is unknown
+		check(0, 4000)
+	}
+	runSnapshotterTest(t, fn, fs)
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+)
+
+// snapshotStatusQueue is a bounded queue of the snapshot statuses reported by
+// the transport. Raft only cares about the final status of the snapshot sent to
+// a replica, so the queue keeps at most one status per target replica and a
+// newer status replaces the pending one.
+type snapshotStatusQueue struct {
+	sync.Mutex
+	capacity int
+	// order is the target replicas in the order their statuses were queued
+	order    []uint64
+	statuses map[uint64]snapshotStatus
+}
+
+func newSnapshotStatusQueue(capacity int) *snapshotStatusQueue {
+	return &snapshotStatusQueue{
+		capacity: capacity,
+		statuses: make(map[uint64]snapshotStatus),
+	}
+}
+
+// put adds the snapshot status into the queue, the pending status of the same
+// target replica is replaced. The oldest status is dropped and returned if the
+// queue is full.
+func (q *snapshotStatusQueue) put(ss snapshotStatus) (snapshotStatus, bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	if _, ok := q.statuses[ss.to]; ok {
+		q.statuses[ss.to] = ss
+		return snapshotStatus{}, false
+	}
+
+	var dropped snapshotStatus
+	full := q.capacity > 0 && len(q.order) >= q.capacity
+	if full {
+		dropped = q.statuses[q.order[0]]
+		delete(q.statuses, q.order[0])
+		q.order = q.order[1:]
+	}
+	q.order = append(q.order, ss.to)
+	q.statuses[ss.to] = ss
+	return dropped, full
+}
+
+// get moves at most max statuses into the items, returns the number of moved
+// statuses.
+func (q *snapshotStatusQueue) get(max int64, items []interface{}) int64 {
+	q.Lock()
+	defer q.Unlock()
+
+	n := int64(len(q.order))
+	if n > max {
+		n = max
+	}
+	if n > int64(len(items)) {
+		n = int64(len(items))
+	}
+	for i := int64(0); i < n; i++ {
+		items[i] = q.statuses[q.order[i]]
+		delete(q.statuses, q.order[i])
+	}
+	q.order = q.order[n:]
+	return n
+}
+
+func (q *snapshotStatusQueue) len() int64 {
+	q.Lock()
+	defer q.Unlock()
+	return int64(len(q.order))
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotStatusQueueKeepsLatestStatusPerReplica(t *testing.T) {
+	q := newSnapshotStatusQueue(4)
+	for i := 0; i < 1000; i++ {
+		_, dropped := q.put(snapshotStatus{to: 2, rejected: i%2 == 0})
+		assert.False(t, dropped)
+	}
+	q.put(snapshotStatus{to: 3, rejected: true})
+	q.put(snapshotStatus{to: 2, rejected: false})
+	assert.Equal(t, int64(2), q.len())
+
+	items := make([]interface{}, 8)
+	assert.Equal(t, int64(2), q.get(8, items))
+	assert.Equal(t, snapshotStatus{to: 2, rejected: false}, items[0])
+	assert.Equal(t, snapshotStatus{to: 3, rejected: true}, items[1])
+	assert.Equal(t, int64(0), q.len())
+}
+
+func TestSnapshotStatusQueueDropsOldestStatusWhenFull(t *testing.T) {
+	q := newSnapshotStatusQueue(2)
+	q.put(snapshotStatus{to: 2})
+	q.put(snapshotStatus{to: 3})
+	dropped, ok := q.put(snapshotStatus{to: 4, rejected: true})
+	assert.True(t, ok)
+	assert.Equal(t, snapshotStatus{to: 2}, dropped)
+	assert.Equal(t, int64(2), q.len())
+
+	items := make([]interface{}, 8)
+	assert.Equal(t, int64(1), q.get(1, items))
+	assert.Equal(t, snapshotStatus{to: 3}, items[0])
+	assert.Equal(t, int64(1), q.get(8, items))
+	assert.Equal(t, snapshotStatus{to: 4, rejected: true}, items[0])
+}
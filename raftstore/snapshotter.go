@@ -158,6 +158,62 @@ func (s *snapshotter) removeOrphanSnapshots() error {
 	return nil
 }
 
+// removeAllSnapshots removes the snapshot directory of the replica including
+// all snapshots in it, it returns the number of bytes reclaimed.
+func (s *snapshotter) removeAllSnapshots() (uint64, error) {
+	exist, err := fileutil.Exist(s.rootDir, s.fs)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return 0, nil
+	}
+	size, err := s.getDirSize(s.rootDir)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.fs.RemoveAll(s.rootDir); err != nil {
+		return 0, err
+	}
+	// make sure no orphan snapshot left
+	exist, err = fileutil.Exist(s.rootDir, s.fs)
+	if err != nil {
+		return 0, err
+	}
+	if exist {
+		return 0, errors.Newf("snapshot dir %s still exists after removal", s.rootDir)
+	}
+	s.logger.Info("all snapshots removed",
+		zap.String("dir", s.rootDir),
+		zap.Uint64("reclaimed-bytes", size))
+	return size, nil
+}
+
+func (s *snapshotter) getDirSize(dir string) (uint64, error) {
+	files, err := s.fs.List(dir)
+	if err != nil {
+		return 0, err
+	}
+	size := uint64(0)
+	for _, n := range files {
+		fn := s.fs.PathJoin(dir, n)
+		fi, err := s.fs.Stat(fn)
+		if err != nil {
+			return 0, err
+		}
+		if fi.IsDir() {
+			v, err := s.getDirSize(fn)
+			if err != nil {
+				return 0, err
+			}
+			size += v
+			continue
+		}
+		size += uint64(fi.Size())
+	}
+	return size, nil
+}
+
 func (s *snapshotter) save(de saveable,
 	cs raftpb.ConfState, index uint64, term uint64) (ss raftpb.Snapshot,
 	env snapshot.SSEnv, err error) {
@@ -175,9 +231,15 @@ func (s *snapshotter) save(de saveable,
 			zap.Error(err))
 		return raftpb.Snapshot{}, env, err
 	}
+	size, err := s.getDirSize(env.GetTempDir())
+	if err != nil {
+		s.logger.Error("failed to get the size of the snapshot",
+			zap.Error(err))
+		return raftpb.Snapshot{}, env, err
+	}
 	env.FinalizeIndex(index)
 	return raftpb.Snapshot{
-		Data: protoc.MustMarshal(&metapb.SnapshotInfo{Extra: extra}),
+		Data: protoc.MustMarshal(&metapb.SnapshotInfo{Extra: extra, TotalSize: size}),
 		Metadata: raftpb.SnapshotMetadata{
 			Index:     index,
 			Term:      term,
@@ -191,8 +253,10 @@ func (s *snapshotter) recover(rc recoverable,
 	env := s.getRecoverSnapshotEnv(ss)
 	s.logger.Info("recovering from snapshot",
 		zap.String("dir", env.GetFinalDir()))
+	var si metapb.SnapshotInfo
+	protoc.MustUnmarshal(&si, ss.Data)
 	// TODO: double check to see whether we do have the snapshot folder on disk
-	if err := rc.ApplySnapshot(s.shardID, env.GetFinalDir()); err != nil {
+	if err := s.applySnapshot(rc, env.GetFinalDir(), si.TotalSize); err != nil {
 		s.logger.Error("data storage failed to apply snapshot",
 			zap.Error(err))
 		return metapb.ShardMetadata{}, err
@@ -216,6 +280,30 @@ func (s *snapshotter) recover(rc recoverable,
 	panic("missing shard metadata after recovering from snapshot")
 }
 
+// applySnapshot applies the snapshot in the specified directory, the progress
+// is reported if the data storage supports it. total is the size recorded in
+// the SnapshotInfo, the snapshot directory is measured if it is unknown.
+func (s *snapshotter) applySnapshot(rc recoverable, dir string, total uint64) error {
+	applier, ok := rc.(storage.ProgressiveSnapshotApplier)
+	if !ok {
+		return rc.ApplySnapshot(s.shardID, dir)
+	}
+	if total == 0 {
+		v, err := s.getDirSize(dir)
+		if err != nil {
+			return err
+		}
+		total = v
+	}
+	progress := newSnapshotApplyProgress(s.shardID, total, s.logger)
+	if err := applier.ApplySnapshotWithProgress(s.shardID, dir,
+		progress.update); err != nil {
+		return err
+	}
+	progress.done()
+	return nil
+}
+
 func (s *snapshotter) commit(ss raftpb.Snapshot, env snapshot.SSEnv) error {
 	env.FinalizeIndex(ss.Metadata.Index)
 	if err := env.FinalizeSnapshot(); err != nil {
@@ -228,6 +228,37 @@ func TestOnlyMostRecentSnapshotIsKept(t *testing.T) {
 	runSnapshotterTest(t, fn, fs)
 }
 
+func TestRemoveAllSnapshots(t *testing.T) {
+	fs := vfs.GetTestFS()
+	fn := func(t *testing.T, ldb logdb.LogDB, s *snapshotter) {
+		var dirs []string
+		for i := uint64(1); i <= 3; i++ {
+			env := s.getCreatingSnapshotEnv(i)
+			env.FinalizeIndex(i * 100)
+			fd := env.GetFinalDir()
+			assert.NoError(t, fs.MkdirAll(fd, 0755))
+			f, err := fs.Create(fs.PathJoin(fd, "data"))
+			assert.NoError(t, err)
+			_, err = f.Write(make([]byte, 10))
+			assert.NoError(t, err)
+			assert.NoError(t, f.Close())
+			dirs = append(dirs, fd)
+		}
+		reclaimed, err := s.removeAllSnapshots()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(30), reclaimed)
+		for _, fd := range dirs {
+			if _, err := fs.Stat(fd); !vfs.IsNotExist(err) {
+				t.Errorf("%s not removed", fd)
+			}
+		}
+		reclaimed, err = s.removeAllSnapshots()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), reclaimed)
+	}
+	runSnapshotterTest(t, fn, fs)
+}
+
 func TestFirstSnapshotBecomeOrphanedIsHandled(t *testing.T) {
 	fs := vfs.GetTestFS()
 	fn := func(t *testing.T, ldb logdb.LogDB, s *snapshotter) {
@@ -154,8 +154,7 @@ func (sc *splitChecker) doChecker(shard Shard) bool {
 		act.splitCheckData.splitIDs = newIDs
 	}
 
-	pr.addAction(act)
-	return true
+	return pr.addAction(act) == nil
 }
 
 func (sc *splitChecker) close() {
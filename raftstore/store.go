@@ -19,13 +19,18 @@
 package raftstore
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/fagongzi/util/protoc"
+	"github.com/juju/ratelimit"
 	"github.com/lni/goutils/syncutil"
 	"github.com/matrixorigin/matrixcube/aware"
 	"github.com/matrixorigin/matrixcube/components/log"
@@ -34,6 +39,7 @@ import (
 	putil "github.com/matrixorigin/matrixcube/components/prophet/util"
 	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/logdb"
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -68,8 +74,19 @@ type Store interface {
 	OnRequest(rpcpb.Request) error
 	// OnRequestWithCB receive a request, and call cb while the request is completed
 	OnRequestWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBatch)) error
+	// OnRequestsWithCB receive a batch of requests which may belong to different
+	// shards, each request is routed to its shard as OnRequestWithCB does, and
+	// the requests of the same shard are added into its request queue in one
+	// call. cb is called while each request is completed or rejected.
+	OnRequestsWithCB(reqs []rpcpb.Request, cb func(resp rpcpb.ResponseBatch))
 	// DataStorageByGroup returns a DataStorage of the shard group
 	DataStorageByGroup(uint64) storage.DataStorage
+	// RegisterDataStorage registers the DataStorage of the shard group, so
+	// different shard groups can use different storage engines, e.g. an LSM
+	// engine for one group and an in-memory engine for another. It must be
+	// invoked before the store is started, and each group can only be
+	// registered once.
+	RegisterDataStorage(group uint64, ds storage.DataStorage) error
 	// MaybeLeader returns the shard replica maybe leader
 	MaybeLeader(uint64) bool
 	// MustAllocID returns an uint64 id, panic if it has an error
@@ -83,6 +100,97 @@ type Store interface {
 	CreateShardPool(...metapb.ShardPoolJobMeta) (ShardsPool, error)
 	// GetShardPool returns `ShardsPool`, nil if `CreateShardPool` not completed
 	GetShardPool() ShardsPool
+	// ShardReadStats returns the accumulated read metrics of the shard replica
+	// on the current store, false if the shard replica not found.
+	ShardReadStats(shardID uint64) (readBytes, readKeys uint64, ok bool)
+	// ShardsReadStats returns the accumulated read metrics of all shard replicas
+	// on the current store.
+	ShardsReadStats() map[uint64]ReadStats
+	// AddLearner submits a config change to add the target replica to the shard as
+	// a learner. The shard leader must be on the current store, the config change
+	// is applied asynchronously.
+	AddLearner(shardID uint64, target Replica) error
+	// RemoveReplica submits a config change to remove the target replica from the
+	// shard. The shard leader must be on the current store, the config change is
+	// applied asynchronously.
+	RemoveReplica(shardID uint64, target Replica) error
+	// DemoteVoter submits a config change to demote the target voter to learner.
+	// The shard enters the joint state with the target replica as DemotingVoter
+	// and then leaves the joint state with it as Learner. The shard leader must be
+	// on the current store, the config change is applied asynchronously.
+	DemoteVoter(shardID uint64, target Replica) error
+	// OnConfigChange registers an observer which will be invoked with the new
+	// shard metadata and the applied changes after a config change of a shard
+	// replica on the current store is applied. Observers are invoked in a
+	// dedicated goroutine.
+	OnConfigChange(observer ConfigChangeObserver)
+	// OnLeaderChange registers an observer which will be invoked when a shard
+	// replica on the current store observes a new leader of the shard, including
+	// the replicas losing or gaining the leadership. Observers are invoked in a
+	// dedicated goroutine.
+	OnLeaderChange(observer LeaderChangeObserver)
+	// OnShardUnavailable registers an observer which will be invoked when a shard
+	// leader on the current store made no commit progress with pending proposals
+	// for Raft.UnavailableTimeout, e.g. the quorum of the shard is lost. Observers
+	// are invoked in a dedicated goroutine.
+	OnShardUnavailable(observer ShardUnavailableObserver)
+	// InFlightSnapshots returns the number of snapshots currently being created
+	// or applied by the shard replicas on the current store.
+	InFlightSnapshots() int
+	// ShardConfigHistory returns the most recently applied config changes of the
+	// shard replica on the current store, ordered from the oldest to the most
+	// recent. Nil is returned if the shard replica not found.
+	ShardConfigHistory(shardID uint64) []ConfigChangeRecord
+	// ReplicaHealth returns the liveness of the event loop of the shard replica
+	// on the current store. lastProgress is the last time the event loop
+	// completed a round, ticksTotal and ticksHandled are the number of raft ticks
+	// added and handled. Total ticks keep climbing while the handled ticks stall
+	// indicates a wedged event loop. ok is false if the shard replica not found.
+	ReplicaHealth(shardID uint64) (lastProgress time.Time, ticksHandled, ticksTotal uint64, ok bool)
+	// GroupCompactionStats returns the compaction statistics of the shard
+	// replicas on the current store aggregated by shard group. It is empty unless
+	// Raft.RaftLog.EnableGroupCompactionStats is set.
+	GroupCompactionStats() map[uint64]CompactionStats
+	// ShardProgress returns the replication progress of all replicas of the
+	// shard keyed by replica ID, it is collected in the event worker of the shard
+	// replica on the current store. ok is false if the shard replica not found,
+	// it is not the leader, it is stopped or the ctx is done before the event
+	// worker answers.
+	ShardProgress(ctx context.Context, shardID uint64) (progress map[uint64]ReplicaProgress, ok bool)
+	// OnStaleReadWithCB serves the read request from the local data of the shard
+	// replica which has been removed from the shard by a config change but not
+	// yet destroyed, the result may be stale. Raft.ServeStaleReadsAfterRemoved
+	// must be enabled, otherwise ErrStaleReadNotAllowed is returned, as it is for
+	// the replicas still in the shard and for the write requests.
+	OnStaleReadWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBatch)) error
+	// HostedGroups returns the sorted distinct groups of the shard replicas on the
+	// current store.
+	HostedGroups() []uint64
+	// QuarantinedShards returns the sorted IDs of the shards whose replicas on the
+	// current store are quarantined after failing to apply the same committed
+	// log Raft.MaxApplyFailures times.
+	QuarantinedShards() []uint64
+	// CreateConsistentSnapshot creates the snapshots of the shards whose leader
+	// replicas are on the current store, all the shards are frozen before any
+	// snapshot is created and stay frozen until all of them are created, so the
+	// snapshots are mutually consistent, e.g. for a cross-shard backup. An error
+	// identifying the shards not led by the current store is returned without
+	// creating any snapshot, ErrFreezeLost is returned if any shard applied
+	// writes before all the snapshots are created.
+	CreateConsistentSnapshot(shardIDs []uint64) (map[uint64]raftpb.Snapshot, error)
+	// ShardForKey returns the shard of the group whose key range [Start, End)
+	// contains the key, an empty End means the range is unbounded. Only the
+	// shards with replicas on the current store are known, false is returned if
+	// no such shard owns the key.
+	ShardForKey(group uint64, key []byte) (Shard, bool)
+	// ShardsInRange returns the shards of the group with replicas on the current
+	// store whose key ranges overlap [start, end) in the key order, an empty end
+	// means the range is unbounded.
+	ShardsInRange(group uint64, start, end []byte) []Shard
+	// ReplicasDebugState returns the dumps of the internal state of all shard
+	// replicas on the current store sorted by shard ID, see
+	// ReplicaDebugState. It never waits for the event workers of the replicas.
+	ReplicasDebugState() []ReplicaDebugState
 }
 
 type store struct {
@@ -103,11 +211,20 @@ type store struct {
 	splitChecker          *splitChecker
 	watcher               prophet.EventWatcher
 	vacuumCleaner         *vacuumCleaner
+	configChangeNotifier  *notifier
+	leaderChangeNotifier  *notifier
+	unavailableNotifier   *notifier
+	queueMetrics          *queueMetrics
+	metricsAggregator     *metricsAggregator
+	shardMetrics          *metric.ShardCollector // nil if Metric.MaxShardLabels is 0
 	createShardsProtector *createShardsProtector
 	keyRanges             sync.Map // group id -> *util.ShardTree
 	replicaRecords        sync.Map // replica id -> metapb.Replica
 	replicas              sync.Map // shard id -> *replica
 	droppedVoteMsgs       sync.Map // shard id -> raftpb.Message
+	inFlightSnapshots     int64
+	// heartbeatFailures the number of consecutive failed shard heartbeats
+	heartbeatFailures uint64
 
 	state    uint32
 	stopOnce sync.Once
@@ -121,10 +238,33 @@ type store struct {
 	groupController *replicaGroupController
 
 	storageStatsReader storageStatsReader
+	// pressure caches the store pressure attached to the shard heartbeats
+	pressure *storePressureCache
+	// compactionSnapshotLimiter limits the snapshots induced by log compactions,
+	// nil means no limit
+	compactionSnapshotLimiter *ratelimit.Bucket
+	// compactionStats the compaction statistics by shard group, nil if disabled
+	compactionStats *groupCompactionStats
+	// heartbeatBatcher sends the shard heartbeats in batches, nil if disabled
+	heartbeatBatcher *shardHeartbeatBatcher
+	// writeThrough forwards the applied writes to the secondary storage, nil if
+	// no handler is set
+	writeThrough *writeThroughForwarder
+	// dataStorages the data storages registered by RegisterDataStorage
+	dataStorages dataStorageRegistry
+	// clock provides the current time to replicas
+	clock clock
+	// tombstoneSince the time the removed replicas are first found by the
+	// tombstone GC, only accessed by the timer tasks.
+	tombstoneSince map[uint64]time.Time
 
 	mu struct {
 		sync.RWMutex
 		unavailableShards *roaring64.Bitmap
+		// refusedShards the shards whose local replicas are refused to start by
+		// the consistency check, they must not be created again over the same
+		// inconsistent data.
+		refusedShards *roaring64.Bitmap
 	}
 }
 
@@ -142,15 +282,27 @@ func NewStore(cfg *config.Config) Store {
 		stopper:               syncutil.NewStopper(),
 		createShardsProtector: newCreateShardsProtector(),
 		groupController:       newReplicaGroupController(),
+		configChangeNotifier:  newNotifier("config-change", maxPendingNotifications),
+		leaderChangeNotifier:  newNotifier("leader-change", maxPendingNotifications),
+		unavailableNotifier:   newNotifier("shard-unavailable", maxPendingNotifications),
+		queueMetrics:          newQueueMetrics(),
+		metricsAggregator:     newMetricsAggregator(cfg.Raft.MetricsFlushInterval.Duration),
+		clock:                 realClock{},
 	}
 
 	s.vacuumCleaner = newVacuumCleaner(s.vacuum)
+	s.compactionSnapshotLimiter = newCompactionSnapshotLimiter(cfg.Raft.RaftLog.MaxCompactionSnapshotRate)
+	s.compactionStats = newGroupCompactionStats(cfg.Raft.RaftLog.EnableGroupCompactionStats)
+	s.heartbeatBatcher = newShardHeartbeatBatcher(cfg.Replication.ShardHeartbeatBatchSize,
+		cfg.Replication.ShardHeartbeatBatchInterval.Duration)
+	s.writeThrough = newWriteThroughForwarder(cfg.Customize.CustomWriteThroughHandler,
+		cfg.Raft.WriteThroughQueueSize, logger.Named("write-through"))
 	// TODO: make maxWaitToChecker configurable
 	s.splitChecker = newSplitChecker(4, &storeReplicaGetter{s},
 		func(group uint64) storage.Feature {
-			return s.cfg.Storage.DataStorageFactory(group).Feature()
+			return s.DataStorageByGroup(group).Feature()
 		}, func(group uint64) splitCheckFunc {
-			return s.cfg.Storage.DataStorageFactory(group).SplitCheck
+			return s.DataStorageByGroup(group).SplitCheck
 		})
 	s.workerPool = newWorkerPool(s.logger, s.logdb, &storeReplicaLoader{s}, s.cfg.Worker.RaftEventWorkers)
 	s.shardPool = newDynamicShardsPool(cfg, s.logger)
@@ -164,8 +316,11 @@ func NewStore(cfg *config.Config) Store {
 	} else {
 		s.storageStatsReader = newDiskStorageStatsReader(s.cfg.DataPath)
 	}
+	s.pressure = newStorePressureCache(newStorePressureSampler(s),
+		cfg.Replication.StoreHeartbeatDuration.Duration)
 
 	s.mu.unavailableShards = roaring64.New()
+	s.mu.refusedShards = roaring64.New()
 	return s
 }
 
@@ -175,6 +330,7 @@ func (s *store) GetConfig() *config.Config {
 
 func (s *store) Start() {
 	s.logger.Info("begin to start raftstore")
+	s.freezeDataStorages()
 	s.workerPool.start()
 	s.logger.Info("worker pool started",
 		s.storeField())
@@ -183,6 +339,22 @@ func (s *store) Start() {
 	s.logger.Info("vacuum cleaner started",
 		s.storeField())
 
+	s.configChangeNotifier.start()
+	s.logger.Info("config change notifier started",
+		s.storeField())
+
+	s.leaderChangeNotifier.start()
+	s.logger.Info("leader change notifier started",
+		s.storeField())
+
+	s.unavailableNotifier.start()
+	s.logger.Info("shard unavailable notifier started",
+		s.storeField())
+
+	s.metricsAggregator.start()
+
+	s.writeThrough.start()
+
 	s.splitChecker.start()
 	s.logger.Info("split checker started",
 		s.storeField())
@@ -191,6 +363,14 @@ func (s *store) Start() {
 	s.logger.Info("prophet started",
 		s.storeField())
 
+	s.startShardMetrics()
+
+	s.heartbeatBatcher.start(s.pd.GetClient())
+	if s.heartbeatBatcher.enabled() {
+		s.logger.Info("shard heartbeat batcher started",
+			s.storeField())
+	}
+
 	s.createTransport()
 	s.logger.Info("raft internal transport created",
 		s.storeField())
@@ -272,6 +452,30 @@ func (s *store) Stop() {
 		s.logger.Info("shards stopped",
 			s.storeField())
 
+		if s.shardMetrics != nil {
+			metric.Unregister(s.shardMetrics)
+		}
+		// all replicas are shutdown, no more metrics to aggregate
+		s.metricsAggregator.close()
+
+		s.configChangeNotifier.close()
+		s.logger.Info("config change notifier stopped",
+			s.storeField())
+
+		s.leaderChangeNotifier.close()
+		s.logger.Info("leader change notifier stopped",
+			s.storeField())
+
+		s.unavailableNotifier.close()
+		s.logger.Info("shard unavailable notifier stopped",
+			s.storeField())
+
+		s.writeThrough.close()
+
+		s.heartbeatBatcher.close()
+		s.logger.Info("shard heartbeat batcher stopped",
+			s.storeField())
+
 		s.stopper.Stop()
 		s.logger.Info("stopper stopped",
 			s.storeField())
@@ -343,6 +547,44 @@ func (s *store) OnRequest(req rpcpb.Request) error {
 }
 
 func (s *store) OnRequestWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBatch)) error {
+	pr := s.routeRequest(req, cb)
+	if pr == nil {
+		return nil
+	}
+	if err := pr.onReq(req, cb); err != nil {
+		s.respRejectedRequest(pr.getShardID(), err, req, cb)
+	}
+	return nil
+}
+
+func (s *store) OnRequestsWithCB(reqs []rpcpb.Request, cb func(resp rpcpb.ResponseBatch)) {
+	// the requests of each shard in the order they are received
+	var replicas []*replica
+	batches := make(map[*replica][]reqCtx)
+	for _, req := range reqs {
+		pr := s.routeRequest(req, cb)
+		if pr == nil {
+			continue
+		}
+		if _, ok := batches[pr]; !ok {
+			replicas = append(replicas, pr)
+		}
+		batches[pr] = append(batches[pr], newReqCtx(req, cb))
+	}
+	for _, pr := range replicas {
+		batch := batches[pr]
+		for i, err := range pr.addRequests(batch) {
+			if err != nil {
+				s.respRejectedRequest(pr.getShardID(), err, batch[i].req, cb)
+			}
+		}
+	}
+}
+
+// routeRequest returns the replica of the shard to handle the request, nil if
+// the request has been responded, e.g. the shard is not on the current store or
+// the request is handled by the lease holder.
+func (s *store) routeRequest(req rpcpb.Request, cb func(resp rpcpb.ResponseBatch)) *replica {
 	if ce := s.logger.Check(zap.DebugLevel, "receive request"); ce != nil {
 		ce.Write(log.RequestIDField(req.ID),
 			s.storeField())
@@ -378,12 +620,8 @@ func (s *store) OnRequestWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBa
 					log.ReasonField("key not match"))
 			}
 
-			if err == errStoreNotMatch {
-				respStoreNotMatch(err, req, cb)
-				return nil
-			}
-
-			return err
+			respStoreNotMatch(err, req, cb)
+			return nil
 		}
 	}
 
@@ -425,24 +663,257 @@ func (s *store) OnRequestWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBa
 		return nil
 	}
 
-	if err := pr.onReq(req, cb); err != nil {
-		if s.isShardUnavailable(pr.getShardID()) {
-			respShardUnavailable(pr.getShardID(), req, cb)
+	return pr
+}
+
+// respRejectedRequest responds the request rejected by the replica, the error
+// in the response tells the client whether the request can be retried.
+func (s *store) respRejectedRequest(shardID uint64, err error, req rpcpb.Request, cb func(rpcpb.ResponseBatch)) {
+	switch err {
+	case ErrRequestQueueFull, ErrRateLimited:
+		respServerIsBusy(err, req, cb)
+	case ErrRequestTooLarge:
+		respRaftEntryTooLarge(shardID, uint64(req.Size()), req, cb)
+	case ErrReplicaQuarantined:
+		respShardQuarantined(shardID, req, cb)
+	default:
+		if s.isShardUnavailable(shardID) {
+			respShardUnavailable(shardID, req, cb)
 		} else {
 			respStoreNotMatch(errStoreNotMatch, req, cb)
 		}
 	}
-	return nil
-}
-
-func (s *store) DataStorageByGroup(group uint64) storage.DataStorage {
-	return s.cfg.Storage.DataStorageFactory(group)
 }
 
 func (s *store) MaybeLeader(shard uint64) bool {
 	return nil != s.getReplica(shard, true)
 }
 
+func (s *store) AddLearner(shardID uint64, target Replica) error {
+	target.Role = metapb.ReplicaRole_Learner
+	return s.addConfigChange(shardID, rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+		Replica:    target,
+	})
+}
+
+func (s *store) RemoveReplica(shardID uint64, target Replica) error {
+	return s.addConfigChange(shardID, rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_RemoveNode,
+		Replica:    target,
+	})
+}
+
+func (s *store) DemoteVoter(shardID uint64, target Replica) error {
+	if pr := s.getReplica(shardID, false); pr != nil {
+		voters := 0
+		found := false
+		for _, r := range pr.getShard().Replicas {
+			if r.Role != metapb.ReplicaRole_Voter {
+				continue
+			}
+			if r.ID == target.ID {
+				found = true
+				continue
+			}
+			voters++
+		}
+		if !found {
+			return ErrInvalidConfigChangeRequest
+		}
+		if voters == 0 {
+			return ErrNoVoterLeft
+		}
+	}
+	target.Role = metapb.ReplicaRole_DemotingVoter
+	return s.addConfigChange(shardID, rpcpb.ConfigChangeRequest{
+		ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+		Replica:    target,
+	})
+}
+
+func (s *store) OnConfigChange(observer ConfigChangeObserver) {
+	s.configChangeNotifier.addObserver(func(event interface{}) {
+		e := event.(configChangeEvent)
+		observer(e.shardID, e.result)
+	})
+}
+
+func (s *store) OnLeaderChange(observer LeaderChangeObserver) {
+	s.leaderChangeNotifier.addObserver(func(event interface{}) {
+		e := event.(leaderChangeEvent)
+		observer(e.shardID, e.leaderID, e.term)
+	})
+}
+
+func (s *store) OnShardUnavailable(observer ShardUnavailableObserver) {
+	s.unavailableNotifier.addObserver(func(event interface{}) {
+		observer(event.(uint64))
+	})
+}
+
+func (s *store) addConfigChange(shardID uint64, req rpcpb.ConfigChangeRequest) error {
+	if req.Replica.ID == 0 || req.Replica.StoreID == 0 {
+		return ErrInvalidConfigChangeRequest
+	}
+
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return errShardNotFound
+	}
+	if !pr.isLeader() {
+		return errNotLeader
+	}
+
+	s.logger.Info("send conf change request",
+		s.storeField(),
+		log.ShardIDField(shardID),
+		log.ConfigChangeField("change", &req))
+	return pr.tryAddAdminRequest(rpcpb.CmdConfigChange, &req)
+}
+
+func (s *store) ShardReadStats(shardID uint64) (uint64, uint64, bool) {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return 0, 0, false
+	}
+
+	stats := pr.stats.getReadStats()
+	return stats.ReadBytes, stats.ReadKeys, true
+}
+
+func (s *store) ShardsReadStats() map[uint64]ReadStats {
+	values := make(map[uint64]ReadStats)
+	s.forEachReplica(func(pr *replica) bool {
+		values[pr.shardID] = pr.stats.getReadStats()
+		return true
+	})
+	return values
+}
+
+func (s *store) ShardConfigHistory(shardID uint64) []ConfigChangeRecord {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return nil
+	}
+	return pr.sm.configChangeHistory.get()
+}
+
+func (s *store) ReplicaHealth(shardID uint64) (time.Time, uint64, uint64, bool) {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return time.Time{}, 0, 0, false
+	}
+	return pr.getLastProgress(), pr.getTickHandledCount(), pr.getTickTotalCount(), true
+}
+
+func (s *store) ShardProgress(ctx context.Context, shardID uint64) (map[uint64]ReplicaProgress, bool) {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return nil, false
+	}
+	c := make(chan map[uint64]ReplicaProgress, 1)
+	if err := pr.addAction(action{
+		actionType: getProgressAction,
+		actionCallback: func(arg interface{}) {
+			c <- arg.(map[uint64]ReplicaProgress)
+		},
+	}); err != nil {
+		return nil, false
+	}
+	select {
+	case progress := <-c:
+		return progress, progress != nil
+	case <-pr.closedC:
+		return nil, false
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+func (s *store) OnStaleReadWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBatch)) error {
+	pr := s.getReplica(req.ToShard, false)
+	if pr == nil {
+		return ErrStaleReadNotAllowed
+	}
+	return pr.staleRead(req, cb)
+}
+
+func (s *store) GroupCompactionStats() map[uint64]CompactionStats {
+	return s.compactionStats.get()
+}
+
+func (s *store) HostedGroups() []uint64 {
+	groups := make(map[uint64]struct{})
+	s.forEachReplica(func(pr *replica) bool {
+		groups[pr.getShard().Group] = struct{}{}
+		return true
+	})
+	values := make([]uint64, 0, len(groups))
+	for group := range groups {
+		values = append(values, group)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+func (s *store) QuarantinedShards() []uint64 {
+	var values []uint64
+	s.forEachReplica(func(pr *replica) bool {
+		if pr.sm.isQuarantined() {
+			values = append(values, pr.shardID)
+		}
+		return true
+	})
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+func (s *store) ReplicasDebugState() []ReplicaDebugState {
+	var values []ReplicaDebugState
+	s.forEachReplica(func(pr *replica) bool {
+		values = append(values, pr.DebugState())
+		return true
+	})
+	sort.Slice(values, func(i, j int) bool { return values[i].ShardID < values[j].ShardID })
+	return values
+}
+
+func (s *store) InFlightSnapshots() int {
+	return int(atomic.LoadInt64(&s.inFlightSnapshots))
+}
+
+func (s *store) snapshotStarted() {
+	atomic.AddInt64(&s.inFlightSnapshots, 1)
+}
+
+func (s *store) snapshotCompleted() {
+	atomic.AddInt64(&s.inFlightSnapshots, -1)
+}
+
+func newCompactionSnapshotLimiter(rate float64) *ratelimit.Bucket {
+	if rate <= 0 {
+		return nil
+	}
+	return ratelimit.NewBucketWithRate(rate, int64(math.Ceil(rate)))
+}
+
+// allowCompactionSnapshots returns true if the log compaction inducing the
+// specified number of snapshots is allowed by the rate limit. Up to one second
+// of snapshots are allowed in a burst, so a log compaction inducing more
+// snapshots than the burst is allowed once the bucket is full.
+func (s *store) allowCompactionSnapshots(n int) bool {
+	if s.compactionSnapshotLimiter == nil || n == 0 {
+		return true
+	}
+	count := int64(n)
+	if max := s.compactionSnapshotLimiter.Capacity(); count > max {
+		count = max
+	}
+	_, ok := s.compactionSnapshotLimiter.TakeMaxDuration(count, 0)
+	return ok
+}
+
 func (s *store) MustAllocID() uint64 {
 	for {
 		id, err := s.pd.GetClient().AllocID()
@@ -470,6 +941,19 @@ func (s *store) startProphet() {
 	s.shardPool.setProphetClient(s.pd.GetClient())
 }
 
+// startShardMetrics registers the collector of the metrics labeled by shard ID
+// once the store ID is known, before any replica is created.
+func (s *store) startShardMetrics() {
+	if s.cfg.Metric.MaxShardLabels <= 0 {
+		return
+	}
+	s.shardMetrics = metric.NewShardCollector(s.Meta().ID, s.cfg.Metric.MaxShardLabels)
+	metric.MustRegister(s.shardMetrics)
+	s.logger.Info("shard metrics started",
+		s.storeField(),
+		zap.Int("max-shard-labels", s.cfg.Metric.MaxShardLabels))
+}
+
 func (s *store) createTransport() {
 	s.trans = transport.NewTransport(s.logger,
 		s.cfg.RaftAddr, s.Meta().ID, s.handle, s.unreachable, s.snapshotStatus,
@@ -498,7 +982,7 @@ func (s *store) startShards() {
 	shards := make(map[uint64]metapb.ShardLocalState)
 	localDestroyings := make(map[uint64]metapb.ShardMetadata)
 	confirmShards := roaring64.New()
-	s.cfg.Storage.ForeachDataStorageFunc(func(group uint64, ds storage.DataStorage) {
+	s.forEachDataStorage(func(group uint64, ds storage.DataStorage) {
 		initStates, err := ds.GetInitialStates()
 		if err != nil {
 			s.logger.Fatal("fail to get initial state",
@@ -564,6 +1048,14 @@ func (s *store) startShards() {
 	var readyBootstrapShards []Shard
 	leases := make(map[uint64]*metapb.EpochLease)
 	for _, sls := range shards {
+		if err := s.checkShardConsistency(sls.Shard); err != nil {
+			s.logger.Error("replica refused to start",
+				s.storeField(),
+				log.ShardField("shard", sls.Shard),
+				zap.Error(err))
+			s.addRefusedShard(sls.Shard.ID)
+			continue
+		}
 		readyBootstrapShards = append(readyBootstrapShards, sls.Shard)
 		leases[sls.Shard.ID] = sls.Lease
 	}
@@ -597,6 +1089,12 @@ func (s *store) addReplica(pr *replica) bool {
 
 func (s *store) removeReplica(shard Shard) {
 	s.replicas.Delete(shard.ID)
+	s.shardMetrics.Remove(shard.ID)
+	metric.DeleteShardUnavailable(shard.ID)
+	metric.DeleteShardApplyRate(shard.ID)
+	metric.DeleteRaftLogSize(shard.ID)
+	metric.DeleteShardQPS(shard.ID)
+	metric.DeleteSnapshotApplyProgress(shard.ID)
 	if s.aware != nil {
 		s.aware.Destroyed(shard)
 	}
@@ -836,6 +1334,32 @@ func (s *store) searchShard(group uint64, key []byte) Shard {
 	return Shard{}
 }
 
+func (s *store) ShardForKey(group uint64, key []byte) (Shard, bool) {
+	shard := s.searchShard(group, key)
+	return shard, shard.ID != 0
+}
+
+func (s *store) ShardsInRange(group uint64, start, end []byte) []Shard {
+	value, ok := s.keyRanges.Load(group)
+	if !ok {
+		return nil
+	}
+
+	// the key ranges of the shards on the current store may not be continuous,
+	// so the shards are not located by the start key
+	var shards []Shard
+	value.(*util.ShardTree).Ascend(func(shard *Shard) bool {
+		if len(end) > 0 && bytes.Compare(shard.Start, end) >= 0 {
+			return false
+		}
+		if len(shard.End) == 0 || bytes.Compare(shard.End, start) > 0 {
+			shards = append(shards, *shard)
+		}
+		return true
+	})
+	return shards
+}
+
 func (s *store) nextShard(shard Shard) *Shard {
 	if value, ok := s.keyRanges.Load(shard.Group); ok {
 		return value.(*util.ShardTree).NextShard(shard.Start)
@@ -851,6 +1375,26 @@ func (s *store) storeField() zap.Field {
 	return log.StoreIDField(s.meta.GetID())
 }
 
+// isDecommissioningStore returns true if the store is offline and its replicas
+// are being moved out. Prophet marks the offline stores as StoreState_Down, see
+// core.OfflineStore. An offline store which is physically destroyed can not be
+// reached any more, its replicas are removed but it is not a decommission, so
+// errStoreDestroyed is returned. The store metadata is read from the router
+// cache, so it never blocks on prophet.
+func (s *store) isDecommissioningStore(storeID uint64) (bool, error) {
+	if s.router == nil {
+		return false, nil
+	}
+	v, ok := s.router.GetStore(storeID)
+	if !ok || v.State != metapb.StoreState_Down {
+		return false, nil
+	}
+	if v.Destroyed {
+		return false, errStoreDestroyed
+	}
+	return true, nil
+}
+
 func (s *store) containerResolver(storeID uint64) (string, error) {
 	container, err := s.pd.GetStorage().GetStore(storeID)
 	if err != nil {
@@ -905,6 +1449,17 @@ func (s *store) getReplicaCount() uint64 {
 	return n
 }
 
+func (s *store) heartbeatSucceeded() {
+	if atomic.SwapUint64(&s.heartbeatFailures, 0) > 0 {
+		metric.SetProphetHeartbeatFailures(s.Meta().ID, 0)
+	}
+}
+
+func (s *store) heartbeatFailed() {
+	metric.SetProphetHeartbeatFailures(s.Meta().ID,
+		atomic.AddUint64(&s.heartbeatFailures, 1))
+}
+
 func (s *store) isShardUnavailable(id uint64) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -928,6 +1483,19 @@ func (s *store) addUnavailableShardWithIds(shards *roaring64.Bitmap) {
 	s.mu.Unlock()
 }
 
+func (s *store) isShardRefused(id uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.mu.refusedShards.Contains(id)
+}
+
+func (s *store) addRefusedShard(id uint64) {
+	s.mu.Lock()
+	s.mu.refusedShards.Add(id)
+	s.mu.Unlock()
+}
+
 type storeReplicaGetter struct {
 	store *store
 }
@@ -1008,7 +1576,7 @@ func (s *store) getStoreHeartbeat(last time.Time) (rpcpb.StoreHeartbeatReq, erro
 	stats.SendingSnapCount = s.trans.SendingSnapshotCount()
 	stats.StartTime = uint64(s.Meta().StartTime)
 
-	s.cfg.Storage.ForeachDataStorageFunc(func(_ uint64, db storage.DataStorage) {
+	s.forEachDataStorage(func(_ uint64, db storage.DataStorage) {
 		st := db.Stats()
 		stats.WrittenBytes += st.WrittenBytes
 		stats.WrittenKeys += st.WrittenKeys
@@ -0,0 +1,93 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"fmt"
+
+	"go.etcd.io/etcd/raft/v3"
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/logdb"
+)
+
+var (
+	// errLogBehindDataStorage the raft log in logdb misses the entries the data
+	// storage claims to have applied
+	errLogBehindDataStorage = errors.New("raft log is behind the data storage")
+	// errAppliedNotCommitted the data storage claims to have applied the entries
+	// which are not committed
+	errAppliedNotCommitted = errors.New("data storage applied uncommitted entries")
+)
+
+// checkShardConsistency cross-checks the applied index persisted by the data
+// storage against the raft log persisted in logdb for the local replica of the
+// shard, they can disagree after a crash if the storages are not synced as
+// expected. An inconsistent replica must not be started.
+func (s *store) checkShardConsistency(shard Shard) error {
+	replica := findReplica(shard, s.Meta().ID)
+	if replica == nil {
+		return nil
+	}
+	applied, err := s.DataStorageByGroup(shard.Group).GetPersistentLogIndex(shard.ID)
+	if err != nil {
+		return err
+	}
+	// replicas are created with the metadata at index 1, the raft log of the
+	// replicas not being the initial members is never saved at that index
+	if applied <= 1 {
+		return nil
+	}
+
+	ss, err := s.logdb.GetSnapshot(shard.ID)
+	if err != nil && err != logdb.ErrNoSnapshot {
+		return err
+	}
+	committed, last := ss.Metadata.Index, ss.Metadata.Index
+	rs, err := s.logdb.ReadRaftState(shard.ID, replica.ID, ss.Metadata.Index)
+	if err != nil && !errors.Is(err, logdb.ErrNoSavedLog) {
+		return err
+	}
+	if err == nil {
+		if !raft.IsEmptyHardState(rs.State) && rs.State.Commit > committed {
+			committed = rs.State.Commit
+		}
+		if rs.EntryCount > 0 && rs.FirstIndex+rs.EntryCount-1 > last {
+			last = rs.FirstIndex + rs.EntryCount - 1
+		}
+	}
+
+	var inconsistent error
+	if applied > last {
+		inconsistent = errLogBehindDataStorage
+	} else if applied > committed {
+		inconsistent = errAppliedNotCommitted
+	}
+	if inconsistent != nil {
+		s.logger.Error("inconsistent logdb and data storage",
+			s.storeField(),
+			log.ShardIDField(shard.ID),
+			log.ReplicaIDField(replica.ID),
+			zap.Uint64("applied-index", applied),
+			zap.Uint64("committed-index", committed),
+			zap.Uint64("first-index", rs.FirstIndex),
+			zap.Uint64("last-index", last),
+			zap.Uint64("snapshot-index", ss.Metadata.Index),
+			zap.Error(inconsistent))
+		return fmt.Errorf("shard %d: %w", shard.ID, inconsistent)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestCheckShardConsistency(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	newShard := func(id, applied, lastIndex, committed uint64) Shard {
+		shard := Shard{ID: id, Replicas: []Replica{{ID: id + 100, StoreID: s.Meta().ID}}}
+		ds := s.DataStorageByGroup(0)
+		require.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{
+			{ShardID: id, LogIndex: applied, Metadata: metapb.ShardLocalState{Shard: shard}},
+		}))
+		require.NoError(t, ds.Sync([]uint64{id}))
+		if lastIndex == 0 {
+			return shard
+		}
+		var entries []raftpb.Entry
+		for i := uint64(1); i <= lastIndex; i++ {
+			entries = append(entries, raftpb.Entry{Index: i, Term: 1})
+		}
+		wc := s.logdb.NewWorkerContext()
+		defer wc.Close()
+		require.NoError(t, s.logdb.SaveRaftState(id, id+100, raft.Ready{
+			Entries:   entries,
+			HardState: raftpb.HardState{Term: 1, Commit: committed},
+		}, wc))
+		return shard
+	}
+
+	// consistent
+	assert.NoError(t, s.checkShardConsistency(newShard(1, 10, 10, 10)))
+	assert.NoError(t, s.checkShardConsistency(newShard(2, 10, 20, 15)))
+	// created but nothing applied
+	assert.NoError(t, s.checkShardConsistency(newShard(3, 1, 0, 0)))
+	// log behind the data storage
+	err := s.checkShardConsistency(newShard(4, 10, 5, 5))
+	assert.True(t, errors.Is(err, errLogBehindDataStorage))
+	err = s.checkShardConsistency(newShard(5, 10, 0, 0))
+	assert.True(t, errors.Is(err, errLogBehindDataStorage))
+	// applied but not committed, impossible
+	err = s.checkShardConsistency(newShard(6, 10, 20, 5))
+	assert.True(t, errors.Is(err, errAppliedNotCommitted))
+}
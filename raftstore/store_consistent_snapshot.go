@@ -0,0 +1,122 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+
+	"go.etcd.io/etcd/raft/v3/raftpb"
+	"go.uber.org/zap"
+)
+
+// CreateConsistentSnapshot freezes all the shard replicas at the same time and
+// creates their snapshots once all of them are frozen. A frozen replica neither
+// applies nor acknowledges any write until all the snapshots are created, so a
+// write acknowledged before the write of any other shard was issued is always
+// included when the latter is, the snapshots are mutually consistent for
+// backups. The replicas frozen earlier keep waiting for the others, the whole
+// operation is bounded by Raft.MaxFreezeDuration. ErrFreezeLost is returned if
+// any replica lost its freeze, e.g. the max freeze duration is exceeded, before
+// all the snapshots are created.
+func (s *store) CreateConsistentSnapshot(shardIDs []uint64) (map[uint64]raftpb.Snapshot, error) {
+	var replicas []*replica
+	var notLeaders []uint64
+	for _, id := range shardIDs {
+		pr := s.getReplica(id, true)
+		if pr == nil {
+			notLeaders = append(notLeaders, id)
+			continue
+		}
+		replicas = append(replicas, pr)
+	}
+	if len(notLeaders) > 0 {
+		return nil, fmt.Errorf("shards %v: %w", notLeaders, errNotLeader)
+	}
+
+	// a failed Freeze may still freeze the replica later, all the replicas are
+	// unfrozen on errors
+	unfrozen := false
+	defer func() {
+		if !unfrozen {
+			for _, pr := range replicas {
+				_ = pr.Unfreeze()
+			}
+		}
+	}()
+	type freezeResult struct {
+		index uint64
+		err   error
+	}
+	results := make([]chan freezeResult, len(replicas))
+	for i, pr := range replicas {
+		results[i] = make(chan freezeResult, 1)
+		go func(pr *replica, c chan freezeResult) {
+			index, err := pr.Freeze()
+			c <- freezeResult{index: index, err: err}
+		}(pr, results[i])
+	}
+	barrier := make([]uint64, 0, len(replicas))
+	var freezeErr error
+	for i, pr := range replicas {
+		result := <-results[i]
+		if result.err != nil && freezeErr == nil {
+			freezeErr = fmt.Errorf("shard %d: %w", pr.shardID, result.err)
+		}
+		barrier = append(barrier, result.index)
+	}
+	if freezeErr != nil {
+		return nil, freezeErr
+	}
+	s.logger.Info("consistent snapshot barrier reached",
+		s.storeField(),
+		zap.Uint64s("shards", shardIDs),
+		zap.Uint64s("applied-indexes", barrier))
+
+	snapshots := make(map[uint64]raftpb.Snapshot, len(replicas))
+	for _, pr := range replicas {
+		c := make(chan createSnapshotResult, 1)
+		if err := pr.addAction(action{
+			actionType: createSnapshotAction,
+			actionCallback: func(arg interface{}) {
+				c <- arg.(createSnapshotResult)
+			},
+		}); err != nil {
+			return nil, fmt.Errorf("shard %d: %w", pr.shardID, err)
+		}
+		select {
+		case result := <-c:
+			if result.err != nil {
+				return nil, fmt.Errorf("shard %d: %w", pr.shardID, result.err)
+			}
+			snapshots[pr.shardID] = result.snapshot
+		case <-pr.closedC:
+			return nil, fmt.Errorf("shard %d: %w", pr.shardID, ErrReplicaStopped)
+		}
+	}
+
+	unfrozen = true
+	var lost []uint64
+	for _, pr := range replicas {
+		if err := pr.Unfreeze(); err != nil {
+			lost = append(lost, pr.shardID)
+		}
+	}
+	if len(lost) > 0 {
+		return nil, fmt.Errorf("shards %v: %w", lost, ErrFreezeLost)
+	}
+	s.logger.Info("consistent snapshot created",
+		s.storeField(),
+		zap.Int("shards", len(snapshots)))
+	return snapshots, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/storage"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestCreateConsistentSnapshot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	c := NewSingleTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Customize.CustomInitShardsFactory = func() []Shard {
+				return []Shard{{Start: []byte("a"), End: []byte("b")}, {Start: []byte("b"), End: []byte("c")}}
+			}
+		}))
+	c.Start()
+	defer c.Stop()
+	c.WaitShardByCountPerNode(2, testWaitTimeout)
+	c.WaitLeadersByCount(2, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	require.NoError(t, kv.Set("a1", "v1", testWaitTimeout))
+	require.NoError(t, kv.Set("b1", "v1", testWaitTimeout))
+
+	s := c.GetStore(0).(*store)
+	shards := []uint64{c.GetShardByIndex(0, 0).ID, c.GetShardByIndex(0, 1).ID}
+	_, err := s.CreateConsistentSnapshot(append(shards, 10000))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errNotLeader))
+	assert.Contains(t, err.Error(), "10000")
+
+	snapshots, err := s.CreateConsistentSnapshot(shards)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(snapshots))
+	keys := map[uint64]string{shards[0]: "a1", shards[1]: "b1"}
+	for _, id := range shards {
+		pr := s.getReplica(id, false)
+		require.NotNil(t, pr)
+		ss, ok := snapshots[id]
+		require.True(t, ok)
+		index, _ := pr.sm.getAppliedIndexTerm()
+		assert.Equal(t, index, ss.Metadata.Index)
+
+		ds := newTestMemDataStorage(s.cfg.FS)
+		env := pr.snapshotter.getRecoverSnapshotEnv(ss)
+		require.NoError(t, ds.ApplySnapshot(id, env.GetFinalDir()))
+		v, err := ds.(storage.KVStorageWrapper).GetKVStorage().Get(keysutil.EncodeDataKey([]byte(keys[id]), nil))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1"), v)
+		require.NoError(t, ds.Close())
+	}
+
+	// the shards are unfrozen once the snapshots are created
+	require.NoError(t, kv.Set("a2", "v2", testWaitTimeout))
+	require.NoError(t, kv.Set("b2", "v2", testWaitTimeout))
+}
+
+// slowSnapshotDataStorage delays the creation of snapshots
+type slowSnapshotDataStorage struct {
+	storage.DataStorage
+	delay time.Duration
+}
+
+func (s slowSnapshotDataStorage) CreateSnapshot(shardID uint64, path string) error {
+	time.Sleep(s.delay)
+	return s.DataStorage.CreateSnapshot(shardID, path)
+}
+
+func TestCreateConsistentSnapshotWithFreezeLost(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	var ds storage.DataStorage
+	c := NewSingleTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Raft.MaxFreezeDuration.Duration = time.Second
+			cfg.Customize.CustomInitShardsFactory = func() []Shard {
+				return []Shard{{Start: []byte("a"), End: []byte("b")}, {Start: []byte("b"), End: []byte("c")}}
+			}
+			// the freezes expire while the snapshots are being created
+			ds = slowSnapshotDataStorage{DataStorage: newTestMemDataStorage(cfg.FS),
+				delay: cfg.Raft.MaxFreezeDuration.Duration * 2}
+			cfg.Storage.DataStorageFactory = func(group uint64) storage.DataStorage {
+				return ds
+			}
+			cfg.Storage.ForeachDataStorageFunc = func(cb func(uint64, storage.DataStorage)) {
+				cb(0, ds)
+			}
+		}))
+	c.Start()
+	defer func() {
+		c.Stop()
+		require.NoError(t, ds.Close())
+	}()
+	c.WaitShardByCountPerNode(2, testWaitTimeout)
+	c.WaitLeadersByCount(2, testWaitTimeout)
+
+	s := c.GetStore(0).(*store)
+	shards := []uint64{c.GetShardByIndex(0, 0).ID, c.GetShardByIndex(0, 1).ID}
+	_, err := s.CreateConsistentSnapshot(shards)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrFreezeLost))
+
+	// the shards are unfrozen
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	require.NoError(t, kv.Set("a1", "v1", testWaitTimeout))
+	require.NoError(t, kv.Set("b1", "v1", testWaitTimeout))
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/matrixorigin/matrixcube/storage"
+)
+
+// dataStorageRegistry holds the data storages registered to the store by
+// RegisterDataStorage, they take precedence over the data storages provided by
+// Config.Storage. The registry is frozen once the store is started, so the
+// replicas always resolve the same data storage of their groups.
+type dataStorageRegistry struct {
+	sync.RWMutex
+	started  bool
+	storages map[uint64]storage.DataStorage
+}
+
+func (r *dataStorageRegistry) register(group uint64, ds storage.DataStorage) error {
+	r.Lock()
+	defer r.Unlock()
+	if r.started {
+		return ErrStoreStarted
+	}
+	if _, ok := r.storages[group]; ok {
+		return ErrDataStorageRegistered
+	}
+	if r.storages == nil {
+		r.storages = make(map[uint64]storage.DataStorage)
+	}
+	r.storages[group] = ds
+	return nil
+}
+
+func (r *dataStorageRegistry) get(group uint64) (storage.DataStorage, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	ds, ok := r.storages[group]
+	return ds, ok
+}
+
+// freeze marks the store as started and returns the registered groups.
+func (r *dataStorageRegistry) freeze() []uint64 {
+	r.Lock()
+	r.started = true
+	r.Unlock()
+	return r.groups()
+}
+
+// groups returns the registered groups in order.
+func (r *dataStorageRegistry) groups() []uint64 {
+	r.RLock()
+	defer r.RUnlock()
+	groups := make([]uint64, 0, len(r.storages))
+	for g := range r.storages {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i] < groups[j] })
+	return groups
+}
+
+// RegisterDataStorage registers the data storage of the shard group, so the
+// shard groups can use different storage engines. It must be invoked before
+// the store is started, and the group must not have a data storage provided by
+// Config.Storage.ForeachDataStorageFunc.
+func (s *store) RegisterDataStorage(group uint64, ds storage.DataStorage) error {
+	registered := false
+	s.cfg.Storage.ForeachDataStorageFunc(func(g uint64, _ storage.DataStorage) {
+		registered = registered || g == group
+	})
+	if registered {
+		return ErrDataStorageRegistered
+	}
+	return s.dataStorages.register(group, ds)
+}
+
+func (s *store) DataStorageByGroup(group uint64) storage.DataStorage {
+	if ds, ok := s.dataStorages.get(group); ok {
+		return ds
+	}
+	return s.cfg.Storage.DataStorageFactory(group)
+}
+
+// forEachDataStorage invokes cb with the data storages provided by
+// Config.Storage and registered by RegisterDataStorage.
+func (s *store) forEachDataStorage(cb func(uint64, storage.DataStorage)) {
+	s.cfg.Storage.ForeachDataStorageFunc(func(group uint64, ds storage.DataStorage) {
+		if _, ok := s.dataStorages.get(group); !ok {
+			cb(group, ds)
+		}
+	})
+	for _, g := range s.dataStorages.groups() {
+		ds, _ := s.dataStorages.get(g)
+		cb(g, ds)
+	}
+}
+
+// freezeDataStorages rejects the data storages registered later, and adds the
+// registered groups to the groups scheduled by the prophet.
+func (s *store) freezeDataStorages() {
+	for _, g := range s.dataStorages.freeze() {
+		found := false
+		for _, v := range s.cfg.Prophet.Replication.Groups {
+			found = found || v == g
+		}
+		if !found {
+			s.cfg.Prophet.Replication.Groups = append(s.cfg.Prophet.Replication.Groups, g)
+		}
+	}
+}
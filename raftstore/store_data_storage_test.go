@@ -0,0 +1,113 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/executor"
+	"github.com/matrixorigin/matrixcube/storage/kv"
+	"github.com/matrixorigin/matrixcube/storage/kv/mem"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+func newTestMemDataStorage(fs vfs.FS) storage.DataStorage {
+	kvs := mem.NewStorage()
+	return kv.NewKVDataStorage(kv.NewBaseStorage(kvs, fs), executor.NewKVExecutor(kvs))
+}
+
+func TestRegisterDataStorage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	ds := newTestMemDataStorage(s.cfg.FS)
+	defer ds.Close()
+	assert.NoError(t, s.RegisterDataStorage(1, ds))
+	assert.Equal(t, ErrDataStorageRegistered, s.RegisterDataStorage(1, ds))
+	// group 0 is provided by the config
+	assert.Equal(t, ErrDataStorageRegistered, s.RegisterDataStorage(0, ds))
+
+	assert.Equal(t, ds, s.DataStorageByGroup(1))
+	assert.NotEqual(t, ds, s.DataStorageByGroup(0))
+	var groups []uint64
+	s.forEachDataStorage(func(group uint64, _ storage.DataStorage) {
+		groups = append(groups, group)
+	})
+	assert.Equal(t, []uint64{0, 1}, groups)
+
+	s.freezeDataStorages()
+	assert.Equal(t, ErrStoreStarted, s.RegisterDataStorage(2, ds))
+	assert.Equal(t, []uint64{0, 1}, s.cfg.Prophet.Replication.Groups)
+}
+
+func TestRegisteredDataStorageReceivesWritesOfGroup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	var registered storage.DataStorage
+	c := NewSingleTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Customize.CustomInitShardsFactory = func() []Shard {
+				return []Shard{{Start: []byte("a"), End: []byte("b")}, {Group: 1, Start: []byte("a"), End: []byte("b")}}
+			}
+		}),
+		WithTestClusterStoreFactory(func(node int, cfg *config.Config) Store {
+			s := NewStore(cfg)
+			registered = newTestMemDataStorage(cfg.FS)
+			require.NoError(t, s.RegisterDataStorage(1, registered))
+			return s
+		}))
+	c.Start()
+	defer func() {
+		c.Stop()
+		assert.NoError(t, registered.Close())
+	}()
+	c.WaitShardByCountPerNode(2, testWaitTimeout)
+	c.WaitLeadersByCount(2, testWaitTimeout)
+
+	// the clients of a store share the same proxy, use one client for all groups
+	group := uint64(0)
+	kv := c.CreateTestKVClientWithAdjust(0, func(req *rpcpb.Request) {
+		req.Group = group
+	})
+	defer kv.Close()
+	assert.NoError(t, kv.Set("a0", "v0", testWaitTimeout))
+	group = 1
+	assert.NoError(t, kv.Set("a1", "v1", testWaitTimeout))
+
+	has := func(ds storage.DataStorage, key string) bool {
+		v, err := ds.(storage.KVStorageWrapper).GetKVStorage().Get(keysutil.EncodeDataKey([]byte(key), nil))
+		require.NoError(t, err)
+		return len(v) > 0
+	}
+	configured := c.(*testRaftCluster).dataStorages[0]
+	assert.True(t, has(configured, "a0"))
+	assert.False(t, has(configured, "a1"))
+	assert.True(t, has(registered, "a1"))
+	assert.False(t, has(registered, "a0"))
+}
@@ -74,6 +74,17 @@ func (s *store) handleDestroyReplicaMessage(msg metapb.RaftMessage) {
 	if pr := s.getReplica(shardID, false); pr != nil {
 		fromEpoch := msg.ShardEpoch
 		shard := pr.getShard()
+		// the tombstone message may be sent before the local replica catches up
+		// with a newer epoch in any dimension, e.g. it is re-added by the leader,
+		// such stale tombstone is ignored.
+		if isEpochStale(fromEpoch, shard.Epoch) {
+			s.logger.Info("received stale destroy message, ignored",
+				s.storeField(),
+				log.ShardIDField(shardID),
+				log.EpochField("self-epoch", shard.Epoch),
+				log.EpochField("msg-epoch", fromEpoch))
+			return
+		}
 		if isEpochStale(shard.Epoch, fromEpoch) {
 			s.logger.Info("received destroy message, remove self",
 				s.storeField(),
@@ -175,6 +186,15 @@ func (s *store) tryToCreateReplicate(msg metapb.RaftMessage) bool {
 		return false
 	}
 
+	// the local data of the shard is inconsistent, see checkShardConsistency
+	if s.isShardRefused(msg.ShardID) {
+		s.logger.Debug("skip create replica",
+			s.storeField(),
+			log.ReasonField("shard refused to start"),
+			log.ShardIDField(msg.ShardID))
+		return false
+	}
+
 	if s.createShardsProtector.inDestroyState(msg.ShardID) {
 		s.logger.Debug("skip create replica",
 			s.storeField(),
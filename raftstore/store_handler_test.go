@@ -35,6 +35,7 @@ func TestTryToCreateReplicate(t *testing.T) {
 		msg        metapb.RaftMessage
 		ok         bool
 		checkCache bool
+		refused    bool
 	}{
 		{
 			name: "normal",
@@ -77,6 +78,12 @@ func TestTryToCreateReplicate(t *testing.T) {
 			msg:   metapb.RaftMessage{To: Replica{ID: 2}, ShardID: 1, Message: raftpb.Message{Type: raftpb.MsgVote}, Start: []byte("b"), End: []byte("c")},
 			ok:    true,
 		},
+		{
+			name:    "refused to start",
+			msg:     metapb.RaftMessage{To: Replica{ID: 2}, ShardID: 1, Message: raftpb.Message{Type: raftpb.MsgVote}, Start: []byte("b"), End: []byte("c")},
+			ok:      false,
+			refused: true,
+		},
 	}
 
 	for idx, c := range cases {
@@ -101,6 +108,10 @@ func TestTryToCreateReplicate(t *testing.T) {
 				s.updateShardKeyRange(c.pr.getShard().Group, c.pr.getShard())
 			}
 
+			if c.refused {
+				s.addRefusedShard(c.msg.ShardID)
+			}
+
 			c.msg.From = Replica{ID: 100, StoreID: 1000}
 			assert.Equal(t, c.ok, s.tryToCreateReplicate(c.msg), "index %d", idx)
 			if c.checkCache {
@@ -127,10 +138,12 @@ func TestHandleDestroyReplicaMessage(t *testing.T) {
 		unloadedC:         make(chan struct{}),
 		store:             s,
 		logger:            s.logger,
+		clock:             realClock{},
 		ticks:             task.New(32),
 		messages:          task.New(32),
 		requests:          task.New(32),
 		actions:           task.New(32),
+		priorityActions:   task.New(32),
 		feedbacks:         task.New(32),
 		pendingProposals:  newPendingProposals(),
 		incomingProposals: newProposalBatch(s.logger, 10, 1, r),
@@ -145,7 +158,15 @@ func TestHandleDestroyReplicaMessage(t *testing.T) {
 	s.addReplica(pr)
 
 	assert.NotNil(t, s.getReplica(1, false))
-	s.handleDestroyReplicaMessage(metapb.RaftMessage{IsTombstone: true, ShardID: 1, ShardEpoch: Epoch{Generation: 1}})
+	// stale tombstones are ignored
+	pr.sm.updateShard(Shard{ID: pr.shardID, Replicas: []Replica{pr.replica}, Epoch: Epoch{ConfigVer: 2}})
+	s.handleDestroyReplicaMessage(metapb.RaftMessage{IsTombstone: true, ShardID: 1, ShardEpoch: Epoch{ConfigVer: 1}})
+	s.handleDestroyReplicaMessage(metapb.RaftMessage{IsTombstone: true, ShardID: 1, ShardEpoch: Epoch{ConfigVer: 2}})
+	s.handleDestroyReplicaMessage(metapb.RaftMessage{IsTombstone: true, ShardID: 1, ShardEpoch: Epoch{Generation: 1, ConfigVer: 1}})
+	assert.False(t, pr.closed())
+	assert.NotNil(t, s.getReplica(1, false))
+
+	s.handleDestroyReplicaMessage(metapb.RaftMessage{IsTombstone: true, ShardID: 1, ShardEpoch: Epoch{Generation: 1, ConfigVer: 2}})
 	for {
 		if pr.closed() {
 			break
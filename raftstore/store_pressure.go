@@ -0,0 +1,103 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/util"
+)
+
+// storePressure is the resource pressure of the store attached to the shard
+// heartbeats, so prophet can avoid moving replicas onto already hot stores.
+type storePressure struct {
+	// diskUsage is the disk usage percentage
+	diskUsage float64
+	// cpuLoad is the CPU usage percentage since the last sample
+	cpuLoad float64
+}
+
+// storePressureSampler measures the resource pressure of the store.
+type storePressureSampler interface {
+	sample() (storePressure, error)
+}
+
+type defaultStorePressureSampler struct {
+	s *store
+}
+
+func newStorePressureSampler(s *store) storePressureSampler {
+	return &defaultStorePressureSampler{s: s}
+}
+
+func (p *defaultStorePressureSampler) sample() (storePressure, error) {
+	v, err := p.s.storageStatsReader.stats()
+	if err != nil {
+		return storePressure{}, err
+	}
+	capacity := v.capacity
+	if p.s.cfg.Capacity > 0 {
+		capacity = uint64(p.s.cfg.Capacity)
+	}
+	load, err := util.CPULoad()
+	if err != nil {
+		return storePressure{}, err
+	}
+
+	pressure := storePressure{cpuLoad: load}
+	if capacity > 0 {
+		pressure.diskUsage = float64(v.usedSize) * 100 / float64(capacity)
+	}
+	return pressure, nil
+}
+
+// storePressureCache caches the sampled store pressure for an interval, so the
+// heartbeats of the shards on the store do not each measure it again.
+type storePressureCache struct {
+	sampler  storePressureSampler
+	interval time.Duration
+
+	mu struct {
+		sync.Mutex
+		sampled  time.Time
+		pressure storePressure
+	}
+}
+
+func newStorePressureCache(sampler storePressureSampler, interval time.Duration) *storePressureCache {
+	return &storePressureCache{sampler: sampler, interval: interval}
+}
+
+// get returns the cached store pressure, it is sampled again once the cached
+// one is older than the interval. The last sample is kept for another interval
+// if sampling fails. A nil storePressureCache returns the zero pressure.
+func (c *storePressureCache) get(now time.Time) (storePressure, error) {
+	if c == nil {
+		return storePressure{}, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.mu.sampled.IsZero() && now.Sub(c.mu.sampled) < c.interval {
+		return c.mu.pressure, nil
+	}
+	c.mu.sampled = now
+	pressure, err := c.sampler.sample()
+	if err != nil {
+		return c.mu.pressure, err
+	}
+	c.mu.pressure = pressure
+	return pressure, nil
+}
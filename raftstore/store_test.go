@@ -14,11 +14,14 @@
 package raftstore
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/juju/ratelimit"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
@@ -27,7 +30,9 @@ import (
 	keysutil "github.com/matrixorigin/matrixcube/util/keys"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/matrixorigin/matrixcube/util/task"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/raft/v3/raftpb"
 )
 
@@ -102,6 +107,124 @@ func TestUpdateShardKeyRange(t *testing.T) {
 	assert.Equal(t, uint64(1), s.searchShard(0, []byte("b")).ID)
 }
 
+func TestShardForKey(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.updateShardKeyRange(1,
+		Shard{ID: 1, Group: 1, End: []byte("b")},
+		Shard{ID: 2, Group: 1, Start: []byte("b"), End: []byte("d")},
+		Shard{ID: 3, Group: 1, Start: []byte("d")})
+
+	cases := []struct {
+		key     []byte
+		shardID uint64
+	}{
+		{key: nil, shardID: 1},
+		{key: []byte("a"), shardID: 1},
+		// the end key is not included
+		{key: []byte("b"), shardID: 2},
+		{key: []byte("c"), shardID: 2},
+		{key: []byte("d"), shardID: 3},
+		// the last shard is unbounded
+		{key: []byte("zzzz"), shardID: 3},
+	}
+	for _, c := range cases {
+		shard, ok := s.ShardForKey(1, c.key)
+		assert.True(t, ok, "key %q", c.key)
+		assert.Equal(t, c.shardID, shard.ID, "key %q", c.key)
+	}
+
+	_, ok := s.ShardForKey(2, []byte("a"))
+	assert.False(t, ok)
+}
+
+func TestShardForKeyWithGaps(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.updateShardKeyRange(0,
+		Shard{ID: 1, Start: []byte("b"), End: []byte("c")},
+		Shard{ID: 2, Start: []byte("e"), End: []byte("f")})
+
+	_, ok := s.ShardForKey(0, []byte("a"))
+	assert.False(t, ok)
+	_, ok = s.ShardForKey(0, []byte("c"))
+	assert.False(t, ok)
+	shard, ok := s.ShardForKey(0, []byte("e"))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), shard.ID)
+	_, ok = s.ShardForKey(0, []byte("f"))
+	assert.False(t, ok)
+}
+
+func TestShardsInRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.updateShardKeyRange(1,
+		Shard{ID: 1, Group: 1, End: []byte("b")},
+		Shard{ID: 2, Group: 1, Start: []byte("b"), End: []byte("d")},
+		Shard{ID: 3, Group: 1, Start: []byte("d")})
+
+	cases := []struct {
+		start    []byte
+		end      []byte
+		shardIDs []uint64
+	}{
+		{start: nil, end: nil, shardIDs: []uint64{1, 2, 3}},
+		{start: nil, end: []byte("b"), shardIDs: []uint64{1}},
+		{start: nil, end: []byte("b1"), shardIDs: []uint64{1, 2}},
+		{start: []byte("a"), end: []byte("c"), shardIDs: []uint64{1, 2}},
+		{start: []byte("b"), end: []byte("d"), shardIDs: []uint64{2}},
+		{start: []byte("b"), end: []byte("d1"), shardIDs: []uint64{2, 3}},
+		{start: []byte("c"), end: nil, shardIDs: []uint64{2, 3}},
+		{start: []byte("d"), end: nil, shardIDs: []uint64{3}},
+		{start: []byte("x"), end: []byte("z"), shardIDs: []uint64{3}},
+	}
+	for _, c := range cases {
+		var ids []uint64
+		for _, shard := range s.ShardsInRange(1, c.start, c.end) {
+			ids = append(ids, shard.ID)
+		}
+		assert.Equal(t, c.shardIDs, ids, "range [%q, %q)", c.start, c.end)
+	}
+
+	assert.Empty(t, s.ShardsInRange(2, nil, nil))
+}
+
+func TestShardsInRangeWithGaps(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.updateShardKeyRange(0,
+		Shard{ID: 1, Start: []byte("b"), End: []byte("c")},
+		Shard{ID: 2, Start: []byte("e"), End: []byte("f")})
+
+	cases := []struct {
+		start    []byte
+		end      []byte
+		shardIDs []uint64
+	}{
+		{start: []byte("a"), end: []byte("z"), shardIDs: []uint64{1, 2}},
+		{start: []byte("c"), end: []byte("e"), shardIDs: nil},
+		{start: []byte("c"), end: []byte("e1"), shardIDs: []uint64{2}},
+		{start: []byte("a"), end: []byte("b"), shardIDs: nil},
+		{start: []byte("f"), end: nil, shardIDs: nil},
+	}
+	for _, c := range cases {
+		var ids []uint64
+		for _, shard := range s.ShardsInRange(0, c.start, c.end) {
+			ids = append(ids, shard.ID)
+		}
+		assert.Equal(t, c.shardIDs, ids, "range [%q, %q)", c.start, c.end)
+	}
+}
+
 func TestStoreSelectShard(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -152,13 +275,13 @@ func TestValidateShard(t *testing.T) {
 		ok    bool
 	}{
 		{
-			pr:  &replica{shardID: 1, startedC: make(chan struct{}), actions: task.New(32)},
+			pr:  &replica{shardID: 1, startedC: make(chan struct{}), actions: task.New(32), priorityActions: task.New(32)},
 			req: rpcpb.RequestBatch{},
 			err: errShardNotFound.Error(),
 			ok:  true,
 		},
 		{
-			pr:  &replica{replica: Replica{ID: 1}, startedC: make(chan struct{}), actions: task.New(32)},
+			pr:  &replica{replica: Replica{ID: 1}, startedC: make(chan struct{}), actions: task.New(32), priorityActions: task.New(32)},
 			req: rpcpb.RequestBatch{},
 			err: errNotLeader.Error(),
 			ok:  true,
@@ -169,13 +292,13 @@ func TestValidateShard(t *testing.T) {
 		// extra info when necessary and then use errors.Is(err, ErrMismatchedReplica)
 		// to do error comparison
 		{
-			pr:  &replica{replica: Replica{ID: 1}, leaderID: 1, startedC: make(chan struct{}), actions: task.New(32)},
+			pr:  &replica{replica: Replica{ID: 1}, leaderID: 1, startedC: make(chan struct{}), actions: task.New(32), priorityActions: task.New(32)},
 			req: rpcpb.RequestBatch{},
 			err: "mismatch replica id, want 1, but 0",
 			ok:  true,
 		},
 		{
-			pr:    &replica{replica: Replica{ID: 1}, leaderID: 1, startedC: make(chan struct{}), actions: task.New(32)},
+			pr:    &replica{replica: Replica{ID: 1}, leaderID: 1, startedC: make(chan struct{}), actions: task.New(32), priorityActions: task.New(32)},
 			epoch: Epoch{Generation: 1},
 			req:   rpcpb.RequestBatch{Header: rpcpb.RequestBatchHeader{Replica: Replica{ID: 1}}, Requests: []rpcpb.Request{{}}},
 			err:   errStaleEpoch.Error(),
@@ -372,7 +495,7 @@ func TestDoShardHeartbeatRsp(t *testing.T) {
 				ChangeType: metapb.ConfigChangeType_AddLearnerNode,
 			}},
 			fn: func(s *store) *replica {
-				pr := &replica{shardID: 1, startedC: make(chan struct{}), requests: task.New(32), actions: task.New(32)}
+				pr := &replica{shardID: 1, startedC: make(chan struct{}), requests: task.New(32), actions: task.New(32), priorityActions: task.New(32)}
 				pr.store = s
 				close(pr.startedC)
 				s.addReplica(pr)
@@ -389,7 +512,7 @@ func TestDoShardHeartbeatRsp(t *testing.T) {
 				Replica: metapb.Replica{ID: 1, StoreID: 1},
 			}},
 			fn: func(s *store) *replica {
-				pr := &replica{shardID: 1, startedC: make(chan struct{}), requests: task.New(32), actions: task.New(32)}
+				pr := &replica{shardID: 1, startedC: make(chan struct{}), requests: task.New(32), actions: task.New(32), priorityActions: task.New(32)}
 				pr.store = s
 				close(pr.startedC)
 				s.addReplica(pr)
@@ -420,6 +543,57 @@ func TestDoShardHeartbeatRsp(t *testing.T) {
 	}
 }
 
+func TestAddLearnerAndRemoveReplicaRequests(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.workerPool.close() // avoid admin request real handled by event worker
+
+	target := Replica{ID: 2, StoreID: 2}
+	assert.Equal(t, errShardNotFound, s.AddLearner(1, target))
+	assert.Equal(t, errShardNotFound, s.RemoveReplica(1, target))
+
+	pr := &replica{shardID: 1, replicaID: 1, startedC: make(chan struct{}), requests: task.New(32), actions: task.New(32), priorityActions: task.New(32)}
+	pr.store = s
+	close(pr.startedC)
+	pr.limiter = ratelimit.NewBucketWithRate(1<<30, 1<<30)
+	pr.sm = &stateMachine{}
+	pr.sm.metadataMu.shard = Shard{ID: 1}
+	s.addReplica(pr)
+
+	pr.setLeaderReplicaID(3)
+	assert.Equal(t, errNotLeader, s.AddLearner(1, target))
+	assert.Equal(t, errNotLeader, s.RemoveReplica(1, target))
+
+	pr.setLeaderReplicaID(1)
+	assert.Equal(t, ErrInvalidConfigChangeRequest, s.AddLearner(1, Replica{StoreID: 2}))
+	assert.NoError(t, s.AddLearner(1, target))
+	assert.NoError(t, s.RemoveReplica(1, target))
+
+	items := make([]interface{}, 2)
+	n, err := pr.requests.Get(2, items)
+	assert.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	expects := []rpcpb.ConfigChangeRequest{
+		{
+			ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+			Replica:    Replica{ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Learner},
+		},
+		{
+			ChangeType: metapb.ConfigChangeType_RemoveNode,
+			Replica:    target,
+		},
+	}
+	for i, expect := range expects {
+		req := items[i].(reqCtx).req
+		assert.Equal(t, uint64(rpcpb.CmdConfigChange), req.CustomType)
+		var ccr rpcpb.ConfigChangeRequest
+		protoc.MustUnmarshal(&ccr, req.Cmd)
+		assert.Equal(t, expect, ccr)
+	}
+}
+
 func TestOnRequestWithLease(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -489,3 +663,222 @@ func TestOnRequestWithLease(t *testing.T) {
 		}()
 	}
 }
+
+func TestOnRequestsWithCB(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr1 := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	pr2 := newTestReplica(Shard{ID: 2}, Replica{ID: 2}, s)
+	s.addReplica(pr1)
+	s.addReplica(pr2)
+
+	var resps []rpcpb.ResponseBatch
+	reqs := []rpcpb.Request{
+		{ID: []byte("r1"), Type: rpcpb.Write, ToShard: 1},
+		{ID: []byte("r2"), Type: rpcpb.Write, ToShard: 2},
+		{ID: []byte("r3"), Type: rpcpb.Write, ToShard: 1},
+		{ID: []byte("r4"), Type: rpcpb.Write, ToShard: 3},
+	}
+	s.OnRequestsWithCB(reqs, func(resp rpcpb.ResponseBatch) {
+		resps = append(resps, resp)
+	})
+	assert.Equal(t, int64(2), pr1.requests.Len())
+	assert.Equal(t, int64(1), pr2.requests.Len())
+
+	// shard 3 is not on the current store
+	require.Equal(t, 1, len(resps))
+	require.Equal(t, 1, len(resps[0].Responses))
+	assert.Equal(t, []byte("r4"), resps[0].Responses[0].ID)
+	assert.NotNil(t, resps[0].Header.Error.StoreMismatch)
+
+	// the requests of the same shard are checked together against the queue size
+	resps = resps[:0]
+	pr1.cfg.Raft.MaxRequestQueueSize = 3
+	s.OnRequestsWithCB([]rpcpb.Request{
+		{ID: []byte("r5"), Type: rpcpb.Write, ToShard: 1},
+		{ID: []byte("r6"), Type: rpcpb.Write, ToShard: 1},
+	}, func(resp rpcpb.ResponseBatch) {
+		resps = append(resps, resp)
+	})
+	assert.Equal(t, int64(3), pr1.requests.Len())
+	require.Equal(t, 1, len(resps))
+	require.Equal(t, 1, len(resps[0].Responses))
+	assert.Equal(t, []byte("r6"), resps[0].Responses[0].ID)
+	assert.NotNil(t, resps[0].Header.Error.ServerIsBusy)
+}
+
+func TestIsDecommissioningStore(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s := &store{}
+	decommissioning, err := s.isDecommissioningStore(1)
+	assert.NoError(t, err)
+	assert.False(t, decommissioning)
+
+	rr, err := newRouterBuilder().build(make(chan rpcpb.EventNotify))
+	require.NoError(t, err)
+	r := rr.(*defaultRouter)
+	s.router = r
+	for _, store := range []metapb.Store{
+		{ID: 1, State: metapb.StoreState_Up},
+		{ID: 2, State: metapb.StoreState_Down},
+		{ID: 3, State: metapb.StoreState_Down, Destroyed: true},
+		{ID: 4, State: metapb.StoreState_StoreTombstone},
+	} {
+		r.updateStoreLocked(protoc.MustMarshal(&store))
+	}
+
+	cases := []struct {
+		storeID         uint64
+		decommissioning bool
+		err             error
+	}{
+		{storeID: 1},
+		{storeID: 2, decommissioning: true},
+		{storeID: 3, err: errStoreDestroyed},
+		{storeID: 4},
+		{storeID: 5},
+	}
+	for i, c := range cases {
+		decommissioning, err := s.isDecommissioningStore(c.storeID)
+		assert.Equal(t, c.err, err, "index %d", i)
+		assert.Equal(t, c.decommissioning, decommissioning, "index %d", i)
+	}
+}
+
+func TestShardProgressNotAnswered(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	_, ok := s.ShardProgress(context.Background(), 1)
+	assert.False(t, ok)
+
+	// the event worker of the replica is not running
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	s.addReplica(pr)
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancelCtx()
+	_, ok = s.ShardProgress(ctx, 1)
+	assert.False(t, ok)
+
+	close(pr.closedC)
+	_, ok = s.ShardProgress(context.Background(), 1)
+	assert.False(t, ok)
+}
+
+func TestShardReadStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	_, _, ok := s.ShardReadStats(1)
+	assert.False(t, ok)
+
+	pr1 := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	pr2 := newTestReplica(Shard{ID: 2}, Replica{ID: 2}, s)
+	s.addReplica(pr1)
+	s.addReplica(pr2)
+
+	for i := 0; i < 3; i++ {
+		pr1.addAction(action{actionType: updateReadMetrics,
+			readMetrics: readMetrics{readBytes: 10, readKeys: 1}})
+	}
+	pr2.addAction(action{actionType: updateReadMetrics,
+		readMetrics: readMetrics{readBytes: 100, readKeys: 2}})
+	_, err := pr1.handleAction(make([]interface{}, readyBatchSize))
+	assert.NoError(t, err)
+	_, err = pr2.handleAction(make([]interface{}, readyBatchSize))
+	assert.NoError(t, err)
+
+	readBytes, readKeys, ok := s.ShardReadStats(1)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(30), readBytes)
+	assert.Equal(t, uint64(3), readKeys)
+
+	assert.Equal(t, map[uint64]ReadStats{
+		1: {ReadBytes: 30, ReadKeys: 3},
+		2: {ReadBytes: 100, ReadKeys: 2},
+	}, s.ShardsReadStats())
+}
+
+func TestStoreHostedGroups(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	assert.Empty(t, s.HostedGroups())
+
+	s.addReplica(newTestReplica(Shard{ID: 1, Group: 2}, Replica{ID: 1}, s))
+	s.addReplica(newTestReplica(Shard{ID: 2, Group: 0}, Replica{ID: 2}, s))
+	s.addReplica(newTestReplica(Shard{ID: 3, Group: 2}, Replica{ID: 3}, s))
+	s.addReplica(newTestReplica(Shard{ID: 4, Group: 1}, Replica{ID: 4}, s))
+	assert.Equal(t, []uint64{0, 1, 2}, s.HostedGroups())
+
+	s.removeReplica(s.getReplica(2, false).getShard())
+	assert.Equal(t, []uint64{1, 2}, s.HostedGroups())
+}
+
+func TestStoreShardMetrics(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewSingleTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Metric.MaxShardLabels = 1
+		}))
+	c.Start()
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	kv := c.CreateTestKVClient(0)
+	require.NoError(t, kv.Set("k1", "v1", testWaitTimeout))
+	kv.Close()
+
+	s := c.GetStore(0).(*store)
+	require.NotNil(t, s.shardMetrics)
+	pr := s.getReplica(c.GetShardByIndex(0, 0).ID, false)
+	require.NotNil(t, pr)
+	assert.Equal(t, s.shardMetrics.Shard(pr.shardID), pr.shardMetrics)
+	assert.Equal(t, pr.shardMetrics, pr.sm.shardMetrics)
+	assert.Equal(t, 1, testutil.CollectAndCount(s.shardMetrics,
+		"matrixcube_raftstore_shard_applied_total"))
+
+	// unregistered once the store is stopped
+	c.Stop()
+	assert.False(t, metric.Unregister(s.shardMetrics))
+}
+
+func TestRemoveReplicaDeletesShardGauges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	shardGauges := []struct {
+		name   string
+		labels map[string]string
+	}{
+		{"matrixcube_raftstore_shard_apply_entries_per_second", map[string]string{"shard": "104"}},
+		{"matrixcube_raftstore_raft_log_size_bytes", map[string]string{"shard": "104"}},
+		{"matrixcube_raftstore_shard_keys_per_second", map[string]string{"shard": "104", "type": "read"}},
+		{"matrixcube_raftstore_shard_keys_per_second", map[string]string{"shard": "104", "type": "write"}},
+		{"matrixcube_raftstore_snapshot_apply_progress_bytes", map[string]string{"shard": "104", "type": "applied"}},
+		{"matrixcube_raftstore_snapshot_apply_progress_bytes", map[string]string{"shard": "104", "type": "total"}},
+	}
+	metric.SetShardApplyRate(104, 1)
+	metric.SetRaftLogSize(104, 1)
+	metric.SetShardQPS(104, 1, 1)
+	metric.SetSnapshotApplyProgress(104, 1, 2)
+	for _, g := range shardGauges {
+		_, ok := getMetricValue(t, g.name, g.labels)
+		assert.True(t, ok, g.name)
+	}
+
+	s.removeReplica(Shard{ID: 104})
+	for _, g := range shardGauges {
+		_, ok := getMetricValue(t, g.name, g.labels)
+		assert.False(t, ok, g.name)
+	}
+}
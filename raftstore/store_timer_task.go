@@ -17,7 +17,9 @@ import (
 	"time"
 
 	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/matrixorigin/matrixcube/components/log"
 	putil "github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/storage"
 	"go.uber.org/zap"
 )
@@ -44,6 +46,12 @@ func (s *store) startTimerTasks() {
 		debugTicker := time.NewTicker(time.Second * 10)
 		defer debugTicker.Stop()
 
+		leaderCountTicker := time.NewTicker(s.cfg.Replication.LeaderCountReportDuration.Duration)
+		defer leaderCountTicker.Stop()
+
+		tombstoneGCTicker := time.NewTicker(s.cfg.Replication.TombstoneGCDuration.Duration)
+		defer tombstoneGCTicker.Stop()
+
 		for {
 			select {
 			case <-s.stopper.ShouldStop():
@@ -63,11 +71,15 @@ func (s *store) startTimerTasks() {
 				s.handleRefreshScheduleGroupRule()
 			case <-debugTicker.C:
 				s.doLogDebugInfo()
+			case <-leaderCountTicker.C:
+				s.handleLeaderCountTask()
+			case <-tombstoneGCTicker.C:
+				s.handleTombstoneGCTask()
 			}
 		}
 	})
 
-	s.cfg.Storage.ForeachDataStorageFunc(func(group uint64, ds storage.DataStorage) {
+	s.forEachDataStorage(func(group uint64, ds storage.DataStorage) {
 		s.stopper.RunWorker(func() {
 			policy := ds.Feature()
 			if policy.DisableShardSplit {
@@ -165,6 +177,58 @@ func (s *store) handleCompactLogTask() {
 	})
 }
 
+func (s *store) handleLeaderCountTask() {
+	for group, count := range s.getGroupLeaderCounts() {
+		metric.SetGroupLeaderCount(group, count)
+	}
+}
+
+// handleTombstoneGCTask destroys the replicas removed by config changes but
+// still hosted by the current store, e.g. the ones never receiving a GC message,
+// once they have been found removed for longer than
+// Replication.TombstoneRetention.
+func (s *store) handleTombstoneGCTask() {
+	now := s.clock.Now()
+	retention := s.cfg.Replication.TombstoneRetention.Duration
+	tombstoneSince := make(map[uint64]time.Time)
+	s.forEachReplica(func(pr *replica) bool {
+		if pr.sm == nil || !pr.sm.isRemoved() || pr.closed() {
+			return true
+		}
+		since, ok := s.tombstoneSince[pr.shardID]
+		if !ok {
+			since = now
+		}
+		if now.Sub(since) < retention {
+			tombstoneSince[pr.shardID] = since
+			return true
+		}
+		s.logger.Info("destroy tombstone replica",
+			s.storeField(),
+			log.ShardIDField(pr.shardID),
+			zap.Duration("tombstoned", now.Sub(since)))
+		s.destroyReplica(pr.shardID, false, true, "tombstone gc")
+		return true
+	})
+	s.tombstoneSince = tombstoneSince
+}
+
+// getGroupLeaderCounts returns the number of shard leaders of each group hosted
+// by the current store, groups without any leader on the current store are
+// included with a zero count.
+func (s *store) getGroupLeaderCounts() map[uint64]int {
+	counts := make(map[uint64]int)
+	s.forEachReplica(func(pr *replica) bool {
+		if pr.isLeader() {
+			counts[pr.group]++
+		} else if _, ok := counts[pr.group]; !ok {
+			counts[pr.group] = 0
+		}
+		return true
+	})
+	return counts
+}
+
 func (s *store) handleStoreHeartbeatTask(last time.Time) {
 	req, err := s.getStoreHeartbeat(last)
 	if err != nil {
@@ -52,7 +52,7 @@ func TestHandleShardHeartbeatTask(t *testing.T) {
 			s.addReplica(pr)
 			s.handleShardHeartbeatTask()
 			if c.hasAction {
-				v, err := pr.actions.Peek()
+				v, err := pr.priorityActions.Peek()
 				assert.NoError(t, err)
 				assert.Equal(t, c.action, v)
 			}
@@ -152,3 +152,29 @@ func TestHandleCompactLogTask(t *testing.T) {
 		}()
 	}
 }
+
+func TestGetGroupLeaderCounts(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	cases := []struct {
+		shard  Shard
+		leader uint64
+	}{
+		{shard: Shard{ID: 1, Group: 1}, leader: 1},
+		{shard: Shard{ID: 2, Group: 1}, leader: 1},
+		{shard: Shard{ID: 3, Group: 1}, leader: 1},
+		{shard: Shard{ID: 4, Group: 1}, leader: 2},
+		{shard: Shard{ID: 5, Group: 2}, leader: 2},
+		{shard: Shard{ID: 6, Group: 2}, leader: 0},
+	}
+	for _, c := range cases {
+		pr := newTestReplica(c.shard, Replica{ID: 1}, s)
+		pr.leaderID = c.leader
+		s.addReplica(pr)
+	}
+	assert.Equal(t, map[uint64]int{1: 3, 2: 0}, s.getGroupLeaderCounts())
+	s.handleLeaderCountTask()
+}
@@ -1170,9 +1170,9 @@ func (c *testRaftCluster) resetNode(node int, init bool) {
 		} else {
 			kvs = mem.NewStorage()
 		}
-		base := kv.NewBaseStorage(kvs, cfg.FS)
-		dataStorage = kv.NewKVDataStorage(base, executor.NewKVExecutor(kvs),
-			kv.WithLogger(cfg.Logger), kv.WithFeature(storage.Feature{
+		base := kv.NewBaseStorage(kvs, cfg.FS, kv.WithKeyCodec(cfg.Customize.KeyCodec))
+		dataStorage = kv.NewKVDataStorage(base, executor.NewKVExecutor(kvs, executor.WithKeyCodec(cfg.Customize.KeyCodec)),
+			kv.WithLogger(cfg.Logger), kv.WithKeyCodec(cfg.Customize.KeyCodec), kv.WithFeature(storage.Feature{
 				ShardSplitCheckDuration: time.Millisecond * 100,
 				ShardCapacityBytes:      c.opts.shardCapacityBytes,
 				ShardSplitCheckBytes:    c.opts.shardSplitCheckBytes,
@@ -0,0 +1,125 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync/atomic"
+
+	"github.com/lni/goutils/syncutil"
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/storage"
+)
+
+// writeThroughForwarder forwards the writes applied by the replicas on the
+// current store to the CustomWriteThroughHandler in a dedicated worker. The
+// events are queued without blocking the apply loop, they are dropped once the
+// queue is full. A nil writeThroughForwarder is valid and means no handler is
+// set.
+type writeThroughForwarder struct {
+	logger  *zap.Logger
+	handler func(config.WriteThroughEvent) error
+	stopper *syncutil.Stopper
+	eventC  chan config.WriteThroughEvent
+	// failed the number of events failed by the handler
+	failed uint64
+	// dropped the number of events dropped because of the queue size
+	dropped uint64
+}
+
+func newWriteThroughForwarder(handler func(config.WriteThroughEvent) error,
+	queueSize int, logger *zap.Logger) *writeThroughForwarder {
+	if handler == nil {
+		return nil
+	}
+	return &writeThroughForwarder{
+		logger:  logger,
+		handler: handler,
+		stopper: syncutil.NewStopper(),
+		eventC:  make(chan config.WriteThroughEvent, queueSize),
+	}
+}
+
+func (f *writeThroughForwarder) start() {
+	if f == nil {
+		return
+	}
+	f.stopper.RunWorker(func() {
+		for {
+			select {
+			case <-f.stopper.ShouldStop():
+				return
+			case e := <-f.eventC:
+				f.forward(e)
+			}
+		}
+	})
+}
+
+func (f *writeThroughForwarder) close() {
+	if f == nil {
+		return
+	}
+	f.stopper.Stop()
+}
+
+// addEvent queues the writes applied at the specified index, the requests are
+// copied as their buffers are owned by the apply loop.
+func (f *writeThroughForwarder) addEvent(shardID uint64, index uint64,
+	requests []storage.Request) {
+	if f == nil || len(requests) == 0 {
+		return
+	}
+	e := config.WriteThroughEvent{
+		ShardID:  shardID,
+		Index:    index,
+		Requests: make([]storage.Request, 0, len(requests)),
+	}
+	for _, req := range requests {
+		e.Requests = append(e.Requests, storage.Request{
+			CmdType: req.CmdType,
+			Key:     append([]byte(nil), req.Key...),
+			Cmd:     append([]byte(nil), req.Cmd...),
+		})
+	}
+	select {
+	case f.eventC <- e:
+	default:
+		atomic.AddUint64(&f.dropped, 1)
+		metric.IncWriteThroughDroppedCount()
+	}
+}
+
+func (f *writeThroughForwarder) forward(e config.WriteThroughEvent) {
+	if err := f.handler(e); err != nil {
+		atomic.AddUint64(&f.failed, 1)
+		metric.IncWriteThroughErrorCount()
+		f.logger.Debug("failed to forward write through event",
+			log.ShardIDField(e.ShardID),
+			log.IndexField(e.Index),
+			zap.Error(err))
+	}
+}
+
+// getFailures returns the number of events failed by the handler and the
+// number of events dropped.
+func (f *writeThroughForwarder) getFailures() (uint64, uint64) {
+	if f == nil {
+		return 0, 0
+	}
+	return atomic.LoadUint64(&f.failed), atomic.LoadUint64(&f.dropped)
+}
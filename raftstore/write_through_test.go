@@ -0,0 +1,122 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util/buf"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestWriteThroughForwarderDisabled(t *testing.T) {
+	f := newWriteThroughForwarder(nil, 1, log.GetDefaultZapLogger())
+	assert.Nil(t, f)
+	f.start()
+	f.addEvent(1, 1, []storage.Request{{Key: []byte("k")}})
+	failed, dropped := f.getFailures()
+	assert.Equal(t, uint64(0), failed)
+	assert.Equal(t, uint64(0), dropped)
+	f.close()
+}
+
+func TestWriteThroughForwarderDropsEventsWhenQueueIsFull(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	blockC := make(chan struct{})
+	f := newWriteThroughForwarder(func(config.WriteThroughEvent) error {
+		<-blockC
+		return nil
+	}, 1, log.GetDefaultZapLogger())
+	// not started, the queue is never drained
+	f.addEvent(1, 1, []storage.Request{{Key: []byte("k1")}})
+	f.addEvent(1, 2, []storage.Request{{Key: []byte("k2")}})
+	f.addEvent(1, 3, []storage.Request{{Key: []byte("k3")}})
+	failed, dropped := f.getFailures()
+	assert.Equal(t, uint64(0), failed)
+	assert.Equal(t, uint64(2), dropped)
+	close(blockC)
+	f.start()
+	f.close()
+}
+
+func TestWriteThroughAfterPrimaryWrite(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	ds := &testDataStorage{}
+	_, err := ds.GetInitialStates()
+	require.NoError(t, err)
+	eventC := make(chan config.WriteThroughEvent, 2)
+	f := newWriteThroughForwarder(func(e config.WriteThroughEvent) error {
+		// the primary write is always applied first
+		assert.True(t, ds.writes > 0)
+		eventC <- e
+		return errors.New("secondary storage unavailable")
+	}, 16, log.GetDefaultZapLogger())
+	f.start()
+	defer f.close()
+
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	pr.sm.dataStorage = ds
+	pr.sm.writeThrough = f
+	// the primary apply is fatal on failures
+	pr.sm.logger = log.GetPanicZapLogger()
+
+	ctx := newApplyContext()
+	ctx.index = 10
+	ctx.req = newTestRequestBatch(3, func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdReserved) + 1
+		r.Cmd = []byte("cmd")
+		if i == 1 {
+			r.Cmd = []byte("invalid")
+		}
+	})
+	resp := pr.sm.execWriteRequest(ctx)
+	require.Equal(t, 3, len(resp.Responses))
+	assert.Equal(t, []byte("OK"), resp.Responses[0].Value)
+	assert.Equal(t, []byte("OK"), resp.Responses[2].Value)
+
+	select {
+	case e := <-eventC:
+		assert.Equal(t, uint64(1), e.ShardID)
+		assert.Equal(t, uint64(10), e.Index)
+		// the failed request is not forwarded
+		assert.Equal(t, []storage.Request{
+			{CmdType: uint64(rpcpb.CmdReserved) + 1, Key: buf.Int2Bytes(0), Cmd: []byte("cmd")},
+			{CmdType: uint64(rpcpb.CmdReserved) + 1, Key: buf.Int2Bytes(2), Cmd: []byte("cmd")},
+		}, e.Requests)
+	case <-time.After(10 * time.Second):
+		assert.FailNow(t, "write through event not received")
+	}
+
+	for i := 0; i < 100; i++ {
+		if failed, _ := f.getFailures(); failed == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	failed, dropped := f.getFailures()
+	assert.Equal(t, uint64(1), failed)
+	assert.Equal(t, uint64(0), dropped)
+}
@@ -38,6 +38,7 @@ type scanOptions struct {
 	withValue  bool
 	buffer     *buf.ByteBuf
 	filterFunc func([]byte) bool
+	keyCodec   storage.KeyCodec
 }
 
 func (opts *scanOptions) adjust(shard metapb.Shard) {
@@ -63,6 +64,18 @@ func (opts *scanOptions) adjust(shard metapb.Shard) {
 	if opts.bytesLimit == 0 {
 		opts.bytesLimit = math.MaxUint64
 	}
+
+	if opts.keyCodec == nil {
+		opts.keyCodec = keysutil.DataKeyCodec{}
+	}
+}
+
+// WithScanKeyCodec set the codec of the scanned data keys, default is the
+// built-in codec
+func WithScanKeyCodec(codec storage.KeyCodec) ScanOption {
+	return func(opts *scanOptions) {
+		opts.keyCodec = codec
+	}
 }
 
 // WithValue set whether the return result of scan contains value
@@ -169,13 +182,12 @@ func (s *kvBasedDataStorageScanner) Scan(shard metapb.Shard, handler func(key, v
 		defer buffer.Release()
 	}
 
-	start := keysutil.EncodeShardStart(opts.startKey, buffer)
-	end := keysutil.EncodeShardEnd(opts.endKey, buffer)
+	start, end := storage.EncodeDataKeyRange(opts.keyCodec, opts.startKey, opts.endKey, buffer)
 	n := uint64(0)
 	bytes := uint64(0)
 	skipByLimit := false
 	err := s.kv.ScanInView(view, start, end, func(key, value []byte) (bool, error) {
-		originKey := keysutil.DecodeDataKey(key)
+		originKey := opts.keyCodec.DecodeDataKey(key)
 		if opts.filterFunc(originKey) {
 			err := handler(originKey, value)
 			if err != nil {
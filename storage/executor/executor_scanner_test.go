@@ -113,6 +113,30 @@ func TestScanner(t *testing.T) {
 	}
 }
 
+func TestScannerWithKeyCodec(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+
+	s := mem.NewStorage()
+	defer s.Close()
+
+	codec := testTableKeyCodec{table: []byte("t1/")}
+	other := testTableKeyCodec{table: []byte("t2/")}
+	scanner := NewKVBasedDataStorageScanner(s)
+	assert.NoError(t, s.Set(codec.EncodeDataKey([]byte("a"), nil), []byte("a"), false))
+	assert.NoError(t, s.Set(codec.EncodeDataKey([]byte("b"), nil), []byte("b"), false))
+	assert.NoError(t, s.Set(other.EncodeDataKey([]byte("b"), nil), []byte("b"), false))
+
+	var keys [][]byte
+	completed, _, err := scanner.Scan(metapb.Shard{Start: []byte("a"), End: []byte("c")}, func(key, value []byte) error {
+		keys = append(keys, copyBytes(key))
+		return nil
+	}, WithScanKeyCodec(codec))
+	assert.NoError(t, err)
+	assert.False(t, completed)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, keys)
+}
+
 func copyBytes(src []byte) []byte {
 	dst := make([]byte, len(src))
 	copy(dst, src)
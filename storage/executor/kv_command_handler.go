@@ -34,24 +34,56 @@ var (
 	rangeDeleteResponse     = protoc.MustMarshal(&rpcpb.KVRangeDeleteResponse{})
 	batchMixedWriteResponse = protoc.MustMarshal(&rpcpb.KVMixedWriteResponse{})
 
+	conditionalSetAppliedResponse = protoc.MustMarshal(&rpcpb.KVConditionalSetResponse{Applied: true})
+	conditionalSetSkippedResponse = protoc.MustMarshal(&rpcpb.KVConditionalSetResponse{})
+
 	emptyGetResponse = protoc.MustMarshal(&rpcpb.KVGetRequest{})
 )
 
-func handleSet(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+// kvCommandHandler handles the built-in kv commands, the keys of the commands
+// are encoded into the data keys by the codec.
+type kvCommandHandler struct {
+	codec storage.KeyCodec
+}
+
+func newKVCommandHandler(codec storage.KeyCodec) kvCommandHandler {
+	if codec == nil {
+		codec = keysutil.DataKeyCodec{}
+	}
+	return kvCommandHandler{codec: codec}
+}
+
+// set adds the value of the data key of the origin key into the write batch,
+// the written bytes are returned.
+func (h kvCommandHandler) set(wb util.WriteBatch, originKey, value []byte, buffer *buf.ByteBuf) int {
+	key := h.codec.EncodeDataKey(originKey, buffer)
+	wb.SetDeferred(len(key), len(value), func(k, v []byte) {
+		copy(k, key)
+		copy(v, value)
+	})
+	return len(key) + len(value)
+}
+
+// delete adds the deletion of the data key of the origin key into the write
+// batch, the written bytes are returned.
+func (h kvCommandHandler) delete(wb util.WriteBatch, originKey []byte, buffer *buf.ByteBuf) int {
+	key := h.codec.EncodeDataKey(originKey, buffer)
+	wb.DeleteDeferred(len(key), func(k []byte) {
+		copy(k, key)
+	})
+	return len(key)
+}
+
+func (h kvCommandHandler) handleSet(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
 	var req rpcpb.KVSetRequest
 	if err := req.FastUnmarshal(cmd); err != nil {
 		panic(err)
 	}
-	return doHandleSet(shard, req, wb, buffer, kvStore)
+	return h.doHandleSet(shard, req, wb, buffer, kvStore)
 }
 
-func doHandleSet(shard metapb.Shard, req rpcpb.KVSetRequest, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
-	kLen, vLen := keysutil.DataKeyLen(req.Key), len(req.Value)
-	wb.SetDeferred(kLen, vLen, func(key, value []byte) {
-		copy(value, req.Value)
-		keysutil.EncodeDataKeyTo(req.Key, key)
-	})
-	changed := kLen + vLen
+func (h kvCommandHandler) doHandleSet(shard metapb.Shard, req rpcpb.KVSetRequest, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+	changed := h.set(wb, req.Key, req.Value, buffer)
 	return KVWriteCommandResult{
 		DiffBytes:    int64(changed),
 		WrittenBytes: uint64(changed),
@@ -59,7 +91,33 @@ func doHandleSet(shard metapb.Shard, req rpcpb.KVSetRequest, wb util.WriteBatch,
 	}, nil
 }
 
-func handleBatchSet(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+// handleConditionalSet writes the value only if the current value of the key
+// equals to the expected value. The current value is read from the kvStore,
+// which includes the writes of the previous requests in the same write batch.
+func (h kvCommandHandler) handleConditionalSet(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+	var req rpcpb.KVConditionalSetRequest
+	if err := req.FastUnmarshal(cmd); err != nil {
+		panic(err)
+	}
+
+	current, err := kvStore.Get(h.codec.EncodeDataKey(req.Key, buffer))
+	buffer.ResetWrite()
+	if err != nil {
+		return KVWriteCommandResult{}, err
+	}
+	if !bytes.Equal(current, req.Expected) {
+		return KVWriteCommandResult{Response: conditionalSetSkippedResponse}, nil
+	}
+
+	result, err := h.doHandleSet(shard, rpcpb.KVSetRequest{Key: req.Key, Value: req.Value}, wb, buffer, kvStore)
+	if err != nil {
+		return result, err
+	}
+	result.Response = conditionalSetAppliedResponse
+	return result, nil
+}
+
+func (h kvCommandHandler) handleBatchSet(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
 	var req rpcpb.KVBatchSetRequest
 	if err := req.FastUnmarshal(cmd); err != nil {
 		panic(err)
@@ -67,12 +125,7 @@ func handleBatchSet(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *
 
 	changed := 0
 	for i := range req.Keys {
-		kLen, vLen := keysutil.DataKeyLen(req.Keys[i]), len(req.Values[i])
-		wb.SetDeferred(kLen, vLen, func(key, value []byte) {
-			copy(value, req.Values[i])
-			keysutil.EncodeDataKeyTo(req.Keys[i], key)
-		})
-		changed += kLen + vLen
+		changed += h.set(wb, req.Keys[i], req.Values[i], buffer)
 	}
 
 	return KVWriteCommandResult{
@@ -82,22 +135,17 @@ func handleBatchSet(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *
 	}, nil
 }
 
-func handleDelete(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+func (h kvCommandHandler) handleDelete(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
 	var req rpcpb.KVDeleteRequest
 	if err := req.FastUnmarshal(cmd); err != nil {
 		panic(err)
 	}
 
-	return doHandleDelete(shard, req, wb, buffer, kvStore)
+	return h.doHandleDelete(shard, req, wb, buffer, kvStore)
 }
 
-func doHandleDelete(shard metapb.Shard, req rpcpb.KVDeleteRequest, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
-	kLen := keysutil.DataKeyLen(req.Key)
-	wb.DeleteDeferred(kLen, func(key []byte) {
-		keysutil.EncodeDataKeyTo(req.Key, key)
-	})
-
-	changed := kLen
+func (h kvCommandHandler) doHandleDelete(shard metapb.Shard, req rpcpb.KVDeleteRequest, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+	changed := h.delete(wb, req.Key, buffer)
 	return KVWriteCommandResult{
 		DiffBytes:    -int64(changed),
 		WrittenBytes: uint64(changed),
@@ -105,7 +153,7 @@ func doHandleDelete(shard metapb.Shard, req rpcpb.KVDeleteRequest, wb util.Write
 	}, nil
 }
 
-func handleBatchDelete(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+func (h kvCommandHandler) handleBatchDelete(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
 	var req rpcpb.KVBatchDeleteRequest
 	if err := req.FastUnmarshal(cmd); err != nil {
 		panic(err)
@@ -113,11 +161,7 @@ func handleBatchDelete(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffe
 
 	changed := 0
 	for i := range req.Keys {
-		kLen := keysutil.DataKeyLen(req.Keys[i])
-		wb.DeleteDeferred(kLen, func(key []byte) {
-			keysutil.EncodeDataKeyTo(req.Keys[i], key)
-		})
-		changed += kLen
+		changed += h.delete(wb, req.Keys[i], buffer)
 	}
 
 	return KVWriteCommandResult{
@@ -127,22 +171,22 @@ func handleBatchDelete(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffe
 	}, nil
 }
 
-func handleRangeDelete(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+func (h kvCommandHandler) handleRangeDelete(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
 	var req rpcpb.KVRangeDeleteRequest
 	if err := req.FastUnmarshal(cmd); err != nil {
 		panic(err)
 	}
 
-	return doHandleRangeDelete(shard, req, wb, buffer, kvStore)
+	return h.doHandleRangeDelete(shard, req, wb, buffer, kvStore)
 }
 
-func doHandleRangeDelete(shard metapb.Shard, req rpcpb.KVRangeDeleteRequest, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
-	sLen, eLen := keysutil.DataKeyLen(req.Start), keysutil.DataKeyLen(req.End)
-	wb.DeleteRangeDeferred(sLen, eLen, func(start, end []byte) {
-		keysutil.EncodeShardStartTo(req.Start, start)
-		keysutil.EncodeShardEndTo(req.End, end)
+func (h kvCommandHandler) doHandleRangeDelete(shard metapb.Shard, req rpcpb.KVRangeDeleteRequest, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+	start, end := storage.EncodeDataKeyRange(h.codec, req.Start, req.End, buffer)
+	wb.DeleteRangeDeferred(len(start), len(end), func(startKey, endKey []byte) {
+		copy(startKey, start)
+		copy(endKey, end)
 	})
-	changed := sLen + eLen
+	changed := len(start) + len(end)
 	return KVWriteCommandResult{
 		DiffBytes:    -int64(changed),
 		WrittenBytes: uint64(changed),
@@ -150,7 +194,7 @@ func doHandleRangeDelete(shard metapb.Shard, req rpcpb.KVRangeDeleteRequest, wb
 	}, nil
 }
 
-func handleGet(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVReadCommandResult, error) {
+func (h kvCommandHandler) handleGet(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVReadCommandResult, error) {
 	defer buffer.ResetWrite()
 
 	var req rpcpb.KVGetRequest
@@ -160,7 +204,7 @@ func handleGet(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore stor
 
 	var result KVReadCommandResult
 	result.Response = emptyGetResponse
-	err := kvStore.GetWithFunc(keysutil.EncodeDataKey(req.Key, buffer), func(value []byte) error {
+	err := kvStore.GetWithFunc(h.codec.EncodeDataKey(req.Key, buffer), func(value []byte) error {
 		result = KVReadCommandResult{
 			ReadBytes: uint64(len(value)),
 			Response:  protoc.MustMarshal(&rpcpb.KVGetResponse{Value: value}),
@@ -170,7 +214,7 @@ func handleGet(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore stor
 	return result, err
 }
 
-func handleBatchGet(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVReadCommandResult, error) {
+func (h kvCommandHandler) handleBatchGet(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVReadCommandResult, error) {
 	var req rpcpb.KVBatchGetRequest
 	if err := req.FastUnmarshal(cmd); err != nil {
 		panic(err)
@@ -182,7 +226,7 @@ func handleBatchGet(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore
 
 	readed := 0
 	for _, key := range req.Keys {
-		v, err := kvStore.Get(keysutil.EncodeDataKey(key, buffer))
+		v, err := kvStore.Get(h.codec.EncodeDataKey(key, buffer))
 		buffer.ResetWrite()
 		if err != nil {
 			return KVReadCommandResult{}, err
@@ -197,7 +241,7 @@ func handleBatchGet(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore
 	}, nil
 }
 
-func handleScan(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVReadCommandResult, error) {
+func (h kvCommandHandler) handleScan(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVReadCommandResult, error) {
 	var req rpcpb.KVScanRequest
 	if err := req.FastUnmarshal(cmd); err != nil {
 		panic(err)
@@ -224,8 +268,7 @@ func handleScan(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore sto
 	view := kvStore.GetView()
 	defer view.Close()
 
-	start := keysutil.EncodeShardStart(req.Start, buffer)
-	end := keysutil.EncodeShardEnd(req.End, buffer)
+	start, end := storage.EncodeDataKeyRange(h.codec, req.Start, req.End, buffer)
 	n := uint64(0)
 	bytes := uint64(0)
 	skipByLimit := false
@@ -237,7 +280,7 @@ func handleScan(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore sto
 			return true, nil
 		}
 
-		originKey := keysutil.DecodeDataKey(key)
+		originKey := h.codec.DecodeDataKey(key)
 
 		buffer.MarkWrite()
 		buf.MustWrite(buffer, originKey)
@@ -288,7 +331,7 @@ func handleScan(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore sto
 	}, nil
 }
 
-func handleBatchMixedWrite(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+func (h kvCommandHandler) handleBatchMixedWrite(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
 	defer buffer.ResetWrite()
 
 	var req rpcpb.KVBatchMixedWriteRequest
@@ -302,11 +345,11 @@ func handleBatchMixedWrite(shard metapb.Shard, cmd []byte, wb util.WriteBatch, b
 		var err error
 		switch rpcpb.InternalCmd(req.Requests[idx].CmdType) {
 		case rpcpb.CmdKVSet:
-			result, err = doHandleSet(shard, req.Requests[idx].Set, wb, buffer, kvStore)
+			result, err = h.doHandleSet(shard, req.Requests[idx].Set, wb, buffer, kvStore)
 		case rpcpb.CmdKVDelete:
-			result, err = doHandleDelete(shard, req.Requests[idx].Delete, wb, buffer, kvStore)
+			result, err = h.doHandleDelete(shard, req.Requests[idx].Delete, wb, buffer, kvStore)
 		case rpcpb.CmdKVRangeDelete:
-			result, err = doHandleRangeDelete(shard, req.Requests[idx].RangeDelete, wb, buffer, kvStore)
+			result, err = h.doHandleRangeDelete(shard, req.Requests[idx].RangeDelete, wb, buffer, kvStore)
 		}
 
 		if err != nil {
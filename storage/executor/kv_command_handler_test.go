@@ -27,6 +27,74 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var testKVCommandHandler = newKVCommandHandler(nil)
+
+// testTableKeyCodec puts the keys of a table after the table prefix
+type testTableKeyCodec struct {
+	table []byte
+}
+
+func (c testTableKeyCodec) EncodeDataKey(originKey []byte, buffer *buf.ByteBuf) []byte {
+	key := append(keysutil.Clone(c.table), originKey...)
+	return keysutil.EncodeDataKey(key, buffer)
+}
+
+func (c testTableKeyCodec) DecodeDataKey(key []byte) []byte {
+	return keysutil.DecodeDataKey(key)[len(c.table):]
+}
+
+func TestHandleWithKeyCodec(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+
+	kvStore := mem.NewStorage()
+	defer kvStore.Close()
+
+	buffer := buf.NewByteBuf(32)
+	defer buffer.Release()
+
+	codec := testTableKeyCodec{table: []byte("t1/")}
+	h := newKVCommandHandler(codec)
+	// the key of the other table is out of the shard
+	other := testTableKeyCodec{table: []byte("t2/")}
+	assert.NoError(t, kvStore.Set(other.EncodeDataKey([]byte("k2"), nil), []byte("v"), false))
+
+	wb := kvStore.NewWriteBatch().(util.WriteBatch)
+	result, err := h.handleBatchSet(metapb.Shard{}, newTestBatchSetRequest("k1", "v1", "k2", "v2", "k3", "v3"), wb, buffer, kvStore)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3*(1+3+2+2)), result.DiffBytes)
+	assert.NoError(t, kvStore.Write(wb, false))
+	v, err := kvStore.Get(codec.EncodeDataKey([]byte("k1"), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", string(v))
+
+	readed, err := h.handleGet(metapb.Shard{}, newTestGetRequest("k2"), buffer, kvStore)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", string(getTestGetResponseValue(readed.Response)))
+
+	shard := metapb.Shard{Start: []byte("k1"), End: []byte("k4")}
+	readed, err = h.handleScan(shard, protoc.MustMarshal(&rpcpb.KVScanRequest{}), buffer, kvStore)
+	assert.NoError(t, err)
+	resp := &rpcpb.KVScanResponse{}
+	protoc.MustUnmarshal(resp, readed.Response)
+	assert.Equal(t, [][]byte{[]byte("k1"), []byte("k2"), []byte("k3")}, resp.Keys)
+
+	wb.Reset()
+	_, err = h.handleRangeDelete(shard, newTestRangeDeleteRequest("k1", "k3"), wb, buffer, kvStore)
+	assert.NoError(t, err)
+	_, err = h.handleDelete(shard, newTestDeleteRequest("k3"), wb, buffer, kvStore)
+	assert.NoError(t, err)
+	assert.NoError(t, kvStore.Write(wb, false))
+	readed, err = h.handleScan(shard, protoc.MustMarshal(&rpcpb.KVScanRequest{}), buffer, kvStore)
+	assert.NoError(t, err)
+	resp = &rpcpb.KVScanResponse{}
+	protoc.MustUnmarshal(resp, readed.Response)
+	assert.Empty(t, resp.Keys)
+	v, err = kvStore.Get(other.EncodeDataKey([]byte("k2"), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "v", string(v))
+}
+
 func TestHandleSetAndGet(t *testing.T) {
 	fs := vfs.GetTestFS()
 	defer vfs.ReportLeakedFD(fs, t)
@@ -38,7 +106,7 @@ func TestHandleSetAndGet(t *testing.T) {
 	defer buffer.Release()
 
 	wb := kvStore.NewWriteBatch().(util.WriteBatch)
-	result, err := handleSet(metapb.Shard{}, newTestSetRequest("k1", "v1"), wb, buffer, kvStore)
+	result, err := testKVCommandHandler.handleSet(metapb.Shard{}, newTestSetRequest("k1", "v1"), wb, buffer, kvStore)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(5), result.DiffBytes)
 	assert.Equal(t, uint64(5), result.WrittenBytes)
@@ -48,12 +116,55 @@ func TestHandleSetAndGet(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "v1", string(v))
 
-	readed, err := handleGet(metapb.Shard{}, newTestGetRequest("k1"), buffer, kvStore)
+	readed, err := testKVCommandHandler.handleGet(metapb.Shard{}, newTestGetRequest("k1"), buffer, kvStore)
 	assert.NoError(t, err)
 	assert.True(t, readed.ReadBytes > 0)
 	assert.Equal(t, "v1", string(getTestGetResponseValue(readed.Response)))
 }
 
+func TestHandleConditionalSet(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+
+	kvStore := mem.NewStorage()
+	defer kvStore.Close()
+
+	buffer := buf.NewByteBuf(32)
+	defer buffer.Release()
+
+	cases := []struct {
+		expected      string
+		value         string
+		expectApplied bool
+		expectValue   string
+	}{
+		{expected: "v1", value: "v2", expectApplied: false, expectValue: ""},
+		{expected: "", value: "v1", expectApplied: true, expectValue: "v1"},
+		{expected: "", value: "v2", expectApplied: false, expectValue: "v1"},
+		{expected: "v2", value: "v3", expectApplied: false, expectValue: "v1"},
+		{expected: "v1", value: "v2", expectApplied: true, expectValue: "v2"},
+	}
+
+	for i, c := range cases {
+		wb := kvStore.NewWriteBatch().(util.WriteBatch)
+		result, err := testKVCommandHandler.handleConditionalSet(metapb.Shard{}, newTestConditionalSetRequest("k1", c.value, c.expected), wb, buffer, kvStore)
+		assert.NoError(t, err, "index %d", i)
+		assert.Equal(t, c.expectApplied, getTestConditionalSetResponseApplied(result.Response), "index %d", i)
+		if c.expectApplied {
+			assert.Equal(t, int64(5), result.DiffBytes, "index %d", i)
+			assert.Equal(t, uint64(5), result.WrittenBytes, "index %d", i)
+		} else {
+			assert.Equal(t, int64(0), result.DiffBytes, "index %d", i)
+			assert.Equal(t, uint64(0), result.WrittenBytes, "index %d", i)
+		}
+
+		assert.NoError(t, kvStore.Write(wb, false))
+		v, err := kvStore.Get(keysutil.EncodeDataKey([]byte("k1"), buffer))
+		assert.NoError(t, err)
+		assert.Equal(t, c.expectValue, string(v), "index %d", i)
+	}
+}
+
 func TestHandleBatchSetAndBatchGet(t *testing.T) {
 	fs := vfs.GetTestFS()
 	defer vfs.ReportLeakedFD(fs, t)
@@ -65,7 +176,7 @@ func TestHandleBatchSetAndBatchGet(t *testing.T) {
 	defer buffer.Release()
 
 	wb := kvStore.NewWriteBatch().(util.WriteBatch)
-	result, err := handleBatchSet(metapb.Shard{}, newTestBatchSetRequest("k1", "v1", "k2", "v2"), wb, buffer, kvStore)
+	result, err := testKVCommandHandler.handleBatchSet(metapb.Shard{}, newTestBatchSetRequest("k1", "v1", "k2", "v2"), wb, buffer, kvStore)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(10), result.DiffBytes)
 	assert.Equal(t, uint64(10), result.WrittenBytes)
@@ -78,12 +189,12 @@ func TestHandleBatchSetAndBatchGet(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "v2", string(v))
 
-	readed, err := handleBatchGet(metapb.Shard{}, newTestBatchGetRequest("k1"), buffer, kvStore)
+	readed, err := testKVCommandHandler.handleBatchGet(metapb.Shard{}, newTestBatchGetRequest("k1"), buffer, kvStore)
 	assert.NoError(t, err)
 	assert.True(t, readed.ReadBytes > 0)
 	assert.Equal(t, [][]byte{[]byte("v1")}, getTestBatchGetResponseValue(readed.Response))
 
-	readed, err = handleBatchGet(metapb.Shard{}, newTestBatchGetRequest("k2", "k3", "k1"), buffer, kvStore)
+	readed, err = testKVCommandHandler.handleBatchGet(metapb.Shard{}, newTestBatchGetRequest("k2", "k3", "k1"), buffer, kvStore)
 	assert.NoError(t, err)
 	assert.True(t, readed.ReadBytes > 0)
 	assert.Equal(t, [][]byte{[]byte("v2"), {}, []byte("v1")}, getTestBatchGetResponseValue(readed.Response))
@@ -100,10 +211,10 @@ func TestHandleDelete(t *testing.T) {
 	defer buffer.Release()
 
 	wb := kvStore.NewWriteBatch().(util.WriteBatch)
-	_, err := handleSet(metapb.Shard{}, newTestSetRequest("k1", "v1"), wb, buffer, kvStore)
+	_, err := testKVCommandHandler.handleSet(metapb.Shard{}, newTestSetRequest("k1", "v1"), wb, buffer, kvStore)
 	assert.NoError(t, err)
 
-	result, err := handleDelete(metapb.Shard{}, newTestDeleteRequest("k1"), wb, buffer, kvStore)
+	result, err := testKVCommandHandler.handleDelete(metapb.Shard{}, newTestDeleteRequest("k1"), wb, buffer, kvStore)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(-3), result.DiffBytes)
 	assert.Equal(t, uint64(3), result.WrittenBytes)
@@ -125,10 +236,10 @@ func TestHandleBatchDelete(t *testing.T) {
 	defer buffer.Release()
 
 	wb := kvStore.NewWriteBatch().(util.WriteBatch)
-	_, err := handleBatchSet(metapb.Shard{}, newTestBatchSetRequest("k1", "v1", "k2", "v2", "k3", "v3"), wb, buffer, kvStore)
+	_, err := testKVCommandHandler.handleBatchSet(metapb.Shard{}, newTestBatchSetRequest("k1", "v1", "k2", "v2", "k3", "v3"), wb, buffer, kvStore)
 	assert.NoError(t, err)
 
-	result, err := handleBatchDelete(metapb.Shard{}, newTestBatchDeleteRequest("k1", "k3"), wb, buffer, kvStore)
+	result, err := testKVCommandHandler.handleBatchDelete(metapb.Shard{}, newTestBatchDeleteRequest("k1", "k3"), wb, buffer, kvStore)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(-6), result.DiffBytes)
 	assert.Equal(t, uint64(6), result.WrittenBytes)
@@ -156,10 +267,10 @@ func TestHandleRangeDelete(t *testing.T) {
 	defer buffer.Release()
 
 	wb := kvStore.NewWriteBatch().(util.WriteBatch)
-	_, err := handleBatchSet(metapb.Shard{}, newTestBatchSetRequest("k1", "v1", "k2", "v2", "k3", "v3"), wb, buffer, kvStore)
+	_, err := testKVCommandHandler.handleBatchSet(metapb.Shard{}, newTestBatchSetRequest("k1", "v1", "k2", "v2", "k3", "v3"), wb, buffer, kvStore)
 	assert.NoError(t, err)
 
-	result, err := handleRangeDelete(metapb.Shard{}, newTestRangeDeleteRequest("k1", "k3"), wb, buffer, kvStore)
+	result, err := testKVCommandHandler.handleRangeDelete(metapb.Shard{}, newTestRangeDeleteRequest("k1", "k3"), wb, buffer, kvStore)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(-6), result.DiffBytes)
 	assert.Equal(t, uint64(6), result.WrittenBytes)
@@ -176,7 +287,7 @@ func TestHandleRangeDelete(t *testing.T) {
 	assert.Equal(t, "v3", string(v))
 
 	wb.Reset()
-	_, err = handleRangeDelete(metapb.Shard{}, newTestRangeDeleteRequest("", ""), wb, buffer, kvStore)
+	_, err = testKVCommandHandler.handleRangeDelete(metapb.Shard{}, newTestRangeDeleteRequest("", ""), wb, buffer, kvStore)
 	assert.NoError(t, err)
 	assert.NoError(t, kvStore.Write(wb, false))
 	v, err = kvStore.Get(keysutil.EncodeDataKey([]byte("k3"), buffer))
@@ -294,7 +405,7 @@ func TestHandleScan(t *testing.T) {
 		req.LimitBytes = c.limitBytes
 		req.WithValue = c.withValue
 		req.OnlyCount = c.onlyCount
-		result, err := handleScan(c.shard, protoc.MustMarshal(req), buffer, kvStore)
+		result, err := testKVCommandHandler.handleScan(c.shard, protoc.MustMarshal(req), buffer, kvStore)
 		assert.NoError(t, err)
 
 		resp := &rpcpb.KVScanResponse{}
@@ -314,6 +425,14 @@ func newTestSetRequest(k, v string) []byte {
 	})
 }
 
+func newTestConditionalSetRequest(k, v, expected string) []byte {
+	return protoc.MustMarshal(&rpcpb.KVConditionalSetRequest{
+		Key:      []byte(k),
+		Value:    []byte(v),
+		Expected: []byte(expected),
+	})
+}
+
 func newTestGetRequest(k string) []byte {
 	return protoc.MustMarshal(&rpcpb.KVGetRequest{
 		Key: []byte(k),
@@ -372,3 +491,9 @@ func getTestBatchGetResponseValue(data []byte) [][]byte {
 	protoc.MustUnmarshal(&resp, data)
 	return resp.Values
 }
+
+func getTestConditionalSetResponseApplied(data []byte) bool {
+	var resp rpcpb.KVConditionalSetResponse
+	protoc.MustUnmarshal(&resp, data)
+	return resp.Applied
+}
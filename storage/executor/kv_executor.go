@@ -51,15 +51,29 @@ type KVReadCommandResult struct {
 	Response []byte
 }
 
-// KVWriteCommandHandler kv write command handler
+// KVWriteCommandHandler kv write command handler. The writes of the previous
+// requests in the same batch are in the wb, they are visible to the Get and
+// GetWithFunc of the kvStore, but not to its scans.
 type KVWriteCommandHandler func(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error)
 
 // KVReadCommandHandler kv read command handler
 type KVReadCommandHandler func(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVReadCommandResult, error)
 
+// KVExecutorOption is the option to create the kv executor
+type KVExecutorOption func(*kvExecutor)
+
+// WithKeyCodec set the codec of the data keys used by the built-in kv commands,
+// default is the built-in codec.
+func WithKeyCodec(codec storage.KeyCodec) KVExecutorOption {
+	return func(ke *kvExecutor) {
+		ke.keyCodec = codec
+	}
+}
+
 // kvExecutor is a kv executor.
 type kvExecutor struct {
-	kv storage.KVStorage
+	kv       storage.KVStorage
+	keyCodec storage.KeyCodec
 
 	writeHandlers map[uint64]KVWriteCommandHandler
 	readHandlers  map[uint64]KVReadCommandHandler
@@ -68,23 +82,28 @@ type kvExecutor struct {
 var _ storage.Executor = (*kvExecutor)(nil)
 
 // NewKVExecutor returns a kv executor.
-func NewKVExecutor(kv storage.KVStorage) RegisterExecutor {
+func NewKVExecutor(kv storage.KVStorage, opts ...KVExecutorOption) RegisterExecutor {
 	ke := &kvExecutor{
 		kv:            kv,
 		writeHandlers: map[uint64]KVWriteCommandHandler{},
 		readHandlers:  map[uint64]KVReadCommandHandler{},
 	}
+	for _, opt := range opts {
+		opt(ke)
+	}
 
-	ke.writeHandlers[uint64(rpcpb.CmdKVSet)] = handleSet
-	ke.writeHandlers[uint64(rpcpb.CmdKVBatchSet)] = handleBatchSet
-	ke.writeHandlers[uint64(rpcpb.CmdKVDelete)] = handleDelete
-	ke.writeHandlers[uint64(rpcpb.CmdKVBatchDelete)] = handleBatchDelete
-	ke.writeHandlers[uint64(rpcpb.CmdKVRangeDelete)] = handleRangeDelete
-	ke.writeHandlers[uint64(rpcpb.CmdKVBatchMixedWrite)] = handleBatchMixedWrite
-
-	ke.readHandlers[uint64(rpcpb.CmdKVGet)] = handleGet
-	ke.readHandlers[uint64(rpcpb.CmdKVBatchGet)] = handleBatchGet
-	ke.readHandlers[uint64(rpcpb.CmdKVScan)] = handleScan
+	h := newKVCommandHandler(ke.keyCodec)
+	ke.writeHandlers[uint64(rpcpb.CmdKVSet)] = h.handleSet
+	ke.writeHandlers[uint64(rpcpb.CmdKVBatchSet)] = h.handleBatchSet
+	ke.writeHandlers[uint64(rpcpb.CmdKVDelete)] = h.handleDelete
+	ke.writeHandlers[uint64(rpcpb.CmdKVBatchDelete)] = h.handleBatchDelete
+	ke.writeHandlers[uint64(rpcpb.CmdKVRangeDelete)] = h.handleRangeDelete
+	ke.writeHandlers[uint64(rpcpb.CmdKVBatchMixedWrite)] = h.handleBatchMixedWrite
+	ke.writeHandlers[uint64(rpcpb.CmdKVConditionalSet)] = h.handleConditionalSet
+
+	ke.readHandlers[uint64(rpcpb.CmdKVGet)] = h.handleGet
+	ke.readHandlers[uint64(rpcpb.CmdKVBatchGet)] = h.handleBatchGet
+	ke.readHandlers[uint64(rpcpb.CmdKVScan)] = h.handleScan
 	return ke
 }
 
@@ -106,7 +125,9 @@ func (ke *kvExecutor) UpdateWriteBatch(ctx storage.WriteContext) error {
 	changedBytes := int64(0)
 	writtenBytes := uint64(0)
 	r := ctx.WriteBatch()
-	wb := r.(util.WriteBatch)
+	// the handlers read the writes of the previous requests in the batch
+	wb := newPendingWrites(r.(util.WriteBatch))
+	kvStore := pendingKVStore{KVStorage: ke.kv, pending: wb}
 	batch := ctx.Batch()
 	requests := batch.Requests
 	buffer := ctx.(storage.InternalContext).ByteBuf()
@@ -117,7 +138,7 @@ func (ke *kvExecutor) UpdateWriteBatch(ctx storage.WriteContext) error {
 			panic(fmt.Errorf("not support write cmd %d", requests[idx].CmdType))
 		}
 
-		result, err := handlerFunc(ctx.Shard(), requests[idx].Cmd, wb, buffer, ke.kv)
+		result, err := handlerFunc(ctx.Shard(), requests[idx].Cmd, wb, buffer, kvStore)
 		if err != nil {
 			return err
 		}
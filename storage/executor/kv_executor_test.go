@@ -22,6 +22,7 @@ import (
 	"github.com/matrixorigin/matrixcube/storage/kv/mem"
 	"github.com/matrixorigin/matrixcube/util"
 	"github.com/matrixorigin/matrixcube/util/buf"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
 	"github.com/matrixorigin/matrixcube/vfs"
 	"github.com/stretchr/testify/assert"
 )
@@ -65,3 +66,36 @@ func TestRegisterReadHandler(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, handled)
 }
+
+func TestConditionalSetWithPendingWrites(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	kvStore := mem.NewStorage()
+	defer kvStore.Close()
+
+	exec := NewKVExecutor(kvStore)
+	ctx := storage.NewSimpleWriteContext(1, kvStore, storage.Batch{
+		Index: 1,
+		Requests: []storage.Request{
+			{CmdType: uint64(rpcpb.CmdKVSet), Cmd: newTestSetRequest("k1", "v1")},
+			{CmdType: uint64(rpcpb.CmdKVConditionalSet), Cmd: newTestConditionalSetRequest("k1", "v2", "v1")},
+			{CmdType: uint64(rpcpb.CmdKVConditionalSet), Cmd: newTestConditionalSetRequest("k1", "v3", "v1")},
+			{CmdType: uint64(rpcpb.CmdKVDelete), Cmd: newTestDeleteRequest("k1")},
+			{CmdType: uint64(rpcpb.CmdKVConditionalSet), Cmd: newTestConditionalSetRequest("k1", "v4", "")},
+			{CmdType: uint64(rpcpb.CmdKVRangeDelete), Cmd: newTestRangeDeleteRequest("k0", "k2")},
+			{CmdType: uint64(rpcpb.CmdKVConditionalSet), Cmd: newTestConditionalSetRequest("k1", "v5", "v4")},
+			{CmdType: uint64(rpcpb.CmdKVConditionalSet), Cmd: newTestConditionalSetRequest("k1", "v6", "")},
+		},
+	})
+	assert.NoError(t, exec.UpdateWriteBatch(ctx))
+	// the conditions are checked against the writes of the previous requests
+	responses := ctx.Responses()
+	for idx, expected := range map[int]bool{1: true, 2: false, 4: true, 6: false, 7: true} {
+		assert.Equal(t, expected, getTestConditionalSetResponseApplied(responses[idx]), "index %d", idx)
+	}
+
+	assert.NoError(t, exec.ApplyWriteBatch(ctx.WriteBatch()))
+	v, err := kvStore.Get(keysutil.EncodeDataKey([]byte("k1"), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v6"), v)
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"bytes"
+
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util"
+)
+
+// pendingWrites records the writes added into the write batch of a
+// UpdateWriteBatch call. The requests of several committed logs are written in
+// the same batch, so the writes of the previous requests are not in the
+// kvStore yet. The handlers read the kvStore through a pendingKVStore, whose
+// point reads, i.e. Get and GetWithFunc, return the pending values before the
+// ones in the kvStore. Scans only see the kvStore.
+type pendingWrites struct {
+	util.WriteBatch
+
+	// values is the pending values keyed by the data keys, nil for the deleted
+	// keys
+	values map[string][]byte
+	// deletedRanges is the ranges deleted by the pending writes, values set after
+	// the deletion are in the values
+	deletedRanges [][2][]byte
+}
+
+var _ util.WriteBatch = (*pendingWrites)(nil)
+
+func newPendingWrites(wb util.WriteBatch) *pendingWrites {
+	return &pendingWrites{
+		WriteBatch: wb,
+		values:     make(map[string][]byte),
+	}
+}
+
+func (p *pendingWrites) Set(key, value []byte) {
+	p.WriteBatch.Set(key, value)
+	p.set(key, value)
+}
+
+func (p *pendingWrites) SetDeferred(keyLen, valueLen int, setter func(key, value []byte)) {
+	p.WriteBatch.SetDeferred(keyLen, valueLen, func(key, value []byte) {
+		setter(key, value)
+		p.set(key, value)
+	})
+}
+
+func (p *pendingWrites) Delete(key []byte) {
+	p.WriteBatch.Delete(key)
+	p.values[string(key)] = nil
+}
+
+func (p *pendingWrites) DeleteDeferred(keyLen int, setter func(key []byte)) {
+	p.WriteBatch.DeleteDeferred(keyLen, func(key []byte) {
+		setter(key)
+		p.values[string(key)] = nil
+	})
+}
+
+func (p *pendingWrites) DeleteRange(start, end []byte) {
+	p.WriteBatch.DeleteRange(start, end)
+	p.deleteRange(start, end)
+}
+
+func (p *pendingWrites) DeleteRangeDeferred(startLen, endLen int, setter func(start, end []byte)) {
+	p.WriteBatch.DeleteRangeDeferred(startLen, endLen, func(start, end []byte) {
+		setter(start, end)
+		p.deleteRange(start, end)
+	})
+}
+
+func (p *pendingWrites) set(key, value []byte) {
+	p.values[string(key)] = append([]byte{}, value...)
+}
+
+func (p *pendingWrites) deleteRange(start, end []byte) {
+	for key := range p.values {
+		if inRange([]byte(key), start, end) {
+			delete(p.values, key)
+		}
+	}
+	p.deletedRanges = append(p.deletedRanges,
+		[2][]byte{append([]byte{}, start...), append([]byte{}, end...)})
+}
+
+// get returns the pending value of the key, true if the key is written by the
+// pending writes.
+func (p *pendingWrites) get(key []byte) ([]byte, bool) {
+	if value, ok := p.values[string(key)]; ok {
+		if len(value) == 0 {
+			return nil, true
+		}
+		return value, true
+	}
+	for _, r := range p.deletedRanges {
+		if inRange(key, r[0], r[1]) {
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// pendingKVStore is the kvStore with the pendingWrites visible to the point
+// reads.
+type pendingKVStore struct {
+	storage.KVStorage
+	pending *pendingWrites
+}
+
+var _ storage.KVStorage = pendingKVStore{}
+
+func (s pendingKVStore) Get(key []byte) ([]byte, error) {
+	if value, ok := s.pending.get(key); ok {
+		return value, nil
+	}
+	return s.KVStorage.Get(key)
+}
+
+func (s pendingKVStore) GetWithFunc(key []byte, fn func([]byte) error) error {
+	if value, ok := s.pending.get(key); ok {
+		if value == nil {
+			return nil
+		}
+		return fn(value)
+	}
+	return s.KVStorage.GetWithFunc(key, fn)
+}
+
+func inRange(key, start, end []byte) bool {
+	return bytes.Compare(key, start) >= 0 && bytes.Compare(key, end) < 0
+}
@@ -34,15 +34,31 @@ var (
 	ErrNoMetadata = errors.New("no metadata")
 )
 
+// snapshotProgressBytes how many bytes of the snapshot are read between two
+// progress reports when applying a snapshot.
+var snapshotProgressBytes uint64 = 1024 * 1024
+
 type BaseStorage struct {
 	kv storage.KVStorage
 	fs vfs.FS
+	// keyCodec is used to compute the range of the data keys in snapshots
+	keyCodec storage.KeyCodec
 }
 
-func NewBaseStorage(kv storage.KVStorage, fs vfs.FS) storage.KVBaseStorage {
+// NewBaseStorage returns a base storage based on the kv storage, only the key
+// codec set by WithKeyCodec is used in the options.
+func NewBaseStorage(kv storage.KVStorage, fs vfs.FS, opts ...Option) storage.KVBaseStorage {
+	options := newOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.keyCodec == nil {
+		options.keyCodec = keysutil.DataKeyCodec{}
+	}
 	return &BaseStorage{
-		kv: kv,
-		fs: fs,
+		kv:       kv,
+		fs:       fs,
+		keyCodec: options.keyCodec,
 	}
 }
 
@@ -212,10 +228,11 @@ func (s *BaseStorage) CreateSnapshot(shardID uint64, path string) error {
 	protoc.MustUnmarshal(&logIndex, appliedIndexValue)
 	shard := sls.Metadata.Shard
 
-	if err := writeBytes(f, keysutil.EncodeShardStart(shard.Start, nil)); err != nil {
+	start, end := storage.EncodeDataKeyRange(s.keyCodec, shard.Start, shard.End, nil)
+	if err := writeBytes(f, start); err != nil {
 		return err
 	}
-	if err := writeBytes(f, keysutil.EncodeShardEnd(shard.End, nil)); err != nil {
+	if err := writeBytes(f, end); err != nil {
 		return err
 	}
 	if err := writeBytes(f, appliedIndexKey); err != nil {
@@ -232,10 +249,22 @@ func (s *BaseStorage) CreateSnapshot(shardID uint64, path string) error {
 	}
 
 	ios := &pebble.IterOptions{
-		LowerBound: keysutil.EncodeShardStart(shard.Start, nil),
-		UpperBound: keysutil.EncodeShardEnd(shard.End, nil),
+		LowerBound: start,
+		UpperBound: end,
 	}
 
+	if err := writeSnapshotRange(f, snap, ios); err != nil {
+		return err
+	}
+	// the recently applied requests of the shard, see storage.RequestDedupStorage
+	min, max := getAppliedRequestRange(shardID)
+	return writeSnapshotRange(f, snap, &pebble.IterOptions{
+		LowerBound: min,
+		UpperBound: max,
+	})
+}
+
+func writeSnapshotRange(f vfs.File, snap *pebble.Snapshot, ios *pebble.IterOptions) error {
 	iter := snap.NewIter(ios)
 	defer iter.Close()
 	iter.First()
@@ -246,22 +275,34 @@ func (s *BaseStorage) CreateSnapshot(shardID uint64, path string) error {
 		if err := writeBytes(f, iter.Key()); err != nil {
 			return err
 		}
-		if err = writeBytes(f, iter.Value()); err != nil {
+		if err := writeBytes(f, iter.Value()); err != nil {
 			return err
 		}
 		iter.Next()
 	}
-
 	return nil
 }
 
+func getAppliedRequestRange(shardID uint64) ([]byte, []byte) {
+	min, max := keys.GetAppliedRequestRange(shardID)
+	return keysutil.EncodeShardMetadataKey(min, nil), keysutil.EncodeShardMetadataKey(max, nil)
+}
+
 // ApplySnapshot apply a snapshort file from giving path
 func (s *BaseStorage) ApplySnapshot(shardID uint64, path string) error {
-	f, err := s.fs.Open(s.fs.PathJoin(path, "db.data"))
+	return s.ApplySnapshotWithProgress(shardID, path, nil)
+}
+
+// ApplySnapshotWithProgress apply a snapshort file from giving path, the
+// progress is invoked with the bytes of the snapshot file read so far.
+func (s *BaseStorage) ApplySnapshotWithProgress(shardID uint64, path string,
+	progress storage.SnapshotApplyProgress) error {
+	file, err := s.fs.Open(s.fs.PathJoin(path, "db.data"))
 	if err != nil {
 		return err
 	}
-	defer f.Close()
+	defer file.Close()
+	f := newProgressReader(file, progress)
 	batch := s.kv.NewWriteBatch().(util.WriteBatch)
 	defer batch.Close()
 
@@ -296,6 +337,7 @@ func (s *BaseStorage) ApplySnapshot(shardID uint64, path string) error {
 		return err
 	}
 	batch.DeleteRange(start, end)
+	batch.DeleteRange(getAppliedRequestRange(shardID))
 	batch.Set(appliedIndexKey, appliedIndexValue)
 	batch.Set(metadataKey, metadataValue)
 
@@ -319,8 +361,11 @@ func (s *BaseStorage) ApplySnapshot(shardID uint64, path string) error {
 	if err := s.kv.Write(batch, true); err != nil {
 		return err
 	}
-
-	return s.kv.Sync()
+	if err := s.kv.Sync(); err != nil {
+		return err
+	}
+	f.done()
+	return nil
 }
 
 func writeBytes(f vfs.File, data []byte) error {
@@ -335,7 +380,37 @@ func writeBytes(f vfs.File, data []byte) error {
 	return nil
 }
 
-func readBytes(f vfs.File) ([]byte, error) {
+// progressReader counts the bytes read and reports them to the progress every
+// snapshotProgressBytes bytes.
+type progressReader struct {
+	r        io.Reader
+	progress storage.SnapshotApplyProgress
+	read     uint64
+	reported uint64
+}
+
+func newProgressReader(r io.Reader, progress storage.SnapshotApplyProgress) *progressReader {
+	return &progressReader{r: r, progress: progress}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.read += uint64(n)
+	if r.progress != nil && r.read-r.reported >= snapshotProgressBytes {
+		r.reported = r.read
+		r.progress(r.read)
+	}
+	return n, err
+}
+
+func (r *progressReader) done() {
+	if r.progress != nil && r.read != r.reported {
+		r.reported = r.read
+		r.progress(r.read)
+	}
+}
+
+func readBytes(f io.Reader) ([]byte, error) {
 	size := make([]byte, 4)
 	n, err := f.Read(size)
 	if n == 0 && err == io.EOF {
@@ -216,6 +216,59 @@ func TestCreateAndApplySnapshot(t *testing.T) {
 	}()
 }
 
+func TestCreateAndApplySnapshotWithKeyCodec(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := "snapshot-dir-safe-to-delete"
+	shardID := uint64(100)
+	require.NoError(t, fs.RemoveAll(dir))
+	defer func() {
+		require.NoError(t, fs.RemoveAll(dir))
+	}()
+	codec := testTableKeyCodec{table: []byte("t1/")}
+	other := testTableKeyCodec{table: []byte("t2/")}
+	func() {
+		kv := mem.NewStorage()
+		base := NewBaseStorage(kv, fs, WithKeyCodec(codec))
+		ds := NewKVDataStorage(base, executor.NewKVExecutor(kv, executor.WithKeyCodec(codec)),
+			WithKeyCodec(codec))
+		defer ds.Close()
+		assert.NoError(t, base.Set(codec.EncodeDataKey([]byte("bb"), nil), []byte("v"), false))
+		assert.NoError(t, base.Set(codec.EncodeDataKey([]byte("yy"), nil), []byte("vv"), false))
+		assert.NoError(t, base.Set(other.EncodeDataKey([]byte("bb"), nil), []byte("vvv"), false))
+		sm := metapb.ShardMetadata{
+			ShardID:  shardID,
+			LogIndex: 110,
+			Metadata: metapb.ShardLocalState{
+				Shard: metapb.Shard{ID: shardID, Start: []byte("aa"), End: []byte("xx")},
+			},
+		}
+		assert.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{sm}))
+		assert.NoError(t, base.CreateSnapshot(sm.ShardID, dir))
+	}()
+
+	func() {
+		kv := mem.NewStorage()
+		base := NewBaseStorage(kv, fs, WithKeyCodec(codec))
+		defer base.Close()
+		assert.NoError(t, base.Set(codec.EncodeDataKey([]byte("cc"), nil), []byte("v"), false))
+		assert.NoError(t, base.Set(other.EncodeDataKey([]byte("cc"), nil), []byte("v"), false))
+		assert.NoError(t, base.ApplySnapshot(shardID, dir))
+		// only the keys of the shard are replaced by the snapshot
+		for key, value := range map[string]string{
+			string(codec.EncodeDataKey([]byte("bb"), nil)): "v",
+			string(codec.EncodeDataKey([]byte("cc"), nil)): "",
+			string(codec.EncodeDataKey([]byte("yy"), nil)): "",
+			string(other.EncodeDataKey([]byte("bb"), nil)): "",
+			string(other.EncodeDataKey([]byte("cc"), nil)): "v",
+		} {
+			v, err := base.Get([]byte(key))
+			assert.NoError(t, err)
+			assert.Equal(t, value, string(v), "key %x", key)
+		}
+	}()
+}
+
 func TestScanInViewWithOptions(t *testing.T) {
 	fs := vfs.GetTestFS()
 	defer vfs.ReportLeakedFD(fs, t)
@@ -379,3 +432,60 @@ func TestReverseScanInViewWithOptions(t *testing.T) {
 		assert.Equal(t, c.expectKeys, keys, "idx %d", idx)
 	}
 }
+
+func TestApplySnapshotWithProgress(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := "snapshot-dir-safe-to-delete"
+	shardID := uint64(100)
+	require.NoError(t, fs.RemoveAll(dir))
+	defer func() {
+		require.NoError(t, fs.RemoveAll(dir))
+	}()
+	old := snapshotProgressBytes
+	snapshotProgressBytes = 1024
+	defer func() {
+		snapshotProgressBytes = old
+	}()
+
+	value := make([]byte, 256)
+	func() {
+		kv := mem.NewStorage()
+		base := NewBaseStorage(kv, fs)
+		ds := NewKVDataStorage(base, executor.NewKVExecutor(kv))
+		defer ds.Close()
+		for i := 0; i < 64; i++ {
+			key := keysutil.EncodeDataKey([]byte(fmt.Sprintf("b%03d", i)), nil)
+			assert.NoError(t, base.Set(key, value, false))
+		}
+		sm := metapb.ShardMetadata{
+			ShardID:  shardID,
+			LogIndex: 110,
+			Metadata: metapb.ShardLocalState{
+				Shard: metapb.Shard{ID: shardID, Start: []byte("a"), End: []byte("c")},
+			},
+		}
+		assert.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{sm}))
+		assert.NoError(t, base.CreateSnapshot(sm.ShardID, dir))
+	}()
+
+	fi, err := fs.Stat(fs.PathJoin(dir, "db.data"))
+	require.NoError(t, err)
+	kv := mem.NewStorage()
+	base := NewBaseStorage(kv, fs)
+	ds := NewKVDataStorage(base, executor.NewKVExecutor(kv))
+	defer ds.Close()
+	var progress []uint64
+	assert.NoError(t, ds.(storage.ProgressiveSnapshotApplier).ApplySnapshotWithProgress(shardID, dir,
+		func(applied uint64) {
+			progress = append(progress, applied)
+		}))
+	require.True(t, len(progress) > 1)
+	for i := 1; i < len(progress); i++ {
+		assert.True(t, progress[i] > progress[i-1])
+	}
+	assert.Equal(t, uint64(fi.Size()), progress[len(progress)-1])
+	v, err := base.Get(keysutil.EncodeDataKey([]byte("b063"), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, value, v)
+}
@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -37,13 +38,32 @@ var (
 	mb = uint64(1024 * 1024)
 )
 
+// AppliedIndexOrdering is the ordering of making the data writes durable and
+// reporting their applied index as persistent. `Cube` compacts the raft logs up
+// to the persistent applied index returned by `GetPersistentLogIndex`.
+type AppliedIndexOrdering int
+
+const (
+	// PersistBeforeApply the applied index is reported as persistent only after
+	// the data writes up to it are synced to disk. It is the default and safe
+	// ordering, a crash never loses the raft logs of the writes not synced yet.
+	PersistBeforeApply AppliedIndexOrdering = iota
+	// ApplyBeforePersist the applied index is reported as persistent as soon as
+	// the data write is applied, before it is synced to disk. The raft logs can
+	// be compacted before the data is durable, the writes not synced yet are
+	// lost on crash. Only use it when the writes are durable in other ways.
+	ApplyBeforePersist
+)
+
 // Option option func
 type Option func(*options)
 
 type options struct {
-	sampleSync uint64
-	logger     *zap.Logger
-	feature    storage.Feature
+	sampleSync           uint64
+	appliedIndexOrdering AppliedIndexOrdering
+	logger               *zap.Logger
+	feature              storage.Feature
+	keyCodec             storage.KeyCodec
 }
 
 // WithSampleSync set sync sample interval. `Cube` will call the `GetPersistentLogIndex` method of `DataStorage` to obtain
@@ -56,6 +76,14 @@ func WithSampleSync(value uint64) Option {
 	}
 }
 
+// WithAppliedIndexOrdering set the ordering of making the data writes durable
+// and reporting their applied index as persistent, default is PersistBeforeApply.
+func WithAppliedIndexOrdering(value AppliedIndexOrdering) Option {
+	return func(opts *options) {
+		opts.appliedIndexOrdering = value
+	}
+}
+
 // WithLogger set logger
 func WithLogger(logger *zap.Logger) Option {
 	return func(opts *options) {
@@ -63,6 +91,15 @@ func WithLogger(logger *zap.Logger) Option {
 	}
 }
 
+// WithKeyCodec set the codec of the data keys, default is the built-in codec.
+// The same codec must be passed to the base storage, the KV executor and the
+// scanners of the data storage.
+func WithKeyCodec(codec storage.KeyCodec) Option {
+	return func(opts *options) {
+		opts.keyCodec = codec
+	}
+}
+
 // WithFeature set kv data feature
 func WithFeature(feature storage.Feature) Option {
 	return func(opts *options) {
@@ -99,6 +136,14 @@ func (opts *options) adjust() {
 		opts.feature.ForceCompactBytes = opts.feature.ShardCapacityBytes * 3 / 4
 	}
 
+	if opts.keyCodec == nil {
+		opts.keyCodec = keysutil.DataKeyCodec{}
+	}
+	// the transactional storage encodes the keys by the built-in layout
+	if _, ok := opts.keyCodec.(keysutil.DataKeyCodec); !ok && opts.feature.SupportTransaction {
+		panic("the transactional storage only supports the built-in key codec")
+	}
+
 	opts.logger = log.Adjust(opts.logger).Named("kv-data-storage")
 }
 
@@ -118,6 +163,7 @@ type kvDataStorage struct {
 
 var _ storage.DataStorage = (*kvDataStorage)(nil)
 var _ storage.KVStorageWrapper = (*kvDataStorage)(nil)
+var _ storage.RequestDedupStorage = (*kvDataStorage)(nil)
 
 // NewKVDataStorage returns data storage based on a kv base storage.
 func NewKVDataStorage(base storage.KVBaseStorage,
@@ -153,7 +199,7 @@ func (kv *kvDataStorage) Write(ctx storage.WriteContext) error {
 
 	// append data key
 	for idx := range batch.Requests {
-		batch.Requests[idx].Key = keysutil.EncodeDataKey(batch.Requests[idx].Key, ctx.(storage.InternalContext).ByteBuf())
+		batch.Requests[idx].Key = kv.opts.keyCodec.EncodeDataKey(batch.Requests[idx].Key, ctx.(storage.InternalContext).ByteBuf())
 	}
 	if err := kv.executor.UpdateWriteBatch(ctx); err != nil {
 		return err
@@ -162,15 +208,19 @@ func (kv *kvDataStorage) Write(ctx storage.WriteContext) error {
 	defer r.Reset()
 
 	kv.setAppliedIndexToWriteBatch(ctx, batch.Index)
+	kv.setAppliedRequestsToWriteBatch(ctx)
 	kv.updateAppliedIndex(ctx.Shard().ID, batch.Index)
 	if err := kv.executor.ApplyWriteBatch(r); err != nil {
 		return err
 	}
+	if kv.opts.appliedIndexOrdering == ApplyBeforePersist {
+		kv.updatePersistentAppliedIndexes()
+	}
 	return kv.trySync()
 }
 
 func (kv *kvDataStorage) Read(ctx storage.ReadContext) ([]byte, error) {
-	return kv.executor.Read(readContext{base: ctx})
+	return kv.executor.Read(readContext{base: ctx, keyCodec: kv.opts.keyCodec})
 }
 
 func (kv *kvDataStorage) SaveShardMetadata(metadatas []metapb.ShardMetadata) error {
@@ -300,8 +350,7 @@ func (kv *kvDataStorage) RemoveShard(shard metapb.Shard, removeData bool) error
 	// This is not an atomic operation, but it is idempotent, and the metadata is
 	// deleted afterwards, so the cleanup will not be lost.
 	if removeData {
-		min := keysutil.EncodeShardStart(shard.Start, nil)
-		max := keysutil.EncodeShardEnd(shard.End, nil)
+		min, max := kv.encodeShardRange(shard)
 		kv.opts.logger.Debug("remove shard data",
 			log.ShardField("shard", shard),
 			log.HexField("from", min),
@@ -332,26 +381,26 @@ func (kv *kvDataStorage) SplitCheck(shard metapb.Shard,
 	var splitKeys [][]byte
 
 	view := kv.base.GetView()
-	start := keysutil.EncodeShardStart(shard.Start, nil)
-	end := keysutil.EncodeShardEnd(shard.End, nil)
+	start, end := kv.encodeShardRange(shard)
 	if err := kv.base.ScanInViewWithOptions(view, start, end, func(key, val []byte) (storage.NextIterOptions, error) {
 		opts := storage.NextIterOptions{}
+		originKey := kv.opts.keyCodec.DecodeDataKey(key)
 		if appendSplitKey {
 			var realSplitKey []byte
 			if kv.opts.feature.SplitKeyAdjustFunc == nil {
-				realSplitKey = keysutil.Clone(key[1:])
+				realSplitKey = keysutil.Clone(originKey)
 			} else {
-				realSplitKey = keysutil.Clone(kv.opts.feature.SplitKeyAdjustFunc(key[1:]))
+				realSplitKey = keysutil.Clone(kv.opts.feature.SplitKeyAdjustFunc(originKey))
 				// split key changed
-				if !bytes.Equal(realSplitKey, key[1:]) {
-					opts.SeekGE = keysutil.NextKey(keysutil.EncodeDataKey(realSplitKey, nil), nil)
+				if !bytes.Equal(realSplitKey, originKey) {
+					opts.SeekGE = keysutil.NextKey(kv.opts.keyCodec.EncodeDataKey(realSplitKey, nil), nil)
 				}
 			}
 			splitKeys = append(splitKeys, realSplitKey)
 			appendSplitKey = false
 			sum = 0
 		}
-		n := uint64(len(key[1:]) + len(val))
+		n := uint64(len(originKey) + len(val))
 		sum += n
 		total += n
 		keys++
@@ -366,6 +415,12 @@ func (kv *kvDataStorage) SplitCheck(shard metapb.Shard,
 	return total, keys, splitKeys, nil, nil
 }
 
+// encodeShardRange returns the range of the data keys of the shard, the empty
+// start and end keys are encoded as the bounds of all the data keys.
+func (kv *kvDataStorage) encodeShardRange(shard metapb.Shard) ([]byte, []byte) {
+	return storage.EncodeDataKeyRange(kv.opts.keyCodec, shard.Start, shard.End, nil)
+}
+
 func (kv *kvDataStorage) Split(old metapb.ShardMetadata,
 	news []metapb.ShardMetadata, ctx []byte) error {
 	return kv.SaveShardMetadata(append(news, old))
@@ -385,6 +440,56 @@ func (kv *kvDataStorage) setAppliedIndexToWriteBatch(ctx storage.WriteContext, i
 	wb.Set(key, val)
 }
 
+// setAppliedRequestsToWriteBatch records the applied requests of the batch in
+// separate keys with the index of the batch, so only the changed requests are
+// written by each batch.
+func (kv *kvDataStorage) setAppliedRequestsToWriteBatch(ctx storage.WriteContext) {
+	batch := ctx.Batch()
+	if len(batch.AppliedRequests) == 0 && len(batch.RemovedRequests) == 0 {
+		return
+	}
+	wb := ctx.WriteBatch().(util.WriteBatch)
+	shardID := ctx.Shard().ID
+	for _, id := range batch.RemovedRequests {
+		wb.Delete(keysutil.EncodeShardMetadataKey(keys.GetAppliedRequestKey(shardID, id, nil), nil))
+	}
+	val := protoc.MustMarshal(&metapb.LogIndex{Index: batch.Index})
+	for _, id := range batch.AppliedRequests {
+		wb.Set(keysutil.EncodeShardMetadataKey(keys.GetAppliedRequestKey(shardID, id, nil), nil), val)
+	}
+}
+
+func (kv *kvDataStorage) GetAppliedRequests(shardID uint64) ([][]byte, error) {
+	type appliedRequest struct {
+		id    []byte
+		index uint64
+	}
+	var requests []appliedRequest
+	min, max := getAppliedRequestRange(shardID)
+	if err := kv.base.Scan(min, max, func(key, value []byte) (bool, error) {
+		id, err := keys.GetAppliedRequestID(key[1:])
+		if err != nil {
+			return false, err
+		}
+		var idx metapb.LogIndex
+		protoc.MustUnmarshal(&idx, value)
+		requests = append(requests, appliedRequest{id: id, index: idx.Index})
+		return true, nil
+	}, true); err != nil {
+		return nil, err
+	}
+
+	// the requests applied by the same batch are in the order of the IDs
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].index < requests[j].index
+	})
+	ids := make([][]byte, 0, len(requests))
+	for _, r := range requests {
+		ids = append(ids, r.id)
+	}
+	return ids, nil
+}
+
 func (kv *kvDataStorage) updateAppliedIndex(shardID uint64, index uint64) {
 	kv.mu.Lock()
 	defer kv.mu.Unlock()
@@ -416,9 +521,18 @@ func (kv *kvDataStorage) CreateSnapshot(shardID uint64, path string) error {
 }
 
 func (kv *kvDataStorage) ApplySnapshot(shardID uint64, path string) error {
+	return kv.ApplySnapshotWithProgress(shardID, path, nil)
+}
+
+func (kv *kvDataStorage) ApplySnapshotWithProgress(shardID uint64, path string,
+	progress storage.SnapshotApplyProgress) error {
 	// FIXME: kv.base.ApplySnapshot is not atomic
 	// kvDataStorage.ApplySnapshot suffers from the same issue
-	if err := kv.base.ApplySnapshot(shardID, path); err != nil {
+	if base, ok := kv.base.(storage.ProgressiveSnapshotApplier); ok && progress != nil {
+		if err := base.ApplySnapshotWithProgress(shardID, path, progress); err != nil {
+			return err
+		}
+	} else if err := kv.base.ApplySnapshot(shardID, path); err != nil {
 		return err
 	}
 	key := keysutil.EncodeShardMetadataKey(keys.GetAppliedIndexKey(shardID, nil), nil)
@@ -448,7 +562,8 @@ func (kv *kvDataStorage) updatePersistentAppliedIndexes() {
 }
 
 type readContext struct {
-	base storage.ReadContext
+	base     storage.ReadContext
+	keyCodec storage.KeyCodec
 }
 
 func (c readContext) ByteBuf() *buf.ByteBuf { return c.base.(storage.InternalContext).ByteBuf() }
@@ -456,6 +571,6 @@ func (c readContext) Shard() metapb.Shard   { return c.base.Shard() }
 func (c readContext) SetReadBytes(v uint64) { c.base.SetReadBytes(v) }
 func (c readContext) Request() storage.Request {
 	req := c.base.Request()
-	req.Key = keysutil.EncodeDataKey(req.Key, c.base.(storage.InternalContext).ByteBuf())
+	req.Key = c.keyCodec.EncodeDataKey(req.Key, c.base.(storage.InternalContext).ByteBuf())
 	return req
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/storage/executor"
+	"github.com/matrixorigin/matrixcube/storage/kv/mem"
 	"github.com/matrixorigin/matrixcube/storage/kv/pebble"
 	"github.com/matrixorigin/matrixcube/util/buf"
 	keysutil "github.com/matrixorigin/matrixcube/util/keys"
@@ -351,6 +352,153 @@ func TestKVDataStorageRestartWithNotSyncedDataLost(t *testing.T) {
 	}
 }
 
+func TestKVDataStorageAppliedIndexOrdering(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	cases := []struct {
+		ordering          AppliedIndexOrdering
+		persistentIndex   uint64
+		recoveredLogIndex uint64
+		writesLost        bool
+	}{
+		{ordering: PersistBeforeApply, persistentIndex: 5, recoveredLogIndex: 5},
+		{ordering: ApplyBeforePersist, persistentIndex: 8, recoveredLogIndex: 5, writesLost: true},
+	}
+
+	for i, c := range cases {
+		memfs := vfs.NewMemFS()
+		opts := &cpebble.Options{
+			FS: vfs.NewPebbleFS(memfs),
+		}
+		require.NoError(t, memfs.MkdirAll("/test-data", 0755))
+		dir, err := memfs.OpenDir("/")
+		require.NoError(t, err)
+		require.NoError(t, dir.Sync())
+		shardID := uint64(1)
+		func() {
+			kv, err := pebble.NewStorage("test-data", nil, opts)
+			require.NoError(t, err)
+			base := NewBaseStorage(kv, memfs)
+			s := NewKVDataStorage(base, executor.NewKVExecutor(base),
+				WithSampleSync(5), WithAppliedIndexOrdering(c.ordering))
+			defer func() {
+				// to emulate a crash
+				memfs.(*pvfs.MemFS).SetIgnoreSyncs(true)
+				s.Close()
+			}()
+			_, err = s.GetInitialStates()
+			require.NoError(t, err)
+			require.NoError(t, s.SaveShardMetadata([]metapb.ShardMetadata{{
+				ShardID:  shardID,
+				LogIndex: 1,
+				Metadata: metapb.ShardLocalState{Shard: metapb.Shard{ID: shardID}},
+			}}))
+			// synced on the 5th write including the metadata, writes after index 5
+			// are not synced
+			for index := uint64(2); index <= 8; index++ {
+				var batch storage.Batch
+				batch.Index = index
+				k := []byte(fmt.Sprintf("%d", index))
+				batch.Requests = append(batch.Requests, executor.NewWriteRequest(k, k))
+				assert.NoError(t, s.Write(storage.NewSimpleWriteContext(shardID, base, batch)))
+			}
+			v, err := s.GetPersistentLogIndex(shardID)
+			assert.NoError(t, err)
+			assert.Equal(t, c.persistentIndex, v, "index %d", i)
+		}()
+
+		memfs.(*pvfs.MemFS).ResetToSyncedState()
+		memfs.(*pvfs.MemFS).SetIgnoreSyncs(false)
+		kv, err := pebble.NewStorage("test-data", nil, opts)
+		require.NoError(t, err)
+		base := NewBaseStorage(kv, memfs)
+		s := NewKVDataStorage(base, executor.NewKVExecutor(base))
+		_, err = s.GetInitialStates()
+		assert.NoError(t, err)
+		v, err := s.GetPersistentLogIndex(shardID)
+		assert.NoError(t, err)
+		assert.Equal(t, c.recoveredLogIndex, v, "index %d", i)
+		// raft logs up to the persistent index reported before the crash may have
+		// been compacted, writes after the recovered index are lost
+		assert.Equal(t, c.writesLost, c.persistentIndex > v, "index %d", i)
+		require.NoError(t, s.Close())
+		vfs.ReportLeakedFD(memfs, t)
+	}
+}
+
+func TestAppliedRequests(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := "snapshot-dir-safe-to-delete"
+	require.NoError(t, fs.RemoveAll(dir))
+	defer func() {
+		require.NoError(t, fs.RemoveAll(dir))
+	}()
+	shardID := uint64(1)
+	applied := [][]byte{[]byte("r3"), []byte("r1"), []byte("r4")}
+
+	func() {
+		kv := mem.NewStorage()
+		base := NewBaseStorage(kv, fs)
+		s := NewKVDataStorage(base, executor.NewKVExecutor(base))
+		defer s.Close()
+		md := metapb.ShardMetadata{
+			ShardID:  shardID,
+			LogIndex: 1,
+			Metadata: metapb.ShardLocalState{Shard: metapb.Shard{ID: shardID}},
+		}
+		require.NoError(t, s.SaveShardMetadata([]metapb.ShardMetadata{md}))
+
+		write := func(index uint64, applied, removed [][]byte) {
+			var batch storage.Batch
+			batch.Index = index
+			batch.Requests = append(batch.Requests, executor.NewWriteRequest([]byte("k"), []byte("v")))
+			batch.AppliedRequests = applied
+			batch.RemovedRequests = removed
+			require.NoError(t, s.Write(storage.NewSimpleWriteContext(shardID, base, batch)))
+		}
+		// only the changed requests are written by each batch
+		write(2, [][]byte{[]byte("r3"), []byte("r2")}, nil)
+		write(3, [][]byte{[]byte("r1")}, [][]byte{[]byte("r2")})
+		write(4, [][]byte{[]byte("r4")}, nil)
+		v, err := s.(storage.RequestDedupStorage).GetAppliedRequests(shardID)
+		assert.NoError(t, err)
+		assert.Equal(t, applied, v)
+
+		// kept by the metadata updates, e.g. the config changes
+		md.LogIndex = 5
+		require.NoError(t, s.SaveShardMetadata([]metapb.ShardMetadata{md}))
+		v, err = s.(storage.RequestDedupStorage).GetAppliedRequests(shardID)
+		assert.NoError(t, err)
+		assert.Equal(t, applied, v)
+		require.NoError(t, s.CreateSnapshot(shardID, dir))
+	}()
+
+	kv := mem.NewStorage()
+	base := NewBaseStorage(kv, fs)
+	s := NewKVDataStorage(base, executor.NewKVExecutor(base))
+	defer s.Close()
+	v, err := s.(storage.RequestDedupStorage).GetAppliedRequests(shardID)
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+	// replaced by the snapshot
+	var batch storage.Batch
+	batch.Index = 1
+	batch.Requests = append(batch.Requests, executor.NewWriteRequest([]byte("k"), []byte("v")))
+	batch.AppliedRequests = [][]byte{[]byte("r5")}
+	require.NoError(t, s.Write(storage.NewSimpleWriteContext(shardID, base, batch)))
+	require.NoError(t, s.ApplySnapshot(shardID, dir))
+	v, err = s.(storage.RequestDedupStorage).GetAppliedRequests(shardID)
+	assert.NoError(t, err)
+	assert.Equal(t, applied, v)
+
+	// removed with the shard
+	require.NoError(t, s.RemoveShard(metapb.Shard{ID: shardID}, true))
+	v, err = s.(storage.RequestDedupStorage).GetAppliedRequests(shardID)
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+}
+
 func TestRemoveShard(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	fs := vfs.GetTestFS()
@@ -523,3 +671,94 @@ func newTestShardMetadata(n uint64) []metapb.ShardMetadata {
 	}
 	return values
 }
+
+// testTableKeyCodec puts the keys of a table after the table prefix
+type testTableKeyCodec struct {
+	table []byte
+}
+
+func (c testTableKeyCodec) EncodeDataKey(originKey []byte, buffer *buf.ByteBuf) []byte {
+	key := append(keysutil.Clone(c.table), originKey...)
+	return keysutil.EncodeDataKey(key, buffer)
+}
+
+func (c testTableKeyCodec) DecodeDataKey(key []byte) []byte {
+	return keysutil.DecodeDataKey(key)[len(c.table):]
+}
+
+// testInvalidKeyCodec encodes the keys out of the range of the data keys
+type testInvalidKeyCodec struct{}
+
+func (testInvalidKeyCodec) EncodeDataKey(originKey []byte, buffer *buf.ByteBuf) []byte {
+	return keysutil.Clone(originKey)
+}
+
+func (testInvalidKeyCodec) DecodeDataKey(key []byte) []byte {
+	return key
+}
+
+func TestValidateKeyCodec(t *testing.T) {
+	assert.NoError(t, storage.ValidateKeyCodec(keysutil.DataKeyCodec{}))
+	assert.NoError(t, storage.ValidateKeyCodec(testTableKeyCodec{table: []byte("t1/")}))
+	assert.Error(t, storage.ValidateKeyCodec(testInvalidKeyCodec{}))
+}
+
+func TestTransactionalStorageRejectsKeyCodec(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	kv := mem.NewStorage()
+	defer kv.Close()
+	base := NewBaseStorage(kv, fs)
+	assert.Panics(t, func() {
+		NewKVDataStorage(base, nil, WithKeyCodec(testTableKeyCodec{table: []byte("t1/")}),
+			WithFeature(storage.Feature{SupportTransaction: true}))
+	})
+}
+
+func TestSplitCheckWithKeyCodec(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	kv := getTestPebbleStorage(t, fs)
+	base := NewBaseStorage(kv, fs)
+	codec := testTableKeyCodec{table: []byte("t1/")}
+	ds := NewKVDataStorage(base, nil, WithKeyCodec(codec))
+	defer func() {
+		require.NoError(t, fs.RemoveAll(testDir))
+	}()
+	defer ds.Close()
+
+	for _, k := range [][]byte{{1}, {2}, {3}} {
+		require.NoError(t, kv.Set(codec.EncodeDataKey(k, nil), k, false))
+	}
+	// keys of the other table are not in the shard
+	other := testTableKeyCodec{table: []byte("t2/")}
+	require.NoError(t, kv.Set(other.EncodeDataKey([]byte{2}, nil), []byte{2}, false))
+
+	shard := metapb.Shard{Start: []byte{1}, End: []byte{4}}
+	size, keys, splitKeys, ctx, err := ds.SplitCheck(shard, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), size)
+	assert.Equal(t, uint64(3), keys)
+	assert.Equal(t, [][]byte{{2}, {3}}, splitKeys)
+	assert.Empty(t, ctx)
+
+	// each child shard split by the split keys contains exactly one key
+	start := shard.Start
+	for idx := 0; idx <= len(splitKeys); idx++ {
+		end := shard.End
+		if idx < len(splitKeys) {
+			end = splitKeys[idx]
+		}
+		child := metapb.Shard{Start: start, End: end}
+		from, to := ds.(*kvDataStorage).encodeShardRange(child)
+		var originKeys [][]byte
+		require.NoError(t, kv.Scan(from, to, func(key, value []byte) (bool, error) {
+			originKeys = append(originKeys, keysutil.Clone(codec.DecodeDataKey(key)))
+			return true, nil
+		}, false))
+		assert.Equal(t, [][]byte{start}, originKeys)
+		start = end
+	}
+}
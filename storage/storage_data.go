@@ -32,6 +32,10 @@ var (
 	// ErrShardNotFound is returned by the data storage to indicate that the
 	// requested shard is not found.
 	ErrShardNotFound = errors.New("shard not found")
+	// ErrPartialWrite is returned, possibly wrapped, by the data storage's Write
+	// method to indicate that only the requests with an appended response or
+	// error have been applied and the remaining requests in the batch are not.
+	ErrPartialWrite = errors.New("partial write")
 )
 
 // Closeable is an instance that can be closed.
@@ -88,6 +92,60 @@ type BaseStorage interface {
 	ApplySnapshot(shardID uint64, path string) error
 }
 
+// ShardSizeEstimator is optionally implemented by the data storage able to
+// cheaply estimate the absolute size of shards, the estimated size supersedes
+// the size accumulated from the diffs reported by the writes when deciding
+// whether to check the shard for splitting.
+type ShardSizeEstimator interface {
+	// EstimateShardSize returns the estimated bytes of the shard, false if the
+	// estimation is not available.
+	EstimateShardSize(shard metapb.Shard) (uint64, bool)
+}
+
+// SnapshotApplyProgress is invoked with the bytes of the snapshot applied so far
+// when applying a snapshot.
+type SnapshotApplyProgress func(applied uint64)
+
+// ProgressiveSnapshotApplier is optionally implemented by the storages able to
+// report the progress of applying snapshots, it allows operators to observe
+// the restore of large shards.
+type ProgressiveSnapshotApplier interface {
+	// ApplySnapshotWithProgress is the same as ApplySnapshot, the progress is
+	// invoked periodically with the bytes of the snapshot applied so far, it
+	// must be cheap as it is invoked in the apply path.
+	ApplySnapshotWithProgress(shardID uint64, path string,
+		progress SnapshotApplyProgress) error
+}
+
+// RequestDedupStorage is optionally implemented by the storages able to persist
+// the Batch.AppliedRequests and Batch.RemovedRequests atomically with the
+// applied index of the batch, and to include the recorded requests in the
+// snapshots of the shard. The recently applied write requests are then restored
+// after restarts and snapshots, so the retried ones are still not applied
+// twice. Other storages rebuild them from the raft logs not yet compacted. Only
+// the request IDs are recorded, the retries of the requests applied before a
+// restart or a snapshot get empty responses.
+type RequestDedupStorage interface {
+	// GetAppliedRequests returns the IDs of the recorded requests of the shard,
+	// from the least recently applied one to the most recently applied one.
+	GetAppliedRequests(shardID uint64) ([][]byte, error)
+}
+
+// CompactionCoordinator is optionally implemented by the storages running their
+// own background compactions, e.g. the major compactions of LSM engines, which
+// cause latency spikes when they run together with heavy apply work. The
+// replicas pause the compactions before applying a burst of committed logs and
+// resume them once applied, see Raft.ApplyBurstEntries.
+type CompactionCoordinator interface {
+	// PauseCompaction pauses or throttles the background compactions. It is
+	// invoked concurrently by the replicas of different shards, the compactions
+	// should stay paused until every pause is resumed.
+	PauseCompaction()
+	// ResumeCompaction resumes the compactions paused by PauseCompaction, it is
+	// always invoked once for each PauseCompaction.
+	ResumeCompaction()
+}
+
 // DataStorage is the interface to be implemented by data engines for storing
 // both table shards data and shards metadata. We assume that data engines are
 // WAL-less engines meaning some of its most recent writes will be lost on
@@ -260,6 +318,12 @@ type WriteContext interface {
 	Batch() Batch
 	// AppendResponse is used for appending responses once each request is handled.
 	AppendResponse([]byte)
+	// AppendError is used instead of AppendResponse when the request can not be
+	// applied, e.g. the request failed the validation. Such request must not make
+	// any change to the write batch, other requests in the batch are not affected
+	// and the error is returned to the client of the failed request. Errors
+	// returned by `Write` are considered as unrecoverable storage failures.
+	AppendError(error)
 	// SetWrittenBytes set the number of bytes written to storage for all requests
 	// in the current Context instance. This is an approximation value that
 	// contributes to the scheduler's auto-rebalancing feature.
@@ -300,6 +364,16 @@ type Batch struct {
 	Index uint64
 	// Requests is the requests included in the batch.
 	Requests []Request
+	// AppliedRequests is the IDs of the write requests recorded for the dedup of
+	// the retried requests since the previous batch of the shard, including the
+	// ones in the batch. It is only set when the dedup is enabled, see
+	// RequestDedupStorage.
+	AppliedRequests [][]byte
+	// RemovedRequests is the IDs of the requests no longer recorded since the
+	// previous batch of the shard, i.e. evicted from the dedup window or failed
+	// by the storage. The requests of the batch failed by the storage are only
+	// removed by the next batch of the shard.
+	RemovedRequests [][]byte
 }
 
 // Request is the custom request type.
@@ -319,6 +393,7 @@ type SimpleWriteContext struct {
 	wb           Resetable
 	batch        Batch
 	responses    [][]byte
+	errors       []error
 	writtenBytes uint64
 	diffBytes    int64
 }
@@ -343,12 +418,18 @@ func (ctx *SimpleWriteContext) Shard() metapb.Shard   { return ctx.shard }
 func (ctx *SimpleWriteContext) Batch() Batch          { return ctx.batch }
 func (ctx *SimpleWriteContext) AppendResponse(value []byte) {
 	ctx.responses = append(ctx.responses, value)
+	ctx.errors = append(ctx.errors, nil)
+}
+func (ctx *SimpleWriteContext) AppendError(err error) {
+	ctx.responses = append(ctx.responses, nil)
+	ctx.errors = append(ctx.errors, err)
 }
 func (ctx *SimpleWriteContext) SetWrittenBytes(value uint64) { ctx.writtenBytes = value }
 func (ctx *SimpleWriteContext) SetDiffBytes(value int64)     { ctx.diffBytes = value }
 func (ctx *SimpleWriteContext) GetWrittenBytes() uint64      { return ctx.writtenBytes }
 func (ctx *SimpleWriteContext) GetDiffBytes() int64          { return ctx.diffBytes }
 func (ctx *SimpleWriteContext) Responses() [][]byte          { return ctx.responses }
+func (ctx *SimpleWriteContext) Errors() []error              { return ctx.errors }
 
 type SimpleReadContext struct {
 	buf       *buf.ByteBuf
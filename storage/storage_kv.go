@@ -14,7 +14,12 @@
 package storage
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/util/buf"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
 )
 
 // View is a point in time view of the KVStore.
@@ -110,3 +115,64 @@ type KVBaseStorage interface {
 	BaseStorage
 	KVStore
 }
+
+// KeyCodec encodes the origin keys of the application into the physical data
+// keys stored in the KVStorage, and decodes them back. The encoded keys must keep
+// the order of the origin keys, and must be in the range of the data keys of the
+// built-in codec, [keys.EncodeShardStart(nil), keys.EncodeShardEnd(nil)), so
+// they never collide with the internal keys of the KVDataStorage.
+type KeyCodec interface {
+	// EncodeDataKey returns the data key of the origin key, the buffer can be
+	// used to avoid allocations.
+	EncodeDataKey(originKey []byte, buffer *buf.ByteBuf) []byte
+	// DecodeDataKey returns the origin key of the data key.
+	DecodeDataKey(key []byte) []byte
+}
+
+// EncodeDataKeyRange returns the data keys of the bounds of the origin key range
+// [start, end) encoded by the codec, the empty start and end are encoded as the
+// bounds of all the data keys.
+func EncodeDataKeyRange(codec KeyCodec, start, end []byte, buffer *buf.ByteBuf) ([]byte, []byte) {
+	startKey := keysutil.EncodeShardStart(nil, nil)
+	if len(start) > 0 {
+		startKey = codec.EncodeDataKey(start, buffer)
+	}
+	endKey := keysutil.EncodeShardEnd(nil, nil)
+	if len(end) > 0 {
+		endKey = codec.EncodeDataKey(end, buffer)
+	}
+	return startKey, endKey
+}
+
+var keyCodecValidationKeys = [][]byte{
+	{},
+	{0x00},
+	{0x00, 0xff},
+	[]byte("a"),
+	[]byte("ab"),
+	[]byte("b"),
+	{0xff},
+	{0xff, 0xff},
+}
+
+// ValidateKeyCodec checks that the origin keys survive a round trip of the codec,
+// and that the encoded keys keep their order in the range of the data keys.
+func ValidateKeyCodec(codec KeyCodec) error {
+	min := keysutil.EncodeShardStart(nil, nil)
+	max := keysutil.EncodeShardEnd(nil, nil)
+	var prev []byte
+	for _, key := range keyCodecValidationKeys {
+		encoded := keysutil.Clone(codec.EncodeDataKey(key, nil))
+		if decoded := codec.DecodeDataKey(encoded); !bytes.Equal(key, decoded) {
+			return fmt.Errorf("key codec round trip mismatch, origin %x, decoded %x", key, decoded)
+		}
+		if bytes.Compare(encoded, min) < 0 || bytes.Compare(encoded, max) >= 0 {
+			return fmt.Errorf("key codec encoded key %x out of the data key range", encoded)
+		}
+		if prev != nil && bytes.Compare(prev, encoded) >= 0 {
+			return fmt.Errorf("key codec does not keep the order of keys, %x encoded as %x", key, encoded)
+		}
+		prev = encoded
+	}
+	return nil
+}
@@ -374,8 +374,12 @@ func (c *Chunk) toMessage(chunk metapb.SnapshotChunk) metapb.RaftMessageBatch {
 	if chunk.ChunkID != 0 {
 		panic("not the first snapshot chunk")
 	}
+	// the size of the snapshot is recorded by the sender
+	var sent metapb.SnapshotInfo
+	protoc.MustUnmarshal(&sent, chunk.Extra)
 	si := &metapb.SnapshotInfo{
-		Extra: chunk.From,
+		Extra:     chunk.From,
+		TotalSize: sent.TotalSize,
 	}
 	s := raftpb.Snapshot{
 		Metadata: raftpb.SnapshotMetadata{
@@ -438,7 +438,8 @@ func TestAddingFirstChunkAgainResetsTempDir(t *testing.T) {
 
 func TestToMessageFromChunk(t *testing.T) {
 	si := &metapb.SnapshotInfo{
-		Extra: 12345,
+		Extra:     12345,
+		TotalSize: 10240,
 	}
 	chunk := metapb.SnapshotChunk{
 		ShardID:   123,
@@ -449,7 +450,8 @@ func TestToMessageFromChunk(t *testing.T) {
 		Extra:     protoc.MustMarshal(si),
 	}
 	rsi := &metapb.SnapshotInfo{
-		Extra: chunk.From,
+		Extra:     chunk.From,
+		TotalSize: si.TotalSize,
 	}
 	chunks := &Chunk{}
 	mb := chunks.toMessage(chunk)
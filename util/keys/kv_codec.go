@@ -48,6 +48,20 @@ func EncodeDataKey(originKey []byte, buffer *buf.ByteBuf) []byte {
 	return doAppendPrefix(originKey, dataPrefix, buffer)
 }
 
+// DataKeyCodec is the built-in codec of the data keys, the origin key is
+// prefixed with the data key prefix.
+type DataKeyCodec struct{}
+
+// EncodeDataKey encode data key with data key prefix
+func (DataKeyCodec) EncodeDataKey(originKey []byte, buffer *buf.ByteBuf) []byte {
+	return EncodeDataKey(originKey, buffer)
+}
+
+// DecodeDataKey returns the origin data key
+func (DataKeyCodec) DecodeDataKey(key []byte) []byte {
+	return DecodeDataKey(key)
+}
+
 // DecodeDataKey returns the origin data key.
 // Note that no data copy is generated here, only a slice of the key is returned
 func DecodeDataKey(key []byte) []byte {
@@ -38,6 +38,15 @@ func CPUUsages() ([]float64, error) {
 	return cpu.Percent(0, true)
 }
 
+// CPULoad returns the usage of all cpus since the last call
+func CPULoad() (float64, error) {
+	usages, err := cpu.Percent(0, false)
+	if err != nil || len(usages) == 0 {
+		return 0, err
+	}
+	return usages[0], nil
+}
+
 // IORates io rates
 func IORates(path string) (map[string]disk.IOCountersStat, error) {
 	return disk.IOCounters(path)